@@ -0,0 +1,112 @@
+package main
+
+import (
+	"crypto/ed25519"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/JhonCamargo53/prueba-tecnica/internal/domain/attestation"
+	"github.com/JhonCamargo53/prueba-tecnica/internal/domain/models"
+)
+
+/*
+
+Verifica fuera del servidor, sin base de datos, la cadena de reportes de
+riesgo exportada con GET /risk-reports/export: el hash y la firma de cada
+reporte y el enlace de cada uno con el anterior. Las claves públicas se
+obtienen de GET /risk-reports/keys.
+
+	go run ./cmd/risk-report-verify -public-key <base64> risk-reports.jsonl
+	curl -H "Authorization: Bearer $TOKEN" http://localhost:4000/risk-reports/export | \
+		go run ./cmd/risk-report-verify -public-key <base64> -public-key <anterior>
+
+Termina con código 1 si algún reporte no pasa la verificación. Una evaluación
+sin reporte no aparece en la exportación: esas se listan con
+GET /risk-reports/unreported y GET /risk-reports/{id}/verify las marca en la
+verificación COVERAGE.
+
+*/
+
+type publicKeys []ed25519.PublicKey
+
+func (k *publicKeys) String() string {
+	return fmt.Sprintf("%d claves", len(*k))
+}
+
+func (k *publicKeys) Set(value string) error {
+	key, err := attestation.ParsePublicKey(value)
+	if err != nil {
+		return err
+	}
+	*k = append(*k, key)
+	return nil
+}
+
+func main() {
+	var keys publicKeys
+	flag.Var(&keys, "public-key", "clave pública Ed25519 en base64; se puede repetir para las claves anteriores a una rotación")
+	flag.Parse()
+
+	if len(keys) == 0 {
+		log.Fatal("Se requiere al menos una clave pública (-public-key)")
+	}
+
+	input, name := io.Reader(os.Stdin), "entrada estándar"
+	if path := flag.Arg(0); path != "" && path != "-" {
+		file, err := os.Open(path)
+		if err != nil {
+			log.Fatal("Error abriendo la cadena exportada: ", err)
+		}
+		defer file.Close()
+		input, name = file, path
+	}
+
+	reports, err := readReports(input)
+	if err != nil {
+		log.Fatalf("Error leyendo %s: %v", name, err)
+	}
+
+	problems := attestation.VerifyChain(reports, attestation.NewKeyring(keys...))
+	for _, problem := range problems {
+		for _, check := range problem.Checks {
+			fmt.Printf("reporte %d (secuencia %d): %s: %s\n", problem.ReportID, problem.Sequence, check.Name, check.Detail)
+		}
+	}
+
+	if len(problems) > 0 {
+		fmt.Printf("cadena inválida: %d de %d reportes con problemas\n", len(problems), len(reports))
+		os.Exit(1)
+	}
+
+	last := attestation.GenesisHash
+	if len(reports) > 0 {
+		last = reports[len(reports)-1].Hash
+	}
+	fmt.Printf("cadena válida: %d reportes, último hash %s\n", len(reports), last)
+}
+
+// readReports lee un reporte por línea, en el orden de la exportación.
+func readReports(input io.Reader) ([]models.RiskReport, error) {
+	var reports []models.RiskReport
+
+	decoder := json.NewDecoder(input)
+	for line := 1; ; line++ {
+		var report models.RiskReport
+		err := decoder.Decode(&report)
+		if err == io.EOF {
+			return reports, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("reporte %d: %w", line, err)
+		}
+		if strings.TrimSpace(report.Payload) == "" {
+			return nil, fmt.Errorf("reporte %d: no tiene contenido firmado", line)
+		}
+		reports = append(reports, report)
+	}
+}
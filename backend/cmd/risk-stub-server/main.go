@@ -0,0 +1,96 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"log"
+	"math/rand"
+	"net/http"
+	"time"
+
+	"github.com/JhonCamargo53/prueba-tecnica/internal/infrastructure/ai/credit-risk/adapter/http"
+	engines "github.com/JhonCamargo53/prueba-tecnica/internal/infrastructure/ai/credit-risk/engines"
+	"github.com/JhonCamargo53/prueba-tecnica/internal/infrastructure/logger"
+)
+
+/*
+
+Servidor stub del contrato de scoring externo (ver adapter/http/contract.go).
+Evalúa con el motor mock y permite simular latencia y fallas para probar
+timeouts, reintentos y el motor de respaldo del backend.
+
+	go run ./cmd/risk-stub-server -addr :4100 -delay 200ms -fail-rate 0.2
+
+Luego, en el backend:
+
+	RISK_HTTP_URL=http://localhost:4100/v1/score RISK_CHAMPION_ENGINE=http
+
+*/
+
+func main() {
+	addr := flag.String("addr", ":4100", "dirección en la que escucha el servidor")
+	rulesPath := flag.String("rules", "", "archivo de reglas del motor mock (vacío = reglas embebidas)")
+	delay := flag.Duration("delay", 0, "latencia artificial por petición")
+	failRate := flag.Float64("fail-rate", 0, "proporción de peticiones que responden 503 (0 a 1)")
+	flag.Parse()
+
+	logger.InitLogger()
+
+	rules, err := engines.NewRuleSetStore(*rulesPath)
+	if err != nil {
+		log.Fatal("Error cargando reglas: ", err)
+	}
+
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	mux.HandleFunc("/v1/score", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "método no permitido", http.StatusMethodNotAllowed)
+			return
+		}
+
+		if *delay > 0 {
+			time.Sleep(*delay)
+		}
+
+		if *failRate > 0 && rand.Float64() < *failRate {
+			http.Error(w, "falla simulada", http.StatusServiceUnavailable)
+			return
+		}
+
+		var request httpAdapter.ScoreRequest
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			http.Error(w, "JSON inválido: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		if request.ContractVersion != httpAdapter.ContractVersion {
+			http.Error(w, "versión de contrato no soportada: "+request.ContractVersion, http.StatusBadRequest)
+			return
+		}
+
+		customer, creditRequest, otherCredits, assets := request.Input.Models()
+
+		current := rules.Current()
+		assessment, err := engines.AssessCreditRisk(current, customer, creditRequest, otherCredits, assets)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		assessment.EngineVersion = "stub-" + current.Version
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(httpAdapter.NewScoreResponse(assessment))
+	})
+
+	log.Printf("Servidor stub de riesgo escuchando en %s", *addr)
+
+	if err := http.ListenAndServe(*addr, mux); err != nil {
+		log.Fatal("Error iniciando servidor stub: ", err)
+	}
+}
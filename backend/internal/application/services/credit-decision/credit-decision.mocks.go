@@ -0,0 +1,387 @@
+package creditDecision
+
+import (
+	"errors"
+
+	"github.com/JhonCamargo53/prueba-tecnica/internal/domain/models"
+	"github.com/JhonCamargo53/prueba-tecnica/internal/domain/ports"
+)
+
+/* Mock de CreditDecisionRepository */
+
+type MockCreditDecisionRepository struct {
+	Decisions []*models.CreditDecision
+
+	// Apply cambia el estado de la solicitud y guarda el historial en estos mocks
+	CreditRequests *MockCreditRequestRepository
+	History        *MockCreditStatusHistoryRepository
+}
+
+var _ ports.CreditDecisionRepository = (*MockCreditDecisionRepository)(nil)
+
+func (m *MockCreditDecisionRepository) Create(decision *models.CreditDecision) error {
+	decision.ID = uint(len(m.Decisions) + 1)
+	copy := *decision
+	m.Decisions = append(m.Decisions, &copy)
+	return nil
+}
+
+func (m *MockCreditDecisionRepository) Update(decision *models.CreditDecision) error {
+	for i, d := range m.Decisions {
+		if d.ID == decision.ID {
+			copy := *decision
+			m.Decisions[i] = &copy
+			return nil
+		}
+	}
+	return errors.New("decisión no encontrada")
+}
+
+func (m *MockCreditDecisionRepository) Apply(decision *models.CreditDecision, change *models.CreditStatusHistory) error {
+	if change != nil {
+		cr, ok := m.CreditRequests.Requests[change.CreditRequestID]
+		if !ok || cr.CreditStatusID != *change.FromStatusID {
+			return errors.New("el estado de la solicitud cambió")
+		}
+	}
+
+	if decision.ID == 0 {
+		m.Create(decision)
+	} else if err := m.Update(decision); err != nil {
+		return err
+	}
+
+	if change != nil {
+		m.CreditRequests.UpdateCreditStatus(change.CreditRequestID, change.ToStatusID)
+		m.History.Create(change)
+	}
+	return nil
+}
+
+func (m *MockCreditDecisionRepository) FindByID(id uint) (*models.CreditDecision, error) {
+	for _, d := range m.Decisions {
+		if d.ID == id {
+			copy := *d
+			return &copy, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *MockCreditDecisionRepository) FindByCreditRequestID(creditRequestID uint) ([]models.CreditDecision, error) {
+	var res []models.CreditDecision
+	for _, d := range m.Decisions {
+		if d.CreditRequestID == creditRequestID {
+			res = append(res, *d)
+		}
+	}
+	return res, nil
+}
+
+func (m *MockCreditDecisionRepository) FindPending() ([]models.CreditDecision, error) {
+	var res []models.CreditDecision
+	for _, d := range m.Decisions {
+		if d.Status == models.CreditDecisionStatusPendingConfirmation {
+			res = append(res, *d)
+		}
+	}
+	return res, nil
+}
+
+func (m *MockCreditDecisionRepository) FindEscalated(access int) ([]models.CreditDecision, error) {
+	var res []models.CreditDecision
+	for _, d := range m.Decisions {
+		if d.Status == models.CreditDecisionStatusEscalated && d.RequiredAccess <= access {
+			res = append(res, *d)
+		}
+	}
+	return res, nil
+}
+
+/* Mock de CreditStatusHistoryRepository */
+
+type MockCreditStatusHistoryRepository struct {
+	History []models.CreditStatusHistory
+}
+
+var _ ports.CreditStatusHistoryRepository = (*MockCreditStatusHistoryRepository)(nil)
+
+func (m *MockCreditStatusHistoryRepository) Create(history *models.CreditStatusHistory) error {
+	history.ID = uint(len(m.History) + 1)
+	m.History = append(m.History, *history)
+	return nil
+}
+
+func (m *MockCreditStatusHistoryRepository) FindByCreditRequestID(creditRequestID uint) ([]models.CreditStatusHistory, error) {
+	var res []models.CreditStatusHistory
+	for _, h := range m.History {
+		if h.CreditRequestID == creditRequestID {
+			res = append(res, h)
+		}
+	}
+	return res, nil
+}
+
+/* Mock de PaymentScheduleRepository */
+
+type MockPaymentScheduleRepository struct {
+	Schedules map[uint]*models.PaymentSchedule
+}
+
+var _ ports.PaymentScheduleRepository = (*MockPaymentScheduleRepository)(nil)
+
+func (m *MockPaymentScheduleRepository) FindByCreditRequestID(creditRequestID uint) (*models.PaymentSchedule, error) {
+	return m.Schedules[creditRequestID], nil
+}
+
+func (m *MockPaymentScheduleRepository) Create(schedule *models.PaymentSchedule) error {
+	if m.Schedules == nil {
+		m.Schedules = make(map[uint]*models.PaymentSchedule)
+	}
+	m.Schedules[schedule.CreditRequestID] = schedule
+	return nil
+}
+
+/* Mock de LoanAccountRepository */
+
+type MockLoanAccountRepository struct {
+	Accounts map[uint]*models.LoanAccount
+	// Error al abrir una cuenta
+	CreateErr error
+}
+
+var _ ports.LoanAccountRepository = (*MockLoanAccountRepository)(nil)
+
+func (m *MockLoanAccountRepository) FindByCreditRequestID(creditRequestID uint) (*models.LoanAccount, error) {
+	return m.Accounts[creditRequestID], nil
+}
+
+func (m *MockLoanAccountRepository) FindActive() ([]models.LoanAccount, error) {
+	return nil, nil
+}
+
+func (m *MockLoanAccountRepository) FindDelinquent() ([]models.LoanAccount, error) {
+	return nil, nil
+}
+
+func (m *MockLoanAccountRepository) Create(account *models.LoanAccount) error {
+	if m.CreateErr != nil {
+		return m.CreateErr
+	}
+	if m.Accounts == nil {
+		m.Accounts = make(map[uint]*models.LoanAccount)
+	}
+	m.Accounts[account.CreditRequestID] = account
+	return nil
+}
+
+func (m *MockLoanAccountRepository) UpdateDelinquency(account *models.LoanAccount) error {
+	return nil
+}
+
+func (m *MockLoanAccountRepository) RegisterPayment(accountID uint,
+	build func(account *models.LoanAccount) (*models.LoanPayment, []models.PaymentScheduleInstallment, error)) error {
+	return nil
+}
+
+func (m *MockLoanAccountRepository) FindPayments(loanAccountID uint) ([]models.LoanPayment, error) {
+	return nil, nil
+}
+
+/* Mock de CreditRequestRepository */
+
+type MockCreditRequestRepository struct {
+	Requests map[uint]*models.CreditRequest
+
+	StatusUpdates int
+}
+
+var _ ports.CreditRequestRepository = (*MockCreditRequestRepository)(nil)
+
+func NewMockCreditRequestRepository(initial []*models.CreditRequest) *MockCreditRequestRepository {
+	m := &MockCreditRequestRepository{
+		Requests: make(map[uint]*models.CreditRequest),
+	}
+	for _, cr := range initial {
+		m.Requests[cr.ID] = cr
+	}
+	return m
+}
+
+func (m *MockCreditRequestRepository) FindAll(customerID *uint) ([]models.CreditRequest, error) {
+	var res []models.CreditRequest
+	for _, cr := range m.Requests {
+		res = append(res, *cr)
+	}
+	return res, nil
+}
+
+func (m *MockCreditRequestRepository) FindByID(id uint) (*models.CreditRequest, error) {
+	if cr, ok := m.Requests[id]; ok {
+		copy := *cr
+		return &copy, nil
+	}
+	return nil, nil
+}
+
+func (m *MockCreditRequestRepository) HasRequestsByCustomerID(customerID uint) (bool, error) {
+	return false, nil
+}
+
+func (m *MockCreditRequestRepository) Create(creditRequest *models.CreditRequest) (*models.CreditRequest, error) {
+	return creditRequest, nil
+}
+
+func (m *MockCreditRequestRepository) Update(id uint, creditRequest *models.CreditRequest) (*models.CreditRequest, error) {
+	return creditRequest, nil
+}
+
+func (m *MockCreditRequestRepository) Delete(id uint) error {
+	return nil
+}
+
+func (m *MockCreditRequestRepository) UpdateCreditRiskEvaluation(id uint, assessment *models.RiskAssessment) (*models.CreditRequest, error) {
+	return nil, nil
+}
+
+func (m *MockCreditRequestRepository) UpdateCreditStatus(id uint, creditStatusID uint) error {
+	cr, ok := m.Requests[id]
+	if !ok {
+		return errors.New("credit request no encontrada")
+	}
+	m.StatusUpdates++
+	cr.CreditStatusID = creditStatusID
+	return nil
+}
+
+func (m *MockCreditRequestRepository) UpdateCreditOffer(id uint, offer *models.CreditOffer) error {
+	return nil
+}
+
+func (m *MockCreditRequestRepository) FindDataToEvaluateRisk(id uint) (models.Customer, *models.CreditRequest, []models.CreditRequest, []models.CustomerAsset, error) {
+	return models.Customer{}, nil, nil, nil, nil
+}
+
+/* Mock de UserRepository */
+
+type MockUserRepository struct {
+	Users map[uint]*models.User
+}
+
+var _ ports.UserRepository = (*MockUserRepository)(nil)
+
+func (m *MockUserRepository) FindAllOrderedByCreatedDesc() ([]models.User, error) {
+	return nil, nil
+}
+
+func (m *MockUserRepository) FindByID(id uint) (*models.User, error) {
+	if u, ok := m.Users[id]; ok {
+		copy := *u
+		return &copy, nil
+	}
+	return nil, nil
+}
+
+func (m *MockUserRepository) FindByEmail(email string) (*models.User, error) {
+	return nil, nil
+}
+
+func (m *MockUserRepository) Create(user *models.User) error {
+	return nil
+}
+
+func (m *MockUserRepository) Save(user *models.User) error {
+	return nil
+}
+
+func (m *MockUserRepository) Delete(id uint) error {
+	return nil
+}
+
+/* Mock de RoleRepository */
+
+type MockRoleRepository struct {
+	Roles map[uint]*models.Role
+}
+
+var _ ports.RoleRepository = (*MockRoleRepository)(nil)
+
+func (m *MockRoleRepository) FindAll() ([]models.Role, error) {
+	return nil, nil
+}
+
+func (m *MockRoleRepository) FindByID(id uint) (*models.Role, error) {
+	if r, ok := m.Roles[id]; ok {
+		copy := *r
+		return &copy, nil
+	}
+	return nil, nil
+}
+
+/* Mock de CustomerAssetRepository */
+
+type MockCustomerAssetRepository struct {
+	// Cantidad de activos asociados por solicitud
+	Counts map[uint]int64
+}
+
+var _ ports.CustomerAssetRepository = (*MockCustomerAssetRepository)(nil)
+
+func (m *MockCustomerAssetRepository) FindAll(creditRequestID *uint) ([]models.CustomerAsset, error) {
+	return nil, nil
+}
+
+func (m *MockCustomerAssetRepository) FindByID(id uint) (*models.CustomerAsset, error) {
+	return nil, nil
+}
+
+func (m *MockCustomerAssetRepository) CountByCreditRequestID(creditRequestID uint) (int64, error) {
+	return m.Counts[creditRequestID], nil
+}
+
+func (m *MockCustomerAssetRepository) Create(ca *models.CustomerAsset) error {
+	return nil
+}
+
+func (m *MockCustomerAssetRepository) Update(id uint, data *models.CustomerAsset) (*models.CustomerAsset, error) {
+	return nil, nil
+}
+
+func (m *MockCustomerAssetRepository) Delete(id uint) error {
+	return nil
+}
+
+/* Mock de DocumentRepository: FindForCreditRequest retorna los documentos de la solicitud y de su titular */
+
+type MockDocumentRepository struct {
+	Documents []models.Document
+}
+
+var _ ports.DocumentRepository = (*MockDocumentRepository)(nil)
+
+func (m *MockDocumentRepository) FindByID(id uint) (*models.Document, error) {
+	return nil, nil
+}
+
+func (m *MockDocumentRepository) FindByOwner(ownerType string, ownerID uint) ([]models.Document, error) {
+	return nil, nil
+}
+
+func (m *MockDocumentRepository) FindForCreditRequest(creditRequestID, customerID uint) ([]models.Document, error) {
+	var res []models.Document
+	for _, d := range m.Documents {
+		if (d.OwnerType == models.DocumentOwnerCreditRequest && d.OwnerID == creditRequestID) ||
+			(d.OwnerType == models.DocumentOwnerCustomer && d.OwnerID == customerID) {
+			res = append(res, d)
+		}
+	}
+	return res, nil
+}
+
+func (m *MockDocumentRepository) Create(document *models.Document) error {
+	return nil
+}
+
+func (m *MockDocumentRepository) Delete(id uint) error {
+	return nil
+}
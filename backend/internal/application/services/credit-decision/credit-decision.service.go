@@ -0,0 +1,415 @@
+package creditDecision
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	creditWorkflow "github.com/JhonCamargo53/prueba-tecnica/internal/application/services/credit-workflow"
+	loanAccount "github.com/JhonCamargo53/prueba-tecnica/internal/application/services/loan-account"
+	"github.com/JhonCamargo53/prueba-tecnica/internal/domain/authority"
+	"github.com/JhonCamargo53/prueba-tecnica/internal/domain/models"
+	"github.com/JhonCamargo53/prueba-tecnica/internal/domain/ports"
+	"github.com/JhonCamargo53/prueba-tecnica/internal/infrastructure/logger"
+)
+
+// Longitud mínima de la justificación de un override
+const MinJustificationLength = 20
+
+type CreditDecisionService struct {
+	decisionRepo      ports.CreditDecisionRepository
+	creditRequestRepo ports.CreditRequestRepository
+	customerAssetRepo ports.CustomerAssetRepository
+	documentRepo      ports.DocumentRepository
+	userRepo          ports.UserRepository
+	roleRepo          ports.RoleRepository
+	workflow          *creditWorkflow.CreditWorkflowService
+	// Abre la cuenta del crédito, con su plan de pagos, al aprobar una solicitud
+	loanService *loanAccount.LoanAccountService
+	// Atribuciones de aprobación según el nivel de acceso del rol
+	authorityRules *authority.Rules
+	// Monto por encima del cual un override requiere confirmación de un segundo usuario
+	reviewAmount float64
+}
+
+func NewCreditDecisionService(decisionRepo ports.CreditDecisionRepository, creditRequestRepo ports.CreditRequestRepository,
+	customerAssetRepo ports.CustomerAssetRepository, documentRepo ports.DocumentRepository, userRepo ports.UserRepository, roleRepo ports.RoleRepository, workflowService *creditWorkflow.CreditWorkflowService,
+	loanService *loanAccount.LoanAccountService, authorityRules *authority.Rules, reviewAmount float64) *CreditDecisionService {
+	return &CreditDecisionService{
+		decisionRepo:      decisionRepo,
+		creditRequestRepo: creditRequestRepo,
+		customerAssetRepo: customerAssetRepo,
+		documentRepo:      documentRepo,
+		userRepo:          userRepo,
+		roleRepo:          roleRepo,
+		workflow:          workflowService,
+		loanService:       loanService,
+		authorityRules:    authorityRules,
+		reviewAmount:      reviewAmount,
+	}
+}
+
+// IsOverride indica si la decisión contradice la recomendación del motor.
+// Las recomendaciones REVIEW (o la falta de evaluación) admiten cualquier decisión.
+func IsOverride(recommendation string, creditStatusID uint) bool {
+	return (creditStatusID == models.CreditStatusApprovedID && recommendation == models.RiskRecommendationReject) ||
+		(creditStatusID == models.CreditStatusRejectedID && recommendation == models.RiskRecommendationApprove)
+}
+
+// Decide registra la aprobación o el rechazo de una solicitud. El estado de la solicitud
+// cambia de inmediato salvo que sea una aprobación por encima de la atribución del rol
+// de quien decide (se escala) o un override por encima del monto de revisión. Al
+// aplicarse una aprobación se abre la cuenta del crédito con su plan de pagos.
+func (s *CreditDecisionService) Decide(creditRequestID, creditStatusID uint, justification string, deciderID uint) (*models.CreditDecision, error) {
+	if creditStatusID != models.CreditStatusApprovedID && creditStatusID != models.CreditStatusRejectedID {
+		return nil, fmt.Errorf("la decisión debe ser APROBADO (%d) o RECHAZADO (%d)", models.CreditStatusApprovedID, models.CreditStatusRejectedID)
+	}
+
+	creditRequest, err := s.creditRequestRepo.FindByID(creditRequestID)
+	if err != nil {
+		return nil, err
+	}
+	if creditRequest == nil {
+		return nil, fmt.Errorf("no existe solicitud de crédito con id %d", creditRequestID)
+	}
+
+	deciderAccess, err := s.findUserAccess(deciderID)
+	if err != nil {
+		return nil, err
+	}
+
+	pending, err := s.pendingDecision(creditRequestID)
+	if err != nil {
+		return nil, err
+	}
+	if pending != nil {
+		return nil, fmt.Errorf("la solicitud ya tiene una decisión pendiente de confirmación (id %d)", pending.ID)
+	}
+
+	// La transición se valida al decidir aunque el cambio quede pendiente de confirmación
+	role, err := s.workflow.Check(creditRequest.CreditStatusID, creditStatusID, &deciderID)
+	if err != nil {
+		return nil, err
+	}
+
+	if creditStatusID == models.CreditStatusApprovedID {
+		if err := s.checkApprovalRequirements(creditRequest); err != nil {
+			return nil, err
+		}
+	}
+
+	var assessment models.RiskAssessment
+	if len(creditRequest.RiskAssessment) > 0 {
+		if err := json.Unmarshal(creditRequest.RiskAssessment, &assessment); err != nil {
+			return nil, fmt.Errorf("evaluación de riesgo inválida para la solicitud %d: %w", creditRequestID, err)
+		}
+	}
+
+	justification = strings.TrimSpace(justification)
+	override := IsOverride(assessment.Recommendation, creditStatusID)
+
+	if override && utf8.RuneCountInString(justification) < MinJustificationLength {
+		return nil, fmt.Errorf("la decisión contradice la recomendación del motor (%s): la justificación es obligatoria y debe tener al menos %d caracteres",
+			assessment.Recommendation, MinJustificationLength)
+	}
+
+	decision := &models.CreditDecision{
+		CreditRequestID:      creditRequestID,
+		CreditStatusID:       creditStatusID,
+		Status:               models.CreditDecisionStatusApplied,
+		EngineRecommendation: assessment.Recommendation,
+		EngineScore:          creditRequest.RiskScore,
+		EngineVersion:        creditRequest.RiskRuleSetVersion,
+		Override:             override,
+		Justification:        justification,
+		Amount:               creditRequest.Amount,
+		RiskCategory:         creditRequest.RiskCategory,
+		DecidedByID:          deciderID,
+		DecidedAt:            time.Now(),
+	}
+
+	switch {
+	case creditStatusID == models.CreditStatusApprovedID && !s.authorityRules.CanApprove(deciderAccess, *creditRequest):
+		requiredAccess, ok := s.authorityRules.RequiredAccess(*creditRequest)
+		if !ok {
+			return nil, fmt.Errorf("ningún rol puede aprobar la solicitud: el monto %.0f con riesgo %s supera todas las atribuciones configuradas",
+				creditRequest.Amount, creditRequest.RiskCategory)
+		}
+		decision.Status = models.CreditDecisionStatusEscalated
+		decision.RequiredAccess = requiredAccess
+	case override && creditRequest.Amount > s.reviewAmount:
+		decision.Status = models.CreditDecisionStatusPendingConfirmation
+	}
+
+	if decision.Status == models.CreditDecisionStatusApplied {
+		if err := s.apply(decision, creditRequest, &deciderID, role, models.CreditStatusSourceDecision, decision.DecidedAt); err != nil {
+			return nil, err
+		}
+	} else if err := s.decisionRepo.Create(decision); err != nil {
+		return nil, err
+	}
+
+	if decision.Status == models.CreditDecisionStatusEscalated {
+		logDecision("credit_decision_escalated", decision)
+	}
+	if override {
+		logDecision("credit_decision_override", decision)
+	}
+
+	return decision, nil
+}
+
+// Review confirma o descarta un override pendiente o una aprobación escalada. Sólo
+// puede hacerlo un usuario distinto de quien decidió y con mayor nivel de acceso; en
+// una aprobación escalada, además, con atribución para aprobar la solicitud.
+func (s *CreditDecisionService) Review(creditRequestID, decisionID, reviewerID uint, confirm bool, comment string) (*models.CreditDecision, error) {
+	decision, err := s.decisionRepo.FindByID(decisionID)
+	if err != nil {
+		return nil, err
+	}
+	if decision == nil || decision.CreditRequestID != creditRequestID {
+		return nil, fmt.Errorf("no existe decisión con id %d para la solicitud %d", decisionID, creditRequestID)
+	}
+
+	if !isOpen(decision) {
+		return nil, fmt.Errorf("la decisión %d no está pendiente de confirmación", decisionID)
+	}
+
+	if reviewerID == decision.DecidedByID {
+		return nil, fmt.Errorf("quien tomó la decisión no puede confirmarla")
+	}
+
+	deciderAccess, err := s.findUserAccess(decision.DecidedByID)
+	if err != nil {
+		return nil, err
+	}
+	reviewerAccess, err := s.findUserAccess(reviewerID)
+	if err != nil {
+		return nil, err
+	}
+	if reviewerAccess <= deciderAccess {
+		return nil, fmt.Errorf("el usuario que confirma debe tener un nivel de acceso mayor que quien decidió")
+	}
+
+	creditRequest, err := s.creditRequestRepo.FindByID(creditRequestID)
+	if err != nil {
+		return nil, err
+	}
+	if creditRequest == nil {
+		return nil, fmt.Errorf("no existe solicitud de crédito con id %d", creditRequestID)
+	}
+
+	// La atribución se revisa con los datos actuales de la solicitud
+	if decision.Status == models.CreditDecisionStatusEscalated && !s.authorityRules.CanApprove(reviewerAccess, *creditRequest) {
+		return nil, fmt.Errorf("el usuario que confirma no tiene atribución para aprobar la solicitud")
+	}
+
+	// El estado pudo cambiar desde que se tomó la decisión: se valida la transición de nuevo
+	var role string
+	if confirm {
+		role, err = s.workflow.Check(creditRequest.CreditStatusID, decision.CreditStatusID, &reviewerID)
+		if err != nil {
+			return nil, err
+		}
+		if decision.CreditStatusID == models.CreditStatusApprovedID {
+			if err := s.checkApprovalRequirements(creditRequest); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	now := time.Now()
+	decision.ReviewedByID = &reviewerID
+	decision.ReviewedAt = &now
+	decision.ReviewComment = strings.TrimSpace(comment)
+	decision.Status = models.CreditDecisionStatusDeclined
+	if confirm {
+		decision.Status = models.CreditDecisionStatusConfirmed
+	}
+
+	if confirm {
+		if err := s.apply(decision, creditRequest, &reviewerID, role, models.CreditStatusSourceConfirmed, now); err != nil {
+			return nil, err
+		}
+	} else if err := s.decisionRepo.Update(decision); err != nil {
+		return nil, err
+	}
+
+	logDecision("credit_decision_reviewed", decision)
+
+	return decision, nil
+}
+
+func (s *CreditDecisionService) GetDecisionsByCreditRequestID(creditRequestID uint) ([]models.CreditDecision, error) {
+	creditRequest, err := s.creditRequestRepo.FindByID(creditRequestID)
+	if err != nil {
+		return nil, err
+	}
+	if creditRequest == nil {
+		return nil, fmt.Errorf("no existe solicitud de crédito con id %d", creditRequestID)
+	}
+
+	return s.decisionRepo.FindByCreditRequestID(creditRequestID)
+}
+
+func (s *CreditDecisionService) GetPendingDecisions() ([]models.CreditDecision, error) {
+	return s.decisionRepo.FindPending()
+}
+
+// GetEscalatedDecisions retorna las aprobaciones escaladas que el usuario puede resolver.
+func (s *CreditDecisionService) GetEscalatedDecisions(requesterID uint) ([]models.CreditDecision, error) {
+	access, err := s.findUserAccess(requesterID)
+	if err != nil {
+		return nil, err
+	}
+	return s.decisionRepo.FindEscalated(access)
+}
+
+// apply guarda la decisión junto con el cambio de estado de la solicitud en una sola
+// transacción y, si es una aprobación, abre la cuenta del crédito en approvedAt. La
+// decisión ya quedó aplicada aunque la cuenta no se pueda abrir: el job de cartera
+// reintenta abrirla con la fecha de aprobación.
+func (s *CreditDecisionService) apply(decision *models.CreditDecision, creditRequest *models.CreditRequest, actorID *uint,
+	role, source string, approvedAt time.Time) error {
+
+	var change *models.CreditStatusHistory
+	if creditRequest.CreditStatusID != decision.CreditStatusID {
+		change = s.workflow.NewChange(creditRequest.ID, &creditRequest.CreditStatusID, decision.CreditStatusID, actorID, role,
+			source, decision.Justification)
+	}
+
+	if err := s.decisionRepo.Apply(decision, change); err != nil {
+		return err
+	}
+	if change != nil {
+		s.workflow.LogChange(change)
+	}
+
+	if decision.CreditStatusID != models.CreditStatusApprovedID {
+		return nil
+	}
+
+	creditRequest.CreditStatusID = decision.CreditStatusID
+	if _, err := s.loanService.OpenLoanAccount(creditRequest, approvedAt); err != nil {
+		logger.WriteJSON(map[string]interface{}{
+			"timestamp":         time.Now().Format(time.RFC3339),
+			"level":             "error",
+			"event":             "loan_account_open_failed",
+			"decision_id":       decision.ID,
+			"credit_request_id": creditRequest.ID,
+			"error":             err.Error(),
+		})
+	}
+
+	return nil
+}
+
+// isOpen indica si la decisión espera que otro usuario la resuelva.
+func isOpen(decision *models.CreditDecision) bool {
+	return decision.Status == models.CreditDecisionStatusPendingConfirmation ||
+		decision.Status == models.CreditDecisionStatusEscalated
+}
+
+func (s *CreditDecisionService) pendingDecision(creditRequestID uint) (*models.CreditDecision, error) {
+	decisions, err := s.decisionRepo.FindByCreditRequestID(creditRequestID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range decisions {
+		if isOpen(&decisions[i]) {
+			return &decisions[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// checkApprovalRequirements verifica lo que exige el producto para aprobar la
+// solicitud: la garantía y los documentos adjuntos.
+func (s *CreditDecisionService) checkApprovalRequirements(creditRequest *models.CreditRequest) error {
+	if err := s.checkCollateral(creditRequest); err != nil {
+		return err
+	}
+	return s.checkRequiredDocuments(creditRequest)
+}
+
+// checkCollateral impide aprobar una solicitud cuyo producto exige garantía si no
+// tiene activos asociados.
+func (s *CreditDecisionService) checkCollateral(creditRequest *models.CreditRequest) error {
+	if creditRequest.CreditProduct == nil || !creditRequest.CreditProduct.CollateralRequired {
+		return nil
+	}
+
+	count, err := s.customerAssetRepo.CountByCreditRequestID(creditRequest.ID)
+	if err != nil {
+		return err
+	}
+	if count == 0 {
+		return fmt.Errorf("el producto %s exige garantía: la solicitud debe tener al menos un activo asociado para aprobarse",
+			creditRequest.CreditProduct.Code)
+	}
+
+	return nil
+}
+
+// checkRequiredDocuments impide aprobar una solicitud sin los documentos que exige su
+// producto, adjuntos a la solicitud, a su titular o a los bienes asociados.
+func (s *CreditDecisionService) checkRequiredDocuments(creditRequest *models.CreditRequest) error {
+	if creditRequest.CreditProduct == nil || len(creditRequest.CreditProduct.RequiredDocuments) == 0 {
+		return nil
+	}
+
+	documents, err := s.documentRepo.FindForCreditRequest(creditRequest.ID, creditRequest.CustomerID)
+	if err != nil {
+		return err
+	}
+	if missing := creditRequest.CreditProduct.MissingDocuments(documents); len(missing) > 0 {
+		return fmt.Errorf("el producto %s exige documentos que faltan en la solicitud: %s",
+			creditRequest.CreditProduct.Code, strings.Join(missing, ", "))
+	}
+
+	return nil
+}
+
+// findUserAccess retorna el nivel de acceso del rol del usuario.
+func (s *CreditDecisionService) findUserAccess(userID uint) (int, error) {
+	user, err := s.userRepo.FindByID(userID)
+	if err != nil {
+		return 0, err
+	}
+	if user == nil {
+		return 0, fmt.Errorf("no existe usuario con id %d", userID)
+	}
+
+	role, err := s.roleRepo.FindByID(user.RoleId)
+	if err != nil {
+		return 0, err
+	}
+	if role == nil {
+		return 0, fmt.Errorf("no existe rol con id %d", user.RoleId)
+	}
+
+	return role.Access, nil
+}
+
+func logDecision(event string, decision *models.CreditDecision) {
+	entry := map[string]interface{}{
+		"timestamp":             time.Now().Format(time.RFC3339),
+		"level":                 "info",
+		"event":                 event,
+		"decision_id":           decision.ID,
+		"credit_request_id":     decision.CreditRequestID,
+		"credit_status_id":      decision.CreditStatusID,
+		"engine_recommendation": decision.EngineRecommendation,
+		"status":                decision.Status,
+		"decided_by":            decision.DecidedByID,
+	}
+	if decision.RequiredAccess > 0 {
+		entry["required_access"] = decision.RequiredAccess
+	}
+	if decision.ReviewedByID != nil {
+		entry["reviewed_by"] = *decision.ReviewedByID
+	}
+	logger.WriteJSON(entry)
+}
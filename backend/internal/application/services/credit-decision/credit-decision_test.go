@@ -0,0 +1,342 @@
+package creditDecision
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	creditWorkflow "github.com/JhonCamargo53/prueba-tecnica/internal/application/services/credit-workflow"
+	loanAccount "github.com/JhonCamargo53/prueba-tecnica/internal/application/services/loan-account"
+	paymentSchedule "github.com/JhonCamargo53/prueba-tecnica/internal/application/services/payment-schedule"
+	"github.com/JhonCamargo53/prueba-tecnica/internal/domain/authority"
+	"github.com/JhonCamargo53/prueba-tecnica/internal/domain/models"
+	"github.com/JhonCamargo53/prueba-tecnica/internal/domain/workflow"
+)
+
+const (
+	employeeID  uint = 1
+	employee2ID uint = 2
+	adminID     uint = 3
+)
+
+func assessmentJSON(recommendation string) models.JSONB {
+	data, _ := json.Marshal(models.RiskAssessment{Recommendation: recommendation})
+	return data
+}
+
+func newService(requests ...*models.CreditRequest) (*CreditDecisionService, *MockCreditDecisionRepository, *MockCreditRequestRepository) {
+	creditRequestRepo := NewMockCreditRequestRepository(requests)
+	historyRepo := &MockCreditStatusHistoryRepository{}
+	decisionRepo := &MockCreditDecisionRepository{CreditRequests: creditRequestRepo, History: historyRepo}
+	userRepo := &MockUserRepository{Users: map[uint]*models.User{
+		employeeID:  {ID: employeeID, RoleId: 2},
+		employee2ID: {ID: employee2ID, RoleId: 2},
+		adminID:     {ID: adminID, RoleId: 1},
+	}}
+	roleRepo := &MockRoleRepository{Roles: map[uint]*models.Role{
+		1: {ID: 1, Name: "ADMIN", Access: 1000},
+		2: {ID: 2, Name: "EMPLOYEE", Access: 100},
+	}}
+
+	workflowService := creditWorkflow.NewCreditWorkflowService(workflow.DefaultRules(), historyRepo,
+		creditRequestRepo, userRepo, roleRepo)
+
+	customerAssetRepo := &MockCustomerAssetRepository{Counts: map[uint]int64{}}
+	scheduleService := paymentSchedule.NewPaymentScheduleService(&MockPaymentScheduleRepository{}, creditRequestRepo)
+	loanService := loanAccount.NewLoanAccountService(&MockLoanAccountRepository{}, creditRequestRepo, decisionRepo,
+		historyRepo, scheduleService)
+
+	service := NewCreditDecisionService(decisionRepo, creditRequestRepo, customerAssetRepo, &MockDocumentRepository{}, userRepo, roleRepo, workflowService,
+		loanService, authority.DefaultRules(), 50_000_000)
+	return service, decisionRepo, creditRequestRepo
+}
+
+func TestDecide_AcordeAlMotorSeAplicaSinJustificacion(t *testing.T) {
+	service, _, creditRequestRepo := newService(&models.CreditRequest{
+		ID: 10, Amount: 80_000_000, TermMonths: 24, CreditStatusID: models.CreditStatusInStudyID, RiskCategory: "LOW",
+		RiskAssessment: assessmentJSON(models.RiskRecommendationApprove),
+	})
+
+	decision, err := service.Decide(10, models.CreditStatusApprovedID, "", adminID)
+	if err != nil {
+		t.Fatalf("no se esperaba error: %v", err)
+	}
+
+	if decision.Override || decision.Status != models.CreditDecisionStatusApplied {
+		t.Errorf("se esperaba decisión aplicada sin override: %+v", decision)
+	}
+	if creditRequestRepo.Requests[10].CreditStatusID != models.CreditStatusApprovedID {
+		t.Errorf("se esperaba la solicitud aprobada")
+	}
+
+	// La cuenta del crédito y su plan de pagos se abren al aprobar, con la fecha de la decisión
+	account, err := service.loanService.GetLoanAccount(10)
+	if err != nil {
+		t.Fatalf("se esperaba la cuenta del crédito: %v", err)
+	}
+	schedule := account.PaymentSchedule
+	if account.OutstandingPrincipal != 80_000_000 || !schedule.StartDate.Equal(decision.DecidedAt) || len(schedule.Installments) != 24 {
+		t.Errorf("cuenta inesperada: saldo %.0f, inicio %s, %d cuotas", account.OutstandingPrincipal, schedule.StartDate, len(schedule.Installments))
+	}
+}
+
+func TestDecide_AprobacionSeAplicaAunqueLaCuentaNoSeAbra(t *testing.T) {
+	// Sin plazo no se puede generar el plan de pagos de la cuenta
+	service, decisionRepo, creditRequestRepo := newService(&models.CreditRequest{
+		ID: 10, Amount: 10_000_000, CreditStatusID: models.CreditStatusInStudyID, RiskCategory: "LOW",
+		RiskAssessment: assessmentJSON(models.RiskRecommendationApprove),
+	})
+
+	decision, err := service.Decide(10, models.CreditStatusApprovedID, "", adminID)
+	if err != nil {
+		t.Fatalf("no se esperaba error aunque la cuenta no se abra: %v", err)
+	}
+	if decision.Status != models.CreditDecisionStatusApplied || len(decisionRepo.Decisions) != 1 ||
+		creditRequestRepo.Requests[10].CreditStatusID != models.CreditStatusApprovedID || len(decisionRepo.History.History) != 1 {
+		t.Fatalf("se esperaba la decisión, el estado y el historial guardados juntos: %+v", decision)
+	}
+	if _, err := service.loanService.GetLoanAccount(10); err == nil {
+		t.Fatalf("no se esperaba la cuenta abierta")
+	}
+
+	// Una vez corregida la solicitud, el job de cartera abre la cuenta con la fecha de la decisión
+	creditRequestRepo.Requests[10].TermMonths = 12
+	if opened, err := service.loanService.BackfillLoanAccounts(); err != nil || opened != 1 {
+		t.Fatalf("se esperaba abrir la cuenta pendiente: %d, %v", opened, err)
+	}
+	account, err := service.loanService.GetLoanAccount(10)
+	if err != nil || !account.PaymentSchedule.StartDate.Equal(decision.DecidedAt) {
+		t.Errorf("se esperaba la cuenta desde la fecha de la decisión: %+v, %v", account, err)
+	}
+}
+
+func TestDecide_OverrideSinJustificacionEsRechazado(t *testing.T) {
+	service, decisionRepo, creditRequestRepo := newService(&models.CreditRequest{
+		ID: 10, Amount: 5_000_000, TermMonths: 24, CreditStatusID: models.CreditStatusInStudyID,
+		RiskAssessment: assessmentJSON(models.RiskRecommendationReject),
+	})
+
+	if _, err := service.Decide(10, models.CreditStatusApprovedID, "cliente conocido", adminID); err == nil {
+		t.Fatalf("se esperaba error por justificación insuficiente")
+	}
+	if len(decisionRepo.Decisions) != 0 || creditRequestRepo.StatusUpdates != 0 {
+		t.Errorf("no se esperaba registrar ni aplicar la decisión")
+	}
+
+	decision, err := service.Decide(10, models.CreditStatusApprovedID,
+		"Ingresos adicionales demostrados con extractos de los últimos seis meses", adminID)
+	if err != nil {
+		t.Fatalf("no se esperaba error con justificación: %v", err)
+	}
+	// Por debajo del monto de revisión el override se aplica de inmediato
+	if !decision.Override || decision.Status != models.CreditDecisionStatusApplied || decision.EngineRecommendation != models.RiskRecommendationReject {
+		t.Errorf("override inesperado: %+v", decision)
+	}
+}
+
+func TestDecide_OverrideSobreMontoRequiereConfirmacion(t *testing.T) {
+	service, _, creditRequestRepo := newService(&models.CreditRequest{
+		ID: 10, Amount: 80_000_000, TermMonths: 24, CreditStatusID: models.CreditStatusInStudyID,
+		RiskAssessment: assessmentJSON(models.RiskRecommendationApprove),
+	})
+
+	decision, err := service.Decide(10, models.CreditStatusRejectedID,
+		"Información del cliente inconsistente con la central de riesgo", employeeID)
+	if err != nil {
+		t.Fatalf("no se esperaba error: %v", err)
+	}
+	if decision.Status != models.CreditDecisionStatusPendingConfirmation {
+		t.Fatalf("se esperaba la decisión pendiente de confirmación, obtenido %s", decision.Status)
+	}
+	if creditRequestRepo.StatusUpdates != 0 {
+		t.Fatalf("no se esperaba cambiar el estado antes de la confirmación")
+	}
+
+	if _, err := service.Decide(10, models.CreditStatusApprovedID, "", employeeID); err == nil {
+		t.Errorf("se esperaba error por decisión pendiente")
+	}
+
+	if _, err := service.Review(11, decision.ID, adminID, true, ""); err == nil {
+		t.Errorf("se esperaba error al revisar la decisión desde otra solicitud")
+	}
+	if _, err := service.Review(10, decision.ID, employeeID, true, ""); err == nil {
+		t.Errorf("se esperaba error: quien decide no puede confirmar")
+	}
+	if _, err := service.Review(10, decision.ID, employee2ID, true, ""); err == nil {
+		t.Errorf("se esperaba error: el revisor debe tener mayor nivel de acceso")
+	}
+
+	reviewed, err := service.Review(10, decision.ID, adminID, true, "Validado con el área de riesgo")
+	if err != nil {
+		t.Fatalf("no se esperaba error al confirmar: %v", err)
+	}
+	if reviewed.Status != models.CreditDecisionStatusConfirmed || reviewed.ReviewedByID == nil || *reviewed.ReviewedByID != adminID || reviewed.ReviewedAt == nil {
+		t.Errorf("revisión inesperada: %+v", reviewed)
+	}
+	if creditRequestRepo.Requests[10].CreditStatusID != models.CreditStatusRejectedID {
+		t.Errorf("se esperaba la solicitud rechazada tras la confirmación")
+	}
+
+	if _, err := service.Review(10, decision.ID, adminID, true, ""); err == nil {
+		t.Errorf("se esperaba error al revisar una decisión ya confirmada")
+	}
+}
+
+func TestDecide_AprobacionSobreAtribucionSeEscala(t *testing.T) {
+	service, _, creditRequestRepo := newService(&models.CreditRequest{
+		ID: 10, Amount: 60_000_000, TermMonths: 24, CreditStatusID: models.CreditStatusInStudyID, RiskCategory: "LOW",
+		RiskAssessment: assessmentJSON(models.RiskRecommendationApprove),
+	})
+
+	decision, err := service.Decide(10, models.CreditStatusApprovedID, "", employeeID)
+	if err != nil {
+		t.Fatalf("no se esperaba error: %v", err)
+	}
+	if decision.Status != models.CreditDecisionStatusEscalated || decision.RequiredAccess != 1000 {
+		t.Fatalf("se esperaba la aprobación escalada al nivel 1000: %+v", decision)
+	}
+	if creditRequestRepo.StatusUpdates != 0 {
+		t.Fatalf("no se esperaba cambiar el estado antes de resolver la escalación")
+	}
+
+	if queue, _ := service.GetEscalatedDecisions(employee2ID); len(queue) != 0 {
+		t.Errorf("EMPLOYEE no debería ver decisiones escaladas a ADMIN")
+	}
+	if queue, _ := service.GetEscalatedDecisions(adminID); len(queue) != 1 {
+		t.Errorf("se esperaba 1 decisión en la cola de ADMIN, obtenidas %d", len(queue))
+	}
+
+	if _, err := service.Review(10, decision.ID, employee2ID, true, ""); err == nil {
+		t.Errorf("se esperaba error: EMPLOYEE no tiene atribución para aprobar")
+	}
+
+	reviewed, err := service.Review(10, decision.ID, adminID, true, "Dentro de la atribución de la gerencia")
+	if err != nil {
+		t.Fatalf("no se esperaba error al confirmar: %v", err)
+	}
+	if reviewed.Status != models.CreditDecisionStatusConfirmed || creditRequestRepo.Requests[10].CreditStatusID != models.CreditStatusApprovedID {
+		t.Errorf("se esperaba la solicitud aprobada tras la confirmación: %+v", reviewed)
+	}
+}
+
+func TestDecide_RechazoNoTieneLimiteDeAtribucion(t *testing.T) {
+	service, _, creditRequestRepo := newService(&models.CreditRequest{
+		ID: 10, Amount: 200_000_000, TermMonths: 24, CreditStatusID: models.CreditStatusInStudyID, RiskCategory: "HIGH",
+		RiskAssessment: assessmentJSON(models.RiskRecommendationReject),
+	})
+
+	decision, err := service.Decide(10, models.CreditStatusRejectedID, "", employeeID)
+	if err != nil {
+		t.Fatalf("no se esperaba error: %v", err)
+	}
+	if decision.Status != models.CreditDecisionStatusApplied || creditRequestRepo.Requests[10].CreditStatusID != models.CreditStatusRejectedID {
+		t.Errorf("se esperaba el rechazo aplicado: %+v", decision)
+	}
+}
+
+func TestReview_DescartarNoCambiaElEstado(t *testing.T) {
+	service, _, creditRequestRepo := newService(&models.CreditRequest{
+		ID: 10, Amount: 80_000_000, TermMonths: 24, CreditStatusID: models.CreditStatusInStudyID,
+		RiskAssessment: assessmentJSON(models.RiskRecommendationReject),
+	})
+
+	decision, err := service.Decide(10, models.CreditStatusApprovedID,
+		"Codeudor con ingresos suficientes pendiente de registrar", employeeID)
+	if err != nil {
+		t.Fatalf("no se esperaba error: %v", err)
+	}
+
+	reviewed, err := service.Review(10, decision.ID, adminID, false, "Registrar primero el codeudor")
+	if err != nil {
+		t.Fatalf("no se esperaba error al descartar: %v", err)
+	}
+	if reviewed.Status != models.CreditDecisionStatusDeclined || creditRequestRepo.StatusUpdates != 0 {
+		t.Errorf("se esperaba la decisión descartada sin cambiar el estado: %+v", reviewed)
+	}
+}
+
+func TestDecide_EstadoInvalido(t *testing.T) {
+	service, _, _ := newService(&models.CreditRequest{ID: 10, Amount: 1_000_000})
+
+	if _, err := service.Decide(10, models.CreditStatusPendingID, "", employeeID); err == nil {
+		t.Errorf("se esperaba error con un estado que no es una decisión")
+	}
+	if _, err := service.Decide(99, models.CreditStatusApprovedID, "", employeeID); err == nil {
+		t.Errorf("se esperaba error con una solicitud inexistente")
+	}
+}
+
+func TestDecide_SolicitudAprobadaEsTerminal(t *testing.T) {
+	service, decisionRepo, creditRequestRepo := newService(&models.CreditRequest{
+		ID: 10, Amount: 5_000_000, TermMonths: 24, CreditStatusID: models.CreditStatusApprovedID,
+		RiskAssessment: assessmentJSON(models.RiskRecommendationApprove),
+	})
+
+	if _, err := service.Decide(10, models.CreditStatusRejectedID, "Se detectó información inconsistente en los soportes", adminID); err == nil {
+		t.Fatalf("se esperaba error: una solicitud aprobada no cambia de estado")
+	}
+	if len(decisionRepo.Decisions) != 0 || creditRequestRepo.Requests[10].CreditStatusID != models.CreditStatusApprovedID {
+		t.Fatalf("no se debería registrar la decisión ni cambiar el estado")
+	}
+}
+
+func TestDecide_ProductoConGarantiaRequiereActivos(t *testing.T) {
+	service, decisionRepo, creditRequestRepo := newService(&models.CreditRequest{
+		ID: 10, Amount: 30_000_000, TermMonths: 24, CreditStatusID: models.CreditStatusInStudyID, RiskCategory: "LOW",
+		CreditProduct:  &models.CreditProduct{Code: "VEHICLE", CollateralRequired: true},
+		RiskAssessment: assessmentJSON(models.RiskRecommendationApprove),
+	})
+
+	_, err := service.Decide(10, models.CreditStatusApprovedID, "", adminID)
+	if err == nil || !strings.Contains(err.Error(), "exige garantía") {
+		t.Fatalf("se esperaba error por falta de garantía, obtenido: %v", err)
+	}
+	if len(decisionRepo.Decisions) != 0 || creditRequestRepo.StatusUpdates != 0 {
+		t.Fatalf("no se esperaba registrar ni aplicar la decisión")
+	}
+
+	// El rechazo no exige garantía
+	if _, err := service.Decide(10, models.CreditStatusRejectedID, "El cliente desistió de la compra del vehículo", adminID); err != nil {
+		t.Fatalf("no se esperaba error al rechazar: %v", err)
+	}
+}
+
+func TestDecide_ProductoConGarantiaYActivoSeAprueba(t *testing.T) {
+	service, _, creditRequestRepo := newService(&models.CreditRequest{
+		ID: 10, Amount: 30_000_000, TermMonths: 24, CreditStatusID: models.CreditStatusInStudyID, RiskCategory: "LOW",
+		CreditProduct:  &models.CreditProduct{Code: "VEHICLE", CollateralRequired: true},
+		RiskAssessment: assessmentJSON(models.RiskRecommendationApprove),
+	})
+	service.customerAssetRepo.(*MockCustomerAssetRepository).Counts[10] = 1
+
+	if _, err := service.Decide(10, models.CreditStatusApprovedID, "", adminID); err != nil {
+		t.Fatalf("no se esperaba error: %v", err)
+	}
+	if creditRequestRepo.Requests[10].CreditStatusID != models.CreditStatusApprovedID {
+		t.Errorf("se esperaba la solicitud aprobada")
+	}
+}
+
+func TestDecide_ProductoConDocumentosRequeridos(t *testing.T) {
+	service, decisionRepo, creditRequestRepo := newService(&models.CreditRequest{
+		ID: 10, CustomerID: 4, Amount: 30_000_000, TermMonths: 24, CreditStatusID: models.CreditStatusInStudyID, RiskCategory: "LOW",
+		CreditProduct:  &models.CreditProduct{Code: "PERSONAL", RequiredDocuments: models.DocumentCategoryList{"IDENTITY", "INCOME_PROOF"}},
+		RiskAssessment: assessmentJSON(models.RiskRecommendationApprove),
+	})
+	documentRepo := service.documentRepo.(*MockDocumentRepository)
+	documentRepo.Documents = []models.Document{{OwnerType: models.DocumentOwnerCustomer, OwnerID: 4, Category: "IDENTITY"}}
+
+	_, err := service.Decide(10, models.CreditStatusApprovedID, "", adminID)
+	if err == nil || !strings.Contains(err.Error(), "exige documentos") || !strings.Contains(err.Error(), "INCOME_PROOF") {
+		t.Fatalf("se esperaba error por el soporte de ingresos faltante, obtenido: %v", err)
+	}
+	if len(decisionRepo.Decisions) != 0 || creditRequestRepo.StatusUpdates != 0 {
+		t.Fatalf("no se esperaba registrar ni aplicar la decisión")
+	}
+
+	documentRepo.Documents = append(documentRepo.Documents,
+		models.Document{OwnerType: models.DocumentOwnerCreditRequest, OwnerID: 10, Category: "INCOME_PROOF"})
+	if _, err := service.Decide(10, models.CreditStatusApprovedID, "", adminID); err != nil {
+		t.Fatalf("no se esperaba error con los documentos completos: %v", err)
+	}
+}
@@ -0,0 +1,79 @@
+package creditProduct
+
+import (
+	"github.com/JhonCamargo53/prueba-tecnica/internal/domain/models"
+	"github.com/JhonCamargo53/prueba-tecnica/internal/domain/ports"
+)
+
+/* Mock de CreditProductRepository */
+
+type MockCreditProductRepository struct {
+	Products map[uint]*models.CreditProduct
+	// Productos con solicitudes de crédito asociadas
+	WithRequests map[uint]bool
+	NextID       uint
+	Deleted      []uint
+}
+
+var _ ports.CreditProductRepository = (*MockCreditProductRepository)(nil)
+
+func NewMockCreditProductRepository(initial []*models.CreditProduct) *MockCreditProductRepository {
+	m := &MockCreditProductRepository{
+		Products:     make(map[uint]*models.CreditProduct),
+		WithRequests: make(map[uint]bool),
+		NextID:       1,
+	}
+	for _, p := range initial {
+		m.Products[p.ID] = p
+		if p.ID >= m.NextID {
+			m.NextID = p.ID + 1
+		}
+	}
+	return m
+}
+
+func (m *MockCreditProductRepository) FindAll() ([]models.CreditProduct, error) {
+	var res []models.CreditProduct
+	for _, p := range m.Products {
+		res = append(res, *p)
+	}
+	return res, nil
+}
+
+func (m *MockCreditProductRepository) FindByID(id uint) (*models.CreditProduct, error) {
+	if p, ok := m.Products[id]; ok {
+		return p, nil
+	}
+	return nil, nil
+}
+
+func (m *MockCreditProductRepository) FindByCode(code string) (*models.CreditProduct, error) {
+	for _, p := range m.Products {
+		if p.Code == code {
+			return p, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *MockCreditProductRepository) Create(product *models.CreditProduct) error {
+	product.ID = m.NextID
+	m.NextID++
+	m.Products[product.ID] = product
+	return nil
+}
+
+func (m *MockCreditProductRepository) Save(product *models.CreditProduct) error {
+	m.Products[product.ID] = product
+	return nil
+}
+
+func (m *MockCreditProductRepository) Delete(id uint) error {
+	delete(m.Products, id)
+	m.Deleted = append(m.Deleted, id)
+	return nil
+}
+
+func (m *MockCreditProductRepository) HasCreditRequests(id uint) (bool, error) {
+	return m.WithRequests[id], nil
+}
@@ -0,0 +1,189 @@
+package creditProduct
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/JhonCamargo53/prueba-tecnica/internal/domain/finance"
+	"github.com/JhonCamargo53/prueba-tecnica/internal/domain/models"
+	"github.com/JhonCamargo53/prueba-tecnica/internal/domain/ports"
+)
+
+type CreditProductService struct {
+	creditProductRepo ports.CreditProductRepository
+}
+
+func NewCreditProductService(creditProductRepo ports.CreditProductRepository) *CreditProductService {
+	return &CreditProductService{
+		creditProductRepo: creditProductRepo,
+	}
+}
+
+func (s *CreditProductService) GetAllCreditProducts() ([]models.CreditProduct, error) {
+	return s.creditProductRepo.FindAll()
+}
+
+func (s *CreditProductService) GetCreditProductByID(id uint) (*models.CreditProduct, error) {
+	product, err := s.creditProductRepo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if product == nil {
+		return nil, fmt.Errorf("no existe producto de crédito con id %d", id)
+	}
+	return product, nil
+}
+
+func (s *CreditProductService) CreateCreditProduct(product *models.CreditProduct) (*models.CreditProduct, error) {
+	product.Code = normalizeCode(product.Code)
+	product.AmortizationMethod = normalizeMethod(product.AmortizationMethod)
+	product.RequiredDocuments = normalizeDocuments(product.RequiredDocuments)
+	if err := validateProduct(product); err != nil {
+		return nil, err
+	}
+
+	// Validar código único
+	existing, err := s.creditProductRepo.FindByCode(product.Code)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, fmt.Errorf("ya existe un producto de crédito con el código %s", product.Code)
+	}
+
+	if err := s.creditProductRepo.Create(product); err != nil {
+		return nil, err
+	}
+
+	return product, nil
+}
+
+// UpdateCreditProduct reemplaza la configuración del producto. Las solicitudes ya
+// creadas conservan su monto y plazo; los nuevos límites aplican a las siguientes.
+func (s *CreditProductService) UpdateCreditProduct(id uint, productData *models.CreditProduct) (*models.CreditProduct, error) {
+	product, err := s.GetCreditProductByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	productData.Code = normalizeCode(productData.Code)
+	productData.AmortizationMethod = normalizeMethod(productData.AmortizationMethod)
+	productData.RequiredDocuments = normalizeDocuments(productData.RequiredDocuments)
+	if err := validateProduct(productData); err != nil {
+		return nil, err
+	}
+
+	// Validar código único
+	if productData.Code != product.Code {
+		existing, err := s.creditProductRepo.FindByCode(productData.Code)
+		if err != nil {
+			return nil, err
+		}
+		if existing != nil && existing.ID != id {
+			return nil, fmt.Errorf("ya existe un producto de crédito con el código %s", productData.Code)
+		}
+	}
+
+	product.Code = productData.Code
+	product.Name = productData.Name
+	product.Description = productData.Description
+	product.MinAmount = productData.MinAmount
+	product.MaxAmount = productData.MaxAmount
+	product.AllowedTerms = productData.AllowedTerms
+	product.BaseAnnualRate = productData.BaseAnnualRate
+	product.CollateralRequired = productData.CollateralRequired
+	product.RiskWeight = productData.RiskWeight
+	product.AmortizationMethod = productData.AmortizationMethod
+	product.RequiredDocuments = productData.RequiredDocuments
+	product.Status = productData.Status
+
+	if err := s.creditProductRepo.Save(product); err != nil {
+		return nil, err
+	}
+
+	return product, nil
+}
+
+func (s *CreditProductService) DeleteCreditProduct(id uint) error {
+	if _, err := s.GetCreditProductByID(id); err != nil {
+		return err
+	}
+
+	// Verificar solicitudes asociadas
+	hasRequests, err := s.creditProductRepo.HasCreditRequests(id)
+	if err != nil {
+		return err
+	}
+	if hasRequests {
+		return fmt.Errorf("no se puede eliminar el producto de crédito porque tiene solicitudes asociadas; puede desactivarlo")
+	}
+
+	return s.creditProductRepo.Delete(id)
+}
+
+func normalizeCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+// normalizeMethod usa amortización francesa (cuota fija) si no se indica el método.
+func normalizeMethod(method string) string {
+	method = strings.ToUpper(strings.TrimSpace(method))
+	if method == "" {
+		return finance.AmortizationFrench
+	}
+	return method
+}
+
+func normalizeDocuments(categories models.DocumentCategoryList) models.DocumentCategoryList {
+	normalized := models.DocumentCategoryList{}
+	for _, category := range categories {
+		normalized = append(normalized, strings.ToUpper(strings.TrimSpace(category)))
+	}
+	return normalized
+}
+
+func validateProduct(product *models.CreditProduct) error {
+	if product.Code == "" || strings.TrimSpace(product.Name) == "" {
+		return fmt.Errorf("el código y el nombre del producto son obligatorios")
+	}
+	if product.MinAmount <= 0 {
+		return fmt.Errorf("el monto mínimo del producto debe ser mayor que cero")
+	}
+	if product.MaxAmount < product.MinAmount {
+		return fmt.Errorf("el monto máximo del producto no puede ser menor que el monto mínimo")
+	}
+	if len(product.AllowedTerms) == 0 {
+		return fmt.Errorf("el producto debe tener al menos un plazo permitido")
+	}
+	seen := map[int]bool{}
+	for _, term := range product.AllowedTerms {
+		if term <= 0 {
+			return fmt.Errorf("los plazos permitidos deben ser mayores que cero")
+		}
+		if seen[term] {
+			return fmt.Errorf("el plazo de %d meses está repetido", term)
+		}
+		seen[term] = true
+	}
+	if product.BaseAnnualRate <= 0 {
+		return fmt.Errorf("la tasa base del producto debe ser mayor que cero")
+	}
+	if product.RiskWeight <= 0 {
+		return fmt.Errorf("el ponderador de riesgo del producto debe ser mayor que cero")
+	}
+	if !finance.IsValidAmortizationMethod(product.AmortizationMethod) {
+		return fmt.Errorf("el método de amortización %s del producto no es válido (FRENCH, GERMAN o BULLET)", product.AmortizationMethod)
+	}
+	required := map[string]bool{}
+	for _, category := range product.RequiredDocuments {
+		if !models.IsValidDocumentCategory(category) {
+			return fmt.Errorf("la categoría de documento %s exigida por el producto no es válida (%s)",
+				category, strings.Join(models.DocumentCategories(), ", "))
+		}
+		if required[category] {
+			return fmt.Errorf("el producto exige dos veces el documento %s", category)
+		}
+		required[category] = true
+	}
+	return nil
+}
@@ -0,0 +1,108 @@
+package creditProduct
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/JhonCamargo53/prueba-tecnica/internal/domain/models"
+)
+
+func housingProduct() *models.CreditProduct {
+	return &models.CreditProduct{
+		Code:           " housing ",
+		Name:           "Crédito de vivienda",
+		MinAmount:      20_000_000,
+		MaxAmount:      1_500_000_000,
+		AllowedTerms:   models.TermList{120, 240, 360},
+		BaseAnnualRate: 12,
+		RiskWeight:     0.5,
+		Status:         true,
+	}
+}
+
+func TestCreateCreditProduct_NormalizaCodigo(t *testing.T) {
+	repo := NewMockCreditProductRepository(nil)
+	service := NewCreditProductService(repo)
+
+	product, err := service.CreateCreditProduct(housingProduct())
+	if err != nil {
+		t.Fatalf("no se esperaba error: %v", err)
+	}
+	if product.ID == 0 || product.Code != "HOUSING" || product.AmortizationMethod != "FRENCH" {
+		t.Errorf("producto inesperado: %+v", product)
+	}
+
+	if _, err := service.CreateCreditProduct(housingProduct()); err == nil || !strings.Contains(err.Error(), "ya existe") {
+		t.Fatalf("se esperaba error por código repetido, obtenido: %v", err)
+	}
+}
+
+func TestCreateCreditProduct_Invalido(t *testing.T) {
+	service := NewCreditProductService(NewMockCreditProductRepository(nil))
+
+	cases := map[string]func(p *models.CreditProduct){
+		"sin nombre":          func(p *models.CreditProduct) { p.Name = "" },
+		"monto mínimo cero":   func(p *models.CreditProduct) { p.MinAmount = 0 },
+		"máximo menor":        func(p *models.CreditProduct) { p.MaxAmount = 1_000 },
+		"sin plazos":          func(p *models.CreditProduct) { p.AllowedTerms = nil },
+		"plazo repetido":      func(p *models.CreditProduct) { p.AllowedTerms = models.TermList{12, 12} },
+		"sin tasa":            func(p *models.CreditProduct) { p.BaseAnnualRate = 0 },
+		"ponderador negativo": func(p *models.CreditProduct) { p.RiskWeight = -1 },
+		"método desconocido":  func(p *models.CreditProduct) { p.AmortizationMethod = "ANTICIPADO" },
+		"documento inválido":  func(p *models.CreditProduct) { p.RequiredDocuments = []string{"PASAPORTE"} },
+		"documento repetido":  func(p *models.CreditProduct) { p.RequiredDocuments = []string{"identity", "IDENTITY"} },
+	}
+
+	for name, mutate := range cases {
+		product := housingProduct()
+		mutate(product)
+		if _, err := service.CreateCreditProduct(product); err == nil {
+			t.Errorf("%s: se esperaba error", name)
+		}
+	}
+}
+
+func TestUpdateCreditProduct_ReemplazaConfiguracion(t *testing.T) {
+	existing := housingProduct()
+	existing.ID = 1
+	existing.Code = "HOUSING"
+	repo := NewMockCreditProductRepository([]*models.CreditProduct{existing})
+	service := NewCreditProductService(repo)
+
+	data := housingProduct()
+	data.MaxAmount = 900_000_000
+	data.CollateralRequired = true
+	data.Status = false
+
+	updated, err := service.UpdateCreditProduct(1, data)
+	if err != nil {
+		t.Fatalf("no se esperaba error: %v", err)
+	}
+	if updated.MaxAmount != 900_000_000 || !updated.CollateralRequired || updated.Status {
+		t.Errorf("producto no actualizado: %+v", updated)
+	}
+
+	if _, err := service.UpdateCreditProduct(99, housingProduct()); err == nil || !strings.Contains(err.Error(), "no existe") {
+		t.Fatalf("se esperaba error por producto inexistente, obtenido: %v", err)
+	}
+}
+
+func TestDeleteCreditProduct_ConSolicitudes(t *testing.T) {
+	existing := housingProduct()
+	existing.ID = 1
+	repo := NewMockCreditProductRepository([]*models.CreditProduct{existing})
+	repo.WithRequests[1] = true
+	service := NewCreditProductService(repo)
+
+	if err := service.DeleteCreditProduct(1); err == nil || !strings.Contains(err.Error(), "no se puede eliminar") {
+		t.Fatalf("se esperaba error por solicitudes asociadas, obtenido: %v", err)
+	}
+
+	repo.WithRequests[1] = false
+	if err := service.DeleteCreditProduct(1); err != nil {
+		t.Fatalf("no se esperaba error: %v", err)
+	}
+	if len(repo.Deleted) != 1 {
+		t.Errorf("se esperaba el producto eliminado")
+	}
+}
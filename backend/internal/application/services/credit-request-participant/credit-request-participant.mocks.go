@@ -0,0 +1,228 @@
+package creditRequestParticipant
+
+import (
+	"errors"
+
+	"github.com/JhonCamargo53/prueba-tecnica/internal/domain/models"
+	"github.com/JhonCamargo53/prueba-tecnica/internal/domain/ports"
+)
+
+/* Mock de CreditRequestParticipantRepository */
+
+type MockCreditRequestParticipantRepository struct {
+	Participants []models.CreditRequestParticipant
+}
+
+var _ ports.CreditRequestParticipantRepository = (*MockCreditRequestParticipantRepository)(nil)
+
+func (m *MockCreditRequestParticipantRepository) FindByCreditRequestID(creditRequestID uint) ([]models.CreditRequestParticipant, error) {
+	var res []models.CreditRequestParticipant
+	for _, p := range m.Participants {
+		if p.CreditRequestID == creditRequestID {
+			res = append(res, p)
+		}
+	}
+	return res, nil
+}
+
+func (m *MockCreditRequestParticipantRepository) FindByID(id uint) (*models.CreditRequestParticipant, error) {
+	for i := range m.Participants {
+		if m.Participants[i].ID == id {
+			return &m.Participants[i], nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *MockCreditRequestParticipantRepository) Create(participant *models.CreditRequestParticipant) error {
+	participant.ID = uint(len(m.Participants) + 1)
+	m.Participants = append(m.Participants, *participant)
+	return nil
+}
+
+func (m *MockCreditRequestParticipantRepository) Delete(id uint) error {
+	for i, p := range m.Participants {
+		if p.ID == id {
+			m.Participants = append(m.Participants[:i], m.Participants[i+1:]...)
+			return nil
+		}
+	}
+	return nil
+}
+
+/* Mock de CreditRequestRepository: FindDataToEvaluateRisk carga los participantes */
+
+type MockCreditRequestRepository struct {
+	Requests     map[uint]*models.CreditRequest
+	Participants *MockCreditRequestParticipantRepository
+	Customers    *MockCustomerRepository
+}
+
+var _ ports.CreditRequestRepository = (*MockCreditRequestRepository)(nil)
+
+func (m *MockCreditRequestRepository) FindAll(customerID *uint) ([]models.CreditRequest, error) {
+	return nil, nil
+}
+
+func (m *MockCreditRequestRepository) FindByID(id uint) (*models.CreditRequest, error) {
+	return m.Requests[id], nil
+}
+
+func (m *MockCreditRequestRepository) HasRequestsByCustomerID(customerID uint) (bool, error) {
+	return false, nil
+}
+
+func (m *MockCreditRequestRepository) Create(creditRequest *models.CreditRequest) (*models.CreditRequest, error) {
+	m.Requests[creditRequest.ID] = creditRequest
+	return creditRequest, nil
+}
+
+func (m *MockCreditRequestRepository) Update(id uint, creditRequest *models.CreditRequest) (*models.CreditRequest, error) {
+	m.Requests[id] = creditRequest
+	return creditRequest, nil
+}
+
+func (m *MockCreditRequestRepository) Delete(id uint) error {
+	delete(m.Requests, id)
+	return nil
+}
+
+func (m *MockCreditRequestRepository) UpdateCreditRiskEvaluation(id uint, assessment *models.RiskAssessment) (*models.CreditRequest, error) {
+	return m.Requests[id], nil
+}
+
+func (m *MockCreditRequestRepository) UpdateCreditStatus(id uint, creditStatusID uint) error {
+	return nil
+}
+
+func (m *MockCreditRequestRepository) UpdateCreditOffer(id uint, offer *models.CreditOffer) error {
+	return nil
+}
+
+func (m *MockCreditRequestRepository) FindDataToEvaluateRisk(id uint) (models.Customer, *models.CreditRequest, []models.CreditRequest, []models.CustomerAsset, error) {
+	cr, ok := m.Requests[id]
+	if !ok {
+		return models.Customer{}, nil, nil, nil, errors.New("credit request no encontrada")
+	}
+
+	evaluated := *cr
+	evaluated.Participants, _ = m.Participants.FindByCreditRequestID(id)
+	for i := range evaluated.Participants {
+		if customer := m.Customers.Customers[evaluated.Participants[i].CustomerID]; customer != nil {
+			evaluated.Participants[i].Customer = *customer
+		}
+	}
+
+	customer := models.Customer{ID: cr.CustomerID, MonthlyIncome: 5_000_000}
+	return customer, &evaluated, nil, nil, nil
+}
+
+/* Mock de CustomerRepository */
+
+type MockCustomerRepository struct {
+	Customers map[uint]*models.Customer
+}
+
+var _ ports.CustomerRepository = (*MockCustomerRepository)(nil)
+
+func (m *MockCustomerRepository) FindAllOrderedByCreatedDesc() ([]models.Customer, error) {
+	return nil, nil
+}
+
+func (m *MockCustomerRepository) FindByID(id uint) (*models.Customer, error) {
+	return m.Customers[id], nil
+}
+
+func (m *MockCustomerRepository) FindByEmail(email string) (*models.Customer, error) {
+	return nil, nil
+}
+
+func (m *MockCustomerRepository) FindByDocument(documentNumber string, documentTypeID uint, excludeID *uint) (*models.Customer, error) {
+	return nil, nil
+}
+
+func (m *MockCustomerRepository) Create(customer *models.Customer) error {
+	m.Customers[customer.ID] = customer
+	return nil
+}
+
+func (m *MockCustomerRepository) Update(id uint, customerData *models.Customer) (*models.Customer, error) {
+	m.Customers[id] = customerData
+	return customerData, nil
+}
+
+func (m *MockCustomerRepository) Delete(id uint) error {
+	delete(m.Customers, id)
+	return nil
+}
+
+/* Mock de CustomerAssetRepository */
+
+type MockCustomerAssetRepository struct {
+	Assets []models.CustomerAsset
+}
+
+var _ ports.CustomerAssetRepository = (*MockCustomerAssetRepository)(nil)
+
+func (m *MockCustomerAssetRepository) FindAll(creditRequestID *uint) ([]models.CustomerAsset, error) {
+	var res []models.CustomerAsset
+	for _, a := range m.Assets {
+		if creditRequestID == nil || a.CreditRequestID == *creditRequestID {
+			res = append(res, a)
+		}
+	}
+	return res, nil
+}
+
+func (m *MockCustomerAssetRepository) FindByID(id uint) (*models.CustomerAsset, error) {
+	return nil, nil
+}
+
+func (m *MockCustomerAssetRepository) CountByCreditRequestID(creditRequestID uint) (int64, error) {
+	assets, _ := m.FindAll(&creditRequestID)
+	return int64(len(assets)), nil
+}
+
+func (m *MockCustomerAssetRepository) Create(ca *models.CustomerAsset) error {
+	m.Assets = append(m.Assets, *ca)
+	return nil
+}
+
+func (m *MockCustomerAssetRepository) Update(id uint, data *models.CustomerAsset) (*models.CustomerAsset, error) {
+	return data, nil
+}
+
+func (m *MockCustomerAssetRepository) Delete(id uint) error {
+	return nil
+}
+
+/* Mock de RiskEvaluator: guarda la solicitud evaluada */
+
+type MockRiskEvaluator struct {
+	Evaluated []models.CreditRequest
+}
+
+var _ ports.RiskEvaluator = (*MockRiskEvaluator)(nil)
+
+func (m *MockRiskEvaluator) Evaluate(customer models.Customer, currentCreditRequest models.CreditRequest,
+	otherCredits []models.CreditRequest, assets []models.CustomerAsset) (*models.RiskAssessment, error) {
+	m.Evaluated = append(m.Evaluated, currentCreditRequest)
+	return &models.RiskAssessment{EngineVersion: "mock-test", Score: 70, Category: "MEDIUM"}, nil
+}
+
+/* Mock de RiskEvaluationRepository */
+
+type MockRiskEvaluationRepository struct {
+	Evaluations []models.RiskEvaluation
+}
+
+var _ ports.RiskEvaluationRepository = (*MockRiskEvaluationRepository)(nil)
+
+func (m *MockRiskEvaluationRepository) Create(evaluation *models.RiskEvaluation) error {
+	m.Evaluations = append(m.Evaluations, *evaluation)
+	return nil
+}
+
+func (m *MockRiskEvaluationRepository) FindByCreditRequestID(creditRequestID uint) ([]models.RiskEvaluation, error) {
+	return m.Evaluations, nil
+}
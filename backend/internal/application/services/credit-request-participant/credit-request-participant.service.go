@@ -0,0 +1,156 @@
+package creditRequestParticipant
+
+import (
+	"fmt"
+
+	creditWorkflow "github.com/JhonCamargo53/prueba-tecnica/internal/application/services/credit-workflow"
+	riskEvaluation "github.com/JhonCamargo53/prueba-tecnica/internal/application/services/risk-evaluation"
+	"github.com/JhonCamargo53/prueba-tecnica/internal/domain/models"
+	"github.com/JhonCamargo53/prueba-tecnica/internal/domain/ports"
+)
+
+type CreditRequestParticipantService struct {
+	participantRepo   ports.CreditRequestParticipantRepository
+	creditRequestRepo ports.CreditRequestRepository
+	customerRepo      ports.CustomerRepository
+	customerAssetRepo ports.CustomerAssetRepository
+	riskEvaluation    *riskEvaluation.RiskEvaluationService
+	workflow          *creditWorkflow.CreditWorkflowService
+}
+
+func NewCreditRequestParticipantService(participantRepo ports.CreditRequestParticipantRepository,
+	creditRequestRepo ports.CreditRequestRepository, customerRepo ports.CustomerRepository,
+	customerAssetRepo ports.CustomerAssetRepository, riskEvaluationService *riskEvaluation.RiskEvaluationService,
+	workflowService *creditWorkflow.CreditWorkflowService) *CreditRequestParticipantService {
+	return &CreditRequestParticipantService{
+		participantRepo:   participantRepo,
+		creditRequestRepo: creditRequestRepo,
+		customerRepo:      customerRepo,
+		customerAssetRepo: customerAssetRepo,
+		riskEvaluation:    riskEvaluationService,
+		workflow:          workflowService,
+	}
+}
+
+func (s *CreditRequestParticipantService) GetParticipants(creditRequestID uint) ([]models.CreditRequestParticipant, error) {
+	if _, err := s.findCreditRequest(creditRequestID); err != nil {
+		return nil, err
+	}
+	return s.participantRepo.FindByCreditRequestID(creditRequestID)
+}
+
+// AddParticipant vincula un cliente a la solicitud como codeudor o fiador y
+// recalcula el riesgo. El titular es el cliente de la solicitud.
+func (s *CreditRequestParticipantService) AddParticipant(creditRequestID uint, customerID uint, role string) (*models.CreditRequestParticipant, error) {
+	if role != models.ParticipantRoleCoBorrower && role != models.ParticipantRoleGuarantor {
+		return nil, fmt.Errorf("el rol %s no es válido (CO_BORROWER o GUARANTOR); el titular es el cliente de la solicitud", role)
+	}
+
+	creditRequest, err := s.findEditableCreditRequest(creditRequestID)
+	if err != nil {
+		return nil, err
+	}
+
+	customer, err := s.customerRepo.FindByID(customerID)
+	if err != nil {
+		return nil, err
+	}
+	if customer == nil {
+		return nil, fmt.Errorf("no existe cliente con id %d", customerID)
+	}
+
+	if customerID == creditRequest.CustomerID {
+		return nil, fmt.Errorf("el cliente %d ya participa en la solicitud %d como titular", customerID, creditRequestID)
+	}
+
+	participants, err := s.participantRepo.FindByCreditRequestID(creditRequestID)
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range participants {
+		if p.CustomerID == customerID {
+			return nil, fmt.Errorf("el cliente %d ya participa en la solicitud %d como %s", customerID, creditRequestID, p.Role)
+		}
+	}
+
+	participant := &models.CreditRequestParticipant{
+		CreditRequestID: creditRequestID,
+		CustomerID:      customerID,
+		Role:            role,
+	}
+	if err := s.participantRepo.Create(participant); err != nil {
+		return nil, err
+	}
+	participant.Customer = *customer
+
+	// Recalcular riesgo y guardar historial
+	if _, err := s.riskEvaluation.EvaluateCreditRequest(creditRequestID, models.RiskTriggerParticipantAdded); err != nil {
+		return nil, err
+	}
+
+	return participant, nil
+}
+
+// RemoveParticipant desvincula un codeudor o fiador de la solicitud y recalcula el riesgo.
+func (s *CreditRequestParticipantService) RemoveParticipant(creditRequestID uint, participantID uint) error {
+	if _, err := s.findEditableCreditRequest(creditRequestID); err != nil {
+		return err
+	}
+
+	participant, err := s.participantRepo.FindByID(participantID)
+	if err != nil {
+		return err
+	}
+	if participant == nil || participant.CreditRequestID != creditRequestID {
+		return fmt.Errorf("no existe participante %d en la solicitud %d", participantID, creditRequestID)
+	}
+	if participant.Role == models.ParticipantRoleHolder {
+		return fmt.Errorf("el titular no se puede retirar de la solicitud; cambie el cliente de la solicitud")
+	}
+
+	// Los bienes del participante dejarían de tener ponderación en la garantía
+	assets, err := s.customerAssetRepo.FindAll(&creditRequestID)
+	if err != nil {
+		return err
+	}
+	for _, a := range assets {
+		if a.CustomerID == participant.CustomerID {
+			return fmt.Errorf("el cliente %d tiene bienes registrados en la solicitud %d: retírelos antes de quitarlo", participant.CustomerID, creditRequestID)
+		}
+	}
+
+	if err := s.participantRepo.Delete(participantID); err != nil {
+		return err
+	}
+
+	// Recalcular riesgo y guardar historial
+	_, err = s.riskEvaluation.EvaluateCreditRequest(creditRequestID, models.RiskTriggerParticipantRemoved)
+	return err
+}
+
+func (s *CreditRequestParticipantService) findCreditRequest(creditRequestID uint) (*models.CreditRequest, error) {
+	creditRequest, err := s.creditRequestRepo.FindByID(creditRequestID)
+	if err != nil {
+		return nil, err
+	}
+	if creditRequest == nil {
+		return nil, fmt.Errorf("no existe solicitud de crédito con id %d", creditRequestID)
+	}
+	return creditRequest, nil
+}
+
+// findEditableCreditRequest retorna la solicitud si sus participantes aún se pueden
+// modificar: una solicitud en estado terminal, aprobada o rechazada conserva los de su decisión.
+func (s *CreditRequestParticipantService) findEditableCreditRequest(creditRequestID uint) (*models.CreditRequest, error) {
+	creditRequest, err := s.findCreditRequest(creditRequestID)
+	if err != nil {
+		return nil, err
+	}
+	if creditRequest.CreditStatusID == models.CreditStatusApprovedID || creditRequest.CreditStatusID == models.CreditStatusRejectedID {
+		return nil, fmt.Errorf("la solicitud %d ya fue decidida: no se pueden modificar sus participantes", creditRequestID)
+	}
+	if err := s.workflow.CheckEditable(creditRequest); err != nil {
+		return nil, err
+	}
+	return creditRequest, nil
+}
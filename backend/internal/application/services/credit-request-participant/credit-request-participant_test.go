@@ -0,0 +1,135 @@
+package creditRequestParticipant
+
+import (
+	"strings"
+	"testing"
+
+	creditWorkflow "github.com/JhonCamargo53/prueba-tecnica/internal/application/services/credit-workflow"
+	riskEvaluation "github.com/JhonCamargo53/prueba-tecnica/internal/application/services/risk-evaluation"
+	"github.com/JhonCamargo53/prueba-tecnica/internal/domain/models"
+	"github.com/JhonCamargo53/prueba-tecnica/internal/domain/workflow"
+)
+
+type fixture struct {
+	service       *CreditRequestParticipantService
+	participants  *MockCreditRequestParticipantRepository
+	assets        *MockCustomerAssetRepository
+	riskEvaluator *MockRiskEvaluator
+}
+
+// Solicitud 10 del cliente 1 (titular) y solicitud 11 ya aprobada; los clientes 2 y 3 pueden participar
+func newFixture() *fixture {
+	customers := &MockCustomerRepository{Customers: map[uint]*models.Customer{
+		1: {ID: 1, MonthlyIncome: 5_000_000},
+		2: {ID: 2, MonthlyIncome: 3_000_000},
+		3: {ID: 3, MonthlyIncome: 8_000_000},
+	}}
+	participants := &MockCreditRequestParticipantRepository{Participants: []models.CreditRequestParticipant{
+		{ID: 1, CreditRequestID: 10, CustomerID: 1, Role: models.ParticipantRoleHolder},
+	}}
+	creditRequestRepo := &MockCreditRequestRepository{
+		Requests: map[uint]*models.CreditRequest{
+			10: {ID: 10, CustomerID: 1, CreditStatusID: models.CreditStatusPendingID},
+			11: {ID: 11, CustomerID: 1, CreditStatusID: models.CreditStatusApprovedID},
+		},
+		Participants: participants,
+		Customers:    customers,
+	}
+	assets := &MockCustomerAssetRepository{}
+	riskEvaluator := &MockRiskEvaluator{}
+
+	riskEvaluationService := riskEvaluation.NewRiskEvaluationService(creditRequestRepo, &MockRiskEvaluationRepository{}, riskEvaluator)
+
+	return &fixture{
+		service: NewCreditRequestParticipantService(participants, creditRequestRepo, customers, assets, riskEvaluationService,
+			creditWorkflow.NewCreditWorkflowService(workflow.DefaultRules(), nil, creditRequestRepo, nil, nil)),
+		participants:  participants,
+		assets:        assets,
+		riskEvaluator: riskEvaluator,
+	}
+}
+
+func TestAddParticipant_RecalculaElRiesgoConElParticipante(t *testing.T) {
+	f := newFixture()
+
+	participant, err := f.service.AddParticipant(10, 2, models.ParticipantRoleCoBorrower)
+	if err != nil {
+		t.Fatalf("no se esperaba error: %v", err)
+	}
+	if participant.ID == 0 || participant.Customer.MonthlyIncome != 3_000_000 {
+		t.Errorf("participante inesperado: %+v", participant)
+	}
+
+	if len(f.riskEvaluator.Evaluated) != 1 {
+		t.Fatalf("se esperaba recalcular el riesgo una vez, obtenido: %d", len(f.riskEvaluator.Evaluated))
+	}
+	evaluated := f.riskEvaluator.Evaluated[0]
+	if len(evaluated.Participants) != 2 || evaluated.Participants[1].Customer.MonthlyIncome != 3_000_000 {
+		t.Errorf("el motor debería recibir al titular y al codeudor con sus ingresos: %+v", evaluated.Participants)
+	}
+
+	participants, _ := f.service.GetParticipants(10)
+	if len(participants) != 2 || participants[1].Role != models.ParticipantRoleCoBorrower {
+		t.Errorf("participantes inesperados: %+v", participants)
+	}
+}
+
+func TestAddParticipant_Validaciones(t *testing.T) {
+	f := newFixture()
+	if _, err := f.service.AddParticipant(10, 3, models.ParticipantRoleGuarantor); err != nil {
+		t.Fatalf("no se esperaba error: %v", err)
+	}
+
+	cases := map[string]struct {
+		creditRequestID uint
+		customerID      uint
+		role            string
+		want            string
+	}{
+		"rol titular":           {10, 2, models.ParticipantRoleHolder, "no es válido"},
+		"rol desconocido":       {10, 2, "AVALISTA", "no es válido"},
+		"titular":               {10, 1, models.ParticipantRoleCoBorrower, "como titular"},
+		"participante repetido": {10, 3, models.ParticipantRoleCoBorrower, "ya participa"},
+		"cliente inexistente":   {10, 99, models.ParticipantRoleCoBorrower, "no existe cliente"},
+		"solicitud inexistente": {99, 2, models.ParticipantRoleCoBorrower, "no existe solicitud"},
+		"solicitud decidida":    {11, 2, models.ParticipantRoleCoBorrower, "ya fue decidida"},
+	}
+
+	for name, c := range cases {
+		if _, err := f.service.AddParticipant(c.creditRequestID, c.customerID, c.role); err == nil || !strings.Contains(err.Error(), c.want) {
+			t.Errorf("%s: se esperaba error %q, obtenido: %v", name, c.want, err)
+		}
+	}
+}
+
+func TestRemoveParticipant(t *testing.T) {
+	f := newFixture()
+	guarantor, err := f.service.AddParticipant(10, 3, models.ParticipantRoleGuarantor)
+	if err != nil {
+		t.Fatalf("no se esperaba error: %v", err)
+	}
+
+	if err := f.service.RemoveParticipant(10, 1); err == nil || !strings.Contains(err.Error(), "titular") {
+		t.Errorf("se esperaba error al retirar al titular, obtenido: %v", err)
+	}
+	if err := f.service.RemoveParticipant(11, guarantor.ID); err == nil || !strings.Contains(err.Error(), "ya fue decidida") {
+		t.Errorf("se esperaba error por solicitud decidida, obtenido: %v", err)
+	}
+
+	// Con bienes registrados en la solicitud el fiador no se puede retirar
+	f.assets.Assets = []models.CustomerAsset{{ID: 1, CreditRequestID: 10, CustomerID: 3, MarketValue: 100_000_000}}
+	if err := f.service.RemoveParticipant(10, guarantor.ID); err == nil || !strings.Contains(err.Error(), "bienes registrados") {
+		t.Errorf("se esperaba error por bienes del fiador, obtenido: %v", err)
+	}
+
+	f.assets.Assets = nil
+	if err := f.service.RemoveParticipant(10, guarantor.ID); err != nil {
+		t.Fatalf("no se esperaba error: %v", err)
+	}
+	if participants, _ := f.service.GetParticipants(10); len(participants) != 1 {
+		t.Errorf("sólo debería quedar el titular: %+v", participants)
+	}
+	if len(f.riskEvaluator.Evaluated) != 2 {
+		t.Errorf("se esperaba recalcular el riesgo al agregar y al retirar, obtenido: %d", len(f.riskEvaluator.Evaluated))
+	}
+}
@@ -21,11 +21,14 @@ type MockCreditRequestRepository struct {
 	ErrUpdateRisk error
 	ErrFindData   error
 
-	UpdateRiskCalled bool
-	LastRiskID       uint
-	LastScore        float64
-	LastCategory     string
-	LastExplanation  string
+	UpdateRiskCalled   bool
+	LastRiskID         uint
+	LastScore          float64
+	LastCategory       string
+	LastExplanation    string
+	LastRuleSetVersion string
+	LastStatusID       uint
+	LastOffer          *models.CreditOffer
 }
 
 var _ ports.CreditRequestRepository = (*MockCreditRequestRepository)(nil)
@@ -130,15 +133,16 @@ func (m *MockCreditRequestRepository) Delete(id uint) error {
 	return nil
 }
 
-func (m *MockCreditRequestRepository) UpdateCreditRiskEvaluation(id uint, score float64, category string, explanation string) (*models.CreditRequest, error) {
+func (m *MockCreditRequestRepository) UpdateCreditRiskEvaluation(id uint, assessment *models.RiskAssessment) (*models.CreditRequest, error) {
 	if m.ErrUpdateRisk != nil {
 		return nil, m.ErrUpdateRisk
 	}
 	m.UpdateRiskCalled = true
 	m.LastRiskID = id
-	m.LastScore = score
-	m.LastCategory = category
-	m.LastExplanation = explanation
+	m.LastScore = assessment.Score
+	m.LastCategory = assessment.Category
+	m.LastExplanation = assessment.Explanation
+	m.LastRuleSetVersion = assessment.EngineVersion
 
 	cr, ok := m.Requests[id]
 	if !ok {
@@ -149,6 +153,25 @@ func (m *MockCreditRequestRepository) UpdateCreditRiskEvaluation(id uint, score
 	return &copy, nil
 }
 
+func (m *MockCreditRequestRepository) UpdateCreditStatus(id uint, creditStatusID uint) error {
+	m.LastStatusID = creditStatusID
+	if cr, ok := m.Requests[id]; ok {
+		cr.CreditStatusID = creditStatusID
+	}
+	return nil
+}
+
+func (m *MockCreditRequestRepository) UpdateCreditOffer(id uint, offer *models.CreditOffer) error {
+	m.LastOffer = offer
+	if cr, ok := m.Requests[id]; ok {
+		cr.Offer = models.CreditOffer{}
+		if offer != nil {
+			cr.Offer = *offer
+		}
+	}
+	return nil
+}
+
 func (m *MockCreditRequestRepository) FindDataToEvaluateRisk(id uint) (models.Customer, *models.CreditRequest, []models.CreditRequest, []models.CustomerAsset, error) {
 	if m.ErrFindData != nil {
 		return models.Customer{}, nil, nil, nil, m.ErrFindData
@@ -344,10 +367,11 @@ func (m *MockCustomerAssetRepository) Delete(id uint) error {
 
 /* Mock de RiskEvaluator */
 type MockRiskEvaluator struct {
-	Score       float64
-	Category    string
-	Explanation string
-	Err         error
+	Score          float64
+	Category       string
+	Explanation    string
+	RuleSetVersion string
+	Err            error
 
 	Called       bool
 	CalledWithCR uint
@@ -356,13 +380,189 @@ type MockRiskEvaluator struct {
 var _ ports.RiskEvaluator = (*MockRiskEvaluator)(nil)
 
 func (m *MockRiskEvaluator) Evaluate(customer models.Customer, currentCreditRequest models.CreditRequest,
-	otherCredits []models.CreditRequest, assets []models.CustomerAsset) (float64, string, string, error) {
+	otherCredits []models.CreditRequest, assets []models.CustomerAsset) (*models.RiskAssessment, error) {
 
 	m.Called = true
 	m.CalledWithCR = currentCreditRequest.ID
 
 	if m.Err != nil {
-		return 0, "", "", m.Err
+		return nil, m.Err
 	}
-	return m.Score, m.Category, m.Explanation, nil
+	return &models.RiskAssessment{
+		EngineVersion: m.RuleSetVersion,
+		Score:         m.Score,
+		Category:      m.Category,
+		Explanation:   m.Explanation,
+	}, nil
+}
+
+/* Mock de RiskEvaluationRepository */
+
+type MockRiskEvaluationRepository struct {
+	Evaluations []models.RiskEvaluation
+
+	ErrCreate error
+}
+
+var _ ports.RiskEvaluationRepository = (*MockRiskEvaluationRepository)(nil)
+
+func NewMockRiskEvaluationRepository() *MockRiskEvaluationRepository {
+	return &MockRiskEvaluationRepository{}
+}
+
+func (m *MockRiskEvaluationRepository) Create(evaluation *models.RiskEvaluation) error {
+	if m.ErrCreate != nil {
+		return m.ErrCreate
+	}
+	evaluation.ID = uint(len(m.Evaluations) + 1)
+	m.Evaluations = append(m.Evaluations, *evaluation)
+	return nil
+}
+
+func (m *MockRiskEvaluationRepository) FindByCreditRequestID(creditRequestID uint) ([]models.RiskEvaluation, error) {
+	var res []models.RiskEvaluation
+	for _, e := range m.Evaluations {
+		if e.CreditRequestID == creditRequestID {
+			res = append(res, e)
+		}
+	}
+	return res, nil
+}
+
+/* Mock de CreditStatusHistoryRepository */
+
+type MockCreditStatusHistoryRepository struct {
+	History []models.CreditStatusHistory
+}
+
+var _ ports.CreditStatusHistoryRepository = (*MockCreditStatusHistoryRepository)(nil)
+
+func (m *MockCreditStatusHistoryRepository) Create(history *models.CreditStatusHistory) error {
+	history.ID = uint(len(m.History) + 1)
+	m.History = append(m.History, *history)
+	return nil
+}
+
+func (m *MockCreditStatusHistoryRepository) FindByCreditRequestID(creditRequestID uint) ([]models.CreditStatusHistory, error) {
+	var res []models.CreditStatusHistory
+	for _, h := range m.History {
+		if h.CreditRequestID == creditRequestID {
+			res = append(res, h)
+		}
+	}
+	return res, nil
+}
+
+/* Mock de UserRepository */
+
+type MockUserRepository struct {
+	Users map[uint]*models.User
+}
+
+var _ ports.UserRepository = (*MockUserRepository)(nil)
+
+func (m *MockUserRepository) FindAllOrderedByCreatedDesc() ([]models.User, error) {
+	return nil, nil
+}
+
+func (m *MockUserRepository) FindByID(id uint) (*models.User, error) {
+	if u, ok := m.Users[id]; ok {
+		copy := *u
+		return &copy, nil
+	}
+	return nil, nil
+}
+
+func (m *MockUserRepository) FindByEmail(email string) (*models.User, error) {
+	return nil, nil
+}
+
+func (m *MockUserRepository) Create(user *models.User) error {
+	return nil
+}
+
+func (m *MockUserRepository) Save(user *models.User) error {
+	return nil
+}
+
+func (m *MockUserRepository) Delete(id uint) error {
+	return nil
+}
+
+/* Mock de RoleRepository */
+
+type MockRoleRepository struct {
+	Roles map[uint]*models.Role
+}
+
+var _ ports.RoleRepository = (*MockRoleRepository)(nil)
+
+func (m *MockRoleRepository) FindAll() ([]models.Role, error) {
+	return nil, nil
+}
+
+func (m *MockRoleRepository) FindByID(id uint) (*models.Role, error) {
+	if r, ok := m.Roles[id]; ok {
+		copy := *r
+		return &copy, nil
+	}
+	return nil, nil
+}
+
+/* Mock de CreditProductRepository */
+
+type MockCreditProductRepository struct {
+	Products map[uint]*models.CreditProduct
+}
+
+var _ ports.CreditProductRepository = (*MockCreditProductRepository)(nil)
+
+func NewMockCreditProductRepository(products []*models.CreditProduct) *MockCreditProductRepository {
+	m := &MockCreditProductRepository{Products: make(map[uint]*models.CreditProduct)}
+	for _, p := range products {
+		m.Products[p.ID] = p
+	}
+	return m
+}
+
+func (m *MockCreditProductRepository) FindAll() ([]models.CreditProduct, error) {
+	var res []models.CreditProduct
+	for _, p := range m.Products {
+		res = append(res, *p)
+	}
+	return res, nil
+}
+
+func (m *MockCreditProductRepository) FindByID(id uint) (*models.CreditProduct, error) {
+	if p, ok := m.Products[id]; ok {
+		copy := *p
+		return &copy, nil
+	}
+	return nil, nil
+}
+
+func (m *MockCreditProductRepository) FindByCode(code string) (*models.CreditProduct, error) {
+	for _, p := range m.Products {
+		if p.Code == code {
+			copy := *p
+			return &copy, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *MockCreditProductRepository) Create(product *models.CreditProduct) error {
+	return nil
+}
+
+func (m *MockCreditProductRepository) Save(product *models.CreditProduct) error {
+	return nil
+}
+
+func (m *MockCreditProductRepository) Delete(id uint) error {
+	return nil
+}
+
+func (m *MockCreditProductRepository) HasCreditRequests(id uint) (bool, error) {
+	return false, nil
 }
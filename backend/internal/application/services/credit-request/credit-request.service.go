@@ -1,28 +1,43 @@
 package creditRequest
 
 import (
+	"encoding/json"
 	"fmt"
 
+	creditWorkflow "github.com/JhonCamargo53/prueba-tecnica/internal/application/services/credit-workflow"
+	riskEvaluation "github.com/JhonCamargo53/prueba-tecnica/internal/application/services/risk-evaluation"
+	"github.com/JhonCamargo53/prueba-tecnica/internal/domain/i18n"
 	"github.com/JhonCamargo53/prueba-tecnica/internal/domain/models"
 	"github.com/JhonCamargo53/prueba-tecnica/internal/domain/ports"
 )
 
+var errDecisionRequired = fmt.Errorf("la aprobación o el rechazo deben registrarse como decisión en POST /credit-requests/{id}/decision")
+
+func isDecisionStatus(creditStatusID uint) bool {
+	return creditStatusID == models.CreditStatusApprovedID || creditStatusID == models.CreditStatusRejectedID
+}
+
 type CreditRequestService struct {
 	creditRequestRepo ports.CreditRequestRepository
 	customerRepo      ports.CustomerRepository
 	creditStatusRepo  ports.CreditStatusRepository
 	customerAssetRepo ports.CustomerAssetRepository
-	riskEvaluator     ports.RiskEvaluator
+	creditProductRepo ports.CreditProductRepository
+	riskEvaluation    *riskEvaluation.RiskEvaluationService
+	workflow          *creditWorkflow.CreditWorkflowService
 }
 
 func NewCreditRequestService(creditRequestRepo ports.CreditRequestRepository, customerRepo ports.CustomerRepository,
-	creditStatusRepo ports.CreditStatusRepository, customerAssetRepo ports.CustomerAssetRepository, riskEvaluator ports.RiskEvaluator) *CreditRequestService {
+	creditStatusRepo ports.CreditStatusRepository, customerAssetRepo ports.CustomerAssetRepository,
+	creditProductRepo ports.CreditProductRepository, riskEvaluationService *riskEvaluation.RiskEvaluationService, workflowService *creditWorkflow.CreditWorkflowService) *CreditRequestService {
 	return &CreditRequestService{
 		creditRequestRepo: creditRequestRepo,
 		customerRepo:      customerRepo,
 		creditStatusRepo:  creditStatusRepo,
 		customerAssetRepo: customerAssetRepo,
-		riskEvaluator:     riskEvaluator,
+		creditProductRepo: creditProductRepo,
+		riskEvaluation:    riskEvaluationService,
+		workflow:          workflowService,
 	}
 }
 
@@ -51,7 +66,37 @@ func (s *CreditRequestService) GetCreditRequestByID(id uint) (*models.CreditRequ
 	return cr, nil
 }
 
-func (s *CreditRequestService) CreateCreditRequest(creditRequest *models.CreditRequest) (*models.CreditRequest, error) {
+// GetCreditRequestByIDInLanguage retorna la solicitud con la explicación de riesgo
+// renderizada en el idioma pedido a partir de la evaluación estructurada.
+func (s *CreditRequestService) GetCreditRequestByIDInLanguage(id uint, lang string) (*models.CreditRequest, error) {
+	cr, err := s.GetCreditRequestByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	// Solicitudes evaluadas antes de guardar la evaluación estructurada
+	if len(cr.RiskAssessment) == 0 {
+		return cr, nil
+	}
+
+	var assessment models.RiskAssessment
+	if err := json.Unmarshal(cr.RiskAssessment, &assessment); err != nil {
+		return nil, fmt.Errorf("evaluación de riesgo inválida para la solicitud %d: %w", id, err)
+	}
+
+	i18n.Localize(&assessment, lang)
+
+	localized, err := json.Marshal(assessment)
+	if err != nil {
+		return nil, err
+	}
+
+	cr.RiskAssessment = localized
+	cr.RiskExplanation = assessment.Explanation
+	return cr, nil
+}
+
+func (s *CreditRequestService) CreateCreditRequest(creditRequest *models.CreditRequest, requesterID uint) (*models.CreditRequest, error) {
 	// Validar cliente
 	customer, err := s.customerRepo.FindByID(creditRequest.CustomerID)
 	if err != nil {
@@ -69,28 +114,36 @@ func (s *CreditRequestService) CreateCreditRequest(creditRequest *models.CreditR
 	if status == nil {
 		return nil, fmt.Errorf("no existe el estado de solicitud con id %d", creditRequest.CreditStatusID)
 	}
-	_, err = s.creditRequestRepo.Create(creditRequest)
-	// Crear solicitud
-	if err != nil {
+	if isDecisionStatus(creditRequest.CreditStatusID) {
+		return nil, errDecisionRequired
+	}
+	if err := s.workflow.CheckInitial(creditRequest.CreditStatusID); err != nil {
 		return nil, err
 	}
 
-	// Evaluar riesgo (IA/MOCK)
-	customerData, creditRequest, otherCredits, customerAssets, err := s.creditRequestRepo.FindDataToEvaluateRisk(creditRequest.ID)
-
-	if err != nil {
+	// Validar monto y plazo contra el producto
+	if err := s.applyProduct(creditRequest, nil); err != nil {
 		return nil, err
 	}
 
-	// Recalcular riesgo
-	score, category, explanation, err := s.riskEvaluator.Evaluate(customerData, *creditRequest, otherCredits, customerAssets)
+	// El cliente de la solicitud queda como su titular
+	creditRequest.Participants = []models.CreditRequestParticipant{
+		{CustomerID: creditRequest.CustomerID, Role: models.ParticipantRoleHolder},
+	}
 
+	_, err = s.creditRequestRepo.Create(creditRequest)
+	// Crear solicitud
 	if err != nil {
 		return nil, err
 	}
 
-	//Actualizar riesgo
-	updatedCreditRequest, err := s.creditRequestRepo.UpdateCreditRiskEvaluation(creditRequest.ID, score, category, explanation)
+	// Registrar el estado inicial en el historial
+	if err := s.workflow.RecordCreation(creditRequest, &requesterID); err != nil {
+		return nil, err
+	}
+
+	// Evaluar riesgo (IA/MOCK) y guardar historial
+	updatedCreditRequest, err := s.riskEvaluation.EvaluateCreditRequest(creditRequest.ID, models.RiskTriggerCreditRequestCreated)
 
 	if err != nil {
 		return nil, err
@@ -99,13 +152,18 @@ func (s *CreditRequestService) CreateCreditRequest(creditRequest *models.CreditR
 	return updatedCreditRequest, nil
 }
 
-func (s *CreditRequestService) UpdateCreditRequest(id uint, crData *models.CreditRequest) (*models.CreditRequest, error) {
+func (s *CreditRequestService) UpdateCreditRequest(id uint, crData *models.CreditRequest, requesterID uint) (*models.CreditRequest, error) {
 	// Verificar que la solicitud exista
 	existing, err := s.GetCreditRequestByID(id)
 	if err != nil {
 		return nil, err
 	}
 
+	// Una solicitud en estado terminal conserva los datos de su plan de pagos y su cuenta
+	if err := s.workflow.CheckEditable(existing); err != nil {
+		return nil, err
+	}
+
 	// Validar cliente
 	customer, err := s.customerRepo.FindByID(crData.CustomerID)
 	if err != nil {
@@ -124,27 +182,35 @@ func (s *CreditRequestService) UpdateCreditRequest(id uint, crData *models.Credi
 		return nil, fmt.Errorf("no existe el estado de solicitud con id %d", crData.CreditStatusID)
 	}
 
-	// Actualizar
-	updated, err := s.creditRequestRepo.Update(id, crData)
-	if err != nil {
+	// Validar monto y plazo contra el producto
+	if err := s.applyProduct(crData, existing.CreditProductID); err != nil {
 		return nil, err
 	}
 
-	customerData, creditRequest, otherCredits, customerAssets, err := s.creditRequestRepo.FindDataToEvaluateRisk(id)
+	// La aprobación y el rechazo se registran como decisiones, con su justificación
+	if crData.CreditStatusID != existing.CreditStatusID && isDecisionStatus(crData.CreditStatusID) {
+		return nil, errDecisionRequired
+	}
 
+	// Validar la transición de estado según el rol de quien actualiza
+	role, err := s.workflow.Check(existing.CreditStatusID, crData.CreditStatusID, &requesterID)
 	if err != nil {
 		return nil, err
 	}
 
-	// Recalcular riesgo
-	score, category, explanation, err := s.riskEvaluator.Evaluate(customerData, *creditRequest, otherCredits, customerAssets)
-
+	// Actualizar
+	updated, err := s.creditRequestRepo.Update(id, crData)
 	if err != nil {
 		return nil, err
 	}
 
-	//Actualizar riesgo
-	updatedCreditRequest, err := s.creditRequestRepo.UpdateCreditRiskEvaluation(creditRequest.ID, score, category, explanation)
+	if err := s.workflow.Record(id, &existing.CreditStatusID, crData.CreditStatusID, &requesterID, role,
+		models.CreditStatusSourceUpdated, ""); err != nil {
+		return nil, err
+	}
+
+	// Recalcular riesgo y guardar historial
+	updatedCreditRequest, err := s.riskEvaluation.EvaluateCreditRequest(id, models.RiskTriggerCreditRequestUpdated)
 
 	if err != nil {
 		return nil, err
@@ -160,10 +226,16 @@ func (s *CreditRequestService) UpdateCreditRequest(id uint, crData *models.Credi
 func (s *CreditRequestService) DeleteCreditRequest(id uint) error {
 
 	// Verificar que exista
-	if _, err := s.GetCreditRequestByID(id); err != nil {
+	existing, err := s.GetCreditRequestByID(id)
+	if err != nil {
 		return err
 	}
 
+	// Una solicitud decidida conserva su decisión y, si se aprobó, su plan de pagos, su cuenta y sus pagos
+	if isDecisionStatus(existing.CreditStatusID) {
+		return fmt.Errorf("no se puede eliminar la solicitud de crédito %d porque ya fue decidida", id)
+	}
+
 	// Verificar activos asociados
 	count, err := s.customerAssetRepo.CountByCreditRequestID(id)
 	if err != nil {
@@ -180,3 +252,39 @@ func (s *CreditRequestService) DeleteCreditRequest(id uint) error {
 
 	return nil
 }
+
+// applyProduct valida que el producto exista y admita el monto y el plazo de la
+// solicitud, y copia su nombre en ProductType. Un producto desactivado sólo se
+// admite si la solicitud ya lo tenía (currentProductID).
+func (s *CreditRequestService) applyProduct(creditRequest *models.CreditRequest, currentProductID *uint) error {
+	if creditRequest.CreditProductID == nil || *creditRequest.CreditProductID == 0 {
+		return fmt.Errorf("el producto de crédito es obligatorio")
+	}
+
+	productID := *creditRequest.CreditProductID
+	product, err := s.creditProductRepo.FindByID(productID)
+	if err != nil {
+		return err
+	}
+	if product == nil {
+		return fmt.Errorf("no existe producto de crédito con id %d", productID)
+	}
+
+	keepsProduct := currentProductID != nil && *currentProductID == productID
+	if !product.Status && !keepsProduct {
+		return fmt.Errorf("el producto de crédito %s no está activo", product.Code)
+	}
+
+	if creditRequest.Amount < product.MinAmount || creditRequest.Amount > product.MaxAmount {
+		return fmt.Errorf("el monto %.0f está fuera del rango del producto %s (%.0f - %.0f)",
+			creditRequest.Amount, product.Code, product.MinAmount, product.MaxAmount)
+	}
+
+	if !product.AllowsTerm(creditRequest.TermMonths) {
+		return fmt.Errorf("el plazo de %d meses no está permitido para el producto %s (plazos permitidos: %v)",
+			creditRequest.TermMonths, product.Code, []int(product.AllowedTerms))
+	}
+
+	creditRequest.ProductType = product.Name
+	return nil
+}
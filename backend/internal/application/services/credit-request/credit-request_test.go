@@ -1,11 +1,51 @@
 package creditRequest
 
 import (
+	"strings"
 	"testing"
 
+	creditWorkflow "github.com/JhonCamargo53/prueba-tecnica/internal/application/services/credit-workflow"
+	riskEvaluation "github.com/JhonCamargo53/prueba-tecnica/internal/application/services/risk-evaluation"
 	"github.com/JhonCamargo53/prueba-tecnica/internal/domain/models"
+	"github.com/JhonCamargo53/prueba-tecnica/internal/domain/ports"
+	"github.com/JhonCamargo53/prueba-tecnica/internal/domain/workflow"
 )
 
+// Usuario con rol EMPLOYEE que hace las solicitudes en las pruebas
+const requesterID uint = 1
+
+const (
+	personalProductID uint = 1
+	inactiveProductID uint = 2
+)
+
+func productID(id uint) *uint {
+	return &id
+}
+
+func newCreditProductRepo() *MockCreditProductRepository {
+	return NewMockCreditProductRepository([]*models.CreditProduct{
+		{ID: personalProductID, Code: "PERSONAL", Name: "Préstamo personal", MinAmount: 1_000_000, MaxAmount: 80_000_000,
+			AllowedTerms: models.TermList{12, 24, 36}, BaseAnnualRate: 22, RiskWeight: 1, Status: true},
+		{ID: inactiveProductID, Code: "OLD", Name: "Producto retirado", MinAmount: 1_000_000, MaxAmount: 80_000_000,
+			AllowedTerms: models.TermList{12, 24, 36}, BaseAnnualRate: 22, RiskWeight: 1, Status: false},
+	})
+}
+
+func newWorkflowService(creditRequestRepo ports.CreditRequestRepository, historyRepo *MockCreditStatusHistoryRepository) *creditWorkflow.CreditWorkflowService {
+	if historyRepo == nil {
+		historyRepo = &MockCreditStatusHistoryRepository{}
+	}
+	userRepo := &MockUserRepository{Users: map[uint]*models.User{
+		requesterID: {ID: requesterID, RoleId: 2},
+	}}
+	roleRepo := &MockRoleRepository{Roles: map[uint]*models.Role{
+		1: {ID: 1, Name: "ADMIN", Access: 1000},
+		2: {ID: 2, Name: "EMPLOYEE", Access: 100},
+	}}
+	return creditWorkflow.NewCreditWorkflowService(workflow.DefaultRules(), historyRepo, creditRequestRepo, userRepo, roleRepo)
+}
+
 /* GetAllCreditRequests */
 
 func TestGetAllCreditRequests_ClienteNoExiste(t *testing.T) {
@@ -16,7 +56,8 @@ func TestGetAllCreditRequests_ClienteNoExiste(t *testing.T) {
 	customerAssetRepo := NewMockCustomerAssetRepository(nil)
 	riskEvaluator := &MockRiskEvaluator{}
 
-	service := NewCreditRequestService(creditRequestRepo, customerRepo, statusRepo, customerAssetRepo, riskEvaluator)
+	riskEvaluationService := riskEvaluation.NewRiskEvaluationService(creditRequestRepo, NewMockRiskEvaluationRepository(), riskEvaluator)
+	service := NewCreditRequestService(creditRequestRepo, customerRepo, statusRepo, customerAssetRepo, newCreditProductRepo(), riskEvaluationService, newWorkflowService(creditRequestRepo, nil))
 
 	customerID := uint(1)
 	creditRequest, err := service.GetAllCreditRequests(&customerID)
@@ -40,7 +81,8 @@ func TestGetAllCreditRequests_Exitoso(t *testing.T) {
 	statusRepo := NewMockCreditStatusRepository(nil)
 	customerAssetRepo := NewMockCustomerAssetRepository(nil)
 	riskEvaluator := &MockRiskEvaluator{}
-	service := NewCreditRequestService(creditRequestRepo, customerRepo, statusRepo, customerAssetRepo, riskEvaluator)
+	riskEvaluationService := riskEvaluation.NewRiskEvaluationService(creditRequestRepo, NewMockRiskEvaluationRepository(), riskEvaluator)
+	service := NewCreditRequestService(creditRequestRepo, customerRepo, statusRepo, customerAssetRepo, newCreditProductRepo(), riskEvaluationService, newWorkflowService(creditRequestRepo, nil))
 
 	customerID := uint(10)
 	creditRequest, err := service.GetAllCreditRequests(&customerID)
@@ -62,7 +104,8 @@ func TestGetCreditRequestByID_NoExiste(t *testing.T) {
 	customerAssetRepo := NewMockCustomerAssetRepository(nil)
 	riskEvaluator := &MockRiskEvaluator{}
 
-	service := NewCreditRequestService(creditRequestRepo, customerRepo, statusRepo, customerAssetRepo, riskEvaluator)
+	riskEvaluationService := riskEvaluation.NewRiskEvaluationService(creditRequestRepo, NewMockRiskEvaluationRepository(), riskEvaluator)
+	service := NewCreditRequestService(creditRequestRepo, customerRepo, statusRepo, customerAssetRepo, newCreditProductRepo(), riskEvaluationService, newWorkflowService(creditRequestRepo, nil))
 
 	cr, err := service.GetCreditRequestByID(99)
 
@@ -83,7 +126,8 @@ func TestGetCreditRequestByID_Exitoso(t *testing.T) {
 	customerAssetRepo := NewMockCustomerAssetRepository(nil)
 	riskEvaluator := &MockRiskEvaluator{}
 
-	service := NewCreditRequestService(creditRequestRepo, customerRepo, statusRepo, customerAssetRepo, riskEvaluator)
+	riskEvaluationService := riskEvaluation.NewRiskEvaluationService(creditRequestRepo, NewMockRiskEvaluationRepository(), riskEvaluator)
+	service := NewCreditRequestService(creditRequestRepo, customerRepo, statusRepo, customerAssetRepo, newCreditProductRepo(), riskEvaluationService, newWorkflowService(creditRequestRepo, nil))
 
 	cr, err := service.GetCreditRequestByID(5)
 
@@ -110,7 +154,8 @@ func TestCreateCreditRequest_ClienteNoExiste(t *testing.T) {
 		Explanation: "OK",
 	}
 
-	service := NewCreditRequestService(creditRequestRepo, customerRepo, statusRepo, customerAssetRepo, riskEvaluator)
+	riskEvaluationService := riskEvaluation.NewRiskEvaluationService(creditRequestRepo, NewMockRiskEvaluationRepository(), riskEvaluator)
+	service := NewCreditRequestService(creditRequestRepo, customerRepo, statusRepo, customerAssetRepo, newCreditProductRepo(), riskEvaluationService, newWorkflowService(creditRequestRepo, nil))
 
 	cr := &models.CreditRequest{
 		CustomerID:     99, // no existe
@@ -118,7 +163,7 @@ func TestCreateCreditRequest_ClienteNoExiste(t *testing.T) {
 		Amount:         10_000_000,
 	}
 
-	created, err := service.CreateCreditRequest(cr)
+	created, err := service.CreateCreditRequest(cr, requesterID)
 
 	if err == nil {
 		t.Fatalf("se esperaba error porque el cliente no existe")
@@ -141,7 +186,8 @@ func TestCreateCreditRequest_EstadoNoExiste(t *testing.T) {
 		Explanation: "OK",
 	}
 
-	service := NewCreditRequestService(creditRequestRepo, customerRepo, statusRepo, customerAssetRepo, riskEvaluator)
+	riskEvaluationService := riskEvaluation.NewRiskEvaluationService(creditRequestRepo, NewMockRiskEvaluationRepository(), riskEvaluator)
+	service := NewCreditRequestService(creditRequestRepo, customerRepo, statusRepo, customerAssetRepo, newCreditProductRepo(), riskEvaluationService, newWorkflowService(creditRequestRepo, nil))
 
 	cr := &models.CreditRequest{
 		CustomerID:     1,
@@ -149,7 +195,7 @@ func TestCreateCreditRequest_EstadoNoExiste(t *testing.T) {
 		Amount:         10_000_000,
 	}
 
-	created, err := service.CreateCreditRequest(cr)
+	created, err := service.CreateCreditRequest(cr, requesterID)
 
 	if err == nil {
 		t.Fatalf("se esperaba error porque el estado de crédito no existe")
@@ -177,15 +223,18 @@ func TestCreateCreditRequest_Exitoso_RecalculaRiesgo(t *testing.T) {
 		Explanation: "Buen perfil",
 	}
 
-	service := NewCreditRequestService(creditRequestRepo, customerRepo, statusRepo, customerAssetRepo, riskEvaluator)
+	riskEvaluationService := riskEvaluation.NewRiskEvaluationService(creditRequestRepo, NewMockRiskEvaluationRepository(), riskEvaluator)
+	service := NewCreditRequestService(creditRequestRepo, customerRepo, statusRepo, customerAssetRepo, newCreditProductRepo(), riskEvaluationService, newWorkflowService(creditRequestRepo, nil))
 
 	cr := &models.CreditRequest{
-		CustomerID:     1,
-		CreditStatusID: 1,
-		Amount:         20_000_000,
+		CustomerID:      1,
+		CreditStatusID:  1,
+		Amount:          20_000_000,
+		TermMonths:      24,
+		CreditProductID: productID(personalProductID),
 	}
 
-	created, err := service.CreateCreditRequest(cr)
+	created, err := service.CreateCreditRequest(cr, requesterID)
 	if err != nil {
 		t.Fatalf("no se esperaba error al crear solicitud: %v", err)
 	}
@@ -195,6 +244,9 @@ func TestCreateCreditRequest_Exitoso_RecalculaRiesgo(t *testing.T) {
 	if created.ID == 0 {
 		t.Fatalf("se esperaba que la solicitud tuviera ID asignado por el repo mock")
 	}
+	if len(cr.Participants) != 1 || cr.Participants[0].Role != models.ParticipantRoleHolder || cr.Participants[0].CustomerID != cr.CustomerID {
+		t.Fatalf("se esperaba el cliente de la solicitud como titular, obtenido: %+v", cr.Participants)
+	}
 
 	// Verificar riesgo
 	if !riskEvaluator.Called {
@@ -211,6 +263,68 @@ func TestCreateCreditRequest_Exitoso_RecalculaRiesgo(t *testing.T) {
 	}
 }
 
+func TestCreateCreditRequest_ValidaElProducto(t *testing.T) {
+	customerRepo := NewMockCustomerRepository([]*models.Customer{
+		{ID: 1, Name: "Cliente Test", MonthlyIncome: 5_000_000},
+	})
+	statusRepo := NewMockCreditStatusRepository([]*models.CreditStatus{
+		{ID: 1, Name: "PENDIENTE"},
+	})
+
+	cases := map[string]struct {
+		request *models.CreditRequest
+		message string
+	}{
+		"sin producto":         {&models.CreditRequest{Amount: 20_000_000, TermMonths: 24}, "obligatorio"},
+		"producto inexistente": {&models.CreditRequest{Amount: 20_000_000, TermMonths: 24, CreditProductID: productID(99)}, "no existe"},
+		"producto inactivo":    {&models.CreditRequest{Amount: 20_000_000, TermMonths: 24, CreditProductID: productID(inactiveProductID)}, "no está activo"},
+		"monto sobre máximo":   {&models.CreditRequest{Amount: 90_000_000, TermMonths: 24, CreditProductID: productID(personalProductID)}, "fuera del rango"},
+		"plazo no permitido":   {&models.CreditRequest{Amount: 20_000_000, TermMonths: 30, CreditProductID: productID(personalProductID)}, "no está permitido"},
+	}
+
+	for name, c := range cases {
+		creditRequestRepo := NewMockCreditRequestRepository(nil)
+		riskEvaluator := &MockRiskEvaluator{Score: 75, Category: "LOW"}
+		riskEvaluationService := riskEvaluation.NewRiskEvaluationService(creditRequestRepo, NewMockRiskEvaluationRepository(), riskEvaluator)
+		service := NewCreditRequestService(creditRequestRepo, customerRepo, statusRepo, NewMockCustomerAssetRepository(nil), newCreditProductRepo(),
+			riskEvaluationService, newWorkflowService(creditRequestRepo, nil))
+
+		c.request.CustomerID = 1
+		c.request.CreditStatusID = 1
+		_, err := service.CreateCreditRequest(c.request, requesterID)
+		if err == nil || !strings.Contains(err.Error(), c.message) {
+			t.Errorf("%s: se esperaba error con %q, obtenido: %v", name, c.message, err)
+		}
+		if len(creditRequestRepo.Requests) != 0 || riskEvaluator.Called {
+			t.Errorf("%s: no se debería crear ni evaluar la solicitud", name)
+		}
+	}
+}
+
+func TestCreateCreditRequest_UsaElNombreDelProducto(t *testing.T) {
+	customerRepo := NewMockCustomerRepository([]*models.Customer{
+		{ID: 1, Name: "Cliente Test", MonthlyIncome: 5_000_000},
+	})
+	statusRepo := NewMockCreditStatusRepository([]*models.CreditStatus{
+		{ID: 1, Name: "PENDIENTE"},
+	})
+	creditRequestRepo := NewMockCreditRequestRepository(nil)
+	riskEvaluationService := riskEvaluation.NewRiskEvaluationService(creditRequestRepo, NewMockRiskEvaluationRepository(), &MockRiskEvaluator{Score: 75})
+	service := NewCreditRequestService(creditRequestRepo, customerRepo, statusRepo, NewMockCustomerAssetRepository(nil), newCreditProductRepo(),
+		riskEvaluationService, newWorkflowService(creditRequestRepo, nil))
+
+	// El texto libre se reemplaza por el nombre del producto del catálogo
+	cr := &models.CreditRequest{CustomerID: 1, CreditStatusID: 1, Amount: 20_000_000, TermMonths: 12,
+		CreditProductID: productID(personalProductID), ProductType: "prestamo personl"}
+	if _, err := service.CreateCreditRequest(cr, requesterID); err != nil {
+		t.Fatalf("no se esperaba error: %v", err)
+	}
+
+	if stored := creditRequestRepo.Requests[cr.ID]; stored == nil || stored.ProductType != "Préstamo personal" {
+		t.Fatalf("se esperaba el nombre del producto en ProductType, obtenido: %+v", stored)
+	}
+}
+
 /* UpdateCreditRequest */
 
 func TestUpdateCreditRequest_ClienteNoExiste(t *testing.T) {
@@ -233,7 +347,8 @@ func TestUpdateCreditRequest_ClienteNoExiste(t *testing.T) {
 		Explanation: "OK",
 	}
 
-	service := NewCreditRequestService(creditRequestRepo, customerRepo, statusRepo, customerAssetRepo, riskEvaluator)
+	riskEvaluationService := riskEvaluation.NewRiskEvaluationService(creditRequestRepo, NewMockRiskEvaluationRepository(), riskEvaluator)
+	service := NewCreditRequestService(creditRequestRepo, customerRepo, statusRepo, customerAssetRepo, newCreditProductRepo(), riskEvaluationService, newWorkflowService(creditRequestRepo, nil))
 
 	updateData := &models.CreditRequest{
 		CustomerID:     99, // no existe
@@ -241,7 +356,7 @@ func TestUpdateCreditRequest_ClienteNoExiste(t *testing.T) {
 		Amount:         30_000_000,
 	}
 
-	updated, err := service.UpdateCreditRequest(10, updateData)
+	updated, err := service.UpdateCreditRequest(10, updateData, requesterID)
 
 	if err == nil {
 		t.Fatalf("se esperaba error porque el cliente no existe")
@@ -266,7 +381,8 @@ func TestUpdateCreditRequest_EstadoNoExiste(t *testing.T) {
 		Explanation: "Cambio de estado",
 	}
 
-	service := NewCreditRequestService(creditRequestRepo, customerRepo, statusRepo, customerAssetRepo, riskEvaluator)
+	riskEvaluationService := riskEvaluation.NewRiskEvaluationService(creditRequestRepo, NewMockRiskEvaluationRepository(), riskEvaluator)
+	service := NewCreditRequestService(creditRequestRepo, customerRepo, statusRepo, customerAssetRepo, newCreditProductRepo(), riskEvaluationService, newWorkflowService(creditRequestRepo, nil))
 
 	updateData := &models.CreditRequest{
 		CustomerID:     1,
@@ -274,7 +390,7 @@ func TestUpdateCreditRequest_EstadoNoExiste(t *testing.T) {
 		Amount:         30_000_000,
 	}
 
-	updated, err := service.UpdateCreditRequest(10, updateData)
+	updated, err := service.UpdateCreditRequest(10, updateData, requesterID)
 
 	if err == nil {
 		t.Fatalf("se esperaba error porque el estado no existe")
@@ -293,7 +409,7 @@ func TestUpdateCreditRequest_Exitoso_RecalculaRiesgo(t *testing.T) {
 	})
 	statusRepo := NewMockCreditStatusRepository([]*models.CreditStatus{
 		{ID: 1, Name: "PENDIENTE"},
-		{ID: 2, Name: "EN ESTUDIO"},
+		{ID: 4, Name: "EN ESTUDIO"},
 	})
 	customerAssetRepo := NewMockCustomerAssetRepository(nil)
 
@@ -303,15 +419,18 @@ func TestUpdateCreditRequest_Exitoso_RecalculaRiesgo(t *testing.T) {
 		Explanation: "Actualización de condiciones",
 	}
 
-	service := NewCreditRequestService(creditRequestRepo, customerRepo, statusRepo, customerAssetRepo, riskEvaluator)
+	riskEvaluationService := riskEvaluation.NewRiskEvaluationService(creditRequestRepo, NewMockRiskEvaluationRepository(), riskEvaluator)
+	service := NewCreditRequestService(creditRequestRepo, customerRepo, statusRepo, customerAssetRepo, newCreditProductRepo(), riskEvaluationService, newWorkflowService(creditRequestRepo, nil))
 
 	updateData := &models.CreditRequest{
-		CustomerID:     1,
-		CreditStatusID: 2,
-		Amount:         25_000_000,
+		CustomerID:      1,
+		CreditStatusID:  4,
+		Amount:          25_000_000,
+		TermMonths:      24,
+		CreditProductID: productID(personalProductID),
 	}
 
-	updated, err := service.UpdateCreditRequest(10, updateData)
+	updated, err := service.UpdateCreditRequest(10, updateData, requesterID)
 
 	if err != nil {
 		t.Fatalf("no se esperaba error al actualizar: %v", err)
@@ -328,6 +447,120 @@ func TestUpdateCreditRequest_Exitoso_RecalculaRiesgo(t *testing.T) {
 	}
 }
 
+func TestUpdateCreditRequest_AprobarRequiereDecision(t *testing.T) {
+	creditRequestRepo := NewMockCreditRequestRepository([]*models.CreditRequest{
+		{ID: 10, CustomerID: 1, CreditStatusID: 4, Amount: 20_000_000},
+	})
+	customerRepo := NewMockCustomerRepository([]*models.Customer{
+		{ID: 1, Name: "Cliente Test", MonthlyIncome: 5_000_000},
+	})
+	statusRepo := NewMockCreditStatusRepository([]*models.CreditStatus{
+		{ID: 2, Name: "APROBADO"},
+		{ID: 4, Name: "EN ESTUDIO"},
+	})
+
+	riskEvaluator := &MockRiskEvaluator{Score: 80, Category: "LOW"}
+	riskEvaluationService := riskEvaluation.NewRiskEvaluationService(creditRequestRepo, NewMockRiskEvaluationRepository(), riskEvaluator)
+	service := NewCreditRequestService(creditRequestRepo, customerRepo, statusRepo, NewMockCustomerAssetRepository(nil), newCreditProductRepo(), riskEvaluationService, newWorkflowService(creditRequestRepo, nil))
+
+	_, err := service.UpdateCreditRequest(10, &models.CreditRequest{CustomerID: 1, CreditStatusID: 2, Amount: 20_000_000,
+		TermMonths: 24, CreditProductID: productID(personalProductID)}, requesterID)
+	if err == nil {
+		t.Fatalf("se esperaba error: la aprobación debe registrarse como decisión")
+	}
+	if creditRequestRepo.Requests[10].CreditStatusID != 4 || riskEvaluator.Called {
+		t.Fatalf("no se debería modificar ni re-evaluar la solicitud")
+	}
+}
+
+func TestUpdateCreditRequest_RegistraCambioDeEstado(t *testing.T) {
+	creditRequestRepo := NewMockCreditRequestRepository([]*models.CreditRequest{
+		{ID: 10, CustomerID: 1, CreditStatusID: 1, Amount: 20_000_000},
+	})
+	customerRepo := NewMockCustomerRepository([]*models.Customer{
+		{ID: 1, Name: "Cliente Test", MonthlyIncome: 5_000_000},
+	})
+	statusRepo := NewMockCreditStatusRepository([]*models.CreditStatus{
+		{ID: 1, Name: "PENDIENTE"},
+		{ID: 4, Name: "EN ESTUDIO"},
+	})
+	historyRepo := &MockCreditStatusHistoryRepository{}
+
+	riskEvaluationService := riskEvaluation.NewRiskEvaluationService(creditRequestRepo, NewMockRiskEvaluationRepository(), &MockRiskEvaluator{Score: 80})
+	service := NewCreditRequestService(creditRequestRepo, customerRepo, statusRepo, NewMockCustomerAssetRepository(nil), newCreditProductRepo(),
+		riskEvaluationService, newWorkflowService(creditRequestRepo, historyRepo))
+
+	if _, err := service.UpdateCreditRequest(10, &models.CreditRequest{CustomerID: 1, CreditStatusID: 4, Amount: 20_000_000,
+		TermMonths: 24, CreditProductID: productID(personalProductID)}, requesterID); err != nil {
+		t.Fatalf("no se esperaba error: %v", err)
+	}
+
+	if len(historyRepo.History) != 1 {
+		t.Fatalf("se esperaba 1 cambio de estado en el historial, obtenidos %d", len(historyRepo.History))
+	}
+	h := historyRepo.History[0]
+	if *h.FromStatusID != 1 || h.ToStatusID != 4 || h.Role != "EMPLOYEE" || h.Source != models.CreditStatusSourceUpdated {
+		t.Errorf("historial inesperado: %+v", h)
+	}
+}
+
+func TestUpdateCreditRequest_AprobadaNoVuelveAPendiente(t *testing.T) {
+	creditRequestRepo := NewMockCreditRequestRepository([]*models.CreditRequest{
+		{ID: 10, CustomerID: 1, CreditStatusID: 2, Amount: 20_000_000},
+	})
+	customerRepo := NewMockCustomerRepository([]*models.Customer{
+		{ID: 1, Name: "Cliente Test", MonthlyIncome: 5_000_000},
+	})
+	statusRepo := NewMockCreditStatusRepository([]*models.CreditStatus{
+		{ID: 1, Name: "PENDIENTE"},
+		{ID: 2, Name: "APROBADO"},
+	})
+
+	riskEvaluator := &MockRiskEvaluator{Score: 80}
+	riskEvaluationService := riskEvaluation.NewRiskEvaluationService(creditRequestRepo, NewMockRiskEvaluationRepository(), riskEvaluator)
+	service := NewCreditRequestService(creditRequestRepo, customerRepo, statusRepo, NewMockCustomerAssetRepository(nil), newCreditProductRepo(),
+		riskEvaluationService, newWorkflowService(creditRequestRepo, nil))
+
+	_, err := service.UpdateCreditRequest(10, &models.CreditRequest{CustomerID: 1, CreditStatusID: 1, Amount: 20_000_000,
+		TermMonths: 24, CreditProductID: productID(personalProductID)}, requesterID)
+	if err == nil || !strings.Contains(err.Error(), "terminal") {
+		t.Fatalf("se esperaba error por estado terminal, obtenido: %v", err)
+	}
+	if creditRequestRepo.Requests[10].CreditStatusID != 2 || riskEvaluator.Called {
+		t.Fatalf("no se debería modificar ni re-evaluar la solicitud")
+	}
+}
+
+func TestUpdateCreditRequest_AprobadaNoSeEdita(t *testing.T) {
+	creditRequestRepo := NewMockCreditRequestRepository([]*models.CreditRequest{
+		{ID: 10, CustomerID: 1, CreditStatusID: 2, Amount: 20_000_000, TermMonths: 24, CreditProductID: productID(personalProductID)},
+	})
+	customerRepo := NewMockCustomerRepository([]*models.Customer{
+		{ID: 1, Name: "Cliente Test", MonthlyIncome: 5_000_000},
+		{ID: 2, Name: "Otro Cliente", MonthlyIncome: 9_000_000},
+	})
+	statusRepo := NewMockCreditStatusRepository([]*models.CreditStatus{
+		{ID: 2, Name: "APROBADO"},
+	})
+
+	riskEvaluator := &MockRiskEvaluator{Score: 80}
+	riskEvaluationService := riskEvaluation.NewRiskEvaluationService(creditRequestRepo, NewMockRiskEvaluationRepository(), riskEvaluator)
+	service := NewCreditRequestService(creditRequestRepo, customerRepo, statusRepo, NewMockCustomerAssetRepository(nil), newCreditProductRepo(),
+		riskEvaluationService, newWorkflowService(creditRequestRepo, nil))
+
+	// Mismo estado, pero otro monto, plazo y cliente: el plan de pagos ya se generó con los datos aprobados
+	_, err := service.UpdateCreditRequest(10, &models.CreditRequest{CustomerID: 2, CreditStatusID: 2, Amount: 60_000_000,
+		TermMonths: 36, CreditProductID: productID(personalProductID)}, requesterID)
+	if err == nil || !strings.Contains(err.Error(), "terminal") {
+		t.Fatalf("se esperaba error por estado terminal, obtenido: %v", err)
+	}
+
+	stored := creditRequestRepo.Requests[10]
+	if stored.Amount != 20_000_000 || stored.TermMonths != 24 || stored.CustomerID != 1 || riskEvaluator.Called {
+		t.Fatalf("no se debería modificar ni re-evaluar la solicitud: %+v", stored)
+	}
+}
+
 /* DeleteCreditRequest */
 
 func TestDeleteCreditRequest_ConActivosAsociados(t *testing.T) {
@@ -344,7 +577,8 @@ func TestDeleteCreditRequest_ConActivosAsociados(t *testing.T) {
 
 	riskEvaluator := &MockRiskEvaluator{}
 
-	service := NewCreditRequestService(creditRequestRepo, customerRepo, statusRepo, customerAssetRepo, riskEvaluator)
+	riskEvaluationService := riskEvaluation.NewRiskEvaluationService(creditRequestRepo, NewMockRiskEvaluationRepository(), riskEvaluator)
+	service := NewCreditRequestService(creditRequestRepo, customerRepo, statusRepo, customerAssetRepo, newCreditProductRepo(), riskEvaluationService, newWorkflowService(creditRequestRepo, nil))
 
 	err := service.DeleteCreditRequest(10)
 	if err == nil {
@@ -352,6 +586,24 @@ func TestDeleteCreditRequest_ConActivosAsociados(t *testing.T) {
 	}
 }
 
+func TestDeleteCreditRequest_Decidida(t *testing.T) {
+	for _, statusID := range []uint{models.CreditStatusApprovedID, models.CreditStatusRejectedID} {
+		creditRequestRepo := NewMockCreditRequestRepository([]*models.CreditRequest{
+			{ID: 10, CustomerID: 1, CreditStatusID: statusID},
+		})
+		riskEvaluationService := riskEvaluation.NewRiskEvaluationService(creditRequestRepo, NewMockRiskEvaluationRepository(), &MockRiskEvaluator{})
+		service := NewCreditRequestService(creditRequestRepo, NewMockCustomerRepository(nil), NewMockCreditStatusRepository(nil),
+			NewMockCustomerAssetRepository(nil), newCreditProductRepo(), riskEvaluationService, newWorkflowService(creditRequestRepo, nil))
+
+		if err := service.DeleteCreditRequest(10); err == nil || !strings.Contains(err.Error(), "ya fue decidida") {
+			t.Errorf("estado %d: se esperaba error por solicitud decidida, obtenido: %v", statusID, err)
+		}
+		if _, ok := creditRequestRepo.Requests[10]; !ok {
+			t.Errorf("estado %d: no se debería eliminar la solicitud", statusID)
+		}
+	}
+}
+
 func TestDeleteCreditRequest_Exitoso(t *testing.T) {
 	creditRequestRepo := NewMockCreditRequestRepository([]*models.CreditRequest{
 		{ID: 10, CustomerID: 1, CreditStatusID: 1},
@@ -363,7 +615,8 @@ func TestDeleteCreditRequest_Exitoso(t *testing.T) {
 	customerAssetRepo := NewMockCustomerAssetRepository(nil)
 	riskEvaluator := &MockRiskEvaluator{}
 
-	service := NewCreditRequestService(creditRequestRepo, customerRepo, statusRepo, customerAssetRepo, riskEvaluator)
+	riskEvaluationService := riskEvaluation.NewRiskEvaluationService(creditRequestRepo, NewMockRiskEvaluationRepository(), riskEvaluator)
+	service := NewCreditRequestService(creditRequestRepo, customerRepo, statusRepo, customerAssetRepo, newCreditProductRepo(), riskEvaluationService, newWorkflowService(creditRequestRepo, nil))
 
 	err := service.DeleteCreditRequest(10)
 	if err != nil {
@@ -374,3 +627,48 @@ func TestDeleteCreditRequest_Exitoso(t *testing.T) {
 		t.Fatalf("la solicitud de crédito debería haberse eliminado del repositorio")
 	}
 }
+
+/* GetCreditRequestByIDInLanguage */
+
+func TestGetCreditRequestByIDInLanguage_Ingles(t *testing.T) {
+	assessment := []byte(`{"engineVersion":"v1","score":82,"category":"LOW","recommendation":"APPROVE",
+		"factors":[{"code":"FIRST_REQUEST","points":5,"message":"Es la primera solicitud de crédito registrada para este cliente."}],
+		"improvements":[]}`)
+
+	creditRequestRepo := NewMockCreditRequestRepository([]*models.CreditRequest{
+		{ID: 10, CustomerID: 1, CreditStatusID: 1, RiskExplanation: "texto", RiskAssessment: assessment},
+	})
+	riskEvaluationService := riskEvaluation.NewRiskEvaluationService(creditRequestRepo, NewMockRiskEvaluationRepository(), &MockRiskEvaluator{})
+	service := NewCreditRequestService(creditRequestRepo, NewMockCustomerRepository(nil), NewMockCreditStatusRepository(nil),
+		NewMockCustomerAssetRepository(nil), newCreditProductRepo(), riskEvaluationService, newWorkflowService(creditRequestRepo, nil))
+
+	cr, err := service.GetCreditRequestByIDInLanguage(10, "en")
+	if err != nil {
+		t.Fatalf("no se esperaba error: %v", err)
+	}
+
+	if !strings.Contains(cr.RiskExplanation, "{Risk score:} 82.0/100") ||
+		!strings.Contains(cr.RiskExplanation, "This is the first credit request registered for this customer.") {
+		t.Errorf("se esperaba la explicación en inglés, obtenido: %s", cr.RiskExplanation)
+	}
+	if !strings.Contains(string(cr.RiskAssessment), "This is the first credit request") {
+		t.Errorf("se esperaba la evaluación estructurada en inglés, obtenido: %s", cr.RiskAssessment)
+	}
+}
+
+func TestGetCreditRequestByIDInLanguage_SinEvaluacionEstructurada(t *testing.T) {
+	creditRequestRepo := NewMockCreditRequestRepository([]*models.CreditRequest{
+		{ID: 10, CustomerID: 1, CreditStatusID: 1, RiskExplanation: "texto previo"},
+	})
+	riskEvaluationService := riskEvaluation.NewRiskEvaluationService(creditRequestRepo, NewMockRiskEvaluationRepository(), &MockRiskEvaluator{})
+	service := NewCreditRequestService(creditRequestRepo, NewMockCustomerRepository(nil), NewMockCreditStatusRepository(nil),
+		NewMockCustomerAssetRepository(nil), newCreditProductRepo(), riskEvaluationService, newWorkflowService(creditRequestRepo, nil))
+
+	cr, err := service.GetCreditRequestByIDInLanguage(10, "en")
+	if err != nil {
+		t.Fatalf("no se esperaba error: %v", err)
+	}
+	if cr.RiskExplanation != "texto previo" {
+		t.Errorf("se esperaba conservar la explicación original, obtenido: %s", cr.RiskExplanation)
+	}
+}
@@ -0,0 +1,158 @@
+package creditWorkflow
+
+import (
+	"errors"
+
+	"github.com/JhonCamargo53/prueba-tecnica/internal/domain/models"
+	"github.com/JhonCamargo53/prueba-tecnica/internal/domain/ports"
+)
+
+/* Mock de CreditStatusHistoryRepository */
+
+type MockCreditStatusHistoryRepository struct {
+	History []models.CreditStatusHistory
+}
+
+var _ ports.CreditStatusHistoryRepository = (*MockCreditStatusHistoryRepository)(nil)
+
+func (m *MockCreditStatusHistoryRepository) Create(history *models.CreditStatusHistory) error {
+	history.ID = uint(len(m.History) + 1)
+	m.History = append(m.History, *history)
+	return nil
+}
+
+func (m *MockCreditStatusHistoryRepository) FindByCreditRequestID(creditRequestID uint) ([]models.CreditStatusHistory, error) {
+	var res []models.CreditStatusHistory
+	for _, h := range m.History {
+		if h.CreditRequestID == creditRequestID {
+			res = append(res, h)
+		}
+	}
+	return res, nil
+}
+
+/* Mock de CreditRequestRepository */
+
+type MockCreditRequestRepository struct {
+	Requests map[uint]*models.CreditRequest
+
+	StatusUpdates int
+}
+
+var _ ports.CreditRequestRepository = (*MockCreditRequestRepository)(nil)
+
+func NewMockCreditRequestRepository(initial []*models.CreditRequest) *MockCreditRequestRepository {
+	m := &MockCreditRequestRepository{
+		Requests: make(map[uint]*models.CreditRequest),
+	}
+	for _, cr := range initial {
+		m.Requests[cr.ID] = cr
+	}
+	return m
+}
+
+func (m *MockCreditRequestRepository) FindAll(customerID *uint) ([]models.CreditRequest, error) {
+	return nil, nil
+}
+
+func (m *MockCreditRequestRepository) FindByID(id uint) (*models.CreditRequest, error) {
+	if cr, ok := m.Requests[id]; ok {
+		copy := *cr
+		return &copy, nil
+	}
+	return nil, nil
+}
+
+func (m *MockCreditRequestRepository) HasRequestsByCustomerID(customerID uint) (bool, error) {
+	return false, nil
+}
+
+func (m *MockCreditRequestRepository) Create(creditRequest *models.CreditRequest) (*models.CreditRequest, error) {
+	return creditRequest, nil
+}
+
+func (m *MockCreditRequestRepository) Update(id uint, creditRequest *models.CreditRequest) (*models.CreditRequest, error) {
+	return creditRequest, nil
+}
+
+func (m *MockCreditRequestRepository) Delete(id uint) error {
+	return nil
+}
+
+func (m *MockCreditRequestRepository) UpdateCreditRiskEvaluation(id uint, assessment *models.RiskAssessment) (*models.CreditRequest, error) {
+	return nil, nil
+}
+
+func (m *MockCreditRequestRepository) UpdateCreditStatus(id uint, creditStatusID uint) error {
+	cr, ok := m.Requests[id]
+	if !ok {
+		return errors.New("credit request no encontrada")
+	}
+	m.StatusUpdates++
+	cr.CreditStatusID = creditStatusID
+	return nil
+}
+
+func (m *MockCreditRequestRepository) UpdateCreditOffer(id uint, offer *models.CreditOffer) error {
+	return nil
+}
+
+func (m *MockCreditRequestRepository) FindDataToEvaluateRisk(id uint) (models.Customer, *models.CreditRequest, []models.CreditRequest, []models.CustomerAsset, error) {
+	return models.Customer{}, nil, nil, nil, nil
+}
+
+/* Mock de UserRepository */
+
+type MockUserRepository struct {
+	Users map[uint]*models.User
+}
+
+var _ ports.UserRepository = (*MockUserRepository)(nil)
+
+func (m *MockUserRepository) FindAllOrderedByCreatedDesc() ([]models.User, error) {
+	return nil, nil
+}
+
+func (m *MockUserRepository) FindByID(id uint) (*models.User, error) {
+	if u, ok := m.Users[id]; ok {
+		copy := *u
+		return &copy, nil
+	}
+	return nil, nil
+}
+
+func (m *MockUserRepository) FindByEmail(email string) (*models.User, error) {
+	return nil, nil
+}
+
+func (m *MockUserRepository) Create(user *models.User) error {
+	return nil
+}
+
+func (m *MockUserRepository) Save(user *models.User) error {
+	return nil
+}
+
+func (m *MockUserRepository) Delete(id uint) error {
+	return nil
+}
+
+/* Mock de RoleRepository */
+
+type MockRoleRepository struct {
+	Roles map[uint]*models.Role
+}
+
+var _ ports.RoleRepository = (*MockRoleRepository)(nil)
+
+func (m *MockRoleRepository) FindAll() ([]models.Role, error) {
+	return nil, nil
+}
+
+func (m *MockRoleRepository) FindByID(id uint) (*models.Role, error) {
+	if r, ok := m.Roles[id]; ok {
+		copy := *r
+		return &copy, nil
+	}
+	return nil, nil
+}
@@ -0,0 +1,185 @@
+package creditWorkflow
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/JhonCamargo53/prueba-tecnica/internal/domain/models"
+	"github.com/JhonCamargo53/prueba-tecnica/internal/domain/ports"
+	"github.com/JhonCamargo53/prueba-tecnica/internal/domain/workflow"
+	"github.com/JhonCamargo53/prueba-tecnica/internal/infrastructure/logger"
+)
+
+/*
+
+CreditWorkflowService aplica el grafo de estados de las solicitudes de
+crédito y guarda el historial de cambios. Los servicios que cambian el
+estado de una solicitud validan primero la transición con Check y luego la
+registran con Apply (o con Record si el estado ya se guardó junto con el
+resto de la solicitud). Si el cambio debe guardarse en la misma transacción
+que otros datos, se arma con NewChange y, ya guardado, se registra en el log
+con LogChange. Un actor nil corresponde a un cambio automático.
+
+*/
+
+type CreditWorkflowService struct {
+	rules             *workflow.Rules
+	historyRepo       ports.CreditStatusHistoryRepository
+	creditRequestRepo ports.CreditRequestRepository
+	userRepo          ports.UserRepository
+	roleRepo          ports.RoleRepository
+}
+
+func NewCreditWorkflowService(rules *workflow.Rules, historyRepo ports.CreditStatusHistoryRepository,
+	creditRequestRepo ports.CreditRequestRepository, userRepo ports.UserRepository, roleRepo ports.RoleRepository) *CreditWorkflowService {
+	return &CreditWorkflowService{
+		rules:             rules,
+		historyRepo:       historyRepo,
+		creditRequestRepo: creditRequestRepo,
+		userRepo:          userRepo,
+		roleRepo:          roleRepo,
+	}
+}
+
+// CheckInitial valida que una solicitud pueda crearse con el estado dado.
+func (s *CreditWorkflowService) CheckInitial(creditStatusID uint) error {
+	return s.rules.CheckInitial(creditStatusID)
+}
+
+// CheckEditable valida que los datos de la solicitud (monto, plazo, producto, bienes
+// y participantes) aún se puedan modificar: en un estado terminal se conservan los
+// datos con los que se decidió.
+func (s *CreditWorkflowService) CheckEditable(creditRequest *models.CreditRequest) error {
+	if s.rules.IsTerminal(creditRequest.CreditStatusID) {
+		return fmt.Errorf("la solicitud %d está en estado %s, que es terminal: no se puede modificar",
+			creditRequest.ID, s.rules.StatusName(creditRequest.CreditStatusID))
+	}
+	return nil
+}
+
+// Check valida que el actor pueda cambiar la solicitud de un estado a otro y
+// retorna el rol con el que lo hace. Mantener el mismo estado no es una transición.
+func (s *CreditWorkflowService) Check(from, to uint, actorID *uint) (string, error) {
+	role, err := s.roleOf(actorID)
+	if err != nil {
+		return "", err
+	}
+
+	if from == to {
+		return role, nil
+	}
+
+	if err := s.rules.Check(from, to, role); err != nil {
+		return "", err
+	}
+
+	return role, nil
+}
+
+// Apply cambia el estado de la solicitud y registra el cambio. La transición
+// debe haberse validado antes con Check.
+func (s *CreditWorkflowService) Apply(creditRequestID, from, to uint, actorID *uint, role, source, comment string) error {
+	if from == to {
+		return nil
+	}
+
+	if err := s.creditRequestRepo.UpdateCreditStatus(creditRequestID, to); err != nil {
+		return err
+	}
+
+	return s.Record(creditRequestID, &from, to, actorID, role, source, comment)
+}
+
+// Record guarda un cambio de estado ya persistido. from es nil al crear la solicitud.
+func (s *CreditWorkflowService) Record(creditRequestID uint, from *uint, to uint, actorID *uint, role, source, comment string) error {
+	if from != nil && *from == to {
+		return nil
+	}
+
+	history := s.NewChange(creditRequestID, from, to, actorID, role, source, comment)
+	if err := s.historyRepo.Create(history); err != nil {
+		return err
+	}
+
+	s.LogChange(history)
+	return nil
+}
+
+// NewChange arma el registro de un cambio de estado sin guardarlo.
+func (s *CreditWorkflowService) NewChange(creditRequestID uint, from *uint, to uint, actorID *uint, role, source, comment string) *models.CreditStatusHistory {
+	return &models.CreditStatusHistory{
+		CreditRequestID: creditRequestID,
+		FromStatusID:    from,
+		ToStatusID:      to,
+		ChangedByID:     actorID,
+		Role:            role,
+		Source:          source,
+		Comment:         comment,
+	}
+}
+
+// LogChange escribe en el log un cambio de estado ya guardado.
+func (s *CreditWorkflowService) LogChange(history *models.CreditStatusHistory) {
+	entry := map[string]interface{}{
+		"timestamp":         time.Now().Format(time.RFC3339),
+		"level":             "info",
+		"event":             "credit_status_changed",
+		"credit_request_id": history.CreditRequestID,
+		"to_status":         s.rules.StatusName(history.ToStatusID),
+		"role":              history.Role,
+		"source":            history.Source,
+	}
+	if history.FromStatusID != nil {
+		entry["from_status"] = s.rules.StatusName(*history.FromStatusID)
+	}
+	if history.ChangedByID != nil {
+		entry["changed_by"] = *history.ChangedByID
+	}
+	logger.WriteJSON(entry)
+}
+
+// RecordCreation guarda el estado con el que se creó la solicitud.
+func (s *CreditWorkflowService) RecordCreation(creditRequest *models.CreditRequest, actorID *uint) error {
+	role, err := s.roleOf(actorID)
+	if err != nil {
+		return err
+	}
+	return s.Record(creditRequest.ID, nil, creditRequest.CreditStatusID, actorID, role, models.CreditStatusSourceCreated, "")
+}
+
+func (s *CreditWorkflowService) GetHistory(creditRequestID uint) ([]models.CreditStatusHistory, error) {
+	creditRequest, err := s.creditRequestRepo.FindByID(creditRequestID)
+	if err != nil {
+		return nil, err
+	}
+	if creditRequest == nil {
+		return nil, fmt.Errorf("no existe solicitud de crédito con id %d", creditRequestID)
+	}
+
+	return s.historyRepo.FindByCreditRequestID(creditRequestID)
+}
+
+// roleOf retorna el nombre del rol del actor, o SYSTEM para los cambios automáticos.
+func (s *CreditWorkflowService) roleOf(actorID *uint) (string, error) {
+	if actorID == nil {
+		return workflow.SystemRole, nil
+	}
+
+	user, err := s.userRepo.FindByID(*actorID)
+	if err != nil {
+		return "", err
+	}
+	if user == nil {
+		return "", fmt.Errorf("no existe usuario con id %d", *actorID)
+	}
+
+	role, err := s.roleRepo.FindByID(user.RoleId)
+	if err != nil {
+		return "", err
+	}
+	if role == nil {
+		return "", fmt.Errorf("no existe rol con id %d", user.RoleId)
+	}
+
+	return role.Name, nil
+}
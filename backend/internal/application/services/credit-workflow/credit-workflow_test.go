@@ -0,0 +1,114 @@
+package creditWorkflow
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/JhonCamargo53/prueba-tecnica/internal/domain/models"
+	"github.com/JhonCamargo53/prueba-tecnica/internal/domain/workflow"
+)
+
+const (
+	employeeID uint = 1
+	adminID    uint = 2
+)
+
+func newService(requests ...*models.CreditRequest) (*CreditWorkflowService, *MockCreditStatusHistoryRepository, *MockCreditRequestRepository) {
+	historyRepo := &MockCreditStatusHistoryRepository{}
+	creditRequestRepo := NewMockCreditRequestRepository(requests)
+	userRepo := &MockUserRepository{Users: map[uint]*models.User{
+		employeeID: {ID: employeeID, RoleId: 2},
+		adminID:    {ID: adminID, RoleId: 1},
+	}}
+	roleRepo := &MockRoleRepository{Roles: map[uint]*models.Role{
+		1: {ID: 1, Name: "ADMIN", Access: 1000},
+		2: {ID: 2, Name: "EMPLOYEE", Access: 100},
+	}}
+
+	service := NewCreditWorkflowService(workflow.DefaultRules(), historyRepo, creditRequestRepo, userRepo, roleRepo)
+	return service, historyRepo, creditRequestRepo
+}
+
+func uintPtr(v uint) *uint {
+	return &v
+}
+
+func TestCheckYApply_RegistraElCambio(t *testing.T) {
+	service, historyRepo, creditRequestRepo := newService(&models.CreditRequest{ID: 10, CreditStatusID: models.CreditStatusPendingID})
+
+	role, err := service.Check(models.CreditStatusPendingID, models.CreditStatusInStudyID, uintPtr(employeeID))
+	if err != nil {
+		t.Fatalf("no se esperaba error: %v", err)
+	}
+	if role != "EMPLOYEE" {
+		t.Errorf("se esperaba rol EMPLOYEE, obtenido %s", role)
+	}
+
+	err = service.Apply(10, models.CreditStatusPendingID, models.CreditStatusInStudyID, uintPtr(employeeID), role,
+		models.CreditStatusSourceUpdated, "")
+	if err != nil {
+		t.Fatalf("no se esperaba error: %v", err)
+	}
+
+	if creditRequestRepo.Requests[10].CreditStatusID != models.CreditStatusInStudyID {
+		t.Errorf("se esperaba la solicitud EN ESTUDIO")
+	}
+	if len(historyRepo.History) != 1 {
+		t.Fatalf("se esperaba 1 registro de historial, obtenidos %d", len(historyRepo.History))
+	}
+	h := historyRepo.History[0]
+	if h.FromStatusID == nil || *h.FromStatusID != models.CreditStatusPendingID || h.ToStatusID != models.CreditStatusInStudyID ||
+		h.ChangedByID == nil || *h.ChangedByID != employeeID {
+		t.Errorf("historial inesperado: %+v", h)
+	}
+}
+
+func TestCheck_AprobadoNoVuelveAPendiente(t *testing.T) {
+	service, _, _ := newService()
+
+	_, err := service.Check(models.CreditStatusApprovedID, models.CreditStatusPendingID, uintPtr(adminID))
+	if err == nil || !strings.Contains(err.Error(), "terminal") {
+		t.Fatalf("se esperaba error por estado terminal, obtenido: %v", err)
+	}
+}
+
+func TestCheck_RolSinPermiso(t *testing.T) {
+	service, _, _ := newService()
+
+	if _, err := service.Check(models.CreditStatusRejectedID, models.CreditStatusInStudyID, uintPtr(employeeID)); err == nil {
+		t.Fatalf("EMPLOYEE no debería poder reabrir una solicitud rechazada")
+	}
+	if _, err := service.Check(models.CreditStatusRejectedID, models.CreditStatusInStudyID, uintPtr(adminID)); err != nil {
+		t.Fatalf("ADMIN debería poder reabrir una solicitud rechazada: %v", err)
+	}
+}
+
+func TestApply_CambioAutomaticoConRolSystem(t *testing.T) {
+	service, historyRepo, _ := newService(&models.CreditRequest{ID: 10, CreditStatusID: models.CreditStatusPendingID})
+
+	role, err := service.Check(models.CreditStatusPendingID, models.CreditStatusRejectedID, nil)
+	if err != nil {
+		t.Fatalf("no se esperaba error: %v", err)
+	}
+	if err := service.Apply(10, models.CreditStatusPendingID, models.CreditStatusRejectedID, nil, role,
+		models.CreditStatusSourceKnockOut, "MIN_INCOME"); err != nil {
+		t.Fatalf("no se esperaba error: %v", err)
+	}
+
+	if len(historyRepo.History) != 1 || historyRepo.History[0].Role != workflow.SystemRole || historyRepo.History[0].ChangedByID != nil {
+		t.Fatalf("se esperaba un cambio automático con rol SYSTEM: %+v", historyRepo.History)
+	}
+}
+
+func TestApply_MismoEstadoNoRegistra(t *testing.T) {
+	service, historyRepo, creditRequestRepo := newService(&models.CreditRequest{ID: 10, CreditStatusID: models.CreditStatusPendingID})
+
+	if err := service.Apply(10, models.CreditStatusPendingID, models.CreditStatusPendingID, uintPtr(employeeID), "EMPLOYEE",
+		models.CreditStatusSourceUpdated, ""); err != nil {
+		t.Fatalf("no se esperaba error: %v", err)
+	}
+
+	if len(historyRepo.History) != 0 || creditRequestRepo.StatusUpdates != 0 {
+		t.Fatalf("no se esperaban cambios al mantener el estado")
+	}
+}
@@ -239,11 +239,12 @@ func (m *MockAssetRepository) FindAll() ([]models.Asset, error) {
 type MockCreditRequestRepository struct {
 	CreditRequests map[uint]*models.CreditRequest
 
-	UpdateRiskCalled bool
-	LastRiskID       uint
-	LastScore        float64
-	LastCategory     string
-	LastExplanation  string
+	UpdateRiskCalled   bool
+	LastRiskID         uint
+	LastScore          float64
+	LastCategory       string
+	LastExplanation    string
+	LastRuleSetVersion string
 
 	ErrFindByID   error
 	ErrUpdateRisk error
@@ -309,18 +310,27 @@ func (m *MockCreditRequestRepository) Delete(id uint) error {
 	return nil
 }
 
-func (m *MockCreditRequestRepository) UpdateCreditRiskEvaluation(id uint, score float64, category string, explanation string) (*models.CreditRequest, error) {
+func (m *MockCreditRequestRepository) UpdateCreditRiskEvaluation(id uint, assessment *models.RiskAssessment) (*models.CreditRequest, error) {
 	if m.ErrUpdateRisk != nil {
 		return nil, m.ErrUpdateRisk
 	}
 	m.UpdateRiskCalled = true
 	m.LastRiskID = id
-	m.LastScore = score
-	m.LastCategory = category
-	m.LastExplanation = explanation
+	m.LastScore = assessment.Score
+	m.LastCategory = assessment.Category
+	m.LastExplanation = assessment.Explanation
+	m.LastRuleSetVersion = assessment.EngineVersion
 	return nil, nil
 }
 
+func (m *MockCreditRequestRepository) UpdateCreditStatus(id uint, creditStatusID uint) error {
+	return nil
+}
+
+func (m *MockCreditRequestRepository) UpdateCreditOffer(id uint, offer *models.CreditOffer) error {
+	return nil
+}
+
 func (m *MockCreditRequestRepository) FindDataToEvaluateRisk(id uint) (models.Customer, *models.CreditRequest, []models.CreditRequest, []models.CustomerAsset, error) {
 	cr, ok := m.CreditRequests[id]
 	if !ok {
@@ -344,10 +354,11 @@ func (m *MockCreditRequestRepository) FindDataToEvaluateRisk(id uint) (models.Cu
 
 type MockRiskEvaluator struct {
 	// Valores que vamos a devolver
-	Score       float64
-	Category    string
-	Explanation string
-	Err         error
+	Score          float64
+	Category       string
+	Explanation    string
+	RuleSetVersion string
+	Err            error
 
 	// Para verificar que se llamó
 	Called        bool
@@ -358,14 +369,52 @@ type MockRiskEvaluator struct {
 var _ ports.RiskEvaluator = (*MockRiskEvaluator)(nil)
 
 func (m *MockRiskEvaluator) Evaluate(customer models.Customer, currentCreditRequest models.CreditRequest,
-	otherCredits []models.CreditRequest, assets []models.CustomerAsset) (float64, string, string, error) {
+	otherCredits []models.CreditRequest, assets []models.CustomerAsset) (*models.RiskAssessment, error) {
 
 	m.Called = true
 	m.CalledWithCR = currentCreditRequest.ID
 	m.CalledWithCus = customer.ID
 
 	if m.Err != nil {
-		return 0, "", "", m.Err
+		return nil, m.Err
+	}
+	return &models.RiskAssessment{
+		EngineVersion: m.RuleSetVersion,
+		Score:         m.Score,
+		Category:      m.Category,
+		Explanation:   m.Explanation,
+	}, nil
+}
+
+/* Mock de RiskEvaluationRepository */
+
+type MockRiskEvaluationRepository struct {
+	Evaluations []models.RiskEvaluation
+
+	ErrCreate error
+}
+
+var _ ports.RiskEvaluationRepository = (*MockRiskEvaluationRepository)(nil)
+
+func NewMockRiskEvaluationRepository() *MockRiskEvaluationRepository {
+	return &MockRiskEvaluationRepository{}
+}
+
+func (m *MockRiskEvaluationRepository) Create(evaluation *models.RiskEvaluation) error {
+	if m.ErrCreate != nil {
+		return m.ErrCreate
 	}
-	return m.Score, m.Category, m.Explanation, nil
+	evaluation.ID = uint(len(m.Evaluations) + 1)
+	m.Evaluations = append(m.Evaluations, *evaluation)
+	return nil
+}
+
+func (m *MockRiskEvaluationRepository) FindByCreditRequestID(creditRequestID uint) ([]models.RiskEvaluation, error) {
+	var res []models.RiskEvaluation
+	for _, e := range m.Evaluations {
+		if e.CreditRequestID == creditRequestID {
+			res = append(res, e)
+		}
+	}
+	return res, nil
 }
@@ -3,6 +3,8 @@ package customerAsset
 import (
 	"fmt"
 
+	creditWorkflow "github.com/JhonCamargo53/prueba-tecnica/internal/application/services/credit-workflow"
+	riskEvaluation "github.com/JhonCamargo53/prueba-tecnica/internal/application/services/risk-evaluation"
 	"github.com/JhonCamargo53/prueba-tecnica/internal/domain/models"
 	"github.com/JhonCamargo53/prueba-tecnica/internal/domain/ports"
 )
@@ -12,18 +14,20 @@ type CustomerAssetService struct {
 	customerRepo      ports.CustomerRepository
 	assetRepo         ports.AssetRepository
 	creditRequestRepo ports.CreditRequestRepository
-	riskEvaluator     ports.RiskEvaluator
+	riskEvaluation    *riskEvaluation.RiskEvaluationService
+	workflow          *creditWorkflow.CreditWorkflowService
 }
 
 func NewCustomerAssetService(customerAssetRepo ports.CustomerAssetRepository, customerRepo ports.CustomerRepository,
 	assetRepo ports.AssetRepository, creditRequestRepo ports.CreditRequestRepository,
-	riskEvaluator ports.RiskEvaluator) *CustomerAssetService {
+	riskEvaluationService *riskEvaluation.RiskEvaluationService, workflowService *creditWorkflow.CreditWorkflowService) *CustomerAssetService {
 	return &CustomerAssetService{
 		customerAssetRepo: customerAssetRepo,
 		customerRepo:      customerRepo,
 		assetRepo:         assetRepo,
 		creditRequestRepo: creditRequestRepo,
-		riskEvaluator:     riskEvaluator,
+		riskEvaluation:    riskEvaluationService,
+		workflow:          workflowService,
 	}
 }
 
@@ -78,27 +82,17 @@ func (s *CustomerAssetService) CreateCustomerAsset(customerAsset *models.Custome
 	if creditRequest == nil {
 		return nil, fmt.Errorf("no se pudo obtener la solicitud de crédito con id %d", customerAsset.CreditRequestID)
 	}
-
-	// Crear activo
-	if err := s.customerAssetRepo.Create(customerAsset); err != nil {
-		return nil, err
-	}
-
-	customerData, creditRequest, otherCredits, customerAssets, err := s.creditRequestRepo.FindDataToEvaluateRisk(creditRequest.ID)
-
-	if err != nil {
+	if err := s.workflow.CheckEditable(creditRequest); err != nil {
 		return nil, err
 	}
 
-	// Recalcular riesgo
-	score, category, explanation, err := s.riskEvaluator.Evaluate(customerData, *creditRequest, otherCredits, customerAssets)
-
-	if err != nil {
+	// Crear activo
+	if err := s.customerAssetRepo.Create(customerAsset); err != nil {
 		return nil, err
 	}
 
-	//Actualizar riesgo
-	_, err = s.creditRequestRepo.UpdateCreditRiskEvaluation(creditRequest.ID, score, category, explanation)
+	// Recalcular riesgo y guardar historial
+	_, err = s.riskEvaluation.EvaluateCreditRequest(creditRequest.ID, models.RiskTriggerCustomerAssetCreated)
 
 	if err != nil {
 		return nil, err
@@ -145,28 +139,31 @@ func (s *CustomerAssetService) UpdateCustomerAsset(id uint, customerAssetData *m
 	if creditRequest == nil {
 		return nil, fmt.Errorf("no se pudo obtener la solicitud de crédito asociada con id %d", creditRequestID)
 	}
-
-	// Actualizar activo
-	updated, err := s.customerAssetRepo.Update(id, customerAssetData)
-	if err != nil {
+	if err := s.workflow.CheckEditable(creditRequest); err != nil {
 		return nil, err
 	}
 
-	customerData, creditRequest, otherCredits, customerAssets, err := s.creditRequestRepo.FindDataToEvaluateRisk(creditRequest.ID)
-
-	if err != nil {
-		return nil, err
+	// Mover el bien también modifica la solicitud en la que estaba
+	if existing.CreditRequestID != creditRequestID {
+		previous, err := s.creditRequestRepo.FindByID(existing.CreditRequestID)
+		if err != nil {
+			return nil, err
+		}
+		if previous != nil {
+			if err := s.workflow.CheckEditable(previous); err != nil {
+				return nil, err
+			}
+		}
 	}
 
-	// Recalcular riesgo
-	score, category, explanation, err := s.riskEvaluator.Evaluate(customerData, *creditRequest, otherCredits, customerAssets)
-
+	// Actualizar activo
+	updated, err := s.customerAssetRepo.Update(id, customerAssetData)
 	if err != nil {
 		return nil, err
 	}
 
-	//Actualizar riesgo
-	_, err = s.creditRequestRepo.UpdateCreditRiskEvaluation(creditRequest.ID, score, category, explanation)
+	// Recalcular riesgo y guardar historial
+	_, err = s.riskEvaluation.EvaluateCreditRequest(creditRequest.ID, models.RiskTriggerCustomerAssetUpdated)
 
 	if err != nil {
 		return nil, err
@@ -190,27 +187,17 @@ func (s *CustomerAssetService) DeleteCustomerAsset(id uint) error {
 	if creditRequest == nil {
 		return fmt.Errorf("no se pudo obtener la solicitud de crédito asociada")
 	}
-
-	// Eliminar activo
-	if err := s.customerAssetRepo.Delete(id); err != nil {
+	if err := s.workflow.CheckEditable(creditRequest); err != nil {
 		return err
 	}
 
-	customer, creditRequest, otherCredits, customerAssets, err := s.creditRequestRepo.FindDataToEvaluateRisk(creditRequest.ID)
-
-	if err != nil {
-		return err
-	}
-
-	// Recalcular riesgo
-	score, category, explanation, err := s.riskEvaluator.Evaluate(customer, *creditRequest, otherCredits, customerAssets)
-
-	if err != nil {
+	// Eliminar activo
+	if err := s.customerAssetRepo.Delete(id); err != nil {
 		return err
 	}
 
-	//Actualizar riesgo
-	_, err = s.creditRequestRepo.UpdateCreditRiskEvaluation(creditRequest.ID, score, category, explanation)
+	// Recalcular riesgo y guardar historial
+	_, err = s.riskEvaluation.EvaluateCreditRequest(creditRequest.ID, models.RiskTriggerCustomerAssetDeleted)
 
 	if err != nil {
 		return err
@@ -1,11 +1,19 @@
 package customerAsset
 
 import (
+	"strings"
 	"testing"
 
+	creditWorkflow "github.com/JhonCamargo53/prueba-tecnica/internal/application/services/credit-workflow"
+	riskEvaluation "github.com/JhonCamargo53/prueba-tecnica/internal/application/services/risk-evaluation"
 	"github.com/JhonCamargo53/prueba-tecnica/internal/domain/models"
+	"github.com/JhonCamargo53/prueba-tecnica/internal/domain/workflow"
 )
 
+func newWorkflowService(creditRequestRepo *MockCreditRequestRepository) *creditWorkflow.CreditWorkflowService {
+	return creditWorkflow.NewCreditWorkflowService(workflow.DefaultRules(), nil, creditRequestRepo, nil, nil)
+}
+
 func TestCreateCustomerAsset_ClienteNoExiste(t *testing.T) {
 	assetRepo := NewMockAssetRepository(nil)
 
@@ -18,7 +26,8 @@ func TestCreateCustomerAsset_ClienteNoExiste(t *testing.T) {
 		Explanation: "ok",
 	}
 
-	service := NewCustomerAssetService(customerAssetRepo, customerRepo, assetRepo, creditRequestRepo, riskEvaluator)
+	riskEvaluationService := riskEvaluation.NewRiskEvaluationService(creditRequestRepo, NewMockRiskEvaluationRepository(), riskEvaluator)
+	service := NewCustomerAssetService(customerAssetRepo, customerRepo, assetRepo, creditRequestRepo, riskEvaluationService, newWorkflowService(creditRequestRepo))
 
 	newAsset := &models.CustomerAsset{
 		CustomerID:      1, // no existe
@@ -55,7 +64,8 @@ func TestCreateCustomerAsset_AssetNoExiste(t *testing.T) {
 		Explanation: "ok",
 	}
 
-	service := NewCustomerAssetService(customerAssetRepo, customerRepo, assetRepo, creditRequestRepo, riskEvaluator)
+	riskEvaluationService := riskEvaluation.NewRiskEvaluationService(creditRequestRepo, NewMockRiskEvaluationRepository(), riskEvaluator)
+	service := NewCustomerAssetService(customerAssetRepo, customerRepo, assetRepo, creditRequestRepo, riskEvaluationService, newWorkflowService(creditRequestRepo))
 
 	newAsset := &models.CustomerAsset{
 		CustomerID:      1,
@@ -98,7 +108,8 @@ func TestCreateCustomerAsset_Exitoso_RecalculaRiesgo(t *testing.T) {
 		Explanation: "Cliente con buen ingreso y buenos activos",
 	}
 
-	service := NewCustomerAssetService(customerAssetRepo, customerRepo, assetRepo, creditRequestRepo, riskEvaluator)
+	riskEvaluationService := riskEvaluation.NewRiskEvaluationService(creditRequestRepo, NewMockRiskEvaluationRepository(), riskEvaluator)
+	service := NewCustomerAssetService(customerAssetRepo, customerRepo, assetRepo, creditRequestRepo, riskEvaluationService, newWorkflowService(creditRequestRepo))
 
 	newAsset := &models.CustomerAsset{
 		CustomerID:      1,
@@ -146,7 +157,8 @@ func TestGetAllCustomerAssets_CreditRequestNoExiste(t *testing.T) {
 	creditRequestRepo := NewMockCreditRequestRepository(nil) // ninguna credit request
 	riskEvaluator := &MockRiskEvaluator{}
 
-	service := NewCustomerAssetService(customerAssetRepo, customerRepo, assetRepo, creditRequestRepo, riskEvaluator)
+	riskEvaluationService := riskEvaluation.NewRiskEvaluationService(creditRequestRepo, NewMockRiskEvaluationRepository(), riskEvaluator)
+	service := NewCustomerAssetService(customerAssetRepo, customerRepo, assetRepo, creditRequestRepo, riskEvaluationService, newWorkflowService(creditRequestRepo))
 
 	creditRequestID := uint(99)
 
@@ -191,7 +203,8 @@ func TestDeleteCustomerAsset_Exitoso_RecalculaRiesgo(t *testing.T) {
 		Explanation: "Menos respaldo en activos tras eliminación",
 	}
 
-	service := NewCustomerAssetService(customerAssetRepo, customerRepo, assetRepo, creditRequestRepo, riskEvaluator)
+	riskEvaluationService := riskEvaluation.NewRiskEvaluationService(creditRequestRepo, NewMockRiskEvaluationRepository(), riskEvaluator)
+	service := NewCustomerAssetService(customerAssetRepo, customerRepo, assetRepo, creditRequestRepo, riskEvaluationService, newWorkflowService(creditRequestRepo))
 
 	err := service.DeleteCustomerAsset(1)
 	if err != nil {
@@ -211,3 +224,33 @@ func TestDeleteCustomerAsset_Exitoso_RecalculaRiesgo(t *testing.T) {
 		t.Fatalf("se esperaba que se actualizara la evaluación de riesgo tras eliminar el asset")
 	}
 }
+
+func TestCustomerAsset_SolicitudAprobadaNoSeModifica(t *testing.T) {
+	existingAsset := &models.CustomerAsset{ID: 1, CustomerID: 1, AssetID: 1, CreditRequestID: 10, MarketValue: 10_000_000}
+	customerAssetRepo := NewMockCustomerAssetRepository([]*models.CustomerAsset{existingAsset})
+	creditRequestRepo := NewMockCreditRequestRepository([]*models.CreditRequest{
+		{ID: 10, CustomerID: 1, Amount: 20_000_000, CreditStatusID: models.CreditStatusApprovedID},
+		{ID: 11, CustomerID: 1, Amount: 20_000_000, CreditStatusID: models.CreditStatusPendingID},
+	})
+	customerRepo := NewMockCustomerRepository([]*models.Customer{{ID: 1, Name: "Juan", MonthlyIncome: 5_000_000}})
+	assetRepo := NewMockAssetRepository([]*models.Asset{{ID: 1, Name: "Casa"}})
+	riskEvaluator := &MockRiskEvaluator{Score: 680, Category: "MEDIUM"}
+
+	riskEvaluationService := riskEvaluation.NewRiskEvaluationService(creditRequestRepo, NewMockRiskEvaluationRepository(), riskEvaluator)
+	service := NewCustomerAssetService(customerAssetRepo, customerRepo, assetRepo, creditRequestRepo, riskEvaluationService, newWorkflowService(creditRequestRepo))
+
+	if _, err := service.CreateCustomerAsset(&models.CustomerAsset{CustomerID: 1, AssetID: 1, CreditRequestID: 10, MarketValue: 5_000_000}); err == nil || !strings.Contains(err.Error(), "terminal") {
+		t.Errorf("se esperaba error al agregar un bien a una solicitud aprobada, obtenido: %v", err)
+	}
+	// Tampoco se puede mover el bien a otra solicitud
+	if _, err := service.UpdateCustomerAsset(1, &models.CustomerAsset{CustomerID: 1, AssetID: 1, CreditRequestID: 11, MarketValue: 5_000_000}); err == nil || !strings.Contains(err.Error(), "terminal") {
+		t.Errorf("se esperaba error al modificar un bien de una solicitud aprobada, obtenido: %v", err)
+	}
+	if err := service.DeleteCustomerAsset(1); err == nil || !strings.Contains(err.Error(), "terminal") {
+		t.Errorf("se esperaba error al eliminar un bien de una solicitud aprobada, obtenido: %v", err)
+	}
+
+	if _, ok := customerAssetRepo.Assets[1]; !ok || customerAssetRepo.Assets[1].CreditRequestID != 10 || riskEvaluator.Called {
+		t.Errorf("no se debería modificar el bien ni re-evaluar la solicitud")
+	}
+}
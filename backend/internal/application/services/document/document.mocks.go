@@ -0,0 +1,238 @@
+package document
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+
+	"github.com/JhonCamargo53/prueba-tecnica/internal/domain/models"
+	"github.com/JhonCamargo53/prueba-tecnica/internal/domain/ports"
+)
+
+/* Mock de DocumentRepository: FindForCreditRequest usa los bienes del mock de CustomerAssetRepository */
+
+type MockDocumentRepository struct {
+	Documents []models.Document
+	Assets    *MockCustomerAssetRepository
+}
+
+var _ ports.DocumentRepository = (*MockDocumentRepository)(nil)
+
+func (m *MockDocumentRepository) FindByID(id uint) (*models.Document, error) {
+	for i := range m.Documents {
+		if m.Documents[i].ID == id {
+			return &m.Documents[i], nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *MockDocumentRepository) FindByOwner(ownerType string, ownerID uint) ([]models.Document, error) {
+	var res []models.Document
+	for _, d := range m.Documents {
+		if d.OwnerType == ownerType && d.OwnerID == ownerID {
+			res = append(res, d)
+		}
+	}
+	return res, nil
+}
+
+func (m *MockDocumentRepository) FindForCreditRequest(creditRequestID, customerID uint) ([]models.Document, error) {
+	assetIDs := map[uint]bool{}
+	for _, a := range m.Assets.Assets {
+		if a.CreditRequestID == creditRequestID {
+			assetIDs[a.ID] = true
+		}
+	}
+
+	var res []models.Document
+	for _, d := range m.Documents {
+		if (d.OwnerType == models.DocumentOwnerCreditRequest && d.OwnerID == creditRequestID) ||
+			(d.OwnerType == models.DocumentOwnerCustomer && d.OwnerID == customerID) ||
+			(d.OwnerType == models.DocumentOwnerCustomerAsset && assetIDs[d.OwnerID]) {
+			res = append(res, d)
+		}
+	}
+	return res, nil
+}
+
+func (m *MockDocumentRepository) Create(document *models.Document) error {
+	document.ID = uint(len(m.Documents) + 1)
+	m.Documents = append(m.Documents, *document)
+	return nil
+}
+
+func (m *MockDocumentRepository) Delete(id uint) error {
+	for i, d := range m.Documents {
+		if d.ID == id {
+			m.Documents = append(m.Documents[:i], m.Documents[i+1:]...)
+			return nil
+		}
+	}
+	return nil
+}
+
+/* Mock de DocumentStorage: guarda los archivos en memoria */
+
+type MockDocumentStorage struct {
+	Files map[string][]byte
+}
+
+var _ ports.DocumentStorage = (*MockDocumentStorage)(nil)
+
+func (m *MockDocumentStorage) Save(key string, content io.Reader) error {
+	data, err := io.ReadAll(content)
+	if err != nil {
+		return err
+	}
+	m.Files[key] = data
+	return nil
+}
+
+func (m *MockDocumentStorage) Open(key string) (io.ReadCloser, error) {
+	data, ok := m.Files[key]
+	if !ok {
+		return nil, fmt.Errorf("no existe el archivo %s en el almacenamiento de documentos", key)
+	}
+	return io.NopCloser(bytes.NewReader(data)), nil
+}
+
+func (m *MockDocumentStorage) Delete(key string) error {
+	delete(m.Files, key)
+	return nil
+}
+
+/* Mock de CreditRequestRepository */
+
+type MockCreditRequestRepository struct {
+	Requests map[uint]*models.CreditRequest
+}
+
+var _ ports.CreditRequestRepository = (*MockCreditRequestRepository)(nil)
+
+func (m *MockCreditRequestRepository) FindAll(customerID *uint) ([]models.CreditRequest, error) {
+	return nil, nil
+}
+
+func (m *MockCreditRequestRepository) FindByID(id uint) (*models.CreditRequest, error) {
+	return m.Requests[id], nil
+}
+
+func (m *MockCreditRequestRepository) HasRequestsByCustomerID(customerID uint) (bool, error) {
+	return false, nil
+}
+
+func (m *MockCreditRequestRepository) Create(creditRequest *models.CreditRequest) (*models.CreditRequest, error) {
+	m.Requests[creditRequest.ID] = creditRequest
+	return creditRequest, nil
+}
+
+func (m *MockCreditRequestRepository) Update(id uint, creditRequest *models.CreditRequest) (*models.CreditRequest, error) {
+	m.Requests[id] = creditRequest
+	return creditRequest, nil
+}
+
+func (m *MockCreditRequestRepository) Delete(id uint) error {
+	delete(m.Requests, id)
+	return nil
+}
+
+func (m *MockCreditRequestRepository) UpdateCreditRiskEvaluation(id uint, assessment *models.RiskAssessment) (*models.CreditRequest, error) {
+	return m.Requests[id], nil
+}
+
+func (m *MockCreditRequestRepository) UpdateCreditStatus(id uint, creditStatusID uint) error {
+	return nil
+}
+
+func (m *MockCreditRequestRepository) UpdateCreditOffer(id uint, offer *models.CreditOffer) error {
+	return nil
+}
+
+func (m *MockCreditRequestRepository) FindDataToEvaluateRisk(id uint) (models.Customer, *models.CreditRequest, []models.CreditRequest, []models.CustomerAsset, error) {
+	return models.Customer{}, m.Requests[id], nil, nil, nil
+}
+
+/* Mock de CustomerRepository */
+
+type MockCustomerRepository struct {
+	Customers map[uint]*models.Customer
+}
+
+var _ ports.CustomerRepository = (*MockCustomerRepository)(nil)
+
+func (m *MockCustomerRepository) FindAllOrderedByCreatedDesc() ([]models.Customer, error) {
+	return nil, nil
+}
+
+func (m *MockCustomerRepository) FindByID(id uint) (*models.Customer, error) {
+	return m.Customers[id], nil
+}
+
+func (m *MockCustomerRepository) FindByEmail(email string) (*models.Customer, error) {
+	return nil, nil
+}
+
+func (m *MockCustomerRepository) FindByDocument(documentNumber string, documentTypeID uint, excludeID *uint) (*models.Customer, error) {
+	return nil, nil
+}
+
+func (m *MockCustomerRepository) Create(customer *models.Customer) error {
+	m.Customers[customer.ID] = customer
+	return nil
+}
+
+func (m *MockCustomerRepository) Update(id uint, customerData *models.Customer) (*models.Customer, error) {
+	m.Customers[id] = customerData
+	return customerData, nil
+}
+
+func (m *MockCustomerRepository) Delete(id uint) error {
+	delete(m.Customers, id)
+	return nil
+}
+
+/* Mock de CustomerAssetRepository */
+
+type MockCustomerAssetRepository struct {
+	Assets []models.CustomerAsset
+}
+
+var _ ports.CustomerAssetRepository = (*MockCustomerAssetRepository)(nil)
+
+func (m *MockCustomerAssetRepository) FindAll(creditRequestID *uint) ([]models.CustomerAsset, error) {
+	var res []models.CustomerAsset
+	for _, a := range m.Assets {
+		if creditRequestID == nil || a.CreditRequestID == *creditRequestID {
+			res = append(res, a)
+		}
+	}
+	return res, nil
+}
+
+func (m *MockCustomerAssetRepository) FindByID(id uint) (*models.CustomerAsset, error) {
+	for i := range m.Assets {
+		if m.Assets[i].ID == id {
+			return &m.Assets[i], nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *MockCustomerAssetRepository) CountByCreditRequestID(creditRequestID uint) (int64, error) {
+	assets, _ := m.FindAll(&creditRequestID)
+	return int64(len(assets)), nil
+}
+
+func (m *MockCustomerAssetRepository) Create(ca *models.CustomerAsset) error {
+	m.Assets = append(m.Assets, *ca)
+	return nil
+}
+
+func (m *MockCustomerAssetRepository) Update(id uint, data *models.CustomerAsset) (*models.CustomerAsset, error) {
+	return data, nil
+}
+
+func (m *MockCustomerAssetRepository) Delete(id uint) error {
+	return nil
+}
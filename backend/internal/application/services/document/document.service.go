@@ -0,0 +1,346 @@
+package document
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/JhonCamargo53/prueba-tecnica/internal/domain/models"
+	"github.com/JhonCamargo53/prueba-tecnica/internal/domain/ports"
+	"github.com/JhonCamargo53/prueba-tecnica/internal/infrastructure/logger"
+)
+
+// Tipos de contenido permitidos y la extensión con la que se guardan
+var allowedContentTypes = map[string]string{
+	"application/pdf": ".pdf",
+	"image/jpeg":      ".jpg",
+	"image/png":       ".png",
+}
+
+// DocumentChecklist compara los documentos que exige el producto de una solicitud
+// con los adjuntos a la solicitud, a su titular y a los bienes asociados.
+type DocumentChecklist struct {
+	CreditRequestID uint              `json:"creditRequestId"`
+	ProductCode     string            `json:"productCode"`
+	Required        []string          `json:"required"`
+	Missing         []string          `json:"missing"`
+	Complete        bool              `json:"complete"`
+	Documents       []models.Document `json:"documents"`
+}
+
+type DocumentService struct {
+	documentRepo      ports.DocumentRepository
+	storage           ports.DocumentStorage
+	customerRepo      ports.CustomerRepository
+	creditRequestRepo ports.CreditRequestRepository
+	customerAssetRepo ports.CustomerAssetRepository
+	// Tamaño máximo en bytes de un archivo
+	maxSize int64
+}
+
+func NewDocumentService(documentRepo ports.DocumentRepository, storage ports.DocumentStorage, customerRepo ports.CustomerRepository,
+	creditRequestRepo ports.CreditRequestRepository, customerAssetRepo ports.CustomerAssetRepository, maxSize int64) *DocumentService {
+	return &DocumentService{
+		documentRepo:      documentRepo,
+		storage:           storage,
+		customerRepo:      customerRepo,
+		creditRequestRepo: creditRequestRepo,
+		customerAssetRepo: customerAssetRepo,
+		maxSize:           maxSize,
+	}
+}
+
+// MaxSize retorna el tamaño máximo en bytes de un archivo.
+func (s *DocumentService) MaxSize() int64 {
+	return s.maxSize
+}
+
+// UploadDocument adjunta un archivo al cliente, la solicitud o el bien indicado. El
+// tipo se determina por el contenido del archivo (PDF, JPEG o PNG); el tipo declarado,
+// si se envía, debe coincidir. Se guarda la suma SHA-256 para verificar la descarga.
+func (s *DocumentService) UploadDocument(ownerType string, ownerID uint, category, fileName, contentType string,
+	content io.Reader, uploadedByID uint) (*models.Document, error) {
+	ownerType = strings.ToUpper(strings.TrimSpace(ownerType))
+	category = strings.ToUpper(strings.TrimSpace(category))
+
+	if err := s.checkOwner(ownerType, ownerID); err != nil {
+		return nil, err
+	}
+	if !models.IsValidDocumentCategory(category) {
+		return nil, fmt.Errorf("la categoría de documento %s no es válida (%s)", category, strings.Join(models.DocumentCategories(), ", "))
+	}
+
+	data, err := io.ReadAll(io.LimitReader(content, s.maxSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) == 0 {
+		return nil, fmt.Errorf("el archivo está vacío")
+	}
+	if int64(len(data)) > s.maxSize {
+		return nil, fmt.Errorf("el archivo supera el tamaño máximo permitido (%d bytes)", s.maxSize)
+	}
+
+	detected := mediaType(http.DetectContentType(data))
+	extension, ok := allowedContentTypes[detected]
+	if !ok {
+		return nil, fmt.Errorf("el tipo de archivo %s no está permitido (PDF, JPEG o PNG)", detected)
+	}
+	if declared := mediaType(contentType); declared != "" && declared != "application/octet-stream" && declared != detected {
+		return nil, fmt.Errorf("el tipo declarado %s no coincide con el contenido del archivo (%s)", declared, detected)
+	}
+
+	sum := sha256.Sum256(data)
+	key, err := storageKey(ownerType, ownerID, extension)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.storage.Save(key, bytes.NewReader(data)); err != nil {
+		return nil, err
+	}
+
+	document := &models.Document{
+		OwnerType:    ownerType,
+		OwnerID:      ownerID,
+		Category:     category,
+		FileName:     cleanFileName(fileName, extension),
+		ContentType:  detected,
+		Size:         int64(len(data)),
+		SHA256:       hex.EncodeToString(sum[:]),
+		StorageKey:   key,
+		UploadedByID: uploadedByID,
+	}
+
+	if err := s.documentRepo.Create(document); err != nil {
+		// Sin registro el archivo quedaría huérfano en el almacenamiento
+		s.storage.Delete(key)
+		return nil, err
+	}
+
+	logger.WriteJSON(map[string]interface{}{
+		"timestamp":   time.Now().Format(time.RFC3339),
+		"level":       "info",
+		"event":       "document_uploaded",
+		"document_id": document.ID,
+		"owner_type":  ownerType,
+		"owner_id":    ownerID,
+		"category":    category,
+		"size":        document.Size,
+		"uploaded_by": uploadedByID,
+	})
+
+	return document, nil
+}
+
+func (s *DocumentService) GetDocuments(ownerType string, ownerID uint) ([]models.Document, error) {
+	ownerType = strings.ToUpper(strings.TrimSpace(ownerType))
+	if err := s.checkOwner(ownerType, ownerID); err != nil {
+		return nil, err
+	}
+	return s.documentRepo.FindByOwner(ownerType, ownerID)
+}
+
+func (s *DocumentService) GetDocument(id uint) (*models.Document, error) {
+	document, err := s.documentRepo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if document == nil {
+		return nil, fmt.Errorf("no existe documento adjunto con id %d", id)
+	}
+	return document, nil
+}
+
+// DownloadDocument retorna el documento y su contenido, tras verificar que el
+// contenido guardado coincide con la suma SHA-256 registrada al subirlo.
+func (s *DocumentService) DownloadDocument(id uint) (*models.Document, []byte, error) {
+	document, err := s.GetDocument(id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	file, err := s.storage.Open(document.StorageKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	sum := sha256.Sum256(data)
+	if hex.EncodeToString(sum[:]) != document.SHA256 {
+		logger.WriteJSON(map[string]interface{}{
+			"timestamp":   time.Now().Format(time.RFC3339),
+			"level":       "error",
+			"event":       "document_checksum_mismatch",
+			"document_id": document.ID,
+			"storage_key": document.StorageKey,
+		})
+		return nil, nil, fmt.Errorf("el contenido del documento %d no coincide con su suma SHA-256", id)
+	}
+
+	return document, data, nil
+}
+
+// DeleteDocument elimina el documento y su archivo. Los documentos de una solicitud
+// ya decidida, o de sus bienes, se conservan como soporte de la decisión.
+func (s *DocumentService) DeleteDocument(id uint) error {
+	document, err := s.GetDocument(id)
+	if err != nil {
+		return err
+	}
+
+	creditRequestID, err := s.creditRequestOf(document)
+	if err != nil {
+		return err
+	}
+	if creditRequestID != 0 {
+		creditRequest, err := s.creditRequestRepo.FindByID(creditRequestID)
+		if err != nil {
+			return err
+		}
+		if creditRequest != nil && isDecided(creditRequest.CreditStatusID) {
+			return fmt.Errorf("la solicitud %d ya fue decidida: sus documentos no se pueden eliminar", creditRequestID)
+		}
+	}
+
+	if err := s.documentRepo.Delete(id); err != nil {
+		return err
+	}
+	if err := s.storage.Delete(document.StorageKey); err != nil {
+		logger.WriteJSON(map[string]interface{}{
+			"timestamp":   time.Now().Format(time.RFC3339),
+			"level":       "error",
+			"event":       "document_storage_delete_failed",
+			"document_id": document.ID,
+			"storage_key": document.StorageKey,
+			"error":       err.Error(),
+		})
+	}
+
+	return nil
+}
+
+// GetChecklist retorna los documentos que exige el producto de la solicitud y cuáles faltan.
+func (s *DocumentService) GetChecklist(creditRequestID uint) (*DocumentChecklist, error) {
+	creditRequest, err := s.creditRequestRepo.FindByID(creditRequestID)
+	if err != nil {
+		return nil, err
+	}
+	if creditRequest == nil {
+		return nil, fmt.Errorf("no existe solicitud de crédito con id %d", creditRequestID)
+	}
+
+	documents, err := s.documentRepo.FindForCreditRequest(creditRequest.ID, creditRequest.CustomerID)
+	if err != nil {
+		return nil, err
+	}
+
+	checklist := &DocumentChecklist{
+		CreditRequestID: creditRequest.ID,
+		ProductCode:     creditRequest.ProductCode(),
+		Required:        []string{},
+		Missing:         []string{},
+		Documents:       documents,
+	}
+	if creditRequest.CreditProduct != nil {
+		checklist.Required = append(checklist.Required, creditRequest.CreditProduct.RequiredDocuments...)
+		checklist.Missing = creditRequest.CreditProduct.MissingDocuments(documents)
+	}
+	checklist.Complete = len(checklist.Missing) == 0
+
+	return checklist, nil
+}
+
+func (s *DocumentService) checkOwner(ownerType string, ownerID uint) error {
+	switch ownerType {
+	case models.DocumentOwnerCustomer:
+		customer, err := s.customerRepo.FindByID(ownerID)
+		if err != nil {
+			return err
+		}
+		if customer == nil {
+			return fmt.Errorf("no existe cliente con id %d", ownerID)
+		}
+	case models.DocumentOwnerCreditRequest:
+		creditRequest, err := s.creditRequestRepo.FindByID(ownerID)
+		if err != nil {
+			return err
+		}
+		if creditRequest == nil {
+			return fmt.Errorf("no existe solicitud de crédito con id %d", ownerID)
+		}
+	case models.DocumentOwnerCustomerAsset:
+		asset, err := s.customerAssetRepo.FindByID(ownerID)
+		if err != nil {
+			return err
+		}
+		if asset == nil {
+			return fmt.Errorf("no existe bien del cliente de id %d", ownerID)
+		}
+	default:
+		return fmt.Errorf("el tipo de propietario %s no es válido (CUSTOMER, CREDIT_REQUEST o CUSTOMER_ASSET)", ownerType)
+	}
+	return nil
+}
+
+// creditRequestOf retorna la solicitud a la que pertenece el documento, o cero si es de un cliente.
+func (s *DocumentService) creditRequestOf(document *models.Document) (uint, error) {
+	switch document.OwnerType {
+	case models.DocumentOwnerCreditRequest:
+		return document.OwnerID, nil
+	case models.DocumentOwnerCustomerAsset:
+		asset, err := s.customerAssetRepo.FindByID(document.OwnerID)
+		if err != nil || asset == nil {
+			return 0, err
+		}
+		return asset.CreditRequestID, nil
+	}
+	return 0, nil
+}
+
+func isDecided(creditStatusID uint) bool {
+	return creditStatusID == models.CreditStatusApprovedID || creditStatusID == models.CreditStatusRejectedID
+}
+
+// mediaType retorna el tipo de contenido sin parámetros, en minúsculas.
+func mediaType(contentType string) string {
+	parsed, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return strings.ToLower(strings.TrimSpace(contentType))
+	}
+	return parsed
+}
+
+// storageKey genera una clave aleatoria agrupada por propietario; no depende del
+// nombre del archivo enviado por el cliente.
+func storageKey(ownerType string, ownerID uint, extension string) (string, error) {
+	random := make([]byte, 16)
+	if _, err := rand.Read(random); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s/%d/%s%s", strings.ToLower(ownerType), ownerID, hex.EncodeToString(random), extension), nil
+}
+
+// cleanFileName conserva sólo el nombre base del archivo enviado.
+func cleanFileName(fileName, extension string) string {
+	name := strings.TrimSpace(filepath.Base(strings.ReplaceAll(fileName, "\\", "/")))
+	if name == "" || name == "." || name == "/" {
+		return "documento" + extension
+	}
+	if runes := []rune(name); len(runes) > 255 {
+		name = string(runes[len(runes)-255:])
+	}
+	return name
+}
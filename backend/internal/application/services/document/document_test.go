@@ -0,0 +1,161 @@
+package document
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/JhonCamargo53/prueba-tecnica/internal/domain/models"
+)
+
+const uploaderID uint = 1
+
+var pdfContent = []byte("%PDF-1.4\n1 0 obj << /Type /Catalog >> endobj\n%%EOF")
+
+func newService(maxSize int64) (*DocumentService, *MockDocumentRepository, *MockDocumentStorage, *MockCreditRequestRepository) {
+	housing := &models.CreditProduct{ID: 1, Code: "HOUSING",
+		RequiredDocuments: models.DocumentCategoryList{"IDENTITY", "INCOME_PROOF", "PROPERTY_DEED"}}
+
+	creditRequestRepo := &MockCreditRequestRepository{Requests: map[uint]*models.CreditRequest{
+		5: {ID: 5, CustomerID: 7, CreditProductID: &housing.ID, CreditProduct: housing, CreditStatusID: models.CreditStatusInStudyID},
+	}}
+	customerRepo := &MockCustomerRepository{Customers: map[uint]*models.Customer{7: {ID: 7}}}
+	assetRepo := &MockCustomerAssetRepository{Assets: []models.CustomerAsset{{ID: 3, CreditRequestID: 5, CustomerID: 7}}}
+	documentRepo := &MockDocumentRepository{Assets: assetRepo}
+	storage := &MockDocumentStorage{Files: map[string][]byte{}}
+
+	service := NewDocumentService(documentRepo, storage, customerRepo, creditRequestRepo, assetRepo, maxSize)
+	return service, documentRepo, storage, creditRequestRepo
+}
+
+func TestUploadDocument_GuardaElArchivoConSuSuma(t *testing.T) {
+	service, _, storage, _ := newService(1 << 20)
+
+	document, err := service.UploadDocument("credit_request", 5, "income_proof", "../../tmp/certificado.pdf",
+		"application/pdf", strings.NewReader(string(pdfContent)), uploaderID)
+	if err != nil {
+		t.Fatalf("no se esperaba error: %v", err)
+	}
+
+	sum := sha256.Sum256(pdfContent)
+	if document.OwnerType != models.DocumentOwnerCreditRequest || document.Category != models.DocumentCategoryIncomeProof ||
+		document.ContentType != "application/pdf" || document.Size != int64(len(pdfContent)) || document.SHA256 != hex.EncodeToString(sum[:]) {
+		t.Errorf("documento inesperado: %+v", document)
+	}
+	// El nombre enviado no se usa en la clave y sólo se conserva su nombre base
+	if document.FileName != "certificado.pdf" || strings.Contains(document.StorageKey, "certificado") ||
+		!strings.HasPrefix(document.StorageKey, "credit_request/5/") {
+		t.Errorf("nombre o clave inesperados: %s, %s", document.FileName, document.StorageKey)
+	}
+	if string(storage.Files[document.StorageKey]) != string(pdfContent) {
+		t.Errorf("el archivo no se guardó en el almacenamiento")
+	}
+
+	_, data, err := service.DownloadDocument(document.ID)
+	if err != nil || string(data) != string(pdfContent) {
+		t.Errorf("se esperaba descargar el mismo contenido, obtenido: %q, %v", data, err)
+	}
+}
+
+func TestUploadDocument_Validaciones(t *testing.T) {
+	service, documentRepo, storage, _ := newService(64)
+
+	cases := map[string]struct {
+		ownerType   string
+		ownerID     uint
+		category    string
+		contentType string
+		content     string
+		want        string
+	}{
+		"archivo vacío":         {"CUSTOMER", 7, "IDENTITY", "", "", "vacío"},
+		"supera el tamaño":      {"CUSTOMER", 7, "IDENTITY", "", string(pdfContent) + strings.Repeat(" ", 64), "tamaño máximo"},
+		"tipo no permitido":     {"CUSTOMER", 7, "IDENTITY", "", "nombre,ingreso\nana,100", "no está permitido"},
+		"tipo declarado falso":  {"CUSTOMER", 7, "IDENTITY", "image/png", string(pdfContent), "no coincide"},
+		"categoría inválida":    {"CUSTOMER", 7, "PASAPORTE", "", string(pdfContent), "categoría"},
+		"propietario inválido":  {"USER", 1, "IDENTITY", "", string(pdfContent), "tipo de propietario"},
+		"cliente inexistente":   {"CUSTOMER", 99, "IDENTITY", "", string(pdfContent), "no existe"},
+		"bien inexistente":      {"CUSTOMER_ASSET", 99, "PROPERTY_DEED", "", string(pdfContent), "no existe"},
+		"solicitud inexistente": {"CREDIT_REQUEST", 99, "OTHER", "", string(pdfContent), "no existe"},
+	}
+
+	for name, c := range cases {
+		_, err := service.UploadDocument(c.ownerType, c.ownerID, c.category, "archivo", c.contentType, strings.NewReader(c.content), uploaderID)
+		if err == nil || !strings.Contains(err.Error(), c.want) {
+			t.Errorf("%s: se esperaba error %q, obtenido: %v", name, c.want, err)
+		}
+	}
+
+	if len(documentRepo.Documents) != 0 || len(storage.Files) != 0 {
+		t.Errorf("no se debía guardar ningún documento")
+	}
+}
+
+func TestDownloadDocument_DetectaArchivoAlterado(t *testing.T) {
+	service, _, storage, _ := newService(1 << 20)
+
+	document, err := service.UploadDocument("CUSTOMER", 7, "IDENTITY", "cedula.pdf", "", strings.NewReader(string(pdfContent)), uploaderID)
+	if err != nil {
+		t.Fatalf("no se esperaba error: %v", err)
+	}
+
+	storage.Files[document.StorageKey] = append([]byte{}, storage.Files[document.StorageKey]...)
+	storage.Files[document.StorageKey][0] = 'X'
+
+	if _, _, err := service.DownloadDocument(document.ID); err == nil || !strings.Contains(err.Error(), "SHA-256") {
+		t.Errorf("se esperaba error por suma SHA-256, obtenido: %v", err)
+	}
+}
+
+func TestGetChecklist_DocumentosExigidosPorElProducto(t *testing.T) {
+	service, _, _, _ := newService(1 << 20)
+
+	upload := func(ownerType string, ownerID uint, category string) {
+		if _, err := service.UploadDocument(ownerType, ownerID, category, "", "", strings.NewReader(string(pdfContent)), uploaderID); err != nil {
+			t.Fatalf("no se esperaba error: %v", err)
+		}
+	}
+
+	// La identificación se adjunta al titular y el soporte de ingresos a la solicitud
+	upload(models.DocumentOwnerCustomer, 7, models.DocumentCategoryIdentity)
+	upload(models.DocumentOwnerCreditRequest, 5, models.DocumentCategoryIncomeProof)
+
+	checklist, err := service.GetChecklist(5)
+	if err != nil {
+		t.Fatalf("no se esperaba error: %v", err)
+	}
+	if checklist.Complete || len(checklist.Missing) != 1 || checklist.Missing[0] != models.DocumentCategoryPropertyDeed {
+		t.Errorf("se esperaba que faltara la escritura: %+v", checklist)
+	}
+
+	// La escritura se adjunta al bien asociado a la solicitud
+	upload(models.DocumentOwnerCustomerAsset, 3, models.DocumentCategoryPropertyDeed)
+
+	checklist, _ = service.GetChecklist(5)
+	if !checklist.Complete || len(checklist.Documents) != 3 {
+		t.Errorf("se esperaba la lista completa: %+v", checklist)
+	}
+}
+
+func TestDeleteDocument_SolicitudDecidida(t *testing.T) {
+	service, documentRepo, storage, creditRequestRepo := newService(1 << 20)
+
+	document, err := service.UploadDocument("CUSTOMER_ASSET", 3, "PROPERTY_DEED", "", "", strings.NewReader(string(pdfContent)), uploaderID)
+	if err != nil {
+		t.Fatalf("no se esperaba error: %v", err)
+	}
+
+	creditRequestRepo.Requests[5].CreditStatusID = models.CreditStatusApprovedID
+	if err := service.DeleteDocument(document.ID); err == nil || !strings.Contains(err.Error(), "ya fue decidida") {
+		t.Errorf("se esperaba error por solicitud decidida, obtenido: %v", err)
+	}
+
+	creditRequestRepo.Requests[5].CreditStatusID = models.CreditStatusInStudyID
+	if err := service.DeleteDocument(document.ID); err != nil {
+		t.Fatalf("no se esperaba error: %v", err)
+	}
+	if len(documentRepo.Documents) != 0 || len(storage.Files) != 0 {
+		t.Errorf("se esperaba eliminar el documento y su archivo")
+	}
+}
@@ -0,0 +1,227 @@
+package loanAccount
+
+import (
+	"errors"
+
+	"github.com/JhonCamargo53/prueba-tecnica/internal/domain/models"
+	"github.com/JhonCamargo53/prueba-tecnica/internal/domain/ports"
+)
+
+/* Mock de PaymentScheduleRepository */
+
+type MockPaymentScheduleRepository struct {
+	Schedules map[uint]*models.PaymentSchedule
+}
+
+var _ ports.PaymentScheduleRepository = (*MockPaymentScheduleRepository)(nil)
+
+func (m *MockPaymentScheduleRepository) FindByCreditRequestID(creditRequestID uint) (*models.PaymentSchedule, error) {
+	return m.Schedules[creditRequestID], nil
+}
+
+func (m *MockPaymentScheduleRepository) Create(schedule *models.PaymentSchedule) error {
+	if m.Schedules == nil {
+		m.Schedules = make(map[uint]*models.PaymentSchedule)
+	}
+	schedule.ID = uint(len(m.Schedules) + 1)
+	m.Schedules[schedule.CreditRequestID] = schedule
+	return nil
+}
+
+/* Mock de LoanAccountRepository */
+
+type MockLoanAccountRepository struct {
+	Accounts map[uint]*models.LoanAccount
+	Payments []models.LoanPayment
+}
+
+var _ ports.LoanAccountRepository = (*MockLoanAccountRepository)(nil)
+
+func (m *MockLoanAccountRepository) FindByCreditRequestID(creditRequestID uint) (*models.LoanAccount, error) {
+	return m.Accounts[creditRequestID], nil
+}
+
+func (m *MockLoanAccountRepository) FindActive() ([]models.LoanAccount, error) {
+	var accounts []models.LoanAccount
+	for _, account := range m.Accounts {
+		if account.Status == models.LoanAccountStatusActive {
+			accounts = append(accounts, *account)
+		}
+	}
+	return accounts, nil
+}
+
+func (m *MockLoanAccountRepository) FindDelinquent() ([]models.LoanAccount, error) {
+	var accounts []models.LoanAccount
+	for _, account := range m.Accounts {
+		if account.DaysPastDue > 0 {
+			accounts = append(accounts, *account)
+		}
+	}
+	return accounts, nil
+}
+
+func (m *MockLoanAccountRepository) Create(account *models.LoanAccount) error {
+	if m.Accounts == nil {
+		m.Accounts = make(map[uint]*models.LoanAccount)
+	}
+	account.ID = uint(len(m.Accounts) + 1)
+	m.Accounts[account.CreditRequestID] = account
+	return nil
+}
+
+func (m *MockLoanAccountRepository) UpdateDelinquency(account *models.LoanAccount) error {
+	stored := m.Accounts[account.CreditRequestID]
+	stored.DaysPastDue = account.DaysPastDue
+	stored.DelinquencyBucket = account.DelinquencyBucket
+	stored.MaxDaysPastDue = account.MaxDaysPastDue
+	stored.DelinquencyUpdatedAt = account.DelinquencyUpdatedAt
+	return nil
+}
+
+func (m *MockLoanAccountRepository) RegisterPayment(accountID uint,
+	build func(account *models.LoanAccount) (*models.LoanPayment, []models.PaymentScheduleInstallment, error)) error {
+	for creditRequestID, stored := range m.Accounts {
+		if stored.ID != accountID {
+			continue
+		}
+
+		account := *stored
+		payment, _, err := build(&account)
+		if err != nil {
+			return err
+		}
+		payment.ID = uint(len(m.Payments) + 1)
+		m.Payments = append(m.Payments, *payment)
+		m.Accounts[creditRequestID] = &account
+		return nil
+	}
+	return errors.New("cuenta no encontrada")
+}
+
+func (m *MockLoanAccountRepository) FindPayments(loanAccountID uint) ([]models.LoanPayment, error) {
+	return m.Payments, nil
+}
+
+/* Mock de CreditRequestRepository */
+
+type MockCreditRequestRepository struct {
+	Requests map[uint]*models.CreditRequest
+}
+
+var _ ports.CreditRequestRepository = (*MockCreditRequestRepository)(nil)
+
+func (m *MockCreditRequestRepository) FindAll(customerID *uint) ([]models.CreditRequest, error) {
+	var res []models.CreditRequest
+	for _, cr := range m.Requests {
+		res = append(res, *cr)
+	}
+	return res, nil
+}
+
+func (m *MockCreditRequestRepository) FindByID(id uint) (*models.CreditRequest, error) {
+	if cr, ok := m.Requests[id]; ok {
+		copy := *cr
+		return &copy, nil
+	}
+	return nil, nil
+}
+
+func (m *MockCreditRequestRepository) HasRequestsByCustomerID(customerID uint) (bool, error) {
+	return false, nil
+}
+
+func (m *MockCreditRequestRepository) Create(creditRequest *models.CreditRequest) (*models.CreditRequest, error) {
+	return creditRequest, nil
+}
+
+func (m *MockCreditRequestRepository) Update(id uint, creditRequest *models.CreditRequest) (*models.CreditRequest, error) {
+	return creditRequest, nil
+}
+
+func (m *MockCreditRequestRepository) Delete(id uint) error {
+	return nil
+}
+
+func (m *MockCreditRequestRepository) UpdateCreditRiskEvaluation(id uint, assessment *models.RiskAssessment) (*models.CreditRequest, error) {
+	return nil, nil
+}
+
+func (m *MockCreditRequestRepository) UpdateCreditStatus(id uint, creditStatusID uint) error {
+	return nil
+}
+
+func (m *MockCreditRequestRepository) UpdateCreditOffer(id uint, offer *models.CreditOffer) error {
+	return nil
+}
+
+func (m *MockCreditRequestRepository) FindDataToEvaluateRisk(id uint) (models.Customer, *models.CreditRequest, []models.CreditRequest, []models.CustomerAsset, error) {
+	return models.Customer{}, nil, nil, nil, nil
+}
+
+/* Mock de CreditDecisionRepository */
+
+type MockCreditDecisionRepository struct {
+	Decisions []models.CreditDecision
+}
+
+var _ ports.CreditDecisionRepository = (*MockCreditDecisionRepository)(nil)
+
+func (m *MockCreditDecisionRepository) Create(decision *models.CreditDecision) error {
+	decision.ID = uint(len(m.Decisions) + 1)
+	m.Decisions = append(m.Decisions, *decision)
+	return nil
+}
+
+func (m *MockCreditDecisionRepository) Update(decision *models.CreditDecision) error {
+	return nil
+}
+
+func (m *MockCreditDecisionRepository) Apply(decision *models.CreditDecision, change *models.CreditStatusHistory) error {
+	return m.Create(decision)
+}
+
+func (m *MockCreditDecisionRepository) FindByID(id uint) (*models.CreditDecision, error) {
+	return nil, nil
+}
+
+func (m *MockCreditDecisionRepository) FindByCreditRequestID(creditRequestID uint) ([]models.CreditDecision, error) {
+	var res []models.CreditDecision
+	for _, d := range m.Decisions {
+		if d.CreditRequestID == creditRequestID {
+			res = append(res, d)
+		}
+	}
+	return res, nil
+}
+
+func (m *MockCreditDecisionRepository) FindPending() ([]models.CreditDecision, error) {
+	return nil, nil
+}
+
+func (m *MockCreditDecisionRepository) FindEscalated(access int) ([]models.CreditDecision, error) {
+	return nil, nil
+}
+
+/* Mock de CreditStatusHistoryRepository */
+
+type MockCreditStatusHistoryRepository struct {
+	History []models.CreditStatusHistory
+}
+
+var _ ports.CreditStatusHistoryRepository = (*MockCreditStatusHistoryRepository)(nil)
+
+func (m *MockCreditStatusHistoryRepository) Create(history *models.CreditStatusHistory) error {
+	m.History = append(m.History, *history)
+	return nil
+}
+
+func (m *MockCreditStatusHistoryRepository) FindByCreditRequestID(creditRequestID uint) ([]models.CreditStatusHistory, error) {
+	var res []models.CreditStatusHistory
+	for _, h := range m.History {
+		if h.CreditRequestID == creditRequestID {
+			res = append(res, h)
+		}
+	}
+	return res, nil
+}
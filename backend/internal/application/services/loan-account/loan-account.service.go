@@ -0,0 +1,417 @@
+package loanAccount
+
+import (
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	paymentSchedule "github.com/JhonCamargo53/prueba-tecnica/internal/application/services/payment-schedule"
+	"github.com/JhonCamargo53/prueba-tecnica/internal/domain/models"
+	"github.com/JhonCamargo53/prueba-tecnica/internal/domain/ports"
+	"github.com/JhonCamargo53/prueba-tecnica/internal/domain/servicing"
+	"github.com/JhonCamargo53/prueba-tecnica/internal/infrastructure/logger"
+)
+
+// DelinquencySummary resume una ejecución del cálculo de mora.
+type DelinquencySummary struct {
+	AsOf     time.Time      `json:"asOf"`
+	Accounts int            `json:"accounts"`
+	Buckets  map[string]int `json:"buckets"`
+}
+
+type LoanAccountService struct {
+	loanRepo          ports.LoanAccountRepository
+	creditRequestRepo ports.CreditRequestRepository
+	// Decisiones e historial de estados, de donde se toma la fecha de aprobación
+	decisionRepo    ports.CreditDecisionRepository
+	historyRepo     ports.CreditStatusHistoryRepository
+	scheduleService *paymentSchedule.PaymentScheduleService
+
+	// Evita que un pago y el cálculo de mora modifiquen la misma cuenta a la vez en
+	// este proceso; entre instancias los pagos se serializan con el bloqueo de la cuenta
+	mu sync.Mutex
+}
+
+func NewLoanAccountService(loanRepo ports.LoanAccountRepository, creditRequestRepo ports.CreditRequestRepository,
+	decisionRepo ports.CreditDecisionRepository, historyRepo ports.CreditStatusHistoryRepository,
+	scheduleService *paymentSchedule.PaymentScheduleService) *LoanAccountService {
+	return &LoanAccountService{
+		loanRepo:          loanRepo,
+		creditRequestRepo: creditRequestRepo,
+		decisionRepo:      decisionRepo,
+		historyRepo:       historyRepo,
+		scheduleService:   scheduleService,
+	}
+}
+
+// OpenLoanAccount abre la cuenta del crédito de una solicitud aprobada en approvedAt,
+// con su plan de pagos. Si la solicitud ya tiene cuenta se retorna la existente.
+func (s *LoanAccountService) OpenLoanAccount(creditRequest *models.CreditRequest, approvedAt time.Time) (*models.LoanAccount, error) {
+	existing, err := s.loanRepo.FindByCreditRequestID(creditRequest.ID)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return existing, nil
+	}
+
+	schedule, err := s.scheduleService.GenerateSchedule(creditRequest, approvedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	account := &models.LoanAccount{
+		CreditRequestID:      creditRequest.ID,
+		PaymentScheduleID:    schedule.ID,
+		Principal:            schedule.Principal,
+		OutstandingPrincipal: schedule.Principal,
+		Status:               models.LoanAccountStatusActive,
+		DelinquencyBucket:    servicing.BucketCurrent,
+	}
+
+	if err := s.loanRepo.Create(account); err != nil {
+		return nil, err
+	}
+	account.PaymentSchedule = *schedule
+
+	return account, nil
+}
+
+// GetLoanAccount retorna la cuenta del crédito de la solicitud. No escribe: las
+// cuentas de las solicitudes aprobadas antes de existir la cartera las abre
+// BackfillLoanAccounts al iniciar el servidor.
+func (s *LoanAccountService) GetLoanAccount(creditRequestID uint) (*models.LoanAccount, error) {
+	creditRequest, err := s.creditRequestRepo.FindByID(creditRequestID)
+	if err != nil {
+		return nil, err
+	}
+	if creditRequest == nil {
+		return nil, fmt.Errorf("no existe solicitud de crédito con id %d", creditRequestID)
+	}
+
+	account, err := s.loanRepo.FindByCreditRequestID(creditRequestID)
+	if err != nil {
+		return nil, err
+	}
+	if account != nil {
+		return account, nil
+	}
+
+	if creditRequest.CreditStatusID != models.CreditStatusApprovedID {
+		return nil, fmt.Errorf("la solicitud %d no está aprobada: no tiene cuenta de crédito", creditRequestID)
+	}
+
+	return nil, fmt.Errorf("la solicitud %d está aprobada pero aún no tiene cuenta de crédito", creditRequestID)
+}
+
+// BackfillLoanAccounts abre la cuenta de las solicitudes aprobadas que no la tienen,
+// con la fecha en que se aprobaron. Retorna cuántas cuentas abrió; las solicitudes
+// sin fecha de aprobación conocida se registran y se omiten.
+func (s *LoanAccountService) BackfillLoanAccounts() (int, error) {
+	creditRequests, err := s.creditRequestRepo.FindAll(nil)
+	if err != nil {
+		return 0, err
+	}
+
+	opened := 0
+	for i := range creditRequests {
+		creditRequest := &creditRequests[i]
+		if creditRequest.CreditStatusID != models.CreditStatusApprovedID || creditRequest.LoanAccount != nil {
+			continue
+		}
+
+		approvedAt, err := s.approvedAt(creditRequest.ID)
+		if err != nil {
+			return opened, err
+		}
+		if approvedAt.IsZero() {
+			logger.WriteJSON(map[string]interface{}{
+				"timestamp":         time.Now().Format(time.RFC3339),
+				"level":             "warning",
+				"event":             "loan_account_backfill_skipped",
+				"credit_request_id": creditRequest.ID,
+				"reason":            "sin decisión ni cambio de estado que registre la aprobación",
+			})
+			continue
+		}
+
+		account, err := s.OpenLoanAccount(creditRequest, approvedAt)
+		if err != nil {
+			return opened, err
+		}
+		opened++
+
+		logger.WriteJSON(map[string]interface{}{
+			"timestamp":         time.Now().Format(time.RFC3339),
+			"level":             "info",
+			"event":             "loan_account_backfilled",
+			"credit_request_id": creditRequest.ID,
+			"loan_account_id":   account.ID,
+			"approved_at":       approvedAt.Format(time.RFC3339),
+		})
+	}
+
+	return opened, nil
+}
+
+// approvedAt retorna cuándo se aprobó la solicitud: la decisión de aprobación
+// aplicada o confirmada más reciente o, si no la hay, el último cambio de estado a
+// APROBADO. Retorna la fecha cero si no hay registro de la aprobación.
+func (s *LoanAccountService) approvedAt(creditRequestID uint) (time.Time, error) {
+	decisions, err := s.decisionRepo.FindByCreditRequestID(creditRequestID)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	var approvedAt time.Time
+	for _, decision := range decisions {
+		if decision.CreditStatusID != models.CreditStatusApprovedID {
+			continue
+		}
+		at := decision.DecidedAt
+		switch {
+		case decision.Status == models.CreditDecisionStatusConfirmed && decision.ReviewedAt != nil:
+			at = *decision.ReviewedAt
+		case decision.Status != models.CreditDecisionStatusApplied:
+			continue
+		}
+		if at.After(approvedAt) {
+			approvedAt = at
+		}
+	}
+	if !approvedAt.IsZero() {
+		return approvedAt, nil
+	}
+
+	history, err := s.historyRepo.FindByCreditRequestID(creditRequestID)
+	if err != nil {
+		return time.Time{}, err
+	}
+	for _, change := range history {
+		if change.ToStatusID == models.CreditStatusApprovedID && change.CreatedAt.After(approvedAt) {
+			approvedAt = change.CreatedAt
+		}
+	}
+
+	return approvedAt, nil
+}
+
+// RegisterPayment aplica un pago a las cuotas del plan en orden de vencimiento,
+// primero al interés y luego al capital, y actualiza los saldos y la mora de la cuenta.
+func (s *LoanAccountService) RegisterPayment(creditRequestID uint, amount float64, paidAt time.Time, reference string, registeredByID uint) (*models.LoanPayment, error) {
+	now := time.Now()
+	if paidAt.IsZero() {
+		paidAt = now
+	}
+
+	if amount <= 0 {
+		return nil, fmt.Errorf("el valor del pago debe ser mayor que cero")
+	}
+	if paidAt.After(now) {
+		return nil, fmt.Errorf("la fecha del pago no puede ser futura")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	account, err := s.GetLoanAccount(creditRequestID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Los saldos se calculan sobre la cuenta bloqueada en la transacción del pago
+	var payment *models.LoanPayment
+	err = s.loanRepo.RegisterPayment(account.ID, func(locked *models.LoanAccount) (*models.LoanPayment, []models.PaymentScheduleInstallment, error) {
+		account = locked
+		built, changed, err := applyPayment(locked, amount, paidAt, now, reference, registeredByID)
+		payment = built
+		return built, changed, err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	logger.WriteJSON(map[string]interface{}{
+		"timestamp":         time.Now().Format(time.RFC3339),
+		"level":             "info",
+		"event":             "loan_payment_registered",
+		"credit_request_id": creditRequestID,
+		"loan_account_id":   account.ID,
+		"amount":            amount,
+		"status":            account.Status,
+		"registered_by":     registeredByID,
+	})
+
+	return payment, nil
+}
+
+// applyPayment distribuye el pago entre las cuotas de la cuenta y actualiza sus saldos
+// y su mora. Retorna el pago y las cuotas que cambiaron.
+func applyPayment(account *models.LoanAccount, amount float64, paidAt, now time.Time, reference string,
+	registeredByID uint) (*models.LoanPayment, []models.PaymentScheduleInstallment, error) {
+
+	if account.Status == models.LoanAccountStatusPaidOff {
+		return nil, nil, fmt.Errorf("el crédito de la solicitud %d ya está pagado", account.CreditRequestID)
+	}
+	if paidAt.Before(account.PaymentSchedule.StartDate) {
+		return nil, nil, fmt.Errorf("la fecha del pago no puede ser anterior al desembolso del crédito")
+	}
+
+	installments := account.PaymentSchedule.Installments
+	principal, interest := servicing.Outstanding(installments)
+	if owed := math.Round((principal+interest)*100) / 100; amount > owed {
+		return nil, nil, fmt.Errorf("el pago (%.2f) supera el saldo pendiente del crédito (%.2f)", amount, owed)
+	}
+
+	// La mora que tenía el crédito al momento del pago queda como la mayor alcanzada
+	refreshDelinquency(account, installments, paidAt)
+
+	allocations, _ := servicing.Allocate(installments, amount, paidAt)
+
+	payment := &models.LoanPayment{
+		LoanAccountID:  account.ID,
+		Amount:         amount,
+		PaidAt:         paidAt,
+		Reference:      reference,
+		RegisteredByID: registeredByID,
+		Allocations:    allocations,
+	}
+
+	changed := make([]models.PaymentScheduleInstallment, 0, len(allocations))
+	for _, allocation := range allocations {
+		payment.InterestAmount += allocation.InterestAmount
+		payment.PrincipalAmount += allocation.PrincipalAmount
+		changed = append(changed, installments[allocation.InstallmentNumber-1])
+	}
+	payment.InterestAmount = math.Round(payment.InterestAmount*100) / 100
+	payment.PrincipalAmount = math.Round(payment.PrincipalAmount*100) / 100
+
+	account.InterestPaid = math.Round((account.InterestPaid+payment.InterestAmount)*100) / 100
+	account.PrincipalPaid = math.Round((account.PrincipalPaid+payment.PrincipalAmount)*100) / 100
+	account.OutstandingPrincipal, _ = servicing.Outstanding(installments)
+	if account.LastPaymentAt == nil || paidAt.After(*account.LastPaymentAt) {
+		account.LastPaymentAt = &paidAt
+	}
+	if account.OutstandingPrincipal == 0 {
+		account.Status = models.LoanAccountStatusPaidOff
+	}
+	refreshDelinquency(account, installments, now)
+
+	return payment, changed, nil
+}
+
+func (s *LoanAccountService) GetPayments(creditRequestID uint) ([]models.LoanPayment, error) {
+	account, err := s.GetLoanAccount(creditRequestID)
+	if err != nil {
+		return nil, err
+	}
+	return s.loanRepo.FindPayments(account.ID)
+}
+
+func (s *LoanAccountService) GetDelinquentAccounts() ([]models.LoanAccount, error) {
+	return s.loanRepo.FindDelinquent()
+}
+
+// Schedule abre las cuentas pendientes de las solicitudes aprobadas y calcula la mora
+// de la cartera al iniciar y luego cada intervalo. Retorna una función para detenerlo.
+func (s *LoanAccountService) Schedule(interval time.Duration) (stop func()) {
+	done := make(chan struct{})
+
+	go func() {
+		// La cartera se pone al día sin esperar al primer intervalo
+		s.runPortfolioJob(time.Now())
+		if interval <= 0 {
+			return
+		}
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-done:
+				return
+			case now := <-ticker.C:
+				s.runPortfolioJob(now)
+			}
+		}
+	}()
+
+	var once sync.Once
+	return func() { once.Do(func() { close(done) }) }
+}
+
+// runPortfolioJob reintenta abrir las cuentas que no se abrieron al aprobar y
+// recalcula la mora a la fecha now.
+func (s *LoanAccountService) runPortfolioJob(now time.Time) {
+	if _, err := s.BackfillLoanAccounts(); err != nil {
+		logger.WriteJSON(map[string]interface{}{
+			"timestamp": time.Now().Format(time.RFC3339),
+			"level":     "error",
+			"event":     "loan_account_backfill_failed",
+			"error":     err.Error(),
+		})
+	}
+
+	if _, err := s.UpdateDelinquency(now); err != nil {
+		logger.WriteJSON(map[string]interface{}{
+			"timestamp": time.Now().Format(time.RFC3339),
+			"level":     "error",
+			"event":     "loan_delinquency_failed",
+			"error":     err.Error(),
+		})
+	}
+}
+
+// UpdateDelinquency recalcula los días de mora y la franja de las cuentas activas a la fecha asOf.
+func (s *LoanAccountService) UpdateDelinquency(asOf time.Time) (*DelinquencySummary, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	accounts, err := s.loanRepo.FindActive()
+	if err != nil {
+		return nil, err
+	}
+
+	summary := &DelinquencySummary{AsOf: asOf, Buckets: map[string]int{}}
+
+	for i := range accounts {
+		account := &accounts[i]
+		previousBucket := account.DelinquencyBucket
+
+		refreshDelinquency(account, account.PaymentSchedule.Installments, asOf)
+		if err := s.loanRepo.UpdateDelinquency(account); err != nil {
+			return nil, err
+		}
+
+		summary.Accounts++
+		summary.Buckets[account.DelinquencyBucket]++
+
+		if account.DelinquencyBucket != previousBucket {
+			logger.WriteJSON(map[string]interface{}{
+				"timestamp":         time.Now().Format(time.RFC3339),
+				"level":             "info",
+				"event":             "loan_delinquency_bucket_changed",
+				"credit_request_id": account.CreditRequestID,
+				"loan_account_id":   account.ID,
+				"from_bucket":       previousBucket,
+				"to_bucket":         account.DelinquencyBucket,
+				"days_past_due":     account.DaysPastDue,
+			})
+		}
+	}
+
+	return summary, nil
+}
+
+// refreshDelinquency actualiza los días de mora, la franja y la mayor mora de la cuenta.
+func refreshDelinquency(account *models.LoanAccount, installments []models.PaymentScheduleInstallment, asOf time.Time) {
+	account.DaysPastDue = servicing.DaysPastDue(installments, asOf)
+	account.DelinquencyBucket = servicing.BucketFor(account.DaysPastDue)
+	if account.DaysPastDue > account.MaxDaysPastDue {
+		account.MaxDaysPastDue = account.DaysPastDue
+	}
+	updatedAt := asOf
+	account.DelinquencyUpdatedAt = &updatedAt
+}
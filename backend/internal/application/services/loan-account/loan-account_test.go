@@ -0,0 +1,249 @@
+package loanAccount
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	paymentSchedule "github.com/JhonCamargo53/prueba-tecnica/internal/application/services/payment-schedule"
+	"github.com/JhonCamargo53/prueba-tecnica/internal/domain/models"
+	"github.com/JhonCamargo53/prueba-tecnica/internal/domain/servicing"
+)
+
+const registeredByID uint = 1
+
+func approvedRequest(id uint) *models.CreditRequest {
+	// 12.000.000 a 12 meses con 1% mensual
+	return &models.CreditRequest{ID: id, Amount: 12_000_000, TermMonths: 12, InterestRate: 12.682503,
+		CreditStatusID: models.CreditStatusApprovedID}
+}
+
+func newService(requests ...*models.CreditRequest) (*LoanAccountService, *MockLoanAccountRepository) {
+	service, loanRepo, _, _ := newServiceWithHistory(requests...)
+	return service, loanRepo
+}
+
+func newServiceWithHistory(requests ...*models.CreditRequest) (*LoanAccountService, *MockLoanAccountRepository,
+	*MockCreditDecisionRepository, *MockCreditStatusHistoryRepository) {
+	creditRequestRepo := &MockCreditRequestRepository{Requests: map[uint]*models.CreditRequest{}}
+	for _, cr := range requests {
+		creditRequestRepo.Requests[cr.ID] = cr
+	}
+	scheduleService := paymentSchedule.NewPaymentScheduleService(&MockPaymentScheduleRepository{}, creditRequestRepo)
+	loanRepo := &MockLoanAccountRepository{}
+	decisionRepo := &MockCreditDecisionRepository{}
+	historyRepo := &MockCreditStatusHistoryRepository{}
+	return NewLoanAccountService(loanRepo, creditRequestRepo, decisionRepo, historyRepo, scheduleService), loanRepo, decisionRepo, historyRepo
+}
+
+func TestOpenLoanAccount_AbreLaCuentaConSuPlan(t *testing.T) {
+	cr := approvedRequest(5)
+	service, loanRepo := newService(cr)
+
+	account, err := service.OpenLoanAccount(cr, time.Now())
+	if err != nil {
+		t.Fatalf("no se esperaba error: %v", err)
+	}
+
+	if account.OutstandingPrincipal != 12_000_000 || account.Status != models.LoanAccountStatusActive ||
+		account.DelinquencyBucket != servicing.BucketCurrent || len(account.PaymentSchedule.Installments) != 12 {
+		t.Errorf("cuenta inesperada: %+v", account)
+	}
+
+	again, err := service.OpenLoanAccount(cr, time.Now())
+	if err != nil || again != account || len(loanRepo.Accounts) != 1 {
+		t.Errorf("se esperaba la cuenta existente, obtenido: %+v, %v", again, err)
+	}
+}
+
+func TestGetLoanAccount_NoAbreLaCuenta(t *testing.T) {
+	service, loanRepo := newService(approvedRequest(5))
+
+	if _, err := service.GetLoanAccount(5); err == nil || !strings.Contains(err.Error(), "no tiene cuenta") {
+		t.Errorf("se esperaba error por solicitud sin cuenta, obtenido: %v", err)
+	}
+	if len(loanRepo.Accounts) != 0 {
+		t.Errorf("la consulta no debe abrir la cuenta: %+v", loanRepo.Accounts)
+	}
+}
+
+func TestBackfillLoanAccounts_UsaLaFechaDeAprobacion(t *testing.T) {
+	decided, confirmed, fromHistory, unknown := approvedRequest(5), approvedRequest(6), approvedRequest(7), approvedRequest(8)
+	pending := &models.CreditRequest{ID: 9, Amount: 1_000_000, TermMonths: 12, CreditStatusID: models.CreditStatusPendingID}
+	service, loanRepo, decisionRepo, historyRepo := newServiceWithHistory(decided, confirmed, fromHistory, unknown, pending)
+
+	day := func(d int) time.Time { return time.Date(2026, 3, d, 10, 0, 0, 0, time.UTC) }
+	reviewedAt := day(4)
+	decisionRepo.Decisions = []models.CreditDecision{
+		{CreditRequestID: 5, CreditStatusID: models.CreditStatusApprovedID, Status: models.CreditDecisionStatusApplied, DecidedAt: day(2)},
+		{CreditRequestID: 6, CreditStatusID: models.CreditStatusApprovedID, Status: models.CreditDecisionStatusDeclined, DecidedAt: day(1)},
+		{CreditRequestID: 6, CreditStatusID: models.CreditStatusApprovedID, Status: models.CreditDecisionStatusConfirmed,
+			DecidedAt: day(3), ReviewedAt: &reviewedAt},
+	}
+	historyRepo.History = []models.CreditStatusHistory{
+		{CreditRequestID: 5, ToStatusID: models.CreditStatusApprovedID, CreatedAt: day(20)},
+		{CreditRequestID: 7, ToStatusID: models.CreditStatusInStudyID, CreatedAt: day(5)},
+		{CreditRequestID: 7, ToStatusID: models.CreditStatusApprovedID, CreatedAt: day(6)},
+	}
+
+	opened, err := service.BackfillLoanAccounts()
+	if err != nil {
+		t.Fatalf("no se esperaba error: %v", err)
+	}
+	if opened != 3 || len(loanRepo.Accounts) != 3 {
+		t.Fatalf("se esperaban 3 cuentas abiertas, obtenidas %d", opened)
+	}
+
+	want := map[uint]time.Time{5: day(2), 6: day(4), 7: day(6)}
+	for id, approvedAt := range want {
+		account, err := service.GetLoanAccount(id)
+		if err != nil {
+			t.Fatalf("solicitud %d: se esperaba la cuenta: %v", id, err)
+		}
+		if !account.PaymentSchedule.StartDate.Equal(approvedAt) {
+			t.Errorf("solicitud %d: se esperaba el plan desde %s, obtenido %s", id, approvedAt, account.PaymentSchedule.StartDate)
+		}
+	}
+	if _, err := service.GetLoanAccount(8); err == nil {
+		t.Errorf("no se esperaba abrir la cuenta de una aprobación sin fecha conocida")
+	}
+}
+
+func TestRegisterPayment_DistribuyeInteresYCapital(t *testing.T) {
+	cr := approvedRequest(5)
+	service, _ := newService(cr)
+
+	// Dos cuotas vencidas: la primera hace 35 días y la segunda hace 5
+	now := time.Now()
+	if _, err := service.OpenLoanAccount(cr, now.AddDate(0, -2, -5)); err != nil {
+		t.Fatalf("no se esperaba error: %v", err)
+	}
+
+	payment, err := service.RegisterPayment(5, 700_000, time.Time{}, "consignación 123", registeredByID)
+	if err != nil {
+		t.Fatalf("no se esperaba error: %v", err)
+	}
+
+	if len(payment.Allocations) != 1 || payment.InterestAmount != 120_000 || payment.PrincipalAmount != 580_000 {
+		t.Errorf("se esperaba pagar primero el interés de la primera cuota: %+v", payment)
+	}
+
+	account, _ := service.GetLoanAccount(5)
+	if account.OutstandingPrincipal != 12_000_000-580_000 || account.InterestPaid != 120_000 || account.LastPaymentAt == nil {
+		t.Errorf("saldos inesperados: %+v", account)
+	}
+	// La primera cuota sigue incompleta, así que la mora se mantiene y se registra como la mayor
+	if account.DaysPastDue < 30 || account.DelinquencyBucket != servicing.Bucket30To59 || account.MaxDaysPastDue != account.DaysPastDue {
+		t.Errorf("mora inesperada: %d días, franja %s, máxima %d", account.DaysPastDue, account.DelinquencyBucket, account.MaxDaysPastDue)
+	}
+
+	// Completar la primera cuota deja la mora en la segunda, pero conserva la mayor alcanzada
+	if _, err := service.RegisterPayment(5, 1_000_000, time.Time{}, "", registeredByID); err != nil {
+		t.Fatalf("no se esperaba error: %v", err)
+	}
+	account, _ = service.GetLoanAccount(5)
+	if account.DaysPastDue >= 30 || account.DelinquencyBucket != servicing.Bucket1To29 || account.MaxDaysPastDue < 30 {
+		t.Errorf("mora inesperada tras el segundo pago: %d días, franja %s, máxima %d",
+			account.DaysPastDue, account.DelinquencyBucket, account.MaxDaysPastDue)
+	}
+}
+
+func TestRegisterPayment_PagoTotalCierraLaCuenta(t *testing.T) {
+	cr := approvedRequest(5)
+	service, _ := newService(cr)
+
+	account, err := service.OpenLoanAccount(cr, time.Now().AddDate(0, 0, -1))
+	if err != nil {
+		t.Fatalf("no se esperaba error: %v", err)
+	}
+	principal, interest := servicing.Outstanding(account.PaymentSchedule.Installments)
+
+	if _, err := service.RegisterPayment(5, principal+interest, time.Time{}, "", registeredByID); err != nil {
+		t.Fatalf("no se esperaba error: %v", err)
+	}
+
+	account, _ = service.GetLoanAccount(5)
+	if account.Status != models.LoanAccountStatusPaidOff || account.OutstandingPrincipal != 0 {
+		t.Errorf("se esperaba la cuenta pagada: %+v", account)
+	}
+
+	if _, err := service.RegisterPayment(5, 1_000, time.Time{}, "", registeredByID); err == nil || !strings.Contains(err.Error(), "ya está pagado") {
+		t.Errorf("se esperaba error por crédito pagado, obtenido: %v", err)
+	}
+}
+
+func TestRegisterPayment_Validaciones(t *testing.T) {
+	cr := approvedRequest(5)
+	pending := &models.CreditRequest{ID: 6, Amount: 1_000_000, TermMonths: 12, CreditStatusID: models.CreditStatusPendingID}
+	service, _ := newService(cr, pending)
+
+	if _, err := service.OpenLoanAccount(cr, time.Now().AddDate(0, 0, -10)); err != nil {
+		t.Fatalf("no se esperaba error: %v", err)
+	}
+
+	cases := map[string]struct {
+		creditRequestID uint
+		amount          float64
+		paidAt          time.Time
+		want            string
+	}{
+		"valor cero":            {5, 0, time.Time{}, "mayor que cero"},
+		"fecha futura":          {5, 100_000, time.Now().Add(time.Hour), "futura"},
+		"antes del inicio":      {5, 100_000, time.Now().AddDate(0, 0, -20), "anterior al desembolso"},
+		"supera el saldo":       {5, 20_000_000, time.Time{}, "supera el saldo"},
+		"no aprobada":           {6, 100_000, time.Time{}, "no está aprobada"},
+		"solicitud inexistente": {99, 100_000, time.Time{}, "no existe"},
+	}
+
+	for name, c := range cases {
+		if _, err := service.RegisterPayment(c.creditRequestID, c.amount, c.paidAt, "", registeredByID); err == nil || !strings.Contains(err.Error(), c.want) {
+			t.Errorf("%s: se esperaba error %q, obtenido: %v", name, c.want, err)
+		}
+	}
+}
+
+func TestUpdateDelinquency_CalculaFranjas(t *testing.T) {
+	late, current := approvedRequest(5), approvedRequest(6)
+	service, loanRepo := newService(late, current)
+
+	asOf := time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC)
+	service.OpenLoanAccount(late, time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+	service.OpenLoanAccount(current, time.Date(2026, 4, 15, 0, 0, 0, 0, time.UTC))
+
+	summary, err := service.UpdateDelinquency(asOf)
+	if err != nil {
+		t.Fatalf("no se esperaba error: %v", err)
+	}
+
+	if summary.Accounts != 2 || summary.Buckets[servicing.Bucket60To89] != 1 || summary.Buckets[servicing.BucketCurrent] != 1 {
+		t.Errorf("resumen inesperado: %+v", summary)
+	}
+	// Primera cuota vencida el 1 de febrero: 89 días al 1 de mayo
+	if account := loanRepo.Accounts[5]; account.DaysPastDue != 89 || account.MaxDaysPastDue != 89 {
+		t.Errorf("se esperaban 89 días de mora, obtenido: %+v", account)
+	}
+}
+
+func TestSchedule_CalculaLaMoraAlIniciar(t *testing.T) {
+	cr := approvedRequest(5)
+	service, loanRepo := newService(cr)
+	service.OpenLoanAccount(cr, time.Now().AddDate(0, -2, -5))
+
+	stop := service.Schedule(time.Hour)
+	defer stop()
+
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		service.mu.Lock()
+		daysPastDue := loanRepo.Accounts[5].DaysPastDue
+		service.mu.Unlock()
+
+		if daysPastDue > 30 {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("se esperaba calcular la mora sin esperar al primer intervalo")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
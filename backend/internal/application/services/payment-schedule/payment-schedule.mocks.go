@@ -0,0 +1,79 @@
+package paymentSchedule
+
+import (
+	"github.com/JhonCamargo53/prueba-tecnica/internal/domain/models"
+	"github.com/JhonCamargo53/prueba-tecnica/internal/domain/ports"
+)
+
+/* Mock de PaymentScheduleRepository */
+
+type MockPaymentScheduleRepository struct {
+	Schedules map[uint]*models.PaymentSchedule
+}
+
+var _ ports.PaymentScheduleRepository = (*MockPaymentScheduleRepository)(nil)
+
+func (m *MockPaymentScheduleRepository) FindByCreditRequestID(creditRequestID uint) (*models.PaymentSchedule, error) {
+	return m.Schedules[creditRequestID], nil
+}
+
+func (m *MockPaymentScheduleRepository) Create(schedule *models.PaymentSchedule) error {
+	if m.Schedules == nil {
+		m.Schedules = make(map[uint]*models.PaymentSchedule)
+	}
+	schedule.ID = uint(len(m.Schedules) + 1)
+	m.Schedules[schedule.CreditRequestID] = schedule
+	return nil
+}
+
+/* Mock de CreditRequestRepository */
+
+type MockCreditRequestRepository struct {
+	Requests map[uint]*models.CreditRequest
+}
+
+var _ ports.CreditRequestRepository = (*MockCreditRequestRepository)(nil)
+
+func (m *MockCreditRequestRepository) FindAll(customerID *uint) ([]models.CreditRequest, error) {
+	return nil, nil
+}
+
+func (m *MockCreditRequestRepository) FindByID(id uint) (*models.CreditRequest, error) {
+	if cr, ok := m.Requests[id]; ok {
+		copy := *cr
+		return &copy, nil
+	}
+	return nil, nil
+}
+
+func (m *MockCreditRequestRepository) HasRequestsByCustomerID(customerID uint) (bool, error) {
+	return false, nil
+}
+
+func (m *MockCreditRequestRepository) Create(creditRequest *models.CreditRequest) (*models.CreditRequest, error) {
+	return creditRequest, nil
+}
+
+func (m *MockCreditRequestRepository) Update(id uint, creditRequest *models.CreditRequest) (*models.CreditRequest, error) {
+	return creditRequest, nil
+}
+
+func (m *MockCreditRequestRepository) Delete(id uint) error {
+	return nil
+}
+
+func (m *MockCreditRequestRepository) UpdateCreditRiskEvaluation(id uint, assessment *models.RiskAssessment) (*models.CreditRequest, error) {
+	return nil, nil
+}
+
+func (m *MockCreditRequestRepository) UpdateCreditStatus(id uint, creditStatusID uint) error {
+	return nil
+}
+
+func (m *MockCreditRequestRepository) UpdateCreditOffer(id uint, offer *models.CreditOffer) error {
+	return nil
+}
+
+func (m *MockCreditRequestRepository) FindDataToEvaluateRisk(id uint) (models.Customer, *models.CreditRequest, []models.CreditRequest, []models.CustomerAsset, error) {
+	return models.Customer{}, nil, nil, nil, nil
+}
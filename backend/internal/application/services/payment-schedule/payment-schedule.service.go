@@ -0,0 +1,120 @@
+package paymentSchedule
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/JhonCamargo53/prueba-tecnica/internal/domain/finance"
+	"github.com/JhonCamargo53/prueba-tecnica/internal/domain/models"
+	"github.com/JhonCamargo53/prueba-tecnica/internal/domain/ports"
+)
+
+type PaymentScheduleService struct {
+	scheduleRepo      ports.PaymentScheduleRepository
+	creditRequestRepo ports.CreditRequestRepository
+}
+
+func NewPaymentScheduleService(scheduleRepo ports.PaymentScheduleRepository, creditRequestRepo ports.CreditRequestRepository) *PaymentScheduleService {
+	return &PaymentScheduleService{
+		scheduleRepo:      scheduleRepo,
+		creditRequestRepo: creditRequestRepo,
+	}
+}
+
+// GenerateSchedule genera y guarda el plan de pagos de una solicitud aprobada en
+// approvedAt. Si la solicitud ya tiene plan se retorna el existente.
+func (s *PaymentScheduleService) GenerateSchedule(creditRequest *models.CreditRequest, approvedAt time.Time) (*models.PaymentSchedule, error) {
+	existing, err := s.scheduleRepo.FindByCreditRequestID(creditRequest.ID)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return existing, nil
+	}
+
+	method := finance.AmortizationFrench
+	if creditRequest.CreditProduct != nil && creditRequest.CreditProduct.AmortizationMethod != "" {
+		method = creditRequest.CreditProduct.AmortizationMethod
+	}
+	rate := AnnualRateFor(*creditRequest)
+
+	periods, err := finance.AmortizationSchedule(creditRequest.Amount, rate, creditRequest.TermMonths, method, approvedAt)
+	if err != nil {
+		return nil, fmt.Errorf("no se pudo generar el plan de pagos de la solicitud %d: %w", creditRequest.ID, err)
+	}
+
+	schedule := &models.PaymentSchedule{
+		CreditRequestID: creditRequest.ID,
+		Method:          method,
+		Principal:       creditRequest.Amount,
+		AnnualRate:      rate,
+		TermMonths:      creditRequest.TermMonths,
+		StartDate:       approvedAt,
+		Installments:    make([]models.PaymentScheduleInstallment, 0, len(periods)),
+	}
+
+	for _, period := range periods {
+		schedule.TotalInterest += period.Interest
+		schedule.TotalPayment += period.Payment
+		schedule.Installments = append(schedule.Installments, models.PaymentScheduleInstallment{
+			Number:    period.Number,
+			DueDate:   period.DueDate,
+			Payment:   period.Payment,
+			Principal: period.Principal,
+			Interest:  period.Interest,
+			Balance:   period.Balance,
+		})
+	}
+
+	schedule.TotalInterest = math.Round(schedule.TotalInterest*100) / 100
+	schedule.TotalPayment = math.Round(schedule.TotalPayment*100) / 100
+
+	if err := s.scheduleRepo.Create(schedule); err != nil {
+		return nil, err
+	}
+
+	return schedule, nil
+}
+
+// GetSchedule retorna el plan de pagos de la solicitud. Las solicitudes aprobadas
+// antes de existir los planes lo generan en la primera consulta, con la fecha de su
+// última actualización como fecha de aprobación.
+func (s *PaymentScheduleService) GetSchedule(creditRequestID uint) (*models.PaymentSchedule, error) {
+	creditRequest, err := s.creditRequestRepo.FindByID(creditRequestID)
+	if err != nil {
+		return nil, err
+	}
+	if creditRequest == nil {
+		return nil, fmt.Errorf("no existe solicitud de crédito con id %d", creditRequestID)
+	}
+
+	schedule, err := s.scheduleRepo.FindByCreditRequestID(creditRequestID)
+	if err != nil {
+		return nil, err
+	}
+	if schedule != nil {
+		return schedule, nil
+	}
+
+	if creditRequest.CreditStatusID != models.CreditStatusApprovedID {
+		return nil, fmt.Errorf("la solicitud %d no está aprobada: no tiene plan de pagos", creditRequestID)
+	}
+
+	return s.GenerateSchedule(creditRequest, creditRequest.UpdatedAt)
+}
+
+// AnnualRateFor retorna la tasa E.A. (%) del plan: la de la oferta calculada con la
+// política de precios, la tasa propia de la solicitud o la tasa base del producto.
+func AnnualRateFor(creditRequest models.CreditRequest) float64 {
+	if creditRequest.Offer.PricingVersion != "" && creditRequest.Offer.AnnualRate > 0 {
+		return creditRequest.Offer.AnnualRate
+	}
+	if creditRequest.InterestRate > 0 {
+		return creditRequest.InterestRate
+	}
+	if creditRequest.CreditProduct != nil {
+		return creditRequest.CreditProduct.BaseAnnualRate
+	}
+	return 0
+}
@@ -0,0 +1,99 @@
+package paymentSchedule
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/JhonCamargo53/prueba-tecnica/internal/domain/models"
+)
+
+func newService(requests ...*models.CreditRequest) (*PaymentScheduleService, *MockPaymentScheduleRepository) {
+	creditRequestRepo := &MockCreditRequestRepository{Requests: map[uint]*models.CreditRequest{}}
+	for _, cr := range requests {
+		creditRequestRepo.Requests[cr.ID] = cr
+	}
+	scheduleRepo := &MockPaymentScheduleRepository{}
+	return NewPaymentScheduleService(scheduleRepo, creditRequestRepo), scheduleRepo
+}
+
+func TestGenerateSchedule_UsaMetodoDelProductoYTasaDeLaOferta(t *testing.T) {
+	service, scheduleRepo := newService()
+	approvedAt := time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC)
+	cr := &models.CreditRequest{ID: 7, Amount: 12_000_000, TermMonths: 12, InterestRate: 30,
+		Offer:         models.CreditOffer{PricingVersion: "pricing-2025.1", AnnualRate: 24},
+		CreditProduct: &models.CreditProduct{Code: "PERSONAL", BaseAnnualRate: 22, AmortizationMethod: "GERMAN"}}
+
+	schedule, err := service.GenerateSchedule(cr, approvedAt)
+	if err != nil {
+		t.Fatalf("no se esperaba error: %v", err)
+	}
+
+	if schedule.Method != "GERMAN" || schedule.AnnualRate != 24 || len(schedule.Installments) != 12 {
+		t.Fatalf("plan inesperado: método %s, tasa %.2f, %d cuotas", schedule.Method, schedule.AnnualRate, len(schedule.Installments))
+	}
+	if schedule.Installments[0].Principal != 1_000_000 {
+		t.Errorf("se esperaba abono a capital constante, obtenido: %.2f", schedule.Installments[0].Principal)
+	}
+	if schedule.TotalPayment-schedule.TotalInterest != 12_000_000 {
+		t.Errorf("el total pagado menos intereses debe ser el capital: %.2f - %.2f", schedule.TotalPayment, schedule.TotalInterest)
+	}
+	if scheduleRepo.Schedules[7] == nil {
+		t.Errorf("se esperaba guardar el plan")
+	}
+
+	// Generar de nuevo no reemplaza el plan guardado
+	again, err := service.GenerateSchedule(cr, approvedAt.AddDate(0, 1, 0))
+	if err != nil || again != schedule {
+		t.Errorf("se esperaba el plan existente, obtenido: %+v, %v", again, err)
+	}
+}
+
+func TestAnnualRateFor(t *testing.T) {
+	product := &models.CreditProduct{BaseAnnualRate: 16.5}
+
+	cases := map[string]struct {
+		cr   models.CreditRequest
+		want float64
+	}{
+		"oferta":    {models.CreditRequest{InterestRate: 20, Offer: models.CreditOffer{PricingVersion: "v1", AnnualRate: 25}, CreditProduct: product}, 25},
+		"solicitud": {models.CreditRequest{InterestRate: 20, CreditProduct: product}, 20},
+		"producto":  {models.CreditRequest{CreditProduct: product}, 16.5},
+		"sin datos": {models.CreditRequest{}, 0},
+	}
+
+	for name, c := range cases {
+		if got := AnnualRateFor(c.cr); got != c.want {
+			t.Errorf("%s: se esperaba %.2f, obtenido %.2f", name, c.want, got)
+		}
+	}
+}
+
+func TestGetSchedule_SolicitudNoAprobada(t *testing.T) {
+	service, _ := newService(&models.CreditRequest{ID: 3, Amount: 5_000_000, TermMonths: 12, CreditStatusID: models.CreditStatusInStudyID})
+
+	if _, err := service.GetSchedule(3); err == nil || !strings.Contains(err.Error(), "no está aprobada") {
+		t.Fatalf("se esperaba error por solicitud no aprobada, obtenido: %v", err)
+	}
+	if _, err := service.GetSchedule(99); err == nil || !strings.Contains(err.Error(), "no existe") {
+		t.Fatalf("se esperaba error por solicitud inexistente, obtenido: %v", err)
+	}
+}
+
+func TestGetSchedule_AprobadaSinPlanLoGenera(t *testing.T) {
+	approvedAt := time.Date(2025, 11, 30, 0, 0, 0, 0, time.UTC)
+	service, scheduleRepo := newService(&models.CreditRequest{ID: 4, Amount: 6_000_000, TermMonths: 6, InterestRate: 18,
+		CreditStatusID: models.CreditStatusApprovedID, UpdatedAt: approvedAt})
+
+	schedule, err := service.GetSchedule(4)
+	if err != nil {
+		t.Fatalf("no se esperaba error: %v", err)
+	}
+
+	if schedule.Method != "FRENCH" || !schedule.StartDate.Equal(approvedAt) || scheduleRepo.Schedules[4] == nil {
+		t.Errorf("plan inesperado: %+v", schedule)
+	}
+	if due := schedule.Installments[2].DueDate; !due.Equal(time.Date(2026, 2, 28, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("se esperaba vencimiento el último día de febrero, obtenido: %s", due)
+	}
+}
@@ -0,0 +1,166 @@
+package riskBacktesting
+
+import (
+	"errors"
+	"sort"
+
+	"github.com/JhonCamargo53/prueba-tecnica/internal/domain/models"
+	"github.com/JhonCamargo53/prueba-tecnica/internal/domain/ports"
+)
+
+/* Mock de RiskRescoringRepository: sólo se usa la consulta de IDs */
+
+type MockRiskRescoringRepository struct {
+	Requests map[uint]*models.CreditRequest
+
+	LastFilter models.RiskRescoringFilter
+}
+
+var _ ports.RiskRescoringRepository = (*MockRiskRescoringRepository)(nil)
+
+func (m *MockRiskRescoringRepository) FindCreditRequestIDs(filter models.RiskRescoringFilter, afterID uint, limit int) ([]uint, error) {
+	m.LastFilter = filter
+
+	var ids []uint
+	for id, cr := range m.Requests {
+		if id <= afterID {
+			continue
+		}
+		if len(filter.CreditStatusIDs) > 0 && !containsID(filter.CreditStatusIDs, cr.CreditStatusID) {
+			continue
+		}
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	if len(ids) > limit {
+		ids = ids[:limit]
+	}
+	return ids, nil
+}
+
+func (m *MockRiskRescoringRepository) CreateJob(job *models.RiskRescoringJob) error {
+	return nil
+}
+
+func (m *MockRiskRescoringRepository) UpdateJob(job *models.RiskRescoringJob) error {
+	return nil
+}
+
+func (m *MockRiskRescoringRepository) FindJobByID(id uint) (*models.RiskRescoringJob, error) {
+	return nil, nil
+}
+
+func (m *MockRiskRescoringRepository) FindJobs() ([]models.RiskRescoringJob, error) {
+	return nil, nil
+}
+
+func (m *MockRiskRescoringRepository) CreateChanges(changes []models.RiskRescoringChange) error {
+	return nil
+}
+
+func (m *MockRiskRescoringRepository) FindChangesByJobID(jobID uint) ([]models.RiskRescoringChange, error) {
+	return nil, nil
+}
+
+func containsID(ids []uint, id uint) bool {
+	for _, v := range ids {
+		if v == id {
+			return true
+		}
+	}
+	return false
+}
+
+/* Mock de CreditRequestRepository: sólo se usa la carga de datos para evaluar */
+
+type MockCreditRequestRepository struct {
+	Requests     map[uint]*models.CreditRequest
+	OtherCredits []models.CreditRequest
+}
+
+var _ ports.CreditRequestRepository = (*MockCreditRequestRepository)(nil)
+
+func (m *MockCreditRequestRepository) FindAll(customerID *uint) ([]models.CreditRequest, error) {
+	return nil, nil
+}
+
+func (m *MockCreditRequestRepository) FindByID(id uint) (*models.CreditRequest, error) {
+	if cr, ok := m.Requests[id]; ok {
+		copy := *cr
+		return &copy, nil
+	}
+	return nil, nil
+}
+
+func (m *MockCreditRequestRepository) HasRequestsByCustomerID(customerID uint) (bool, error) {
+	return false, nil
+}
+
+func (m *MockCreditRequestRepository) Create(creditRequest *models.CreditRequest) (*models.CreditRequest, error) {
+	return creditRequest, nil
+}
+
+func (m *MockCreditRequestRepository) Update(id uint, creditRequest *models.CreditRequest) (*models.CreditRequest, error) {
+	return creditRequest, nil
+}
+
+func (m *MockCreditRequestRepository) Delete(id uint) error {
+	return nil
+}
+
+func (m *MockCreditRequestRepository) UpdateCreditRiskEvaluation(id uint, assessment *models.RiskAssessment) (*models.CreditRequest, error) {
+	return nil, errors.New("el back-testing no debe modificar solicitudes")
+}
+
+func (m *MockCreditRequestRepository) UpdateCreditStatus(id uint, creditStatusID uint) error {
+	return errors.New("el back-testing no debe modificar solicitudes")
+}
+
+func (m *MockCreditRequestRepository) UpdateCreditOffer(id uint, offer *models.CreditOffer) error {
+	return errors.New("el back-testing no debe modificar solicitudes")
+}
+
+func (m *MockCreditRequestRepository) FindDataToEvaluateRisk(id uint) (models.Customer, *models.CreditRequest, []models.CreditRequest, []models.CustomerAsset, error) {
+	cr, ok := m.Requests[id]
+	if !ok {
+		return models.Customer{}, nil, nil, nil, errors.New("credit request no encontrada")
+	}
+	copy := *cr
+	return models.Customer{ID: cr.CustomerID}, &copy, m.OtherCredits, nil, nil
+}
+
+/* Mock de RiskEvaluator: el puntaje es el monto en millones */
+
+type MockRiskEvaluator struct {
+	Version string
+	FailIDs map[uint]bool
+
+	LastOtherCredits []models.CreditRequest
+}
+
+var _ ports.RiskEvaluator = (*MockRiskEvaluator)(nil)
+
+func (m *MockRiskEvaluator) Evaluate(customer models.Customer, currentCreditRequest models.CreditRequest,
+	otherCredits []models.CreditRequest, assets []models.CustomerAsset) (*models.RiskAssessment, error) {
+
+	m.LastOtherCredits = otherCredits
+
+	if m.FailIDs[currentCreditRequest.ID] {
+		return nil, errors.New("motor caído")
+	}
+
+	score := currentCreditRequest.Amount / 1_000_000
+	recommendation := models.RiskRecommendationReview
+	if score >= 70 {
+		recommendation = models.RiskRecommendationApprove
+	} else if score < 40 {
+		recommendation = models.RiskRecommendationReject
+	}
+
+	return &models.RiskAssessment{
+		EngineVersion:  m.Version,
+		Score:          score,
+		Recommendation: recommendation,
+	}, nil
+}
@@ -0,0 +1,140 @@
+package riskBacktesting
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/JhonCamargo53/prueba-tecnica/internal/domain/backtesting"
+	"github.com/JhonCamargo53/prueba-tecnica/internal/domain/models"
+	"github.com/JhonCamargo53/prueba-tecnica/internal/domain/ports"
+)
+
+// Tamaño de los lotes de IDs que se leen para el back-testing
+const batchSize = 500
+
+type RiskBacktestingService struct {
+	rescoringRepo     ports.RiskRescoringRepository
+	creditRequestRepo ports.CreditRequestRepository
+	evaluators        map[string]ports.RiskEvaluator
+	defaultEngine     string
+}
+
+// NewRiskBacktestingService recibe los motores configurados por nombre; defaultEngine
+// es el que se usa cuando no se indica ninguno (el champion).
+func NewRiskBacktestingService(rescoringRepo ports.RiskRescoringRepository, creditRequestRepo ports.CreditRequestRepository,
+	evaluators map[string]ports.RiskEvaluator, defaultEngine string) *RiskBacktestingService {
+	return &RiskBacktestingService{
+		rescoringRepo:     rescoringRepo,
+		creditRequestRepo: creditRequestRepo,
+		evaluators:        evaluators,
+		defaultEngine:     defaultEngine,
+	}
+}
+
+// Engines retorna los nombres de los motores disponibles para back-testing.
+func (s *RiskBacktestingService) Engines() []string {
+	names := make([]string, 0, len(s.evaluators))
+	for name := range s.evaluators {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Backtest re-evalúa con el motor indicado las solicitudes APROBADAS y RECHAZADAS
+// creadas en el rango [from, to) y compara el resultado con la decisión final.
+func (s *RiskBacktestingService) Backtest(engine string, from, to *time.Time, bands int) (*models.BacktestReport, error) {
+	if engine == "" {
+		engine = s.defaultEngine
+	}
+
+	evaluator, ok := s.evaluators[engine]
+	if !ok {
+		return nil, fmt.Errorf("motor de riesgo desconocido: %s", engine)
+	}
+
+	if bands <= 0 {
+		bands = backtesting.DefaultBands
+	}
+	if bands > backtesting.MaxBands {
+		return nil, fmt.Errorf("el número de bandas no puede ser mayor que %d", backtesting.MaxBands)
+	}
+
+	filter := models.RiskRescoringFilter{
+		CreditStatusIDs: []uint{models.CreditStatusApprovedID, models.CreditStatusRejectedID},
+		CreatedFrom:     from,
+		CreatedTo:       to,
+	}
+
+	var observations []backtesting.Observation
+	versions := map[string]bool{}
+	errors := 0
+
+	var afterID uint
+	for {
+		ids, err := s.rescoringRepo.FindCreditRequestIDs(filter, afterID, batchSize)
+		if err != nil {
+			return nil, err
+		}
+		if len(ids) == 0 {
+			break
+		}
+
+		for _, id := range ids {
+			creditRequest, assessment, err := s.evaluate(evaluator, id)
+			if err != nil {
+				errors++
+				continue
+			}
+
+			// Los rechazos por políticas no pasan por el motor y no tienen versión
+			if assessment.EngineVersion != "" {
+				versions[assessment.EngineVersion] = true
+			}
+			observations = append(observations, backtesting.Observation{
+				Score:          assessment.Score,
+				Recommendation: assessment.Recommendation,
+				Approved:       creditRequest.CreditStatusID == models.CreditStatusApprovedID,
+			})
+		}
+
+		afterID = ids[len(ids)-1]
+	}
+
+	report := backtesting.BuildReport(engine, observations, bands)
+	report.From = from
+	report.To = to
+	report.Errors = errors
+	for version := range versions {
+		report.EngineVersions = append(report.EngineVersions, version)
+	}
+	sort.Strings(report.EngineVersions)
+
+	return report, nil
+}
+
+// evaluate re-evalúa una solicitud considerando sólo las solicitudes del cliente
+// creadas antes que ella, para no usar información posterior a la decisión.
+func (s *RiskBacktestingService) evaluate(evaluator ports.RiskEvaluator, creditRequestID uint) (*models.CreditRequest, *models.RiskAssessment, error) {
+	customer, creditRequest, otherCredits, assets, err := s.creditRequestRepo.FindDataToEvaluateRisk(creditRequestID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var previous []models.CreditRequest
+	for _, other := range otherCredits {
+		if other.CreatedAt.Before(creditRequest.CreatedAt) {
+			// El comportamiento de pago actual es posterior a la decisión que se evalúa
+			other.LoanAccount = nil
+			previous = append(previous, other)
+		}
+	}
+
+	assessment, err := evaluator.Evaluate(customer, *creditRequest, previous, assets)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return creditRequest, assessment, nil
+}
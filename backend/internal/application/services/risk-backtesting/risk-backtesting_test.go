@@ -0,0 +1,95 @@
+package riskBacktesting
+
+import (
+	"testing"
+	"time"
+
+	"github.com/JhonCamargo53/prueba-tecnica/internal/domain/models"
+	"github.com/JhonCamargo53/prueba-tecnica/internal/domain/ports"
+)
+
+func newRequests() map[uint]*models.CreditRequest {
+	approved, rejected := models.CreditStatusApprovedID, models.CreditStatusRejectedID
+	return map[uint]*models.CreditRequest{
+		1: {ID: 1, Amount: 90_000_000, CreditStatusID: approved},
+		2: {ID: 2, Amount: 75_000_000, CreditStatusID: approved},
+		3: {ID: 3, Amount: 50_000_000, CreditStatusID: rejected},
+		4: {ID: 4, Amount: 20_000_000, CreditStatusID: rejected},
+		5: {ID: 5, Amount: 60_000_000, CreditStatusID: models.CreditStatusPendingID},
+	}
+}
+
+func TestBacktest_SoloSolicitudesDecididas(t *testing.T) {
+	requests := newRequests()
+	evaluator := &MockRiskEvaluator{Version: "v1"}
+	service := NewRiskBacktestingService(
+		&MockRiskRescoringRepository{Requests: requests},
+		&MockCreditRequestRepository{Requests: requests},
+		map[string]ports.RiskEvaluator{"mock": evaluator},
+		"mock",
+	)
+
+	report, err := service.Backtest("", nil, nil, 2)
+	if err != nil {
+		t.Fatalf("no se esperaba error: %v", err)
+	}
+
+	if report.Engine != "mock" || report.Requests != 4 || report.Approved != 2 || report.Rejected != 2 {
+		t.Fatalf("reporte inesperado: %+v", report)
+	}
+	if report.AUC != 1 || report.Gini != 1 || report.KS != 1 {
+		t.Errorf("se esperaba separación perfecta, AUC=%.2f Gini=%.2f KS=%.2f", report.AUC, report.Gini, report.KS)
+	}
+	if len(report.EngineVersions) != 1 || report.EngineVersions[0] != "v1" {
+		t.Errorf("versiones inesperadas: %v", report.EngineVersions)
+	}
+	if len(report.ScoreBands) != 2 {
+		t.Errorf("se esperaban 2 bandas, obtenidas %d", len(report.ScoreBands))
+	}
+}
+
+func TestBacktest_NoUsaSolicitudesPosteriores(t *testing.T) {
+	created := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	requests := map[uint]*models.CreditRequest{
+		1: {ID: 1, Amount: 90_000_000, CreditStatusID: models.CreditStatusApprovedID, CreatedAt: created},
+	}
+	evaluator := &MockRiskEvaluator{Version: "v1"}
+	service := NewRiskBacktestingService(
+		&MockRiskRescoringRepository{Requests: requests},
+		&MockCreditRequestRepository{Requests: requests, OtherCredits: []models.CreditRequest{
+			{ID: 2, CreatedAt: created.AddDate(0, -1, 0)},
+			{ID: 3, CreatedAt: created.AddDate(0, 1, 0)},
+		}},
+		map[string]ports.RiskEvaluator{"mock": evaluator},
+		"mock",
+	)
+
+	if _, err := service.Backtest("mock", nil, nil, 0); err != nil {
+		t.Fatalf("no se esperaba error: %v", err)
+	}
+	if len(evaluator.LastOtherCredits) != 1 || evaluator.LastOtherCredits[0].ID != 2 {
+		t.Errorf("se esperaba evaluar sólo con la solicitud anterior, obtenido: %+v", evaluator.LastOtherCredits)
+	}
+}
+
+func TestBacktest_ErroresYMotorDesconocido(t *testing.T) {
+	requests := newRequests()
+	service := NewRiskBacktestingService(
+		&MockRiskRescoringRepository{Requests: requests},
+		&MockCreditRequestRepository{Requests: requests},
+		map[string]ports.RiskEvaluator{"mock": &MockRiskEvaluator{Version: "v1", FailIDs: map[uint]bool{3: true}}},
+		"mock",
+	)
+
+	report, err := service.Backtest("mock", nil, nil, 0)
+	if err != nil {
+		t.Fatalf("no se esperaba error: %v", err)
+	}
+	if report.Errors != 1 || report.Requests != 3 {
+		t.Errorf("se esperaba 1 error y 3 solicitudes evaluadas, obtenido %d/%d", report.Errors, report.Requests)
+	}
+
+	if _, err := service.Backtest("otro", nil, nil, 0); err == nil {
+		t.Errorf("se esperaba error con un motor desconocido")
+	}
+}
@@ -0,0 +1,61 @@
+package riskDrift
+
+import (
+	"time"
+
+	"github.com/JhonCamargo53/prueba-tecnica/internal/domain/models"
+	"github.com/JhonCamargo53/prueba-tecnica/internal/domain/ports"
+)
+
+/* Mock de RiskDriftRepository */
+
+type MockRiskDriftObservation struct {
+	CreatedAt time.Time
+	models.RiskDriftObservation
+}
+
+type MockRiskDriftRepository struct {
+	Observations []MockRiskDriftObservation
+	Reports      []models.RiskDriftReport
+
+	ErrFind error
+	// Rangos consultados, en orden
+	Queries [][2]time.Time
+}
+
+var _ ports.RiskDriftRepository = (*MockRiskDriftRepository)(nil)
+
+func (m *MockRiskDriftRepository) FindObservations(from, to time.Time) ([]models.RiskDriftObservation, error) {
+	m.Queries = append(m.Queries, [2]time.Time{from, to})
+	if m.ErrFind != nil {
+		return nil, m.ErrFind
+	}
+
+	var res []models.RiskDriftObservation
+	for _, o := range m.Observations {
+		if !o.CreatedAt.Before(from) && o.CreatedAt.Before(to) {
+			res = append(res, o.RiskDriftObservation)
+		}
+	}
+	return res, nil
+}
+
+func (m *MockRiskDriftRepository) Create(report *models.RiskDriftReport) error {
+	report.ID = uint(len(m.Reports) + 1)
+	m.Reports = append(m.Reports, *report)
+	return nil
+}
+
+func (m *MockRiskDriftRepository) FindAll() ([]models.RiskDriftReport, error) {
+	return m.Reports, nil
+}
+
+func (m *MockRiskDriftRepository) FindByID(id uint) (*models.RiskDriftReport, error) {
+	for _, r := range m.Reports {
+		if r.ID == id {
+			copy := r
+			return &copy, nil
+		}
+	}
+	return nil, nil
+}
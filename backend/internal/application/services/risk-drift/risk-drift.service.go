@@ -0,0 +1,288 @@
+package riskDrift
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/JhonCamargo53/prueba-tecnica/internal/domain/drift"
+	"github.com/JhonCamargo53/prueba-tecnica/internal/domain/models"
+	"github.com/JhonCamargo53/prueba-tecnica/internal/domain/ports"
+	"github.com/JhonCamargo53/prueba-tecnica/internal/infrastructure/logger"
+)
+
+// DriftOptions define los períodos comparados y los umbrales de alerta.
+type DriftOptions struct {
+	// Período base [BaselineFrom, BaselineTo); si falta alguno se usa el período
+	// de igual duración inmediatamente anterior al actual
+	BaselineFrom *time.Time
+	BaselineTo   *time.Time
+	// Duración del período actual, que termina en el momento del cálculo
+	WindowDays int
+	Bins       int
+	// Mínimo de solicitudes en cada período para calcular los índices
+	MinSample  int
+	WarningPSI float64
+	AlertPSI   float64
+}
+
+func (o DriftOptions) Validate() error {
+	if o.WindowDays <= 0 {
+		return fmt.Errorf("la ventana de monitoreo debe ser de al menos un día")
+	}
+	if o.BaselineFrom != nil && o.BaselineTo != nil && !o.BaselineFrom.Before(*o.BaselineTo) {
+		return fmt.Errorf("el inicio del período base debe ser anterior a su fin")
+	}
+	if o.WarningPSI <= 0 || o.AlertPSI < o.WarningPSI {
+		return fmt.Errorf("los umbrales deben cumplir 0 < advertencia <= alerta")
+	}
+	return nil
+}
+
+type RiskDriftService struct {
+	repo    ports.RiskDriftRepository
+	options DriftOptions
+
+	// Evita cálculos simultáneos del job y del endpoint
+	mu sync.Mutex
+}
+
+func NewRiskDriftService(repo ports.RiskDriftRepository, options DriftOptions) *RiskDriftService {
+	if options.Bins <= 0 {
+		options.Bins = drift.DefaultBins
+	}
+	return &RiskDriftService{
+		repo:    repo,
+		options: options,
+	}
+}
+
+// Schedule calcula un reporte cada intervalo. Retorna una función para detenerlo.
+func (s *RiskDriftService) Schedule(interval time.Duration) (stop func()) {
+	done := make(chan struct{})
+
+	if interval <= 0 {
+		return func() {}
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-done:
+				return
+			case now := <-ticker.C:
+				if _, err := s.Compute(now); err != nil {
+					logger.WriteJSON(map[string]interface{}{
+						"timestamp": time.Now().Format(time.RFC3339),
+						"level":     "error",
+						"event":     "risk_drift_failed",
+						"error":     err.Error(),
+					})
+				}
+			}
+		}
+	}()
+
+	var once sync.Once
+	return func() { once.Do(func() { close(done) }) }
+}
+
+// Compute compara el período que termina en now con el período base y guarda el reporte.
+func (s *RiskDriftService) Compute(now time.Time) (*models.RiskDriftReport, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	currentTo := now
+	currentFrom := now.AddDate(0, 0, -s.options.WindowDays)
+
+	baselineFrom := currentFrom.AddDate(0, 0, -s.options.WindowDays)
+	baselineTo := currentFrom
+	if s.options.BaselineFrom != nil && s.options.BaselineTo != nil {
+		baselineFrom, baselineTo = *s.options.BaselineFrom, *s.options.BaselineTo
+	}
+
+	baseline, err := s.repo.FindObservations(baselineFrom, baselineTo)
+	if err != nil {
+		return nil, err
+	}
+
+	current, err := s.repo.FindObservations(currentFrom, currentTo)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &models.RiskDriftReport{
+		BaselineFrom:  baselineFrom,
+		BaselineTo:    baselineTo,
+		CurrentFrom:   currentFrom,
+		CurrentTo:     currentTo,
+		BaselineCount: len(baseline),
+		CurrentCount:  len(current),
+		WarningPSI:    s.options.WarningPSI,
+		AlertPSI:      s.options.AlertPSI,
+		Status:        models.RiskDriftStatusInsufficientData,
+	}
+
+	characteristics := []models.RiskDriftCharacteristic{}
+
+	if len(baseline) >= s.options.MinSample && len(current) >= s.options.MinSample && len(baseline) > 0 && len(current) > 0 {
+		characteristics = s.characteristics(baseline, current)
+
+		report.Status = models.RiskDriftStatusStable
+		for _, c := range characteristics {
+			if c.PSI > report.MaxPSI {
+				report.MaxPSI = c.PSI
+			}
+			if severity(c.Status) > severity(report.Status) {
+				report.Status = c.Status
+			}
+		}
+	}
+
+	encoded, err := json.Marshal(characteristics)
+	if err != nil {
+		return nil, err
+	}
+	report.Characteristics = encoded
+
+	if err := s.repo.Create(report); err != nil {
+		return nil, err
+	}
+
+	logDrift(report, characteristics)
+
+	return report, nil
+}
+
+func (s *RiskDriftService) GetReports() ([]models.RiskDriftReport, error) {
+	return s.repo.FindAll()
+}
+
+func (s *RiskDriftService) GetReportByID(id uint) (*models.RiskDriftReport, error) {
+	report, err := s.repo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if report == nil {
+		return nil, fmt.Errorf("no existe reporte de estabilidad con id %d", id)
+	}
+	return report, nil
+}
+
+// characteristics calcula el índice de cada característica monitoreada.
+func (s *RiskDriftService) characteristics(baseline, current []models.RiskDriftObservation) []models.RiskDriftCharacteristic {
+	numeric := []struct {
+		code  string
+		value func(o models.RiskDriftObservation) (float64, bool)
+	}{
+		{models.RiskDriftScore, func(o models.RiskDriftObservation) (float64, bool) {
+			return o.Score, o.EngineVersion != ""
+		}},
+		{models.RiskDriftMonthlyIncome, func(o models.RiskDriftObservation) (float64, bool) {
+			return o.MonthlyIncome, true
+		}},
+		{models.RiskDriftAmount, func(o models.RiskDriftObservation) (float64, bool) {
+			return o.Amount, true
+		}},
+		{models.RiskDriftTermMonths, func(o models.RiskDriftObservation) (float64, bool) {
+			return float64(o.TermMonths), true
+		}},
+		{models.RiskDriftAssetRatio, func(o models.RiskDriftObservation) (float64, bool) {
+			if o.Amount <= 0 {
+				return 0, false
+			}
+			return o.AssetValue / o.Amount, true
+		}},
+	}
+
+	var result []models.RiskDriftCharacteristic
+
+	for _, n := range numeric {
+		value, bins := drift.NumericPSI(collect(baseline, n.value), collect(current, n.value), s.options.Bins)
+		result = append(result, s.characteristic(n.code, value, bins))
+	}
+
+	var baseProducts, currentProducts []string
+	for _, o := range baseline {
+		baseProducts = append(baseProducts, o.ProductType)
+	}
+	for _, o := range current {
+		currentProducts = append(currentProducts, o.ProductType)
+	}
+	value, bins := drift.CategoricalPSI(baseProducts, currentProducts)
+	result = append(result, s.characteristic(models.RiskDriftProductType, value, bins))
+
+	return result
+}
+
+func (s *RiskDriftService) characteristic(code string, value float64, bins []models.RiskDriftBin) models.RiskDriftCharacteristic {
+	status := drift.Status(value, s.options.WarningPSI, s.options.AlertPSI)
+	if len(bins) == 0 {
+		status = models.RiskDriftStatusInsufficientData
+	}
+	return models.RiskDriftCharacteristic{Code: code, PSI: value, Status: status, Bins: bins}
+}
+
+func collect(observations []models.RiskDriftObservation, value func(models.RiskDriftObservation) (float64, bool)) []float64 {
+	var values []float64
+	for _, o := range observations {
+		if v, ok := value(o); ok {
+			values = append(values, v)
+		}
+	}
+	return values
+}
+
+func severity(status string) int {
+	switch status {
+	case models.RiskDriftStatusAlert:
+		return 2
+	case models.RiskDriftStatusWarning:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// logDrift escribe un evento por reporte y uno de advertencia por cada característica inestable.
+func logDrift(report *models.RiskDriftReport, characteristics []models.RiskDriftCharacteristic) {
+	timestamp := time.Now().Format(time.RFC3339)
+
+	for _, c := range characteristics {
+		if c.Status != models.RiskDriftStatusWarning && c.Status != models.RiskDriftStatusAlert {
+			continue
+		}
+		logger.WriteJSON(map[string]interface{}{
+			"timestamp":      timestamp,
+			"level":          "warning",
+			"event":          "risk_drift_alert",
+			"report_id":      report.ID,
+			"characteristic": c.Code,
+			"psi":            c.PSI,
+			"status":         c.Status,
+			"threshold":      thresholdFor(report, c.Status),
+		})
+	}
+
+	logger.WriteJSON(map[string]interface{}{
+		"timestamp":      timestamp,
+		"level":          "info",
+		"event":          "risk_drift_computed",
+		"report_id":      report.ID,
+		"status":         report.Status,
+		"max_psi":        report.MaxPSI,
+		"baseline_count": report.BaselineCount,
+		"current_count":  report.CurrentCount,
+	})
+}
+
+func thresholdFor(report *models.RiskDriftReport, status string) float64 {
+	if status == models.RiskDriftStatusAlert {
+		return report.AlertPSI
+	}
+	return report.WarningPSI
+}
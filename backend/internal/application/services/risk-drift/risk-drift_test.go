@@ -0,0 +1,132 @@
+package riskDrift
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/JhonCamargo53/prueba-tecnica/internal/domain/models"
+)
+
+var now = time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC)
+
+func defaultOptions() DriftOptions {
+	return DriftOptions{WindowDays: 30, Bins: 5, MinSample: 10, WarningPSI: 0.1, AlertPSI: 0.25}
+}
+
+// population genera n solicitudes creadas en el día indicado; amountShift desplaza los montos.
+func population(n int, day time.Time, amountShift float64, product string) []MockRiskDriftObservation {
+	var res []MockRiskDriftObservation
+	for i := 0; i < n; i++ {
+		res = append(res, MockRiskDriftObservation{
+			CreatedAt: day,
+			RiskDriftObservation: models.RiskDriftObservation{
+				MonthlyIncome: 3_000_000 + float64(i)*100_000,
+				Amount:        10_000_000 + float64(i)*1_000_000 + amountShift,
+				TermMonths:    12 * (1 + i%4),
+				AssetValue:    20_000_000,
+				ProductType:   product,
+				Score:         50 + float64(i),
+				EngineVersion: "v1",
+			},
+		})
+	}
+	return res
+}
+
+func characteristics(t *testing.T, report *models.RiskDriftReport) map[string]models.RiskDriftCharacteristic {
+	var list []models.RiskDriftCharacteristic
+	if err := json.Unmarshal(report.Characteristics, &list); err != nil {
+		t.Fatalf("no se pudieron leer las características: %v", err)
+	}
+	res := map[string]models.RiskDriftCharacteristic{}
+	for _, c := range list {
+		res[c.Code] = c
+	}
+	return res
+}
+
+func TestCompute_PoblacionEstableConPeriodoBasePorDefecto(t *testing.T) {
+	repo := &MockRiskDriftRepository{}
+	repo.Observations = append(population(40, now.AddDate(0, 0, -45), 0, "Libre inversión"),
+		population(40, now.AddDate(0, 0, -10), 0, "Libre inversión")...)
+
+	report, err := NewRiskDriftService(repo, defaultOptions()).Compute(now)
+	if err != nil {
+		t.Fatalf("no se esperaba error: %v", err)
+	}
+
+	if !report.BaselineFrom.Equal(now.AddDate(0, 0, -60)) || !report.BaselineTo.Equal(now.AddDate(0, 0, -30)) {
+		t.Errorf("período base inesperado: %s – %s", report.BaselineFrom, report.BaselineTo)
+	}
+	if report.Status != models.RiskDriftStatusStable || report.MaxPSI > 1e-9 {
+		t.Errorf("se esperaba población estable, obtenido %s (PSI %.4f)", report.Status, report.MaxPSI)
+	}
+	if len(characteristics(t, report)) != 6 {
+		t.Errorf("se esperaban 6 características monitoreadas")
+	}
+	if len(repo.Reports) != 1 {
+		t.Errorf("se esperaba el reporte guardado")
+	}
+}
+
+func TestCompute_AlertaPorMontosYProductoNuevo(t *testing.T) {
+	baselineFrom := now.AddDate(0, -6, 0)
+	baselineTo := now.AddDate(0, -3, 0)
+
+	options := defaultOptions()
+	options.BaselineFrom, options.BaselineTo = &baselineFrom, &baselineTo
+
+	repo := &MockRiskDriftRepository{}
+	repo.Observations = append(population(40, now.AddDate(0, -4, 0), 0, "Libre inversión"),
+		population(40, now.AddDate(0, 0, -5), 50_000_000, "Vehículo")...)
+
+	report, err := NewRiskDriftService(repo, options).Compute(now)
+	if err != nil {
+		t.Fatalf("no se esperaba error: %v", err)
+	}
+
+	if !report.BaselineFrom.Equal(baselineFrom) || report.BaselineCount != 40 {
+		t.Errorf("se esperaba el período base configurado con 40 solicitudes, obtenido %s/%d", report.BaselineFrom, report.BaselineCount)
+	}
+	if report.Status != models.RiskDriftStatusAlert {
+		t.Fatalf("se esperaba alerta, obtenido %s", report.Status)
+	}
+
+	byCode := characteristics(t, report)
+	for _, code := range []string{models.RiskDriftAmount, models.RiskDriftProductType, models.RiskDriftAssetRatio} {
+		if byCode[code].Status != models.RiskDriftStatusAlert {
+			t.Errorf("se esperaba alerta en %s, obtenido %s (PSI %.4f)", code, byCode[code].Status, byCode[code].PSI)
+		}
+	}
+	if byCode[models.RiskDriftMonthlyIncome].Status != models.RiskDriftStatusStable {
+		t.Errorf("no se esperaba cambio en el ingreso: %+v", byCode[models.RiskDriftMonthlyIncome])
+	}
+}
+
+func TestCompute_DatosInsuficientes(t *testing.T) {
+	repo := &MockRiskDriftRepository{Observations: population(5, now.AddDate(0, 0, -1), 0, "Vivienda")}
+
+	report, err := NewRiskDriftService(repo, defaultOptions()).Compute(now)
+	if err != nil {
+		t.Fatalf("no se esperaba error: %v", err)
+	}
+	if report.Status != models.RiskDriftStatusInsufficientData || report.CurrentCount != 5 || report.BaselineCount != 0 {
+		t.Errorf("se esperaba datos insuficientes, obtenido %s (%d/%d)", report.Status, report.BaselineCount, report.CurrentCount)
+	}
+	if len(characteristics(t, report)) != 0 {
+		t.Errorf("no se esperaban características sin datos suficientes")
+	}
+}
+
+func TestDriftOptions_Validate(t *testing.T) {
+	options := defaultOptions()
+	options.AlertPSI = 0.05
+
+	if err := options.Validate(); err == nil {
+		t.Errorf("se esperaba error con umbral de alerta menor que el de advertencia")
+	}
+	if err := defaultOptions().Validate(); err != nil {
+		t.Errorf("no se esperaba error con las opciones por defecto: %v", err)
+	}
+}
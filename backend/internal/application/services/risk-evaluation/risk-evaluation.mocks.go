@@ -0,0 +1,362 @@
+package riskEvaluation
+
+import (
+	"errors"
+	"time"
+
+	"github.com/JhonCamargo53/prueba-tecnica/internal/domain/models"
+	"github.com/JhonCamargo53/prueba-tecnica/internal/domain/ports"
+)
+
+/* Mock de RiskEvaluationRepository */
+
+type MockRiskEvaluationRepository struct {
+	Evaluations []models.RiskEvaluation
+	NextID      uint
+
+	ErrCreate error
+	ErrFind   error
+}
+
+var _ ports.RiskEvaluationRepository = (*MockRiskEvaluationRepository)(nil)
+
+func NewMockRiskEvaluationRepository(initial []models.RiskEvaluation) *MockRiskEvaluationRepository {
+	m := &MockRiskEvaluationRepository{
+		NextID: 1,
+	}
+	for _, e := range initial {
+		if e.ID == 0 {
+			e.ID = m.NextID
+		}
+		if e.ID >= m.NextID {
+			m.NextID = e.ID + 1
+		}
+		m.Evaluations = append(m.Evaluations, e)
+	}
+	return m
+}
+
+func (m *MockRiskEvaluationRepository) Create(evaluation *models.RiskEvaluation) error {
+	if m.ErrCreate != nil {
+		return m.ErrCreate
+	}
+	evaluation.ID = m.NextID
+	m.NextID++
+	m.Evaluations = append(m.Evaluations, *evaluation)
+	return nil
+}
+
+func (m *MockRiskEvaluationRepository) FindByCreditRequestID(creditRequestID uint) ([]models.RiskEvaluation, error) {
+	if m.ErrFind != nil {
+		return nil, m.ErrFind
+	}
+	var res []models.RiskEvaluation
+	for _, e := range m.Evaluations {
+		if e.CreditRequestID == creditRequestID {
+			res = append(res, e)
+		}
+	}
+	return res, nil
+}
+
+/* Mock de CreditRequestRepository */
+
+type MockCreditRequestRepository struct {
+	Requests     map[uint]*models.CreditRequest
+	Customer     models.Customer
+	OtherCredits []models.CreditRequest
+	Assets       []models.CustomerAsset
+
+	ErrFindData   error
+	ErrUpdateRisk error
+
+	UpdateRiskCalled   bool
+	LastScore          float64
+	LastCategory       string
+	LastRuleSetVersion string
+	LastStatusID       uint
+	LastOffer          *models.CreditOffer
+}
+
+var _ ports.CreditRequestRepository = (*MockCreditRequestRepository)(nil)
+
+func NewMockCreditRequestRepository(initial []*models.CreditRequest) *MockCreditRequestRepository {
+	m := &MockCreditRequestRepository{
+		Requests: make(map[uint]*models.CreditRequest),
+	}
+	for _, cr := range initial {
+		m.Requests[cr.ID] = cr
+	}
+	return m
+}
+
+func (m *MockCreditRequestRepository) FindAll(customerID *uint) ([]models.CreditRequest, error) {
+	var res []models.CreditRequest
+	for _, cr := range m.Requests {
+		if customerID == nil || cr.CustomerID == *customerID {
+			res = append(res, *cr)
+		}
+	}
+	return res, nil
+}
+
+func (m *MockCreditRequestRepository) FindByID(id uint) (*models.CreditRequest, error) {
+	if cr, ok := m.Requests[id]; ok {
+		copy := *cr
+		return &copy, nil
+	}
+	return nil, nil
+}
+
+func (m *MockCreditRequestRepository) HasRequestsByCustomerID(customerID uint) (bool, error) {
+	for _, cr := range m.Requests {
+		if cr.CustomerID == customerID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (m *MockCreditRequestRepository) Create(creditRequest *models.CreditRequest) (*models.CreditRequest, error) {
+	m.Requests[creditRequest.ID] = creditRequest
+	return creditRequest, nil
+}
+
+func (m *MockCreditRequestRepository) Update(id uint, creditRequest *models.CreditRequest) (*models.CreditRequest, error) {
+	m.Requests[id] = creditRequest
+	return creditRequest, nil
+}
+
+func (m *MockCreditRequestRepository) Delete(id uint) error {
+	delete(m.Requests, id)
+	return nil
+}
+
+func (m *MockCreditRequestRepository) UpdateCreditRiskEvaluation(id uint, assessment *models.RiskAssessment) (*models.CreditRequest, error) {
+	if m.ErrUpdateRisk != nil {
+		return nil, m.ErrUpdateRisk
+	}
+	m.UpdateRiskCalled = true
+	m.LastScore = assessment.Score
+	m.LastCategory = assessment.Category
+	m.LastRuleSetVersion = assessment.EngineVersion
+
+	cr, ok := m.Requests[id]
+	if !ok {
+		return nil, errors.New("credit request no encontrada")
+	}
+	cr.RiskScore = assessment.Score
+	cr.RiskCategory = assessment.Category
+	cr.RiskExplanation = assessment.Explanation
+	cr.RiskRuleSetVersion = assessment.EngineVersion
+
+	copy := *cr
+	return &copy, nil
+}
+
+func (m *MockCreditRequestRepository) UpdateCreditStatus(id uint, creditStatusID uint) error {
+	m.LastStatusID = creditStatusID
+	if cr, ok := m.Requests[id]; ok {
+		cr.CreditStatusID = creditStatusID
+	}
+	return nil
+}
+
+func (m *MockCreditRequestRepository) UpdateCreditOffer(id uint, offer *models.CreditOffer) error {
+	m.LastOffer = offer
+	if cr, ok := m.Requests[id]; ok {
+		cr.Offer = models.CreditOffer{}
+		if offer != nil {
+			cr.Offer = *offer
+		}
+	}
+	return nil
+}
+
+func (m *MockCreditRequestRepository) FindDataToEvaluateRisk(id uint) (models.Customer, *models.CreditRequest, []models.CreditRequest, []models.CustomerAsset, error) {
+	if m.ErrFindData != nil {
+		return models.Customer{}, nil, nil, nil, m.ErrFindData
+	}
+
+	cr, ok := m.Requests[id]
+	if !ok {
+		return models.Customer{}, nil, nil, nil, errors.New("credit request no encontrada")
+	}
+
+	return m.Customer, cr, m.OtherCredits, m.Assets, nil
+}
+
+/* Mock de RiskEvaluator */
+
+type MockRiskEvaluator struct {
+	Score          float64
+	Category       string
+	Explanation    string
+	RuleSetVersion string
+	KnockOuts      []models.RiskKnockOut
+	Err            error
+
+	Called bool
+}
+
+var _ ports.RiskEvaluator = (*MockRiskEvaluator)(nil)
+
+func (m *MockRiskEvaluator) Evaluate(customer models.Customer, currentCreditRequest models.CreditRequest,
+	otherCredits []models.CreditRequest, assets []models.CustomerAsset) (*models.RiskAssessment, error) {
+
+	m.Called = true
+
+	if m.Err != nil {
+		return nil, m.Err
+	}
+	return &models.RiskAssessment{
+		EngineVersion: m.RuleSetVersion,
+		Score:         m.Score,
+		Category:      m.Category,
+		Explanation:   m.Explanation,
+		KnockOuts:     m.KnockOuts,
+	}, nil
+}
+
+/* Mock de ShadowRiskEvaluationRepository */
+
+type MockShadowRiskEvaluationRepository struct {
+	Evaluations []models.ShadowRiskEvaluation
+
+	ErrCreate error
+}
+
+var _ ports.ShadowRiskEvaluationRepository = (*MockShadowRiskEvaluationRepository)(nil)
+
+func (m *MockShadowRiskEvaluationRepository) Create(evaluation *models.ShadowRiskEvaluation) error {
+	if m.ErrCreate != nil {
+		return m.ErrCreate
+	}
+	evaluation.ID = uint(len(m.Evaluations) + 1)
+	m.Evaluations = append(m.Evaluations, *evaluation)
+	return nil
+}
+
+func (m *MockShadowRiskEvaluationRepository) FindBetween(from, to *time.Time) ([]models.ShadowRiskEvaluation, error) {
+	var res []models.ShadowRiskEvaluation
+	for _, e := range m.Evaluations {
+		if from != nil && e.CreatedAt.Before(*from) {
+			continue
+		}
+		if to != nil && !e.CreatedAt.Before(*to) {
+			continue
+		}
+		res = append(res, e)
+	}
+	return res, nil
+}
+
+/* Mock de CreditStatusHistoryRepository */
+
+type MockCreditStatusHistoryRepository struct {
+	History []models.CreditStatusHistory
+}
+
+var _ ports.CreditStatusHistoryRepository = (*MockCreditStatusHistoryRepository)(nil)
+
+func (m *MockCreditStatusHistoryRepository) Create(history *models.CreditStatusHistory) error {
+	history.ID = uint(len(m.History) + 1)
+	m.History = append(m.History, *history)
+	return nil
+}
+
+func (m *MockCreditStatusHistoryRepository) FindByCreditRequestID(creditRequestID uint) ([]models.CreditStatusHistory, error) {
+	var res []models.CreditStatusHistory
+	for _, h := range m.History {
+		if h.CreditRequestID == creditRequestID {
+			res = append(res, h)
+		}
+	}
+	return res, nil
+}
+
+/* Mock de RiskReportRepository */
+
+type MockRiskReportRepository struct {
+	Reports []models.RiskReport
+	// Evaluaciones guardadas, para FindUnreported
+	Evaluations *MockRiskEvaluationRepository
+
+	ErrAppend error
+}
+
+var _ ports.RiskReportRepository = (*MockRiskReportRepository)(nil)
+
+func (m *MockRiskReportRepository) Append(build func(last *models.RiskReport) (*models.RiskReport, error)) (*models.RiskReport, error) {
+	if m.ErrAppend != nil {
+		return nil, m.ErrAppend
+	}
+
+	var last *models.RiskReport
+	if len(m.Reports) > 0 {
+		copy := m.Reports[len(m.Reports)-1]
+		last = &copy
+	}
+
+	report, err := build(last)
+	if err != nil {
+		return nil, err
+	}
+	report.ID = uint(len(m.Reports) + 1)
+	m.Reports = append(m.Reports, *report)
+	return report, nil
+}
+
+func (m *MockRiskReportRepository) FindByID(id uint) (*models.RiskReport, error) {
+	for _, r := range m.Reports {
+		if r.ID == id {
+			copy := r
+			return &copy, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *MockRiskReportRepository) FindBySequence(sequence uint64) (*models.RiskReport, error) {
+	for _, r := range m.Reports {
+		if r.Sequence == sequence {
+			copy := r
+			return &copy, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *MockRiskReportRepository) FindByCreditRequestID(creditRequestID uint) ([]models.RiskReport, error) {
+	var res []models.RiskReport
+	for _, r := range m.Reports {
+		if r.CreditRequestID == creditRequestID {
+			res = append(res, r)
+		}
+	}
+	return res, nil
+}
+
+func (m *MockRiskReportRepository) FindAfter(afterSequence uint64, limit int) ([]models.RiskReport, error) {
+	var res []models.RiskReport
+	for _, r := range m.Reports {
+		if r.Sequence > afterSequence && len(res) < limit {
+			res = append(res, r)
+		}
+	}
+	return res, nil
+}
+
+func (m *MockRiskReportRepository) FindUnreported(afterID uint, limit int) ([]models.RiskEvaluation, error) {
+	var res []models.RiskEvaluation
+	for _, e := range m.Evaluations.Evaluations {
+		reported := false
+		for _, r := range m.Reports {
+			reported = reported || r.RiskEvaluationID == e.ID
+		}
+		if !reported && e.ID > afterID && len(res) < limit {
+			res = append(res, e)
+		}
+	}
+	return res, nil
+}
@@ -0,0 +1,413 @@
+package riskEvaluation
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strings"
+	"sync"
+	"time"
+
+	creditWorkflow "github.com/JhonCamargo53/prueba-tecnica/internal/application/services/credit-workflow"
+	riskReport "github.com/JhonCamargo53/prueba-tecnica/internal/application/services/risk-report"
+	"github.com/JhonCamargo53/prueba-tecnica/internal/domain/models"
+	"github.com/JhonCamargo53/prueba-tecnica/internal/domain/ports"
+	"github.com/JhonCamargo53/prueba-tecnica/internal/domain/pricing"
+	"github.com/JhonCamargo53/prueba-tecnica/internal/infrastructure/logger"
+)
+
+type RiskEvaluationService struct {
+	creditRequestRepo  ports.CreditRequestRepository
+	riskEvaluationRepo ports.RiskEvaluationRepository
+	riskEvaluator      ports.RiskEvaluator
+
+	// Motores challenger que se ejecutan en modo sombra junto al champion (riskEvaluator)
+	shadowRepo ports.ShadowRiskEvaluationRepository
+	shadows    []ports.NamedRiskEvaluator
+
+	// Política de precios para calcular la oferta (nil = sin oferta)
+	pricingRules *pricing.Rules
+
+	// Grafo de estados para el rechazo automático (nil = se rechaza sin validar la transición)
+	workflow *creditWorkflow.CreditWorkflowService
+
+	// Reportes firmados y encadenados de cada evaluación (nil = sin reportes)
+	reports *riskReport.RiskReportService
+}
+
+func NewRiskEvaluationService(creditRequestRepo ports.CreditRequestRepository,
+	riskEvaluationRepo ports.RiskEvaluationRepository, riskEvaluator ports.RiskEvaluator) *RiskEvaluationService {
+	return &RiskEvaluationService{
+		creditRequestRepo:  creditRequestRepo,
+		riskEvaluationRepo: riskEvaluationRepo,
+		riskEvaluator:      riskEvaluator,
+	}
+}
+
+// WithShadowEvaluators registra motores challenger. Su resultado no modifica la
+// solicitud: sólo se registra en el log y se guarda para compararlo con el champion.
+func (s *RiskEvaluationService) WithShadowEvaluators(shadowRepo ports.ShadowRiskEvaluationRepository,
+	shadows ...ports.NamedRiskEvaluator) *RiskEvaluationService {
+	s.shadowRepo = shadowRepo
+	s.shadows = shadows
+	return s
+}
+
+// WithPricing activa el cálculo de la oferta (tasa, monto máximo y plazo sugerido)
+// después de cada evaluación.
+func (s *RiskEvaluationService) WithPricing(rules *pricing.Rules) *RiskEvaluationService {
+	s.pricingRules = rules
+	return s
+}
+
+// WithWorkflow valida el rechazo automático contra el grafo de estados y lo
+// registra en el historial de la solicitud.
+func (s *RiskEvaluationService) WithWorkflow(workflow *creditWorkflow.CreditWorkflowService) *RiskEvaluationService {
+	s.workflow = workflow
+	return s
+}
+
+// WithReports emite un reporte firmado de cada evaluación guardada, enlazado en
+// la cadena de reportes para detectar alteraciones posteriores.
+func (s *RiskEvaluationService) WithReports(reports *riskReport.RiskReportService) *RiskEvaluationService {
+	s.reports = reports
+	return s
+}
+
+// EvaluateCreditRequest recalcula el riesgo de la solicitud, actualiza el registro
+// y agrega la evaluación al historial con el motivo que la originó.
+func (s *RiskEvaluationService) EvaluateCreditRequest(creditRequestID uint, trigger string) (*models.CreditRequest, error) {
+
+	customer, creditRequest, otherCredits, customerAssets, err := s.creditRequestRepo.FindDataToEvaluateRisk(creditRequestID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	// Recalcular riesgo
+	assessment, err := s.riskEvaluator.Evaluate(customer, *creditRequest, otherCredits, customerAssets)
+
+	if err != nil {
+		return nil, err
+	}
+
+	// Rechazo automático por políticas: no se compara con los challengers
+	knockedOut := len(assessment.KnockOuts) > 0
+
+	var shadowResults []shadowResult
+	if knockedOut {
+		if err := s.rejectByKnockOut(creditRequest, assessment); err != nil {
+			return nil, err
+		}
+		logKnockOut(creditRequest.ID, assessment)
+	} else {
+		// Ejecutar challengers en paralelo; sus errores no afectan al champion
+		shadowResults = s.runShadows(customer, *creditRequest, otherCredits, customerAssets)
+	}
+
+	// Oferta según el riesgo; una solicitud sin oferta borra la anterior
+	if s.pricingRules != nil {
+		offer := pricing.Quote(s.pricingRules, assessment, customer, *creditRequest)
+		if err := s.creditRequestRepo.UpdateCreditOffer(creditRequest.ID, offer); err != nil {
+			return nil, err
+		}
+	}
+
+	//Actualizar riesgo
+	updatedCreditRequest, err := s.creditRequestRepo.UpdateCreditRiskEvaluation(creditRequest.ID, assessment)
+
+	if err != nil {
+		return nil, err
+	}
+
+	// Guardar historial
+	snapshot, err := json.Marshal(models.NewRiskInputSnapshot(customer, *creditRequest, otherCredits, customerAssets))
+
+	if err != nil {
+		return nil, err
+	}
+
+	structured, err := json.Marshal(assessment)
+
+	if err != nil {
+		return nil, err
+	}
+
+	evaluation := &models.RiskEvaluation{
+		CreditRequestID: creditRequest.ID,
+		Trigger:         trigger,
+		EngineVersion:   assessment.EngineVersion,
+		Score:           assessment.Score,
+		Category:        assessment.Category,
+		Explanation:     assessment.Explanation,
+		Assessment:      structured,
+		InputSnapshot:   snapshot,
+	}
+
+	if err := s.riskEvaluationRepo.Create(evaluation); err != nil {
+		return nil, err
+	}
+
+	s.issueReport(evaluation)
+	s.saveShadows(evaluation, assessment, shadowResults)
+
+	return updatedCreditRequest, nil
+}
+
+// PreviewCreditRequest evalúa la solicitud con los datos actuales sin guardar nada.
+// Retorna la solicitud tal como está guardada junto con la nueva evaluación.
+func (s *RiskEvaluationService) PreviewCreditRequest(creditRequestID uint) (*models.CreditRequest, *models.RiskAssessment, error) {
+
+	customer, creditRequest, otherCredits, customerAssets, err := s.creditRequestRepo.FindDataToEvaluateRisk(creditRequestID)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	assessment, err := s.riskEvaluator.Evaluate(customer, *creditRequest, otherCredits, customerAssets)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return creditRequest, assessment, nil
+}
+
+func (s *RiskEvaluationService) GetEvaluationsByCreditRequestID(creditRequestID uint) ([]models.RiskEvaluation, error) {
+	creditRequest, err := s.creditRequestRepo.FindByID(creditRequestID)
+	if err != nil {
+		return nil, err
+	}
+	if creditRequest == nil {
+		return nil, fmt.Errorf("no existe solicitud de crédito con id %d", creditRequestID)
+	}
+
+	return s.riskEvaluationRepo.FindByCreditRequestID(creditRequestID)
+}
+
+// rejectByKnockOut rechaza la solicitud por políticas. Si el grafo de estados no
+// permite el rechazo automático (por ejemplo, una solicitud ya aprobada), el
+// estado se conserva y sólo se actualiza la evaluación.
+func (s *RiskEvaluationService) rejectByKnockOut(creditRequest *models.CreditRequest, assessment *models.RiskAssessment) error {
+	if s.workflow == nil {
+		return s.creditRequestRepo.UpdateCreditStatus(creditRequest.ID, models.CreditStatusRejectedID)
+	}
+
+	role, err := s.workflow.Check(creditRequest.CreditStatusID, models.CreditStatusRejectedID, nil)
+	if err != nil {
+		logger.WriteJSON(map[string]interface{}{
+			"timestamp":         time.Now().Format(time.RFC3339),
+			"level":             "warning",
+			"event":             "risk_policy_knockout_status_kept",
+			"credit_request_id": creditRequest.ID,
+			"credit_status_id":  creditRequest.CreditStatusID,
+			"error":             err.Error(),
+		})
+		return nil
+	}
+
+	return s.workflow.Apply(creditRequest.ID, creditRequest.CreditStatusID, models.CreditStatusRejectedID, nil, role,
+		models.CreditStatusSourceKnockOut, strings.Join(knockOutCodes(assessment), ","))
+}
+
+func knockOutCodes(assessment *models.RiskAssessment) []string {
+	codes := make([]string, len(assessment.KnockOuts))
+	for i, k := range assessment.KnockOuts {
+		codes[i] = k.Code
+	}
+	return codes
+}
+
+func logKnockOut(creditRequestID uint, assessment *models.RiskAssessment) {
+	codes := knockOutCodes(assessment)
+
+	logger.WriteJSON(map[string]interface{}{
+		"timestamp":         time.Now().Format(time.RFC3339),
+		"level":             "info",
+		"event":             "risk_policy_knockout",
+		"credit_request_id": creditRequestID,
+		"policy_version":    assessment.PolicyVersion,
+		"codes":             codes,
+	})
+}
+
+type shadowResult struct {
+	engine     string
+	assessment *models.RiskAssessment
+	err        error
+	duration   time.Duration
+}
+
+func (s *RiskEvaluationService) runShadows(customer models.Customer, creditRequest models.CreditRequest,
+	otherCredits []models.CreditRequest, assets []models.CustomerAsset) []shadowResult {
+
+	results := make([]shadowResult, len(s.shadows))
+
+	var wg sync.WaitGroup
+	for i, shadow := range s.shadows {
+		wg.Add(1)
+		go func(i int, shadow ports.NamedRiskEvaluator) {
+			defer wg.Done()
+
+			start := time.Now()
+			assessment, err := shadow.Evaluator.Evaluate(customer, creditRequest, otherCredits, assets)
+			results[i] = shadowResult{
+				engine:     shadow.Name,
+				assessment: assessment,
+				err:        err,
+				duration:   time.Since(start),
+			}
+		}(i, shadow)
+	}
+	wg.Wait()
+
+	return results
+}
+
+// issueReport firma la evaluación. La evaluación y la solicitud ya quedaron
+// actualizadas, así que un error no se propaga: se registra en el log y el
+// reporte se emite al iniciar el servidor (RiskReportService.IssueMissing).
+func (s *RiskEvaluationService) issueReport(evaluation *models.RiskEvaluation) {
+	if s.reports == nil {
+		return
+	}
+
+	if _, err := s.reports.Issue(evaluation); err != nil {
+		logger.WriteJSON(map[string]interface{}{
+			"timestamp":          time.Now().Format(time.RFC3339),
+			"level":              "error",
+			"event":              "risk_report_issue_failed",
+			"credit_request_id":  evaluation.CreditRequestID,
+			"risk_evaluation_id": evaluation.ID,
+			"error":              err.Error(),
+		})
+	}
+}
+
+func (s *RiskEvaluationService) saveShadows(champion *models.RiskEvaluation, championAssessment *models.RiskAssessment,
+	results []shadowResult) {
+
+	for _, result := range results {
+		shadow := &models.ShadowRiskEvaluation{
+			RiskEvaluationID:       champion.ID,
+			CreditRequestID:        champion.CreditRequestID,
+			Engine:                 result.engine,
+			DurationMs:             result.duration.Milliseconds(),
+			ChampionEngineVersion:  championAssessment.EngineVersion,
+			ChampionScore:          championAssessment.Score,
+			ChampionCategory:       championAssessment.Category,
+			ChampionRecommendation: championAssessment.Recommendation,
+		}
+
+		entry := map[string]interface{}{
+			"timestamp":         time.Now().Format(time.RFC3339),
+			"event":             "risk_shadow_evaluation",
+			"credit_request_id": champion.CreditRequestID,
+			"engine":            result.engine,
+			"champion_version":  championAssessment.EngineVersion,
+			"champion_score":    championAssessment.Score,
+			"duration_ms":       shadow.DurationMs,
+		}
+
+		if result.err != nil {
+			shadow.Error = result.err.Error()
+			entry["level"] = "warning"
+			entry["error"] = shadow.Error
+		} else {
+			structured, _ := json.Marshal(result.assessment)
+			shadow.EngineVersion = result.assessment.EngineVersion
+			shadow.Score = result.assessment.Score
+			shadow.Category = result.assessment.Category
+			shadow.Recommendation = result.assessment.Recommendation
+			shadow.Assessment = structured
+
+			entry["level"] = "info"
+			entry["version"] = shadow.EngineVersion
+			entry["score"] = shadow.Score
+			entry["score_delta"] = shadow.Score - championAssessment.Score
+			entry["category_agrees"] = shadow.Category == championAssessment.Category
+		}
+
+		if s.shadowRepo != nil {
+			if err := s.shadowRepo.Create(shadow); err != nil {
+				entry["level"] = "warning"
+				entry["persist_error"] = err.Error()
+			}
+		}
+
+		logger.WriteJSON(entry)
+	}
+}
+
+// CompareEngines resume, por motor challenger y versión, la coincidencia con el champion
+// en el rango de fechas dado.
+func (s *RiskEvaluationService) CompareEngines(from, to *time.Time) ([]models.EngineComparison, error) {
+	if s.shadowRepo == nil {
+		return []models.EngineComparison{}, nil
+	}
+
+	shadows, err := s.shadowRepo.FindBetween(from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	type accumulator struct {
+		comparison          models.EngineComparison
+		categoryAgree       int
+		recommendationAgree int
+		deltaSum            float64
+		absDeltaSum         float64
+	}
+
+	var groups []*accumulator
+	byKey := map[string]*accumulator{}
+
+	for _, sh := range shadows {
+		key := sh.Engine + "|" + sh.EngineVersion + "|" + sh.ChampionEngineVersion
+
+		acc, ok := byKey[key]
+		if !ok {
+			acc = &accumulator{comparison: models.EngineComparison{
+				Engine:                sh.Engine,
+				EngineVersion:         sh.EngineVersion,
+				ChampionEngineVersion: sh.ChampionEngineVersion,
+			}}
+			byKey[key] = acc
+			groups = append(groups, acc)
+		}
+
+		if sh.Error != "" {
+			acc.comparison.Errors++
+			continue
+		}
+
+		acc.comparison.Evaluations++
+		if sh.Category == sh.ChampionCategory {
+			acc.categoryAgree++
+		}
+		if sh.Recommendation == sh.ChampionRecommendation {
+			acc.recommendationAgree++
+		}
+
+		delta := sh.Score - sh.ChampionScore
+		acc.deltaSum += delta
+		acc.absDeltaSum += math.Abs(delta)
+		acc.comparison.MaxAbsoluteScoreDelta = math.Max(acc.comparison.MaxAbsoluteScoreDelta, math.Abs(delta))
+	}
+
+	comparisons := make([]models.EngineComparison, 0, len(groups))
+
+	for _, acc := range groups {
+		c := acc.comparison
+		if c.Evaluations > 0 {
+			n := float64(c.Evaluations)
+			c.CategoryAgreementRate = float64(acc.categoryAgree) / n
+			c.RecommendationAgreementRate = float64(acc.recommendationAgree) / n
+			c.MeanScoreDelta = acc.deltaSum / n
+			c.MeanAbsoluteScoreDelta = acc.absDeltaSum / n
+		}
+		comparisons = append(comparisons, c)
+	}
+
+	return comparisons, nil
+}
@@ -0,0 +1,353 @@
+package riskEvaluation
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	creditWorkflow "github.com/JhonCamargo53/prueba-tecnica/internal/application/services/credit-workflow"
+	riskReport "github.com/JhonCamargo53/prueba-tecnica/internal/application/services/risk-report"
+	"github.com/JhonCamargo53/prueba-tecnica/internal/domain/attestation"
+	"github.com/JhonCamargo53/prueba-tecnica/internal/domain/models"
+	"github.com/JhonCamargo53/prueba-tecnica/internal/domain/ports"
+	"github.com/JhonCamargo53/prueba-tecnica/internal/domain/pricing"
+	"github.com/JhonCamargo53/prueba-tecnica/internal/domain/workflow"
+)
+
+func TestEvaluateCreditRequest_GuardaHistorial(t *testing.T) {
+	creditRequestRepo := NewMockCreditRequestRepository([]*models.CreditRequest{
+		{ID: 7, CustomerID: 1, Amount: 12_000_000, TermMonths: 24, ProductType: "Vivienda"},
+	})
+	creditRequestRepo.Customer = models.Customer{ID: 1, MonthlyIncome: 4_000_000}
+	creditRequestRepo.OtherCredits = []models.CreditRequest{{ID: 3, CreditStatusID: 2}}
+	creditRequestRepo.Assets = []models.CustomerAsset{{ID: 9, AssetID: 1, MarketValue: 30_000_000}}
+
+	evaluationRepo := NewMockRiskEvaluationRepository(nil)
+	riskEvaluator := &MockRiskEvaluator{Score: 82, Category: "LOW", Explanation: "ok", RuleSetVersion: "v1"}
+
+	service := NewRiskEvaluationService(creditRequestRepo, evaluationRepo, riskEvaluator)
+
+	updated, err := service.EvaluateCreditRequest(7, models.RiskTriggerCreditRequestCreated)
+	if err != nil {
+		t.Fatalf("no se esperaba error: %v", err)
+	}
+	if updated == nil || updated.RiskScore != 82 {
+		t.Fatalf("se esperaba la solicitud actualizada con score 82")
+	}
+
+	if len(evaluationRepo.Evaluations) != 1 {
+		t.Fatalf("se esperaba 1 evaluación en el historial, se obtuvo=%d", len(evaluationRepo.Evaluations))
+	}
+
+	evaluation := evaluationRepo.Evaluations[0]
+	if evaluation.Trigger != models.RiskTriggerCreditRequestCreated {
+		t.Errorf("motivo inesperado, se obtuvo=%s", evaluation.Trigger)
+	}
+	if evaluation.EngineVersion != "v1" {
+		t.Errorf("versión de motor inesperada, se obtuvo=%s", evaluation.EngineVersion)
+	}
+
+	var assessment models.RiskAssessment
+	if err := json.Unmarshal(evaluation.Assessment, &assessment); err != nil {
+		t.Fatalf("la evaluación estructurada debería ser JSON válido: %v", err)
+	}
+	if assessment.Score != 82 || assessment.EngineVersion != "v1" {
+		t.Errorf("evaluación estructurada inesperada: %+v", assessment)
+	}
+
+	var snapshot models.RiskInputSnapshot
+	if err := json.Unmarshal(evaluation.InputSnapshot, &snapshot); err != nil {
+		t.Fatalf("el snapshot debería ser JSON válido: %v", err)
+	}
+	if snapshot.MonthlyIncome != 4_000_000 || snapshot.Amount != 12_000_000 || snapshot.TermMonths != 24 {
+		t.Errorf("snapshot con datos inesperados: %+v", snapshot)
+	}
+	if len(snapshot.Assets) != 1 || len(snapshot.PriorCredits) != 1 {
+		t.Errorf("se esperaban 1 activo y 1 crédito previo en el snapshot: %+v", snapshot)
+	}
+}
+
+func TestEvaluateCreditRequest_ErrorEvaluador_NoGuardaHistorial(t *testing.T) {
+	creditRequestRepo := NewMockCreditRequestRepository([]*models.CreditRequest{
+		{ID: 7, CustomerID: 1},
+	})
+	evaluationRepo := NewMockRiskEvaluationRepository(nil)
+	riskEvaluator := &MockRiskEvaluator{Err: errors.New("motor caído")}
+
+	service := NewRiskEvaluationService(creditRequestRepo, evaluationRepo, riskEvaluator)
+
+	if _, err := service.EvaluateCreditRequest(7, models.RiskTriggerCreditRequestUpdated); err == nil {
+		t.Fatalf("se esperaba error del evaluador")
+	}
+	if creditRequestRepo.UpdateRiskCalled {
+		t.Errorf("no se debería actualizar el riesgo si el evaluador falla")
+	}
+	if len(evaluationRepo.Evaluations) != 0 {
+		t.Errorf("no se debería guardar historial si el evaluador falla")
+	}
+}
+
+func TestGetEvaluationsByCreditRequestID_SolicitudNoExiste(t *testing.T) {
+	service := NewRiskEvaluationService(
+		NewMockCreditRequestRepository(nil),
+		NewMockRiskEvaluationRepository(nil),
+		&MockRiskEvaluator{},
+	)
+
+	evaluations, err := service.GetEvaluationsByCreditRequestID(99)
+	if err == nil {
+		t.Fatalf("se esperaba error porque la solicitud no existe")
+	}
+	if evaluations != nil {
+		t.Fatalf("no se esperaba historial para una solicitud inexistente")
+	}
+}
+
+func TestGetEvaluationsByCreditRequestID_Exitoso(t *testing.T) {
+	service := NewRiskEvaluationService(
+		NewMockCreditRequestRepository([]*models.CreditRequest{{ID: 5}}),
+		NewMockRiskEvaluationRepository([]models.RiskEvaluation{
+			{CreditRequestID: 5, Score: 60},
+			{CreditRequestID: 5, Score: 70},
+			{CreditRequestID: 6, Score: 40},
+		}),
+		&MockRiskEvaluator{},
+	)
+
+	evaluations, err := service.GetEvaluationsByCreditRequestID(5)
+	if err != nil {
+		t.Fatalf("no se esperaba error: %v", err)
+	}
+	if len(evaluations) != 2 {
+		t.Fatalf("se esperaban 2 evaluaciones, se obtuvo=%d", len(evaluations))
+	}
+}
+
+func TestEvaluateCreditRequest_ChallengersEnSombra(t *testing.T) {
+	creditRequestRepo := NewMockCreditRequestRepository([]*models.CreditRequest{
+		{ID: 7, CustomerID: 1, Amount: 12_000_000, TermMonths: 24},
+	})
+	evaluationRepo := NewMockRiskEvaluationRepository(nil)
+	shadowRepo := &MockShadowRiskEvaluationRepository{}
+
+	champion := &MockRiskEvaluator{Score: 70, Category: "MEDIUM", RuleSetVersion: "v1"}
+	challenger := &MockRiskEvaluator{Score: 82, Category: "LOW", RuleSetVersion: "v2"}
+	broken := &MockRiskEvaluator{Err: errors.New("timeout")}
+
+	service := NewRiskEvaluationService(creditRequestRepo, evaluationRepo, champion).
+		WithShadowEvaluators(shadowRepo,
+			ports.NamedRiskEvaluator{Name: "challenger", Evaluator: challenger},
+			ports.NamedRiskEvaluator{Name: "broken", Evaluator: broken},
+		)
+
+	updated, err := service.EvaluateCreditRequest(7, models.RiskTriggerCreditRequestUpdated)
+	if err != nil {
+		t.Fatalf("un challenger con error no debería afectar al champion: %v", err)
+	}
+	if updated.RiskScore != 70 || creditRequestRepo.LastRuleSetVersion != "v1" {
+		t.Errorf("se esperaba guardar el resultado del champion, obtenido score=%.1f versión=%s", updated.RiskScore, creditRequestRepo.LastRuleSetVersion)
+	}
+
+	if len(shadowRepo.Evaluations) != 2 {
+		t.Fatalf("se esperaban 2 evaluaciones sombra, obtenido: %d", len(shadowRepo.Evaluations))
+	}
+
+	for _, sh := range shadowRepo.Evaluations {
+		if sh.RiskEvaluationID != evaluationRepo.Evaluations[0].ID || sh.ChampionScore != 70 {
+			t.Errorf("la evaluación sombra debería enlazar al champion: %+v", sh)
+		}
+		switch sh.Engine {
+		case "challenger":
+			if sh.Score != 82 || sh.EngineVersion != "v2" {
+				t.Errorf("resultado del challenger inesperado: %+v", sh)
+			}
+		case "broken":
+			if sh.Error == "" {
+				t.Errorf("se esperaba registrar el error del challenger")
+			}
+		}
+	}
+}
+
+func TestCompareEngines_TasasDeAcuerdoYDiferencias(t *testing.T) {
+	shadowRepo := &MockShadowRiskEvaluationRepository{Evaluations: []models.ShadowRiskEvaluation{
+		{Engine: "scorecard", EngineVersion: "s1", ChampionEngineVersion: "v1", Score: 80, Category: "LOW", Recommendation: "APPROVE",
+			ChampionScore: 70, ChampionCategory: "MEDIUM", ChampionRecommendation: "REVIEW"},
+		{Engine: "scorecard", EngineVersion: "s1", ChampionEngineVersion: "v1", Score: 40, Category: "HIGH", Recommendation: "REJECT",
+			ChampionScore: 45, ChampionCategory: "HIGH", ChampionRecommendation: "REJECT"},
+		{Engine: "scorecard", EngineVersion: "s1", ChampionEngineVersion: "v1", Error: "timeout"},
+	}}
+
+	service := NewRiskEvaluationService(NewMockCreditRequestRepository(nil), NewMockRiskEvaluationRepository(nil), &MockRiskEvaluator{}).
+		WithShadowEvaluators(shadowRepo)
+
+	comparisons, err := service.CompareEngines(nil, nil)
+	if err != nil {
+		t.Fatalf("no se esperaba error: %v", err)
+	}
+
+	var c *models.EngineComparison
+	for i := range comparisons {
+		if comparisons[i].EngineVersion == "s1" {
+			c = &comparisons[i]
+		}
+	}
+	if c == nil {
+		t.Fatalf("se esperaba la comparación del motor scorecard s1, obtenido: %+v", comparisons)
+	}
+
+	if c.Evaluations != 2 || c.CategoryAgreementRate != 0.5 || c.RecommendationAgreementRate != 0.5 {
+		t.Errorf("tasas de acuerdo inesperadas: %+v", c)
+	}
+	if c.MeanScoreDelta != 2.5 || c.MeanAbsoluteScoreDelta != 7.5 || c.MaxAbsoluteScoreDelta != 10 {
+		t.Errorf("diferencias de puntaje inesperadas: %+v", c)
+	}
+}
+
+func TestEvaluateCreditRequest_RechazoPorPoliticas(t *testing.T) {
+	creditRequestRepo := NewMockCreditRequestRepository([]*models.CreditRequest{
+		{ID: 7, CustomerID: 1, Amount: 12_000_000, TermMonths: 24, CreditStatusID: models.CreditStatusPendingID},
+	})
+	evaluationRepo := NewMockRiskEvaluationRepository(nil)
+	shadowRepo := &MockShadowRiskEvaluationRepository{}
+
+	champion := &MockRiskEvaluator{
+		Category:       "HIGH",
+		RuleSetVersion: "policy-v1",
+		KnockOuts:      []models.RiskKnockOut{{Code: "MIN_INCOME"}},
+	}
+	challenger := &MockRiskEvaluator{Score: 82, Category: "LOW", RuleSetVersion: "v2"}
+
+	service := NewRiskEvaluationService(creditRequestRepo, evaluationRepo, champion).
+		WithShadowEvaluators(shadowRepo, ports.NamedRiskEvaluator{Name: "challenger", Evaluator: challenger})
+
+	updated, err := service.EvaluateCreditRequest(7, models.RiskTriggerCreditRequestCreated)
+	if err != nil {
+		t.Fatalf("no se esperaba error: %v", err)
+	}
+
+	if creditRequestRepo.LastStatusID != models.CreditStatusRejectedID || updated.CreditStatusID != models.CreditStatusRejectedID {
+		t.Errorf("se esperaba la solicitud en RECHAZADO, obtenido estado=%d", updated.CreditStatusID)
+	}
+	if len(evaluationRepo.Evaluations) != 1 {
+		t.Fatalf("se esperaba registrar el rechazo en el historial")
+	}
+	if challenger.Called || len(shadowRepo.Evaluations) != 0 {
+		t.Errorf("no se esperaba ejecutar challengers para una solicitud rechazada por políticas")
+	}
+}
+
+func TestEvaluateCreditRequest_RechazoPorPoliticasSegunGrafoDeEstados(t *testing.T) {
+	creditRequestRepo := NewMockCreditRequestRepository([]*models.CreditRequest{
+		{ID: 7, CustomerID: 1, Amount: 12_000_000, TermMonths: 24, CreditStatusID: models.CreditStatusPendingID},
+		{ID: 8, CustomerID: 1, Amount: 12_000_000, TermMonths: 24, CreditStatusID: models.CreditStatusApprovedID},
+	})
+	historyRepo := &MockCreditStatusHistoryRepository{}
+	champion := &MockRiskEvaluator{Category: "HIGH", KnockOuts: []models.RiskKnockOut{{Code: "MIN_INCOME"}}}
+
+	// El rechazo automático no usa usuarios: se registra con el rol SYSTEM
+	workflowService := creditWorkflow.NewCreditWorkflowService(workflow.DefaultRules(), historyRepo, creditRequestRepo, nil, nil)
+	service := NewRiskEvaluationService(creditRequestRepo, NewMockRiskEvaluationRepository(nil), champion).
+		WithWorkflow(workflowService)
+
+	if _, err := service.EvaluateCreditRequest(7, models.RiskTriggerCreditRequestUpdated); err != nil {
+		t.Fatalf("no se esperaba error: %v", err)
+	}
+	if creditRequestRepo.Requests[7].CreditStatusID != models.CreditStatusRejectedID {
+		t.Errorf("se esperaba la solicitud pendiente en RECHAZADO")
+	}
+	if len(historyRepo.History) != 1 || historyRepo.History[0].Source != models.CreditStatusSourceKnockOut ||
+		historyRepo.History[0].Role != workflow.SystemRole || historyRepo.History[0].Comment != "MIN_INCOME" {
+		t.Fatalf("se esperaba el rechazo automático en el historial: %+v", historyRepo.History)
+	}
+
+	// Una solicitud aprobada es terminal: conserva el estado aunque incumpla políticas
+	if _, err := service.EvaluateCreditRequest(8, models.RiskTriggerCreditRequestUpdated); err != nil {
+		t.Fatalf("no se esperaba error: %v", err)
+	}
+	if creditRequestRepo.Requests[8].CreditStatusID != models.CreditStatusApprovedID || len(historyRepo.History) != 1 {
+		t.Errorf("la solicitud aprobada no debería cambiar de estado")
+	}
+}
+
+func TestEvaluateCreditRequest_GuardaOfertaSegunRiesgo(t *testing.T) {
+	creditRequestRepo := NewMockCreditRequestRepository([]*models.CreditRequest{
+		{ID: 7, CustomerID: 1, Amount: 10_000_000, TermMonths: 36, ProductType: "Libre inversión"},
+	})
+	creditRequestRepo.Customer = models.Customer{ID: 1, MonthlyIncome: 5_000_000}
+
+	champion := &MockRiskEvaluator{Score: 82, Category: "LOW", RuleSetVersion: "v1"}
+
+	service := NewRiskEvaluationService(creditRequestRepo, NewMockRiskEvaluationRepository(nil), champion).
+		WithPricing(pricing.DefaultRules())
+
+	updated, err := service.EvaluateCreditRequest(7, models.RiskTriggerCreditRequestCreated)
+	if err != nil {
+		t.Fatalf("no se esperaba error: %v", err)
+	}
+
+	if creditRequestRepo.LastOffer == nil || updated.Offer.PricingVersion == "" {
+		t.Fatalf("se esperaba guardar la oferta en la solicitud")
+	}
+	if updated.Offer.AnnualRate <= 0 || updated.Offer.CounterOffer {
+		t.Errorf("oferta inesperada: %+v", updated.Offer)
+	}
+}
+
+func TestEvaluateCreditRequest_EmiteReporteFirmado(t *testing.T) {
+	creditRequestRepo := NewMockCreditRequestRepository([]*models.CreditRequest{
+		{ID: 7, CustomerID: 1, Amount: 10_000_000, TermMonths: 36},
+	})
+	evaluationRepo := NewMockRiskEvaluationRepository(nil)
+	reportRepo := &MockRiskReportRepository{Evaluations: evaluationRepo}
+	champion := &MockRiskEvaluator{Score: 61.5, Category: "MEDIUM", Explanation: "Ingreso justo", RuleSetVersion: "v1"}
+
+	signingKey := ed25519.NewKeyFromSeed(bytes.Repeat([]byte{1}, ed25519.SeedSize))
+	reports := riskReport.NewRiskReportService(reportRepo, evaluationRepo, creditRequestRepo, signingKey)
+	service := NewRiskEvaluationService(creditRequestRepo, evaluationRepo, champion).WithReports(reports)
+
+	for _, score := range []float64{61.5, 74} {
+		champion.Score = score
+		if _, err := service.EvaluateCreditRequest(7, models.RiskTriggerCreditRequestUpdated); err != nil {
+			t.Fatalf("no se esperaba error: %v", err)
+		}
+	}
+
+	if len(reportRepo.Reports) != 2 || reportRepo.Reports[1].PreviousHash != reportRepo.Reports[0].Hash ||
+		reportRepo.Reports[1].RiskEvaluationID != evaluationRepo.Evaluations[1].ID {
+		t.Fatalf("se esperaba un reporte encadenado por evaluación: %+v", reportRepo.Reports)
+	}
+	if verification, err := reports.Verify(2); err != nil || !verification.Valid {
+		t.Fatalf("se esperaba un reporte válido, obtenido: %+v, %v", verification, err)
+	}
+
+	// Una explicación modificada después de la evaluación no coincide con lo firmado
+	creditRequestRepo.Requests[7].RiskExplanation = "Ingreso holgado"
+	if verification, _ := reports.Verify(2); verification.Valid {
+		t.Errorf("se esperaba detectar la explicación alterada")
+	}
+
+	// Si el reporte no se puede emitir, la evaluación se conserva
+	reportRepo.ErrAppend = errors.New("db caída")
+	if _, err := service.EvaluateCreditRequest(7, models.RiskTriggerCreditRequestUpdated); err != nil {
+		t.Fatalf("no se esperaba error al fallar el reporte: %v", err)
+	}
+	if len(evaluationRepo.Evaluations) != 3 || len(reportRepo.Reports) != 2 {
+		t.Errorf("se esperaban 3 evaluaciones y 2 reportes")
+	}
+
+	// La evaluación sin reporte se detecta y su reporte se emite después
+	if verification, _ := reports.Verify(2); len(attestation.Failed(verification.Checks)) != 1 ||
+		attestation.Failed(verification.Checks)[0].Name != attestation.CheckCoverage {
+		t.Errorf("se esperaba detectar la evaluación sin reporte: %+v", verification.Checks)
+	}
+	reportRepo.ErrAppend = nil
+	if issued, err := reports.IssueMissing(); err != nil || issued != 1 {
+		t.Fatalf("se esperaba emitir el reporte pendiente, obtenido: %d, %v", issued, err)
+	}
+	if verification, _ := reports.Verify(3); !verification.Valid || verification.RiskEvaluationID != evaluationRepo.Evaluations[2].ID {
+		t.Errorf("se esperaba un reporte válido de la última evaluación: %+v", verification)
+	}
+}
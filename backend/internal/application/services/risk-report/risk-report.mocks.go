@@ -0,0 +1,176 @@
+package riskReport
+
+import (
+	"errors"
+
+	"github.com/JhonCamargo53/prueba-tecnica/internal/domain/models"
+	"github.com/JhonCamargo53/prueba-tecnica/internal/domain/ports"
+)
+
+/* Mock de RiskReportRepository */
+
+type MockRiskReportRepository struct {
+	Reports []models.RiskReport
+	// Evaluaciones guardadas, para FindUnreported
+	Evaluations *MockRiskEvaluationRepository
+
+	ErrAppend error
+}
+
+var _ ports.RiskReportRepository = (*MockRiskReportRepository)(nil)
+
+func (m *MockRiskReportRepository) Append(build func(last *models.RiskReport) (*models.RiskReport, error)) (*models.RiskReport, error) {
+	if m.ErrAppend != nil {
+		return nil, m.ErrAppend
+	}
+
+	var last *models.RiskReport
+	if len(m.Reports) > 0 {
+		copy := m.Reports[len(m.Reports)-1]
+		last = &copy
+	}
+
+	report, err := build(last)
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range m.Reports {
+		if r.PreviousHash == report.PreviousHash || r.RiskEvaluationID == report.RiskEvaluationID {
+			return nil, errors.New("violación de índice único en risk_reports")
+		}
+	}
+
+	report.ID = uint(len(m.Reports) + 1)
+	m.Reports = append(m.Reports, *report)
+	return report, nil
+}
+
+func (m *MockRiskReportRepository) FindByID(id uint) (*models.RiskReport, error) {
+	for _, r := range m.Reports {
+		if r.ID == id {
+			copy := r
+			return &copy, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *MockRiskReportRepository) FindBySequence(sequence uint64) (*models.RiskReport, error) {
+	for _, r := range m.Reports {
+		if r.Sequence == sequence {
+			copy := r
+			return &copy, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *MockRiskReportRepository) FindByCreditRequestID(creditRequestID uint) ([]models.RiskReport, error) {
+	var res []models.RiskReport
+	for i := len(m.Reports) - 1; i >= 0; i-- {
+		if m.Reports[i].CreditRequestID == creditRequestID {
+			res = append(res, m.Reports[i])
+		}
+	}
+	return res, nil
+}
+
+func (m *MockRiskReportRepository) FindAfter(afterSequence uint64, limit int) ([]models.RiskReport, error) {
+	var res []models.RiskReport
+	for _, r := range m.Reports {
+		if r.Sequence > afterSequence && len(res) < limit {
+			res = append(res, r)
+		}
+	}
+	return res, nil
+}
+
+func (m *MockRiskReportRepository) FindUnreported(afterID uint, limit int) ([]models.RiskEvaluation, error) {
+	var res []models.RiskEvaluation
+	for _, e := range m.Evaluations.Evaluations {
+		reported := false
+		for _, r := range m.Reports {
+			reported = reported || r.RiskEvaluationID == e.ID
+		}
+		if !reported && e.ID > afterID && len(res) < limit {
+			res = append(res, e)
+		}
+	}
+	return res, nil
+}
+
+/* Mock de RiskEvaluationRepository */
+
+type MockRiskEvaluationRepository struct {
+	Evaluations []models.RiskEvaluation
+}
+
+var _ ports.RiskEvaluationRepository = (*MockRiskEvaluationRepository)(nil)
+
+func (m *MockRiskEvaluationRepository) Create(evaluation *models.RiskEvaluation) error {
+	evaluation.ID = uint(len(m.Evaluations) + 1)
+	m.Evaluations = append(m.Evaluations, *evaluation)
+	return nil
+}
+
+func (m *MockRiskEvaluationRepository) FindByCreditRequestID(creditRequestID uint) ([]models.RiskEvaluation, error) {
+	var res []models.RiskEvaluation
+	for _, e := range m.Evaluations {
+		if e.CreditRequestID == creditRequestID {
+			res = append(res, e)
+		}
+	}
+	return res, nil
+}
+
+/* Mock de CreditRequestRepository */
+
+type MockCreditRequestRepository struct {
+	Requests map[uint]*models.CreditRequest
+}
+
+var _ ports.CreditRequestRepository = (*MockCreditRequestRepository)(nil)
+
+func (m *MockCreditRequestRepository) FindAll(customerID *uint) ([]models.CreditRequest, error) {
+	return nil, nil
+}
+
+func (m *MockCreditRequestRepository) FindByID(id uint) (*models.CreditRequest, error) {
+	if cr, ok := m.Requests[id]; ok {
+		copy := *cr
+		return &copy, nil
+	}
+	return nil, nil
+}
+
+func (m *MockCreditRequestRepository) HasRequestsByCustomerID(customerID uint) (bool, error) {
+	return false, nil
+}
+
+func (m *MockCreditRequestRepository) Create(creditRequest *models.CreditRequest) (*models.CreditRequest, error) {
+	return creditRequest, nil
+}
+
+func (m *MockCreditRequestRepository) Update(id uint, creditRequest *models.CreditRequest) (*models.CreditRequest, error) {
+	return creditRequest, nil
+}
+
+func (m *MockCreditRequestRepository) Delete(id uint) error {
+	return nil
+}
+
+func (m *MockCreditRequestRepository) UpdateCreditRiskEvaluation(id uint, assessment *models.RiskAssessment) (*models.CreditRequest, error) {
+	return nil, nil
+}
+
+func (m *MockCreditRequestRepository) UpdateCreditStatus(id uint, creditStatusID uint) error {
+	return nil
+}
+
+func (m *MockCreditRequestRepository) UpdateCreditOffer(id uint, offer *models.CreditOffer) error {
+	return nil
+}
+
+func (m *MockCreditRequestRepository) FindDataToEvaluateRisk(id uint) (models.Customer, *models.CreditRequest, []models.CreditRequest, []models.CustomerAsset, error) {
+	return models.Customer{}, nil, nil, nil, nil
+}
@@ -0,0 +1,373 @@
+package riskReport
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/JhonCamargo53/prueba-tecnica/internal/domain/attestation"
+	"github.com/JhonCamargo53/prueba-tecnica/internal/domain/models"
+	"github.com/JhonCamargo53/prueba-tecnica/internal/domain/ports"
+	"github.com/JhonCamargo53/prueba-tecnica/internal/infrastructure/logger"
+)
+
+// Reportes leídos por consulta al exportar la cadena
+const exportBatchSize = 500
+
+// RiskReportKey es una clave pública con la que se verifican los reportes.
+type RiskReportKey struct {
+	KeyID     string `json:"keyId"`
+	PublicKey string `json:"publicKey"`
+	// Active indica la clave con la que se firman los reportes nuevos
+	Active bool `json:"active"`
+}
+
+// RiskReportVerification es el resultado de verificar un reporte: su hash, su firma,
+// su enlace en la cadena y que la evaluación y la solicitud no se hayan alterado.
+type RiskReportVerification struct {
+	ReportID         uint                 `json:"reportId"`
+	Sequence         uint64               `json:"sequence"`
+	CreditRequestID  uint                 `json:"creditRequestId"`
+	RiskEvaluationID uint                 `json:"riskEvaluationId"`
+	Valid            bool                 `json:"valid"`
+	Checks           []attestation.Check  `json:"checks"`
+	Payload          *attestation.Payload `json:"payload,omitempty"`
+}
+
+type RiskReportService struct {
+	reportRepo         ports.RiskReportRepository
+	riskEvaluationRepo ports.RiskEvaluationRepository
+	creditRequestRepo  ports.CreditRequestRepository
+
+	signingKey ed25519.PrivateKey
+	// Claves de confianza: la de firma y las anteriores a una rotación
+	keys attestation.Keyring
+
+	now func() time.Time
+}
+
+func NewRiskReportService(reportRepo ports.RiskReportRepository, riskEvaluationRepo ports.RiskEvaluationRepository,
+	creditRequestRepo ports.CreditRequestRepository, signingKey ed25519.PrivateKey, trustedKeys ...ed25519.PublicKey) *RiskReportService {
+	keys := attestation.NewKeyring(trustedKeys...)
+	keys[attestation.KeyID(signingKey.Public().(ed25519.PublicKey))] = signingKey.Public().(ed25519.PublicKey)
+
+	return &RiskReportService{
+		reportRepo:         reportRepo,
+		riskEvaluationRepo: riskEvaluationRepo,
+		creditRequestRepo:  creditRequestRepo,
+		signingKey:         signingKey,
+		keys:               keys,
+		now:                time.Now,
+	}
+}
+
+// Issue firma el reporte de una evaluación y lo agrega al final de la cadena.
+func (s *RiskReportService) Issue(evaluation *models.RiskEvaluation) (*models.RiskReport, error) {
+	publicKey := s.signingKey.Public().(ed25519.PublicKey)
+
+	report, err := s.reportRepo.Append(func(last *models.RiskReport) (*models.RiskReport, error) {
+		sequence, previousHash := uint64(1), attestation.GenesisHash
+		if last != nil {
+			sequence, previousHash = last.Sequence+1, last.Hash
+		}
+
+		payload, err := attestation.NewPayload(*evaluation, sequence, previousHash, s.now())
+		if err != nil {
+			return nil, err
+		}
+		canonical, err := attestation.Canonical(payload)
+		if err != nil {
+			return nil, err
+		}
+
+		return &models.RiskReport{
+			Sequence:         sequence,
+			RiskEvaluationID: evaluation.ID,
+			CreditRequestID:  evaluation.CreditRequestID,
+			Payload:          string(canonical),
+			PreviousHash:     previousHash,
+			Hash:             attestation.Hash(canonical),
+			Signature:        attestation.Sign(s.signingKey, canonical),
+			KeyID:            attestation.KeyID(publicKey),
+		}, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	logger.WriteJSON(map[string]interface{}{
+		"timestamp":          time.Now().Format(time.RFC3339),
+		"level":              "info",
+		"event":              "risk_report_issued",
+		"risk_report_id":     report.ID,
+		"sequence":           report.Sequence,
+		"credit_request_id":  report.CreditRequestID,
+		"risk_evaluation_id": report.RiskEvaluationID,
+		"hash":               report.Hash,
+	})
+
+	return report, nil
+}
+
+func (s *RiskReportService) GetReport(id uint) (*models.RiskReport, error) {
+	report, err := s.reportRepo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if report == nil {
+		return nil, fmt.Errorf("no existe reporte de riesgo con id %d", id)
+	}
+	return report, nil
+}
+
+func (s *RiskReportService) GetReportsByCreditRequestID(creditRequestID uint) ([]models.RiskReport, error) {
+	creditRequest, err := s.creditRequestRepo.FindByID(creditRequestID)
+	if err != nil {
+		return nil, err
+	}
+	if creditRequest == nil {
+		return nil, fmt.Errorf("no existe solicitud de crédito con id %d", creditRequestID)
+	}
+
+	return s.reportRepo.FindByCreditRequestID(creditRequestID)
+}
+
+// PublicKeys retorna las claves de confianza, para verificar los reportes fuera del servidor.
+func (s *RiskReportService) PublicKeys() []RiskReportKey {
+	active := attestation.KeyID(s.signingKey.Public().(ed25519.PublicKey))
+
+	keys := make([]RiskReportKey, 0, len(s.keys))
+	for keyID, key := range s.keys {
+		keys = append(keys, RiskReportKey{
+			KeyID:     keyID,
+			PublicKey: base64.StdEncoding.EncodeToString(key),
+			Active:    keyID == active,
+		})
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].Active != keys[j].Active {
+			return keys[i].Active
+		}
+		return keys[i].KeyID < keys[j].KeyID
+	})
+
+	return keys
+}
+
+// Verify verifica el hash y la firma del reporte, su enlace con el reporte anterior
+// y el siguiente, que la evaluación guardada siga coincidiendo con lo firmado y que
+// las demás evaluaciones de la solicitud tengan reporte. Si es la evaluación más
+// reciente de la solicitud, también compara el puntaje, la categoría y la
+// explicación vigentes de la solicitud.
+func (s *RiskReportService) Verify(id uint) (*RiskReportVerification, error) {
+	report, err := s.GetReport(id)
+	if err != nil {
+		return nil, err
+	}
+
+	var previous *models.RiskReport
+	if report.Sequence > 1 {
+		if previous, err = s.reportRepo.FindBySequence(report.Sequence - 1); err != nil {
+			return nil, err
+		}
+	}
+
+	payload, checks := attestation.VerifyReport(*report, previous, s.keys)
+	if report.Sequence > 1 && previous == nil {
+		// Sin reporte anterior, VerifyReport lo compara con el inicio de la cadena
+		checks = failCheck(withoutCheck(checks, attestation.CheckChain), attestation.CheckChain,
+			fmt.Sprintf("no existe el reporte anterior (secuencia %d)", report.Sequence-1))
+	}
+
+	next, err := s.reportRepo.FindBySequence(report.Sequence + 1)
+	if err != nil {
+		return nil, err
+	}
+	if next != nil && next.PreviousHash != report.Hash {
+		checks = failCheck(checks, attestation.CheckChain, fmt.Sprintf("el reporte siguiente (secuencia %d) apunta al hash %s", next.Sequence, next.PreviousHash))
+	}
+
+	if payload != nil {
+		stateChecks, err := s.verifyState(*payload)
+		if err != nil {
+			return nil, err
+		}
+		checks = append(checks, stateChecks...)
+	}
+
+	verification := &RiskReportVerification{
+		ReportID:         report.ID,
+		Sequence:         report.Sequence,
+		CreditRequestID:  report.CreditRequestID,
+		RiskEvaluationID: report.RiskEvaluationID,
+		Valid:            len(attestation.Failed(checks)) == 0,
+		Checks:           checks,
+		Payload:          payload,
+	}
+
+	if !verification.Valid {
+		logger.WriteJSON(map[string]interface{}{
+			"timestamp":         time.Now().Format(time.RFC3339),
+			"level":             "error",
+			"event":             "risk_report_verification_failed",
+			"risk_report_id":    report.ID,
+			"sequence":          report.Sequence,
+			"credit_request_id": report.CreditRequestID,
+			"failed_checks":     attestation.Failed(checks),
+		})
+	}
+
+	return verification, nil
+}
+
+// Export escribe la cadena completa en formato JSON Lines (un reporte por línea, en
+// orden de secuencia), para verificarla fuera del servidor. Retorna la cantidad de reportes.
+func (s *RiskReportService) Export(w io.Writer) (int, error) {
+	encoder := json.NewEncoder(w)
+	encoder.SetEscapeHTML(false)
+
+	count := 0
+	var after uint64
+	for {
+		reports, err := s.reportRepo.FindAfter(after, exportBatchSize)
+		if err != nil {
+			return count, err
+		}
+		for _, report := range reports {
+			if err := encoder.Encode(report); err != nil {
+				return count, err
+			}
+			after = report.Sequence
+			count++
+		}
+		if len(reports) < exportBatchSize {
+			return count, nil
+		}
+	}
+}
+
+// FindUnreported retorna las evaluaciones que no tienen reporte firmado, por
+// ejemplo porque falló la emisión después de guardar la evaluación.
+func (s *RiskReportService) FindUnreported() ([]models.RiskEvaluation, error) {
+	var unreported []models.RiskEvaluation
+	var after uint
+	for {
+		evaluations, err := s.reportRepo.FindUnreported(after, exportBatchSize)
+		if err != nil {
+			return nil, err
+		}
+		unreported = append(unreported, evaluations...)
+		if len(evaluations) > 0 {
+			after = evaluations[len(evaluations)-1].ID
+		}
+		if len(evaluations) < exportBatchSize {
+			return unreported, nil
+		}
+	}
+}
+
+// IssueMissing emite los reportes de las evaluaciones que quedaron sin reporte,
+// en orden de evaluación. Se detiene en el primer error. Retorna la cantidad emitida.
+func (s *RiskReportService) IssueMissing() (int, error) {
+	issued := 0
+	var after uint
+	for {
+		evaluations, err := s.reportRepo.FindUnreported(after, exportBatchSize)
+		if err != nil {
+			return issued, err
+		}
+		for i := range evaluations {
+			after = evaluations[i].ID
+			if _, err := s.Issue(&evaluations[i]); err != nil {
+				return issued, err
+			}
+			issued++
+
+			logger.WriteJSON(map[string]interface{}{
+				"timestamp":          time.Now().Format(time.RFC3339),
+				"level":              "warning",
+				"event":              "risk_report_issued_late",
+				"credit_request_id":  evaluations[i].CreditRequestID,
+				"risk_evaluation_id": evaluations[i].ID,
+			})
+		}
+		if len(evaluations) < exportBatchSize {
+			return issued, nil
+		}
+	}
+}
+
+// verifyState compara lo firmado con la evaluación guardada y, si es la más
+// reciente, con el riesgo vigente de la solicitud. También revisa que ninguna
+// evaluación de la solicitud haya quedado sin reporte.
+func (s *RiskReportService) verifyState(payload attestation.Payload) ([]attestation.Check, error) {
+	evaluations, err := s.riskEvaluationRepo.FindByCreditRequestID(payload.CreditRequestID)
+	if err != nil {
+		return nil, err
+	}
+
+	var evaluation, latest *models.RiskEvaluation
+	for i := range evaluations {
+		if evaluations[i].ID == payload.RiskEvaluationID {
+			evaluation = &evaluations[i]
+		}
+		if latest == nil || evaluations[i].ID > latest.ID {
+			latest = &evaluations[i]
+		}
+	}
+
+	if evaluation == nil {
+		return []attestation.Check{{Name: attestation.CheckEvaluation,
+			Detail: fmt.Sprintf("no existe la evaluación %d de la solicitud %d", payload.RiskEvaluationID, payload.CreditRequestID)}}, nil
+	}
+
+	reports, err := s.reportRepo.FindByCreditRequestID(payload.CreditRequestID)
+	if err != nil {
+		return nil, err
+	}
+
+	checks := []attestation.Check{attestation.CompareEvaluation(payload, *evaluation), attestation.CompareCoverage(evaluations, reports)}
+	if latest.ID != evaluation.ID {
+		return checks, nil
+	}
+
+	creditRequest, err := s.creditRequestRepo.FindByID(payload.CreditRequestID)
+	if err != nil {
+		return nil, err
+	}
+	if creditRequest == nil {
+		return append(checks, attestation.Check{Name: attestation.CheckCreditRequest,
+			Detail: fmt.Sprintf("no existe solicitud de crédito con id %d", payload.CreditRequestID)}), nil
+	}
+
+	return append(checks, attestation.CompareCreditRequest(payload, *creditRequest)), nil
+}
+
+// failCheck marca como fallida la verificación indicada y agrega el detalle.
+func failCheck(checks []attestation.Check, name, detail string) []attestation.Check {
+	for i := range checks {
+		if checks[i].Name != name {
+			continue
+		}
+		if !checks[i].Passed {
+			detail = checks[i].Detail + "; " + detail
+		}
+		checks[i] = attestation.Check{Name: name, Detail: detail}
+		return checks
+	}
+	return append(checks, attestation.Check{Name: name, Detail: detail})
+}
+
+func withoutCheck(checks []attestation.Check, name string) []attestation.Check {
+	filtered := checks[:0]
+	for _, check := range checks {
+		if check.Name != name {
+			filtered = append(filtered, check)
+		}
+	}
+	return filtered
+}
@@ -0,0 +1,225 @@
+package riskReport
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/JhonCamargo53/prueba-tecnica/internal/domain/attestation"
+	"github.com/JhonCamargo53/prueba-tecnica/internal/domain/models"
+)
+
+var seed = bytes.Repeat([]byte{7}, ed25519.SeedSize)
+
+// newService emite los reportes de dos evaluaciones de la solicitud 5 y una de la 6.
+func newService(t *testing.T) (*RiskReportService, *MockRiskReportRepository, *MockRiskEvaluationRepository, *MockCreditRequestRepository) {
+	t.Helper()
+
+	creditRequestRepo := &MockCreditRequestRepository{Requests: map[uint]*models.CreditRequest{
+		5: {ID: 5, RiskScore: 71.23456, RiskCategory: "MEDIUM", RiskExplanation: "Ingreso suficiente; sin bienes"},
+		6: {ID: 6, RiskScore: 20, RiskCategory: "HIGH", RiskExplanation: "Cuota > 40% del ingreso"},
+	}}
+	evaluationRepo := &MockRiskEvaluationRepository{}
+	reportRepo := &MockRiskReportRepository{Evaluations: evaluationRepo}
+	service := NewRiskReportService(reportRepo, evaluationRepo, creditRequestRepo, ed25519.NewKeyFromSeed(seed))
+
+	for _, evaluation := range []models.RiskEvaluation{
+		{CreditRequestID: 5, EngineVersion: "v1", Score: 55, Category: "HIGH", Explanation: "Sin bienes",
+			InputSnapshot: models.JSONB(`{"monthlyIncome": 1000, "amount": 5000}`)},
+		{CreditRequestID: 6, EngineVersion: "v1", Score: 20, Category: "HIGH", Explanation: "Cuota > 40% del ingreso",
+			InputSnapshot: models.JSONB(`{"monthlyIncome":300,"amount":9000}`)},
+		{CreditRequestID: 5, EngineVersion: "v1", Score: 71.23456, Category: "MEDIUM", Explanation: "Ingreso suficiente; sin bienes",
+			InputSnapshot: models.JSONB(`{"monthlyIncome":1000,"amount":5000,"assets":[]}`)},
+	} {
+		evaluation := evaluation
+		evaluationRepo.Create(&evaluation)
+		if _, err := service.Issue(&evaluation); err != nil {
+			t.Fatalf("no se esperaba error al emitir el reporte: %v", err)
+		}
+	}
+
+	return service, reportRepo, evaluationRepo, creditRequestRepo
+}
+
+func failedChecks(verification *RiskReportVerification) []string {
+	var names []string
+	for _, check := range attestation.Failed(verification.Checks) {
+		names = append(names, check.Name)
+	}
+	return names
+}
+
+func TestIssue_EncadenaLosReportes(t *testing.T) {
+	_, reportRepo, _, _ := newService(t)
+
+	if len(reportRepo.Reports) != 3 {
+		t.Fatalf("se esperaban 3 reportes, obtenidos %d", len(reportRepo.Reports))
+	}
+	if reportRepo.Reports[0].Sequence != 1 || reportRepo.Reports[0].PreviousHash != attestation.GenesisHash {
+		t.Errorf("el primer reporte debe partir del inicio de la cadena: %+v", reportRepo.Reports[0])
+	}
+	for i := 1; i < len(reportRepo.Reports); i++ {
+		if reportRepo.Reports[i].Sequence != uint64(i+1) || reportRepo.Reports[i].PreviousHash != reportRepo.Reports[i-1].Hash {
+			t.Errorf("el reporte %d no apunta al anterior: %+v", i+1, reportRepo.Reports[i])
+		}
+	}
+
+	keys := attestation.NewKeyring(ed25519.NewKeyFromSeed(seed).Public().(ed25519.PublicKey))
+	if problems := attestation.VerifyChain(reportRepo.Reports, keys); len(problems) != 0 {
+		t.Errorf("no se esperaban problemas en la cadena: %+v", problems)
+	}
+}
+
+func TestVerify_ReporteIntacto(t *testing.T) {
+	service, _, _, _ := newService(t)
+
+	for id := uint(1); id <= 3; id++ {
+		verification, err := service.Verify(id)
+		if err != nil {
+			t.Fatalf("no se esperaba error: %v", err)
+		}
+		if !verification.Valid {
+			t.Errorf("reporte %d: se esperaba válido, fallaron %v", id, verification.Checks)
+		}
+	}
+
+	// Sólo la evaluación más reciente se compara con la solicitud
+	latest, _ := service.Verify(3)
+	if len(latest.Checks) != 6 || latest.Checks[5].Name != attestation.CheckCreditRequest {
+		t.Errorf("se esperaba comparar con la solicitud: %+v", latest.Checks)
+	}
+	older, _ := service.Verify(1)
+	if len(older.Checks) != 5 {
+		t.Errorf("no se esperaba comparar una evaluación anterior con la solicitud: %+v", older.Checks)
+	}
+}
+
+func TestVerify_DetectaAlteraciones(t *testing.T) {
+	cases := map[string]struct {
+		reportID uint
+		alter    func(*MockRiskReportRepository, *MockRiskEvaluationRepository, *MockCreditRequestRepository)
+		want     []string
+	}{
+		"explicación de la solicitud": {3, func(_ *MockRiskReportRepository, _ *MockRiskEvaluationRepository, c *MockCreditRequestRepository) {
+			c.Requests[5].RiskExplanation = "Ingreso suficiente"
+		}, []string{attestation.CheckCreditRequest}},
+		"puntaje de la solicitud": {3, func(_ *MockRiskReportRepository, _ *MockRiskEvaluationRepository, c *MockCreditRequestRepository) {
+			c.Requests[5].RiskScore = 90
+		}, []string{attestation.CheckCreditRequest}},
+		"puntaje de la evaluación": {1, func(_ *MockRiskReportRepository, e *MockRiskEvaluationRepository, _ *MockCreditRequestRepository) {
+			e.Evaluations[0].Score = 80
+		}, []string{attestation.CheckEvaluation}},
+		"datos de entrada de la evaluación": {2, func(_ *MockRiskReportRepository, e *MockRiskEvaluationRepository, _ *MockCreditRequestRepository) {
+			e.Evaluations[1].InputSnapshot = models.JSONB(`{"monthlyIncome":3000,"amount":9000}`)
+		}, []string{attestation.CheckEvaluation}},
+		"evaluación eliminada": {2, func(_ *MockRiskReportRepository, e *MockRiskEvaluationRepository, _ *MockCreditRequestRepository) {
+			e.Evaluations = append(e.Evaluations[:1], e.Evaluations[2:]...)
+		}, []string{attestation.CheckEvaluation}},
+		"contenido del reporte": {2, func(r *MockRiskReportRepository, _ *MockRiskEvaluationRepository, _ *MockCreditRequestRepository) {
+			r.Reports[1].Payload = strings.Replace(r.Reports[1].Payload, `"score":20`, `"score":80`, 1)
+		}, []string{attestation.CheckHash, attestation.CheckSignature, attestation.CheckEvaluation, attestation.CheckCreditRequest}},
+		"contenido y hash del reporte": {2, func(r *MockRiskReportRepository, _ *MockRiskEvaluationRepository, _ *MockCreditRequestRepository) {
+			r.Reports[1].Payload = strings.Replace(r.Reports[1].Payload, `"score":20`, `"score":80`, 1)
+			r.Reports[1].Hash = attestation.Hash([]byte(r.Reports[1].Payload))
+		}, []string{attestation.CheckSignature, attestation.CheckChain, attestation.CheckEvaluation, attestation.CheckCreditRequest}},
+		"reporte anterior eliminado": {3, func(r *MockRiskReportRepository, _ *MockRiskEvaluationRepository, _ *MockCreditRequestRepository) {
+			r.Reports = append(r.Reports[:1], r.Reports[2:]...)
+		}, []string{attestation.CheckChain}},
+	}
+
+	for name, c := range cases {
+		service, reportRepo, evaluationRepo, creditRequestRepo := newService(t)
+		c.alter(reportRepo, evaluationRepo, creditRequestRepo)
+
+		verification, err := service.Verify(c.reportID)
+		if err != nil {
+			t.Fatalf("%s: no se esperaba error: %v", name, err)
+		}
+		if verification.Valid || strings.Join(failedChecks(verification), ",") != strings.Join(c.want, ",") {
+			t.Errorf("%s: se esperaba que fallaran %v, fallaron %v", name, c.want, failedChecks(verification))
+		}
+	}
+}
+
+func TestIssueMissing_EmiteLosReportesPendientes(t *testing.T) {
+	service, reportRepo, evaluationRepo, _ := newService(t)
+
+	// La emisión falló después de guardar la evaluación
+	evaluationRepo.Create(&models.RiskEvaluation{CreditRequestID: 5, EngineVersion: "v1", Score: 71.23456, Category: "MEDIUM",
+		Explanation: "Ingreso suficiente; sin bienes", InputSnapshot: models.JSONB(`{"monthlyIncome":1000,"amount":5000,"assets":[]}`)})
+
+	verification, err := service.Verify(3)
+	if err != nil {
+		t.Fatalf("no se esperaba error: %v", err)
+	}
+	if strings.Join(failedChecks(verification), ",") != attestation.CheckCoverage {
+		t.Errorf("se esperaba detectar la evaluación sin reporte, fallaron %v", failedChecks(verification))
+	}
+	if unreported, _ := service.FindUnreported(); len(unreported) != 1 || unreported[0].ID != 4 {
+		t.Errorf("se esperaba la evaluación 4 sin reporte: %+v", unreported)
+	}
+
+	issued, err := service.IssueMissing()
+	if err != nil || issued != 1 {
+		t.Fatalf("se esperaba emitir 1 reporte, obtenido: %d, %v", issued, err)
+	}
+	if unreported, _ := service.FindUnreported(); len(unreported) != 0 {
+		t.Errorf("no se esperaban evaluaciones sin reporte: %+v", unreported)
+	}
+	for id := uint(1); id <= 4; id++ {
+		if verification, _ := service.Verify(id); !verification.Valid {
+			t.Errorf("reporte %d: se esperaba válido, fallaron %v", id, verification.Checks)
+		}
+	}
+
+	keys := attestation.NewKeyring(ed25519.NewKeyFromSeed(seed).Public().(ed25519.PublicKey))
+	if problems := attestation.VerifyChain(reportRepo.Reports, keys); len(problems) != 0 {
+		t.Errorf("no se esperaban problemas en la cadena: %+v", problems)
+	}
+}
+
+func TestVerify_ClaveRotada(t *testing.T) {
+	_, reportRepo, evaluationRepo, creditRequestRepo := newService(t)
+	previousKey := ed25519.NewKeyFromSeed(seed).Public().(ed25519.PublicKey)
+
+	rotatedKey := ed25519.NewKeyFromSeed(bytes.Repeat([]byte{9}, ed25519.SeedSize))
+	rotated := NewRiskReportService(reportRepo, evaluationRepo, creditRequestRepo, rotatedKey, previousKey)
+	if verification, _ := rotated.Verify(1); !verification.Valid {
+		t.Errorf("se esperaba aceptar la clave anterior de confianza: %+v", verification.Checks)
+	}
+	if keys := rotated.PublicKeys(); len(keys) != 2 || !keys[0].Active || keys[0].KeyID == attestation.KeyID(previousKey) {
+		t.Errorf("se esperaba la clave nueva activa primero: %+v", keys)
+	}
+
+	untrusted := NewRiskReportService(reportRepo, evaluationRepo, creditRequestRepo, rotatedKey)
+	if verification, _ := untrusted.Verify(1); verification.Valid {
+		t.Errorf("no se esperaba aceptar una clave que no es de confianza")
+	}
+}
+
+func TestExport_CadenaVerificableFueraDelServidor(t *testing.T) {
+	service, _, _, _ := newService(t)
+
+	var buffer bytes.Buffer
+	count, err := service.Export(&buffer)
+	if err != nil || count != 3 {
+		t.Fatalf("se esperaban 3 reportes exportados, obtenido: %d, %v", count, err)
+	}
+
+	var reports []models.RiskReport
+	decoder := json.NewDecoder(&buffer)
+	for decoder.More() {
+		var report models.RiskReport
+		if err := decoder.Decode(&report); err != nil {
+			t.Fatalf("línea inválida: %v", err)
+		}
+		reports = append(reports, report)
+	}
+
+	keys := attestation.NewKeyring(ed25519.NewKeyFromSeed(seed).Public().(ed25519.PublicKey))
+	if problems := attestation.VerifyChain(reports, keys); len(problems) != 0 {
+		t.Errorf("no se esperaban problemas en la cadena exportada: %+v", problems)
+	}
+}
@@ -0,0 +1,264 @@
+package riskRescoring
+
+import (
+	"errors"
+	"sort"
+	"sync"
+
+	"github.com/JhonCamargo53/prueba-tecnica/internal/domain/models"
+	"github.com/JhonCamargo53/prueba-tecnica/internal/domain/ports"
+)
+
+/*
+Los mocks usan un mutex porque el re-scoring los invoca desde varios workers.
+*/
+
+/* Mock de RiskRescoringRepository */
+
+type MockRiskRescoringRepository struct {
+	mu sync.Mutex
+
+	CreditRequestIDs []uint
+	Jobs             map[uint]*models.RiskRescoringJob
+	Changes          []models.RiskRescoringChange
+	NextID           uint
+
+	// Cancela el contexto del re-scoring después de N consultas de IDs
+	OnFind      func(calls int)
+	FindCalls   int
+	ErrFindIDs  error
+	UpdateCalls int
+}
+
+var _ ports.RiskRescoringRepository = (*MockRiskRescoringRepository)(nil)
+
+func NewMockRiskRescoringRepository(creditRequestIDs []uint) *MockRiskRescoringRepository {
+	return &MockRiskRescoringRepository{
+		CreditRequestIDs: creditRequestIDs,
+		Jobs:             make(map[uint]*models.RiskRescoringJob),
+		NextID:           1,
+	}
+}
+
+func (m *MockRiskRescoringRepository) FindCreditRequestIDs(filter models.RiskRescoringFilter, afterID uint, limit int) ([]uint, error) {
+	m.mu.Lock()
+	m.FindCalls++
+	calls := m.FindCalls
+	m.mu.Unlock()
+
+	if m.OnFind != nil {
+		m.OnFind(calls)
+	}
+	if m.ErrFindIDs != nil {
+		return nil, m.ErrFindIDs
+	}
+
+	ids := append([]uint(nil), m.CreditRequestIDs...)
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	var res []uint
+	for _, id := range ids {
+		if id > afterID && len(res) < limit {
+			res = append(res, id)
+		}
+	}
+	return res, nil
+}
+
+func (m *MockRiskRescoringRepository) CreateJob(job *models.RiskRescoringJob) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	job.ID = m.NextID
+	m.NextID++
+	copy := *job
+	m.Jobs[job.ID] = &copy
+	return nil
+}
+
+func (m *MockRiskRescoringRepository) UpdateJob(job *models.RiskRescoringJob) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.Jobs[job.ID]; !ok {
+		return errors.New("re-scoring no encontrado")
+	}
+	m.UpdateCalls++
+	copy := *job
+	m.Jobs[job.ID] = &copy
+	return nil
+}
+
+func (m *MockRiskRescoringRepository) FindJobByID(id uint) (*models.RiskRescoringJob, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if job, ok := m.Jobs[id]; ok {
+		copy := *job
+		return &copy, nil
+	}
+	return nil, nil
+}
+
+func (m *MockRiskRescoringRepository) FindJobs() ([]models.RiskRescoringJob, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var res []models.RiskRescoringJob
+	for _, job := range m.Jobs {
+		res = append(res, *job)
+	}
+	return res, nil
+}
+
+func (m *MockRiskRescoringRepository) CreateChanges(changes []models.RiskRescoringChange) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.Changes = append(m.Changes, changes...)
+	return nil
+}
+
+func (m *MockRiskRescoringRepository) FindChangesByJobID(jobID uint) ([]models.RiskRescoringChange, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var res []models.RiskRescoringChange
+	for _, c := range m.Changes {
+		if c.JobID == jobID {
+			res = append(res, c)
+		}
+	}
+	return res, nil
+}
+
+/* Mock de CreditRequestRepository */
+
+type MockCreditRequestRepository struct {
+	mu       sync.Mutex
+	Requests map[uint]*models.CreditRequest
+
+	RiskUpdates int
+}
+
+var _ ports.CreditRequestRepository = (*MockCreditRequestRepository)(nil)
+
+func NewMockCreditRequestRepository(initial []*models.CreditRequest) *MockCreditRequestRepository {
+	m := &MockCreditRequestRepository{
+		Requests: make(map[uint]*models.CreditRequest),
+	}
+	for _, cr := range initial {
+		m.Requests[cr.ID] = cr
+	}
+	return m
+}
+
+func (m *MockCreditRequestRepository) FindAll(customerID *uint) ([]models.CreditRequest, error) {
+	return nil, nil
+}
+
+func (m *MockCreditRequestRepository) FindByID(id uint) (*models.CreditRequest, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if cr, ok := m.Requests[id]; ok {
+		copy := *cr
+		return &copy, nil
+	}
+	return nil, nil
+}
+
+func (m *MockCreditRequestRepository) HasRequestsByCustomerID(customerID uint) (bool, error) {
+	return false, nil
+}
+
+func (m *MockCreditRequestRepository) Create(creditRequest *models.CreditRequest) (*models.CreditRequest, error) {
+	return creditRequest, nil
+}
+
+func (m *MockCreditRequestRepository) Update(id uint, creditRequest *models.CreditRequest) (*models.CreditRequest, error) {
+	return creditRequest, nil
+}
+
+func (m *MockCreditRequestRepository) Delete(id uint) error {
+	return nil
+}
+
+func (m *MockCreditRequestRepository) UpdateCreditRiskEvaluation(id uint, assessment *models.RiskAssessment) (*models.CreditRequest, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	cr, ok := m.Requests[id]
+	if !ok {
+		return nil, errors.New("credit request no encontrada")
+	}
+	m.RiskUpdates++
+	cr.RiskScore = assessment.Score
+	cr.RiskCategory = assessment.Category
+	cr.RiskRuleSetVersion = assessment.EngineVersion
+	copy := *cr
+	return &copy, nil
+}
+
+func (m *MockCreditRequestRepository) UpdateCreditStatus(id uint, creditStatusID uint) error {
+	return nil
+}
+
+func (m *MockCreditRequestRepository) UpdateCreditOffer(id uint, offer *models.CreditOffer) error {
+	return nil
+}
+
+func (m *MockCreditRequestRepository) FindDataToEvaluateRisk(id uint) (models.Customer, *models.CreditRequest, []models.CreditRequest, []models.CustomerAsset, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	cr, ok := m.Requests[id]
+	if !ok {
+		return models.Customer{}, nil, nil, nil, errors.New("credit request no encontrada")
+	}
+	copy := *cr
+	return models.Customer{ID: cr.CustomerID}, &copy, nil, nil, nil
+}
+
+/* Mock de RiskEvaluationRepository */
+
+type MockRiskEvaluationRepository struct {
+	mu          sync.Mutex
+	Evaluations []models.RiskEvaluation
+}
+
+var _ ports.RiskEvaluationRepository = (*MockRiskEvaluationRepository)(nil)
+
+func (m *MockRiskEvaluationRepository) Create(evaluation *models.RiskEvaluation) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	evaluation.ID = uint(len(m.Evaluations) + 1)
+	m.Evaluations = append(m.Evaluations, *evaluation)
+	return nil
+}
+
+func (m *MockRiskEvaluationRepository) FindByCreditRequestID(creditRequestID uint) ([]models.RiskEvaluation, error) {
+	return nil, nil
+}
+
+/* Mock de RiskEvaluator: el puntaje depende del monto de la solicitud */
+
+type MockRiskEvaluator struct {
+	Version string
+	// IDs de solicitudes para las que el motor falla
+	FailIDs map[uint]bool
+}
+
+var _ ports.RiskEvaluator = (*MockRiskEvaluator)(nil)
+
+func (m *MockRiskEvaluator) Evaluate(customer models.Customer, currentCreditRequest models.CreditRequest,
+	otherCredits []models.CreditRequest, assets []models.CustomerAsset) (*models.RiskAssessment, error) {
+
+	if m.FailIDs[currentCreditRequest.ID] {
+		return nil, errors.New("motor caído")
+	}
+
+	score := 90.0
+	category := "LOW"
+	if currentCreditRequest.Amount > 10_000_000 {
+		score = 40
+		category = "HIGH"
+	}
+
+	return &models.RiskAssessment{
+		EngineVersion: m.Version,
+		Score:         score,
+		Category:      category,
+	}, nil
+}
@@ -0,0 +1,314 @@
+package riskRescoring
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	riskEvaluation "github.com/JhonCamargo53/prueba-tecnica/internal/application/services/risk-evaluation"
+	"github.com/JhonCamargo53/prueba-tecnica/internal/domain/models"
+	"github.com/JhonCamargo53/prueba-tecnica/internal/domain/ports"
+	"github.com/JhonCamargo53/prueba-tecnica/internal/infrastructure/logger"
+)
+
+const (
+	DefaultWorkers   = 4
+	MaxWorkers       = 32
+	DefaultBatchSize = 100
+)
+
+// RescoringOptions son los parámetros de un nuevo re-scoring.
+type RescoringOptions struct {
+	Filter  models.RiskRescoringFilter
+	DryRun  bool
+	Workers int
+}
+
+type RiskRescoringService struct {
+	rescoringRepo     ports.RiskRescoringRepository
+	creditRequestRepo ports.CreditRequestRepository
+	riskEvaluation    *riskEvaluation.RiskEvaluationService
+	batchSize         int
+
+	// Sólo un re-scoring a la vez por proceso
+	mu      sync.Mutex
+	running bool
+}
+
+func NewRiskRescoringService(rescoringRepo ports.RiskRescoringRepository, creditRequestRepo ports.CreditRequestRepository,
+	riskEvaluationService *riskEvaluation.RiskEvaluationService) *RiskRescoringService {
+	return &RiskRescoringService{
+		rescoringRepo:     rescoringRepo,
+		creditRequestRepo: creditRequestRepo,
+		riskEvaluation:    riskEvaluationService,
+		batchSize:         DefaultBatchSize,
+	}
+}
+
+// Start registra un nuevo re-scoring y reserva el proceso para ejecutarlo con Run.
+func (s *RiskRescoringService) Start(options RescoringOptions) (*models.RiskRescoringJob, error) {
+	if options.Workers <= 0 {
+		options.Workers = DefaultWorkers
+	}
+	if options.Workers > MaxWorkers {
+		return nil, fmt.Errorf("el número de workers no puede ser mayor que %d", MaxWorkers)
+	}
+
+	filter, err := json.Marshal(options.Filter)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.acquire(); err != nil {
+		return nil, err
+	}
+
+	job := &models.RiskRescoringJob{
+		Status:  models.RiskRescoringStatusRunning,
+		DryRun:  options.DryRun,
+		Workers: options.Workers,
+		Filter:  filter,
+	}
+
+	if err := s.rescoringRepo.CreateJob(job); err != nil {
+		s.release()
+		return nil, err
+	}
+
+	return job, nil
+}
+
+// Resume retoma un re-scoring que no terminó desde el último lote completado,
+// con el mismo filtro, modo y número de workers.
+func (s *RiskRescoringService) Resume(jobID uint) (*models.RiskRescoringJob, error) {
+	job, err := s.rescoringRepo.FindJobByID(jobID)
+	if err != nil {
+		return nil, err
+	}
+	if job == nil {
+		return nil, fmt.Errorf("no existe re-scoring con id %d", jobID)
+	}
+	if job.Status == models.RiskRescoringStatusCompleted {
+		return nil, fmt.Errorf("el re-scoring %d ya terminó", jobID)
+	}
+
+	if err := s.acquire(); err != nil {
+		return nil, err
+	}
+
+	job.Status = models.RiskRescoringStatusRunning
+	job.Error = ""
+	job.FinishedAt = nil
+
+	if err := s.rescoringRepo.UpdateJob(job); err != nil {
+		s.release()
+		return nil, err
+	}
+
+	return job, nil
+}
+
+// Run procesa el re-scoring por lotes hasta terminar, fallar o cancelarse el contexto.
+// Al cancelarse termina el lote en curso y queda INTERRUPTED para reanudarse.
+func (s *RiskRescoringService) Run(ctx context.Context, job *models.RiskRescoringJob) error {
+	defer s.release()
+
+	var filter models.RiskRescoringFilter
+	if len(job.Filter) > 0 {
+		if err := json.Unmarshal(job.Filter, &filter); err != nil {
+			return s.finish(job, models.RiskRescoringStatusFailed, fmt.Errorf("filtro de re-scoring inválido: %w", err))
+		}
+	}
+
+	for {
+		if ctx.Err() != nil {
+			return s.finish(job, models.RiskRescoringStatusInterrupted, ctx.Err())
+		}
+
+		ids, err := s.rescoringRepo.FindCreditRequestIDs(filter, job.LastProcessedID, s.batchSize)
+		if err != nil {
+			return s.finish(job, models.RiskRescoringStatusFailed, err)
+		}
+
+		if len(ids) == 0 {
+			return s.finish(job, models.RiskRescoringStatusCompleted, nil)
+		}
+
+		changes := s.processBatch(ids, job)
+
+		for _, change := range changes {
+			if change.Error != "" {
+				job.Failed++
+			} else {
+				job.Changed++
+			}
+		}
+
+		if err := s.rescoringRepo.CreateChanges(changes); err != nil {
+			return s.finish(job, models.RiskRescoringStatusFailed, err)
+		}
+
+		// Punto de control: el lote completo quedó procesado
+		job.LastProcessedID = ids[len(ids)-1]
+		job.Processed += len(ids)
+
+		if err := s.rescoringRepo.UpdateJob(job); err != nil {
+			return s.finish(job, models.RiskRescoringStatusFailed, err)
+		}
+	}
+}
+
+func (s *RiskRescoringService) GetJobs() ([]models.RiskRescoringJob, error) {
+	return s.rescoringRepo.FindJobs()
+}
+
+func (s *RiskRescoringService) GetJobByID(id uint) (*models.RiskRescoringJob, error) {
+	job, err := s.rescoringRepo.FindJobByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if job == nil {
+		return nil, fmt.Errorf("no existe re-scoring con id %d", id)
+	}
+	return job, nil
+}
+
+// GetJobChanges retorna las solicitudes cuyo resultado cambió o falló en el re-scoring.
+func (s *RiskRescoringService) GetJobChanges(id uint) ([]models.RiskRescoringChange, error) {
+	if _, err := s.GetJobByID(id); err != nil {
+		return nil, err
+	}
+	return s.rescoringRepo.FindChangesByJobID(id)
+}
+
+// processBatch re-evalúa los IDs con un pool de workers y retorna sólo los cambios y errores.
+func (s *RiskRescoringService) processBatch(ids []uint, job *models.RiskRescoringJob) []models.RiskRescoringChange {
+	results := make([]*models.RiskRescoringChange, len(ids))
+	indexes := make(chan int)
+
+	var wg sync.WaitGroup
+	for w := 0; w < job.Workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				results[i] = s.rescore(job, ids[i])
+			}
+		}()
+	}
+
+	for i := range ids {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+
+	var changes []models.RiskRescoringChange
+	for _, change := range results {
+		if change != nil {
+			changes = append(changes, *change)
+		}
+	}
+	return changes
+}
+
+// rescore re-evalúa una solicitud. Retorna nil si el puntaje y la categoría no cambian.
+func (s *RiskRescoringService) rescore(job *models.RiskRescoringJob, creditRequestID uint) *models.RiskRescoringChange {
+	change := &models.RiskRescoringChange{JobID: job.ID, CreditRequestID: creditRequestID}
+
+	var previous *models.CreditRequest
+	var err error
+
+	if job.DryRun {
+		var assessment *models.RiskAssessment
+		previous, assessment, err = s.riskEvaluation.PreviewCreditRequest(creditRequestID)
+		if err == nil {
+			change.EngineVersion = assessment.EngineVersion
+			change.Score = assessment.Score
+			change.Category = assessment.Category
+		}
+	} else {
+		previous, err = s.creditRequestRepo.FindByID(creditRequestID)
+		if err == nil && previous == nil {
+			err = fmt.Errorf("no existe solicitud de crédito con id %d", creditRequestID)
+		}
+		if err == nil {
+			var updated *models.CreditRequest
+			updated, err = s.riskEvaluation.EvaluateCreditRequest(creditRequestID, models.RiskTriggerBatchRescoring)
+			if err == nil {
+				change.EngineVersion = updated.RiskRuleSetVersion
+				change.Score = updated.RiskScore
+				change.Category = updated.RiskCategory
+			}
+		}
+	}
+
+	if previous != nil {
+		change.PreviousVersion = previous.RiskRuleSetVersion
+		change.PreviousScore = previous.RiskScore
+		change.PreviousCategory = previous.RiskCategory
+	}
+
+	if err != nil {
+		change.Error = err.Error()
+		return change
+	}
+
+	if math.Abs(change.Score-change.PreviousScore) < 1e-9 && change.Category == change.PreviousCategory {
+		return nil
+	}
+
+	return change
+}
+
+func (s *RiskRescoringService) finish(job *models.RiskRescoringJob, status string, cause error) error {
+	now := time.Now()
+	job.Status = status
+	job.FinishedAt = &now
+	if cause != nil {
+		job.Error = cause.Error()
+	}
+
+	entry := map[string]interface{}{
+		"timestamp": now.Format(time.RFC3339),
+		"level":     "info",
+		"event":     "risk_rescoring_finished",
+		"job_id":    job.ID,
+		"status":    status,
+		"dry_run":   job.DryRun,
+		"processed": job.Processed,
+		"changed":   job.Changed,
+		"failed":    job.Failed,
+	}
+
+	if err := s.rescoringRepo.UpdateJob(job); err != nil && cause == nil {
+		cause = err
+	}
+
+	if cause != nil {
+		entry["level"] = "warning"
+		entry["error"] = cause.Error()
+	}
+	logger.WriteJSON(entry)
+
+	return cause
+}
+
+func (s *RiskRescoringService) acquire() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.running {
+		return fmt.Errorf("ya hay un re-scoring en ejecución")
+	}
+	s.running = true
+	return nil
+}
+
+func (s *RiskRescoringService) release() {
+	s.mu.Lock()
+	s.running = false
+	s.mu.Unlock()
+}
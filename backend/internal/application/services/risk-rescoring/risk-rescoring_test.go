@@ -0,0 +1,152 @@
+package riskRescoring
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	riskEvaluation "github.com/JhonCamargo53/prueba-tecnica/internal/application/services/risk-evaluation"
+	"github.com/JhonCamargo53/prueba-tecnica/internal/domain/models"
+)
+
+func newPortfolio() []*models.CreditRequest {
+	return []*models.CreditRequest{
+		{ID: 1, Amount: 5_000_000, RiskScore: 90, RiskCategory: "LOW", RiskRuleSetVersion: "v1"},
+		{ID: 2, Amount: 20_000_000, RiskScore: 70, RiskCategory: "MEDIUM", RiskRuleSetVersion: "v1"},
+		{ID: 3, Amount: 8_000_000, RiskScore: 60, RiskCategory: "MEDIUM", RiskRuleSetVersion: "v1"},
+		{ID: 4, Amount: 30_000_000, RiskScore: 40, RiskCategory: "HIGH", RiskRuleSetVersion: "v1"},
+		{ID: 5, Amount: 1_000_000, RiskScore: 90, RiskCategory: "LOW", RiskRuleSetVersion: "v1"},
+	}
+}
+
+func newService(requests []*models.CreditRequest, evaluator *MockRiskEvaluator) (*RiskRescoringService,
+	*MockRiskRescoringRepository, *MockCreditRequestRepository, *MockRiskEvaluationRepository) {
+
+	var ids []uint
+	for _, cr := range requests {
+		ids = append(ids, cr.ID)
+	}
+
+	rescoringRepo := NewMockRiskRescoringRepository(ids)
+	creditRequestRepo := NewMockCreditRequestRepository(requests)
+	evaluationRepo := &MockRiskEvaluationRepository{}
+
+	evaluationService := riskEvaluation.NewRiskEvaluationService(creditRequestRepo, evaluationRepo, evaluator)
+	service := NewRiskRescoringService(rescoringRepo, creditRequestRepo, evaluationService)
+	service.batchSize = 2
+
+	return service, rescoringRepo, creditRequestRepo, evaluationRepo
+}
+
+func TestRun_DryRunReportaCambiosSinEscribir(t *testing.T) {
+	service, rescoringRepo, creditRequestRepo, evaluationRepo := newService(newPortfolio(), &MockRiskEvaluator{Version: "v2"})
+
+	job, err := service.Start(RescoringOptions{DryRun: true, Workers: 3})
+	if err != nil {
+		t.Fatalf("no se esperaba error al iniciar: %v", err)
+	}
+	if err := service.Run(context.Background(), job); err != nil {
+		t.Fatalf("no se esperaba error: %v", err)
+	}
+
+	if job.Status != models.RiskRescoringStatusCompleted || job.Processed != 5 {
+		t.Fatalf("se esperaba el re-scoring completo con 5 solicitudes, obtenido: %s/%d", job.Status, job.Processed)
+	}
+
+	// Cambian las solicitudes 2 (MEDIUM→HIGH) y 3 (MEDIUM→LOW)
+	if job.Changed != 2 || len(rescoringRepo.Changes) != 2 {
+		t.Fatalf("se esperaban 2 cambios, obtenidos: %d", job.Changed)
+	}
+	for _, c := range rescoringRepo.Changes {
+		if c.PreviousVersion != "v1" || c.EngineVersion != "v2" {
+			t.Errorf("cambio con versiones inesperadas: %+v", c)
+		}
+	}
+
+	if creditRequestRepo.RiskUpdates != 0 || len(evaluationRepo.Evaluations) != 0 {
+		t.Errorf("el modo dry-run no debería escribir solicitudes ni historial")
+	}
+}
+
+func TestRun_ActualizaSolicitudesYRegistraErrores(t *testing.T) {
+	evaluator := &MockRiskEvaluator{Version: "v2", FailIDs: map[uint]bool{4: true}}
+	service, _, creditRequestRepo, evaluationRepo := newService(newPortfolio(), evaluator)
+
+	job, err := service.Start(RescoringOptions{Workers: 2})
+	if err != nil {
+		t.Fatalf("no se esperaba error al iniciar: %v", err)
+	}
+	if err := service.Run(context.Background(), job); err != nil {
+		t.Fatalf("un error en una solicitud no debería detener el re-scoring: %v", err)
+	}
+
+	if job.Failed != 1 || job.Processed != 5 {
+		t.Errorf("se esperaba 1 error y 5 procesadas, obtenido: %d/%d", job.Failed, job.Processed)
+	}
+	if creditRequestRepo.RiskUpdates != 4 || len(evaluationRepo.Evaluations) != 4 {
+		t.Errorf("se esperaban 4 solicitudes actualizadas, obtenido: %d", creditRequestRepo.RiskUpdates)
+	}
+	for _, e := range evaluationRepo.Evaluations {
+		if e.Trigger != models.RiskTriggerBatchRescoring {
+			t.Errorf("motivo inesperado en el historial: %s", e.Trigger)
+		}
+	}
+	if creditRequestRepo.Requests[2].RiskRuleSetVersion != "v2" {
+		t.Errorf("se esperaba la solicitud re-evaluada con la nueva versión")
+	}
+}
+
+func TestRun_InterrumpidoSeReanudaDesdeElUltimoLote(t *testing.T) {
+	service, rescoringRepo, _, evaluationRepo := newService(newPortfolio(), &MockRiskEvaluator{Version: "v2"})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	rescoringRepo.OnFind = func(calls int) {
+		// Se interrumpe mientras se procesa el primer lote
+		if calls == 1 {
+			cancel()
+		}
+	}
+
+	job, err := service.Start(RescoringOptions{Workers: 2})
+	if err != nil {
+		t.Fatalf("no se esperaba error al iniciar: %v", err)
+	}
+	if err := service.Run(ctx, job); !errors.Is(err, context.Canceled) {
+		t.Fatalf("se esperaba la cancelación como error, obtenido: %v", err)
+	}
+
+	if job.Status != models.RiskRescoringStatusInterrupted || job.LastProcessedID != 2 || job.Processed != 2 {
+		t.Fatalf("se esperaba interrumpido después del primer lote, obtenido: %s/%d/%d", job.Status, job.LastProcessedID, job.Processed)
+	}
+
+	rescoringRepo.OnFind = nil
+	resumed, err := service.Resume(job.ID)
+	if err != nil {
+		t.Fatalf("no se esperaba error al reanudar: %v", err)
+	}
+	if err := service.Run(context.Background(), resumed); err != nil {
+		t.Fatalf("no se esperaba error: %v", err)
+	}
+
+	if resumed.Status != models.RiskRescoringStatusCompleted || resumed.Processed != 5 {
+		t.Errorf("se esperaba completar las 5 solicitudes, obtenido: %s/%d", resumed.Status, resumed.Processed)
+	}
+	if len(evaluationRepo.Evaluations) != 5 {
+		t.Errorf("no se esperaba re-evaluar solicitudes ya procesadas, evaluaciones: %d", len(evaluationRepo.Evaluations))
+	}
+
+	if _, err := service.Resume(job.ID); err == nil {
+		t.Errorf("se esperaba error al reanudar un re-scoring terminado")
+	}
+}
+
+func TestStart_UnSoloReScoringALaVez(t *testing.T) {
+	service, _, _, _ := newService(newPortfolio(), &MockRiskEvaluator{Version: "v2"})
+
+	if _, err := service.Start(RescoringOptions{DryRun: true}); err != nil {
+		t.Fatalf("no se esperaba error al iniciar: %v", err)
+	}
+	if _, err := service.Start(RescoringOptions{DryRun: true}); err == nil {
+		t.Fatalf("se esperaba error porque ya hay un re-scoring en ejecución")
+	}
+}
@@ -0,0 +1,250 @@
+package riskSimulation
+
+import (
+	"github.com/JhonCamargo53/prueba-tecnica/internal/domain/models"
+	"github.com/JhonCamargo53/prueba-tecnica/internal/domain/ports"
+)
+
+/* Mock de CustomerRepository */
+
+type MockCustomerRepository struct {
+	Customers map[uint]*models.Customer
+}
+
+var _ ports.CustomerRepository = (*MockCustomerRepository)(nil)
+
+func NewMockCustomerRepository(initial []*models.Customer) *MockCustomerRepository {
+	m := &MockCustomerRepository{Customers: make(map[uint]*models.Customer)}
+	for _, c := range initial {
+		m.Customers[c.ID] = c
+	}
+	return m
+}
+
+func (m *MockCustomerRepository) FindAllOrderedByCreatedDesc() ([]models.Customer, error) {
+	var res []models.Customer
+	for _, c := range m.Customers {
+		res = append(res, *c)
+	}
+	return res, nil
+}
+
+func (m *MockCustomerRepository) FindByID(id uint) (*models.Customer, error) {
+	if c, ok := m.Customers[id]; ok {
+		copy := *c
+		return &copy, nil
+	}
+	return nil, nil
+}
+
+func (m *MockCustomerRepository) FindByEmail(email string) (*models.Customer, error) {
+	return nil, nil
+}
+
+func (m *MockCustomerRepository) FindByDocument(documentNumber string, documentTypeID uint, excludeID *uint) (*models.Customer, error) {
+	return nil, nil
+}
+
+func (m *MockCustomerRepository) Create(customer *models.Customer) error {
+	m.Customers[customer.ID] = customer
+	return nil
+}
+
+func (m *MockCustomerRepository) Update(id uint, customerData *models.Customer) (*models.Customer, error) {
+	m.Customers[id] = customerData
+	return customerData, nil
+}
+
+func (m *MockCustomerRepository) Delete(id uint) error {
+	delete(m.Customers, id)
+	return nil
+}
+
+/* Mock de CreditRequestRepository: registra cualquier escritura */
+
+type MockCreditRequestRepository struct {
+	Requests []models.CreditRequest
+
+	WriteCalled bool
+}
+
+var _ ports.CreditRequestRepository = (*MockCreditRequestRepository)(nil)
+
+func NewMockCreditRequestRepository(initial []models.CreditRequest) *MockCreditRequestRepository {
+	return &MockCreditRequestRepository{Requests: initial}
+}
+
+func (m *MockCreditRequestRepository) FindAll(customerID *uint) ([]models.CreditRequest, error) {
+	var res []models.CreditRequest
+	for _, cr := range m.Requests {
+		if customerID == nil || cr.CustomerID == *customerID {
+			res = append(res, cr)
+		}
+	}
+	return res, nil
+}
+
+func (m *MockCreditRequestRepository) FindByID(id uint) (*models.CreditRequest, error) {
+	for _, cr := range m.Requests {
+		if cr.ID == id {
+			copy := cr
+			return &copy, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *MockCreditRequestRepository) HasRequestsByCustomerID(customerID uint) (bool, error) {
+	for _, cr := range m.Requests {
+		if cr.CustomerID == customerID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (m *MockCreditRequestRepository) Create(creditRequest *models.CreditRequest) (*models.CreditRequest, error) {
+	m.WriteCalled = true
+	return creditRequest, nil
+}
+
+func (m *MockCreditRequestRepository) Update(id uint, creditRequest *models.CreditRequest) (*models.CreditRequest, error) {
+	m.WriteCalled = true
+	return creditRequest, nil
+}
+
+func (m *MockCreditRequestRepository) Delete(id uint) error {
+	m.WriteCalled = true
+	return nil
+}
+
+func (m *MockCreditRequestRepository) UpdateCreditRiskEvaluation(id uint, assessment *models.RiskAssessment) (*models.CreditRequest, error) {
+	m.WriteCalled = true
+	return nil, nil
+}
+
+func (m *MockCreditRequestRepository) UpdateCreditStatus(id uint, creditStatusID uint) error {
+	return nil
+}
+
+func (m *MockCreditRequestRepository) UpdateCreditOffer(id uint, offer *models.CreditOffer) error {
+	return nil
+}
+
+func (m *MockCreditRequestRepository) FindDataToEvaluateRisk(id uint) (models.Customer, *models.CreditRequest, []models.CreditRequest, []models.CustomerAsset, error) {
+	return models.Customer{}, nil, nil, nil, nil
+}
+
+/* Mock de AssetRepository */
+
+type MockAssetRepository struct {
+	Assets []models.Asset
+}
+
+var _ ports.AssetRepository = (*MockAssetRepository)(nil)
+
+func NewMockAssetRepository(assets []models.Asset) *MockAssetRepository {
+	return &MockAssetRepository{Assets: assets}
+}
+
+func (m *MockAssetRepository) FindAll() ([]models.Asset, error) {
+	return m.Assets, nil
+}
+
+func (m *MockAssetRepository) FindByID(id uint) (*models.Asset, error) {
+	for _, a := range m.Assets {
+		if a.ID == id {
+			return &a, nil
+		}
+	}
+	return nil, nil
+}
+
+/* Mock de CreditProductRepository */
+
+type MockCreditProductRepository struct {
+	Products map[uint]*models.CreditProduct
+}
+
+var _ ports.CreditProductRepository = (*MockCreditProductRepository)(nil)
+
+func NewMockCreditProductRepository(products []*models.CreditProduct) *MockCreditProductRepository {
+	m := &MockCreditProductRepository{Products: make(map[uint]*models.CreditProduct)}
+	for _, p := range products {
+		m.Products[p.ID] = p
+	}
+	return m
+}
+
+func (m *MockCreditProductRepository) FindAll() ([]models.CreditProduct, error) {
+	var res []models.CreditProduct
+	for _, p := range m.Products {
+		res = append(res, *p)
+	}
+	return res, nil
+}
+
+func (m *MockCreditProductRepository) FindByID(id uint) (*models.CreditProduct, error) {
+	if p, ok := m.Products[id]; ok {
+		copy := *p
+		return &copy, nil
+	}
+	return nil, nil
+}
+
+func (m *MockCreditProductRepository) FindByCode(code string) (*models.CreditProduct, error) {
+	for _, p := range m.Products {
+		if p.Code == code {
+			copy := *p
+			return &copy, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *MockCreditProductRepository) Create(product *models.CreditProduct) error {
+	return nil
+}
+
+func (m *MockCreditProductRepository) Save(product *models.CreditProduct) error {
+	return nil
+}
+
+func (m *MockCreditProductRepository) Delete(id uint) error {
+	return nil
+}
+
+func (m *MockCreditProductRepository) HasCreditRequests(id uint) (bool, error) {
+	return false, nil
+}
+
+/* Mock de RiskEvaluator: guarda los datos recibidos */
+
+type MockRiskEvaluator struct {
+	Score    float64
+	Category string
+	Err      error
+
+	Called           bool
+	LastCustomer     models.Customer
+	LastRequest      models.CreditRequest
+	LastOtherCredits []models.CreditRequest
+	LastAssets       []models.CustomerAsset
+}
+
+var _ ports.RiskEvaluator = (*MockRiskEvaluator)(nil)
+
+func (m *MockRiskEvaluator) Evaluate(customer models.Customer, currentCreditRequest models.CreditRequest,
+	otherCredits []models.CreditRequest, assets []models.CustomerAsset) (*models.RiskAssessment, error) {
+
+	m.Called = true
+	m.LastCustomer = customer
+	m.LastRequest = currentCreditRequest
+	m.LastOtherCredits = otherCredits
+	m.LastAssets = assets
+
+	if m.Err != nil {
+		return nil, m.Err
+	}
+	return &models.RiskAssessment{Score: m.Score, Category: m.Category}, nil
+}
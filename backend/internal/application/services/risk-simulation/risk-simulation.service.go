@@ -0,0 +1,155 @@
+package riskSimulation
+
+import (
+	"fmt"
+
+	"github.com/JhonCamargo53/prueba-tecnica/internal/domain/models"
+	"github.com/JhonCamargo53/prueba-tecnica/internal/domain/ports"
+)
+
+/*
+
+RiskSimulationService calcula el riesgo de una solicitud hipotética con el
+RiskEvaluator configurado, sin escribir nada en la base de datos. Sirve para
+probar montos, plazos y garantías con el solicitante antes de radicar.
+
+*/
+
+type RiskSimulationService struct {
+	customerRepo      ports.CustomerRepository
+	creditRequestRepo ports.CreditRequestRepository
+	assetRepo         ports.AssetRepository
+	creditProductRepo ports.CreditProductRepository
+	riskEvaluator     ports.RiskEvaluator
+}
+
+// SimulationInput describe la solicitud hipotética. Si CustomerID es cero se
+// usan los datos del cliente en línea (sin historial de créditos). Con
+// CreditProductID se simula con el producto del catálogo (ponderador de riesgo,
+// tasa base y políticas del producto) en lugar de ProductType.
+type SimulationInput struct {
+	CustomerID      uint
+	Customer        *models.Customer
+	Amount          float64
+	TermMonths      int
+	InterestRate    float64
+	ProductType     string
+	CreditProductID uint
+	Assets          []SimulationAsset
+}
+
+type SimulationAsset struct {
+	AssetID     uint
+	MarketValue float64
+	Description string
+}
+
+func NewRiskSimulationService(customerRepo ports.CustomerRepository, creditRequestRepo ports.CreditRequestRepository,
+	assetRepo ports.AssetRepository, creditProductRepo ports.CreditProductRepository, riskEvaluator ports.RiskEvaluator) *RiskSimulationService {
+	return &RiskSimulationService{
+		customerRepo:      customerRepo,
+		creditRequestRepo: creditRequestRepo,
+		assetRepo:         assetRepo,
+		creditProductRepo: creditProductRepo,
+		riskEvaluator:     riskEvaluator,
+	}
+}
+
+func (s *RiskSimulationService) Simulate(input SimulationInput) (*models.RiskAssessment, error) {
+
+	var customer models.Customer
+	var otherCredits []models.CreditRequest
+
+	switch {
+	case input.CustomerID != 0:
+		existing, err := s.customerRepo.FindByID(input.CustomerID)
+		if err != nil {
+			return nil, err
+		}
+		if existing == nil {
+			return nil, fmt.Errorf("no existe cliente con id %d", input.CustomerID)
+		}
+		customer = *existing
+
+		// Todas las solicitudes del cliente cuentan como historial de la simulación
+		otherCredits, err = s.creditRequestRepo.FindAll(&input.CustomerID)
+		if err != nil {
+			return nil, err
+		}
+	case input.Customer != nil:
+		customer = *input.Customer
+		// Un cliente en línea aún no está registrado: se simula como activo para que
+		// la política de clientes inactivos no lo rechace
+		customer.Status = true
+	default:
+		return nil, fmt.Errorf("se debe indicar el id del cliente o sus datos")
+	}
+
+	// Activos hipotéticos con la política de garantía de su tipo
+	assets := make([]models.CustomerAsset, 0, len(input.Assets))
+
+	for _, a := range input.Assets {
+		assetType, err := s.assetRepo.FindByID(a.AssetID)
+		if err != nil {
+			return nil, err
+		}
+		if assetType == nil {
+			return nil, fmt.Errorf("no existe bien con id %d", a.AssetID)
+		}
+
+		assets = append(assets, models.CustomerAsset{
+			AssetID:     a.AssetID,
+			Asset:       *assetType,
+			CustomerID:  customer.ID,
+			MarketValue: a.MarketValue,
+			Description: a.Description,
+		})
+	}
+
+	creditRequest := models.CreditRequest{
+		Amount:       input.Amount,
+		TermMonths:   input.TermMonths,
+		InterestRate: input.InterestRate,
+		CustomerID:   customer.ID,
+		ProductType:  input.ProductType,
+	}
+
+	if input.CreditProductID != 0 {
+		if err := s.applyProduct(&creditRequest, input.CreditProductID); err != nil {
+			return nil, err
+		}
+	}
+
+	return s.riskEvaluator.Evaluate(customer, creditRequest, otherCredits, assets)
+}
+
+// applyProduct valida el producto con las mismas reglas con que se radica una
+// solicitud y lo asigna a la solicitud hipotética.
+func (s *RiskSimulationService) applyProduct(creditRequest *models.CreditRequest, productID uint) error {
+	product, err := s.creditProductRepo.FindByID(productID)
+	if err != nil {
+		return err
+	}
+	if product == nil {
+		return fmt.Errorf("no existe producto de crédito con id %d", productID)
+	}
+
+	if !product.Status {
+		return fmt.Errorf("el producto de crédito %s no está activo", product.Code)
+	}
+
+	if creditRequest.Amount < product.MinAmount || creditRequest.Amount > product.MaxAmount {
+		return fmt.Errorf("el monto %.0f está fuera del rango del producto %s (%.0f - %.0f)",
+			creditRequest.Amount, product.Code, product.MinAmount, product.MaxAmount)
+	}
+
+	if !product.AllowsTerm(creditRequest.TermMonths) {
+		return fmt.Errorf("el plazo de %d meses no está permitido para el producto %s (plazos permitidos: %v)",
+			creditRequest.TermMonths, product.Code, []int(product.AllowedTerms))
+	}
+
+	creditRequest.CreditProductID = &product.ID
+	creditRequest.CreditProduct = product
+	creditRequest.ProductType = product.Name
+	return nil
+}
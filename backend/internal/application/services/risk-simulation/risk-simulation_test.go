@@ -0,0 +1,171 @@
+package riskSimulation
+
+import (
+	"testing"
+
+	"github.com/JhonCamargo53/prueba-tecnica/internal/domain/models"
+	"github.com/JhonCamargo53/prueba-tecnica/internal/domain/policy"
+)
+
+func TestSimulate_ClienteExistente_UsaHistorialSinGuardar(t *testing.T) {
+	customerRepo := NewMockCustomerRepository([]*models.Customer{
+		{ID: 1, Name: "Cliente", MonthlyIncome: 5_000_000},
+	})
+	creditRequestRepo := NewMockCreditRequestRepository([]models.CreditRequest{
+		{ID: 10, CustomerID: 1, CreditStatusID: 2},
+		{ID: 11, CustomerID: 2, CreditStatusID: 3},
+	})
+	assetRepo := NewMockAssetRepository([]models.Asset{
+		{ID: 1, Name: "INMUEBLE", CollateralEligible: true, CollateralHaircut: 0.3, RealEstate: true},
+	})
+	evaluator := &MockRiskEvaluator{Score: 71, Category: "MEDIUM"}
+
+	service := NewRiskSimulationService(customerRepo, creditRequestRepo, assetRepo,
+		NewMockCreditProductRepository(nil), evaluator)
+
+	assessment, err := service.Simulate(SimulationInput{
+		CustomerID:  1,
+		Amount:      20_000_000,
+		TermMonths:  48,
+		ProductType: "Vivienda",
+		Assets:      []SimulationAsset{{AssetID: 1, MarketValue: 80_000_000}},
+	})
+	if err != nil {
+		t.Fatalf("no se esperaba error: %v", err)
+	}
+	if assessment.Score != 71 {
+		t.Errorf("se esperaba score 71, obtenido: %.1f", assessment.Score)
+	}
+
+	if len(evaluator.LastOtherCredits) != 1 || evaluator.LastOtherCredits[0].ID != 10 {
+		t.Errorf("se esperaba el historial del cliente 1, obtenido: %+v", evaluator.LastOtherCredits)
+	}
+	if len(evaluator.LastAssets) != 1 || !evaluator.LastAssets[0].Asset.RealEstate {
+		t.Errorf("se esperaba el activo hipotético con la política de su tipo, obtenido: %+v", evaluator.LastAssets)
+	}
+	if evaluator.LastRequest.Amount != 20_000_000 || evaluator.LastRequest.TermMonths != 48 {
+		t.Errorf("solicitud simulada inesperada: %+v", evaluator.LastRequest)
+	}
+	if creditRequestRepo.WriteCalled {
+		t.Errorf("la simulación no debería escribir en el repositorio")
+	}
+}
+
+func TestSimulate_ClienteEnLinea(t *testing.T) {
+	evaluator := &MockRiskEvaluator{Score: 60, Category: "MEDIUM"}
+	service := NewRiskSimulationService(NewMockCustomerRepository(nil), NewMockCreditRequestRepository(nil),
+		NewMockAssetRepository(nil), NewMockCreditProductRepository(nil), evaluator)
+
+	_, err := service.Simulate(SimulationInput{
+		Customer:   &models.Customer{MonthlyIncome: 3_000_000},
+		Amount:     5_000_000,
+		TermMonths: 12,
+	})
+	if err != nil {
+		t.Fatalf("no se esperaba error: %v", err)
+	}
+	if evaluator.LastCustomer.MonthlyIncome != 3_000_000 || len(evaluator.LastOtherCredits) != 0 {
+		t.Errorf("se esperaban los datos en línea sin historial, obtenido: %+v", evaluator.LastCustomer)
+	}
+}
+
+func TestSimulate_ClienteEnLineaConPoliticas(t *testing.T) {
+	evaluator := &MockRiskEvaluator{Score: 64, Category: "MEDIUM"}
+	service := NewRiskSimulationService(NewMockCustomerRepository(nil), NewMockCreditRequestRepository(nil),
+		NewMockAssetRepository(nil), NewMockCreditProductRepository(nil),
+		policy.NewPolicyRiskEvaluator(policy.DefaultRules(), evaluator))
+
+	assessment, err := service.Simulate(SimulationInput{
+		Customer:    &models.Customer{Name: "Cliente", MonthlyIncome: 3_000_000},
+		Amount:      5_000_000,
+		TermMonths:  12,
+		ProductType: "Libre inversión",
+	})
+	if err != nil {
+		t.Fatalf("no se esperaba error: %v", err)
+	}
+	if len(assessment.KnockOuts) != 0 {
+		t.Fatalf("no se esperaban rechazos por políticas: %+v", assessment.KnockOuts)
+	}
+	if !evaluator.Called || assessment.Score != 64 {
+		t.Errorf("se esperaba el puntaje del motor, obtenido: %+v", assessment)
+	}
+}
+
+func TestSimulate_ProductoDelCatalogo(t *testing.T) {
+	productRepo := NewMockCreditProductRepository([]*models.CreditProduct{
+		{ID: 1, Code: "HOUSING", Name: "Vivienda", MinAmount: 10_000_000, MaxAmount: 500_000_000,
+			AllowedTerms: models.TermList{120, 240}, BaseAnnualRate: 13.5, RiskWeight: 0.8, Status: true},
+		{ID: 2, Code: "OLD", Name: "Producto anterior", MinAmount: 1, MaxAmount: 10_000_000,
+			AllowedTerms: models.TermList{12}, Status: false},
+	})
+	evaluator := &MockRiskEvaluator{Score: 80, Category: "LOW"}
+	service := NewRiskSimulationService(NewMockCustomerRepository(nil), NewMockCreditRequestRepository(nil),
+		NewMockAssetRepository(nil), productRepo, policy.NewPolicyRiskEvaluator(policy.DefaultRules(), evaluator))
+
+	// 40 veces el ingreso supera el límite general pero no el de vivienda
+	assessment, err := service.Simulate(SimulationInput{
+		Customer:        &models.Customer{MonthlyIncome: 5_000_000},
+		Amount:          200_000_000,
+		TermMonths:      240,
+		ProductType:     "Libre inversión",
+		CreditProductID: 1,
+	})
+	if err != nil {
+		t.Fatalf("no se esperaba error: %v", err)
+	}
+	if len(assessment.KnockOuts) != 0 {
+		t.Fatalf("se esperaban las políticas del producto, rechazos: %+v", assessment.KnockOuts)
+	}
+	request := evaluator.LastRequest
+	if request.CreditProduct == nil || request.CreditProduct.RiskWeight != 0.8 || request.CreditProduct.BaseAnnualRate != 13.5 ||
+		request.ProductType != "Vivienda" {
+		t.Errorf("se esperaba simular con el producto del catálogo, obtenido: %+v", request)
+	}
+
+	cases := map[string]struct {
+		productID  uint
+		termMonths int
+	}{
+		"producto inexistente": {9, 120},
+		"producto inactivo":    {2, 12},
+		"plazo no permitido":   {1, 60},
+	}
+	for name, c := range cases {
+		_, err := service.Simulate(SimulationInput{Customer: &models.Customer{MonthlyIncome: 5_000_000},
+			Amount: 20_000_000, TermMonths: c.termMonths, CreditProductID: c.productID})
+		if err == nil {
+			t.Errorf("%s: se esperaba error", name)
+		}
+	}
+}
+
+func TestSimulate_ClienteNoExiste(t *testing.T) {
+	evaluator := &MockRiskEvaluator{}
+	service := NewRiskSimulationService(NewMockCustomerRepository(nil), NewMockCreditRequestRepository(nil),
+		NewMockAssetRepository(nil), NewMockCreditProductRepository(nil), evaluator)
+
+	_, err := service.Simulate(SimulationInput{CustomerID: 99, Amount: 1_000_000, TermMonths: 12})
+	if err == nil {
+		t.Fatalf("se esperaba error porque el cliente no existe")
+	}
+	if evaluator.Called {
+		t.Errorf("no se debería llamar al evaluador")
+	}
+}
+
+func TestSimulate_ActivoNoExiste(t *testing.T) {
+	evaluator := &MockRiskEvaluator{}
+	service := NewRiskSimulationService(NewMockCustomerRepository(nil), NewMockCreditRequestRepository(nil),
+		NewMockAssetRepository(nil), NewMockCreditProductRepository(nil), evaluator)
+
+	_, err := service.Simulate(SimulationInput{
+		Customer:   &models.Customer{MonthlyIncome: 3_000_000},
+		Amount:     5_000_000,
+		TermMonths: 12,
+		Assets:     []SimulationAsset{{AssetID: 7, MarketValue: 1_000_000}},
+	})
+	if err == nil {
+		t.Fatalf("se esperaba error porque el tipo de activo no existe")
+	}
+}
@@ -3,6 +3,8 @@ package config
 import (
 	"log"
 	"os"
+	"strconv"
+	"strings"
 
 	"github.com/joho/godotenv"
 )
@@ -38,11 +40,103 @@ func getEnv(key, fallback string) string {
 	return fallback
 }
 
+func getEnvInt(key string, fallback int) int {
+	if value := os.Getenv(key); value != "" {
+		if parsed, err := strconv.Atoi(value); err == nil {
+			return parsed
+		}
+		log.Printf("Valor inválido para %s: %s, se usa %d", key, value, fallback)
+	}
+	return fallback
+}
+
+func getEnvFloat(key string, fallback float64) float64 {
+	if value := os.Getenv(key); value != "" {
+		if parsed, err := strconv.ParseFloat(value, 64); err == nil {
+			return parsed
+		}
+		log.Printf("Valor inválido para %s: %s, se usa %g", key, value, fallback)
+	}
+	return fallback
+}
+
+func getEnvList(key string) []string {
+	var values []string
+	for _, value := range strings.Split(os.Getenv(key), ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
 type Config struct {
 	ENV          string
 	Port         string
 	DatabaseURL  string
 	JWTSecretKey string
+
+	// Ruta del archivo JSON de reglas del motor de riesgo (vacío = reglas embebidas)
+	RiskRulesPath string
+	// Intervalo en segundos para revisar cambios del archivo de reglas (0 = sin recarga)
+	RiskRulesReloadSeconds int
+
+	// Archivo JSON de políticas de rechazo automático (vacío = políticas embebidas)
+	RiskPolicyPath string
+	// Archivo JSON de la política de precios según el riesgo (vacío = política embebida)
+	RiskPricingPath string
+
+	// Motor cuyo resultado se guarda en la solicitud
+	RiskChampionEngine string
+	// Motores challenger separados por coma que se ejecutan en modo sombra
+	RiskShadowEngines []string
+	// Archivo de reglas del motor "mock-challenger" (vacío = no se registra)
+	RiskChallengerRulesPath string
+	// Archivo JSON del motor "scorecard" (vacío = scorecard embebido)
+	RiskScorecardPath string
+
+	// Servicio externo de scoring, motor "http" (URL vacía = no se registra)
+	RiskHTTPURL            string
+	RiskHTTPAPIKey         string
+	RiskHTTPTimeoutMs      int
+	RiskHTTPRetries        int
+	RiskHTTPFallbackEngine string
+
+	// Archivo JSON del grafo de estados de las solicitudes (vacío = grafo embebido)
+	CreditWorkflowPath string
+	// Archivo JSON de atribuciones de aprobación por rol y producto (vacío = atribuciones embebidas)
+	CreditAuthorityPath string
+
+	// Monto por encima del cual una decisión contraria al motor requiere confirmación
+	CreditOverrideReviewAmount float64
+
+	// Monitoreo de estabilidad poblacional (PSI/CSI)
+	// Intervalo en horas del cálculo programado (0 = sin cálculo programado)
+	RiskDriftIntervalHours int
+	// Días del período actual, que termina en el momento del cálculo
+	RiskDriftWindowDays int
+	// Período base (YYYY-MM-DD, fin inclusivo); vacío = período anterior de igual duración
+	RiskDriftBaselineFrom string
+	RiskDriftBaselineTo   string
+	RiskDriftBins         int
+	RiskDriftMinSample    int
+	RiskDriftWarningPSI   float64
+	RiskDriftAlertPSI     float64
+
+	// Intervalo en horas del cálculo de mora de la cartera (0 = sin cálculo programado)
+	LoanDelinquencyIntervalHours int
+
+	// Directorio del almacenamiento local de documentos adjuntos
+	DocumentStoragePath string
+	// Tamaño máximo en MB de un documento adjunto
+	DocumentMaxSizeMB int
+
+	// Clave privada Ed25519 en base64 con la que se firman los reportes de riesgo;
+	// si está vacía se usa (o se genera) la clave guardada en RiskReportKeyPath
+	RiskReportSigningKey string
+	RiskReportKeyPath    string
+	// Claves públicas en base64, separadas por coma, de las claves anteriores a una rotación
+	RiskReportTrustedKeys []string
 }
 
 func Load() *Config {
@@ -59,5 +153,45 @@ func Load() *Config {
 		),
 
 		JWTSecretKey: getEnv("JWT_SECRET_KEY", "default-secret-key"),
+
+		RiskRulesPath:          getEnv("RISK_RULES_PATH", ""),
+		RiskRulesReloadSeconds: getEnvInt("RISK_RULES_RELOAD_SECONDS", 30),
+
+		RiskPolicyPath:  getEnv("RISK_POLICY_PATH", ""),
+		RiskPricingPath: getEnv("RISK_PRICING_PATH", ""),
+
+		RiskChampionEngine:      getEnv("RISK_CHAMPION_ENGINE", "mock"),
+		RiskShadowEngines:       getEnvList("RISK_SHADOW_ENGINES"),
+		RiskChallengerRulesPath: getEnv("RISK_CHALLENGER_RULES_PATH", ""),
+		RiskScorecardPath:       getEnv("RISK_SCORECARD_PATH", ""),
+
+		RiskHTTPURL:            getEnv("RISK_HTTP_URL", ""),
+		RiskHTTPAPIKey:         getEnv("RISK_HTTP_API_KEY", ""),
+		RiskHTTPTimeoutMs:      getEnvInt("RISK_HTTP_TIMEOUT_MS", 2000),
+		RiskHTTPRetries:        getEnvInt("RISK_HTTP_RETRIES", 2),
+		RiskHTTPFallbackEngine: getEnv("RISK_HTTP_FALLBACK_ENGINE", "mock"),
+
+		CreditWorkflowPath:  getEnv("CREDIT_WORKFLOW_PATH", ""),
+		CreditAuthorityPath: getEnv("CREDIT_AUTHORITY_PATH", ""),
+
+		CreditOverrideReviewAmount: getEnvFloat("CREDIT_OVERRIDE_REVIEW_AMOUNT", 50_000_000),
+
+		LoanDelinquencyIntervalHours: getEnvInt("LOAN_DELINQUENCY_INTERVAL_HOURS", 24),
+
+		DocumentStoragePath: getEnv("DOCUMENT_STORAGE_PATH", "storage/documents"),
+		DocumentMaxSizeMB:   getEnvInt("DOCUMENT_MAX_SIZE_MB", 10),
+
+		RiskReportSigningKey:  getEnv("RISK_REPORT_SIGNING_KEY", ""),
+		RiskReportKeyPath:     getEnv("RISK_REPORT_KEY_PATH", "storage/keys/risk-report.key"),
+		RiskReportTrustedKeys: getEnvList("RISK_REPORT_TRUSTED_KEYS"),
+
+		RiskDriftIntervalHours: getEnvInt("RISK_DRIFT_INTERVAL_HOURS", 24),
+		RiskDriftWindowDays:    getEnvInt("RISK_DRIFT_WINDOW_DAYS", 30),
+		RiskDriftBaselineFrom:  getEnv("RISK_DRIFT_BASELINE_FROM", ""),
+		RiskDriftBaselineTo:    getEnv("RISK_DRIFT_BASELINE_TO", ""),
+		RiskDriftBins:          getEnvInt("RISK_DRIFT_BINS", 10),
+		RiskDriftMinSample:     getEnvInt("RISK_DRIFT_MIN_SAMPLE", 30),
+		RiskDriftWarningPSI:    getEnvFloat("RISK_DRIFT_WARNING_PSI", 0.1),
+		RiskDriftAlertPSI:      getEnvFloat("RISK_DRIFT_ALERT_PSI", 0.25),
 	}
 }
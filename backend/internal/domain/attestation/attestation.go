@@ -0,0 +1,351 @@
+package attestation
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/JhonCamargo53/prueba-tecnica/internal/domain/models"
+)
+
+/*
+
+Reportes de riesgo firmados y encadenados. Cada evaluación genera un
+reporte cuyo contenido (Payload) se serializa en JSON canónico: claves en
+orden alfabético, sin espacios ni escape HTML. El hash del reporte es el
+SHA-256 de ese JSON y la firma es Ed25519 sobre los mismos bytes. Como el
+contenido incluye el hash del reporte anterior, alterar o eliminar un
+reporte rompe el enlace de todos los siguientes.
+
+*/
+
+// GenesisHash es el hash anterior del primer reporte de la cadena.
+var GenesisHash = strings.Repeat("0", 64)
+
+// Verificaciones de un reporte
+const (
+	CheckHash          = "HASH"
+	CheckSignature     = "SIGNATURE"
+	CheckChain         = "CHAIN"
+	CheckEvaluation    = "EVALUATION"
+	CheckCreditRequest = "CREDIT_REQUEST"
+	CheckCoverage      = "COVERAGE"
+)
+
+// Payload es el contenido firmado de un reporte. Los campos se declaran en orden
+// alfabético de su clave JSON para que la serialización sea canónica.
+type Payload struct {
+	Category         string  `json:"category"`
+	CreditRequestID  uint    `json:"creditRequestId"`
+	EngineVersion    string  `json:"engineVersion"`
+	Explanation      string  `json:"explanation"`
+	InputsHash       string  `json:"inputsHash"`
+	IssuedAt         string  `json:"issuedAt"`
+	PreviousHash     string  `json:"previousHash"`
+	RiskEvaluationID uint    `json:"riskEvaluationId"`
+	Score            float64 `json:"score"`
+	Sequence         uint64  `json:"sequence"`
+}
+
+// Check es el resultado de una verificación de un reporte.
+type Check struct {
+	Name   string `json:"name"`
+	Passed bool   `json:"passed"`
+	Detail string `json:"detail,omitempty"`
+}
+
+// NewPayload arma el contenido del reporte de una evaluación.
+func NewPayload(evaluation models.RiskEvaluation, sequence uint64, previousHash string, issuedAt time.Time) (Payload, error) {
+	inputsHash, err := InputsHash(evaluation.InputSnapshot)
+	if err != nil {
+		return Payload{}, err
+	}
+
+	return Payload{
+		Category:         evaluation.Category,
+		CreditRequestID:  evaluation.CreditRequestID,
+		EngineVersion:    evaluation.EngineVersion,
+		Explanation:      evaluation.Explanation,
+		InputsHash:       inputsHash,
+		IssuedAt:         issuedAt.UTC().Format(time.RFC3339Nano),
+		PreviousHash:     previousHash,
+		RiskEvaluationID: evaluation.ID,
+		Score:            RoundScore(evaluation.Score),
+		Sequence:         sequence,
+	}, nil
+}
+
+// RoundScore redondea el puntaje a cuatro decimales: Postgres guarda los decimales
+// con menos dígitos significativos que un float64 y el reporte debe sobrevivir la ida y vuelta.
+func RoundScore(score float64) float64 {
+	return math.Round(score*1e4) / 1e4
+}
+
+// InputsHash retorna el SHA-256 del snapshot de entrada en forma canónica. El snapshot
+// se normaliza porque jsonb no conserva el orden de las claves ni los espacios.
+func InputsHash(snapshot []byte) (string, error) {
+	if len(snapshot) == 0 {
+		return Hash(nil), nil
+	}
+
+	var value interface{}
+	if err := json.Unmarshal(snapshot, &value); err != nil {
+		return "", fmt.Errorf("snapshot de entrada inválido: %w", err)
+	}
+	canonical, err := marshal(value)
+	if err != nil {
+		return "", err
+	}
+	return Hash(canonical), nil
+}
+
+// Canonical retorna los bytes del contenido que se firman y se encadenan.
+func Canonical(payload Payload) ([]byte, error) {
+	return marshal(payload)
+}
+
+// ParsePayload lee el contenido de un reporte; falla si no está en forma canónica.
+func ParsePayload(data string) (Payload, error) {
+	var payload Payload
+	decoder := json.NewDecoder(strings.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&payload); err != nil {
+		return Payload{}, fmt.Errorf("contenido del reporte inválido: %w", err)
+	}
+
+	canonical, err := Canonical(payload)
+	if err != nil {
+		return Payload{}, err
+	}
+	if string(canonical) != data {
+		return Payload{}, fmt.Errorf("el contenido del reporte no está en forma canónica")
+	}
+	return payload, nil
+}
+
+// Hash retorna el SHA-256 en hexadecimal.
+func Hash(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// Sign firma los bytes canónicos y retorna la firma en base64.
+func Sign(key ed25519.PrivateKey, canonical []byte) string {
+	return base64.StdEncoding.EncodeToString(ed25519.Sign(key, canonical))
+}
+
+// KeyID identifica una clave pública: los primeros 16 caracteres hexadecimales de su SHA-256.
+func KeyID(key ed25519.PublicKey) string {
+	return Hash(key)[:16]
+}
+
+// Keyring reúne las claves públicas de confianza por KeyID.
+type Keyring map[string]ed25519.PublicKey
+
+func NewKeyring(keys ...ed25519.PublicKey) Keyring {
+	keyring := Keyring{}
+	for _, key := range keys {
+		keyring[KeyID(key)] = key
+	}
+	return keyring
+}
+
+// ParsePublicKey lee una clave pública Ed25519 en base64.
+func ParsePublicKey(encoded string) (ed25519.PublicKey, error) {
+	data, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encoded))
+	if err != nil || len(data) != ed25519.PublicKeySize {
+		return nil, fmt.Errorf("clave pública Ed25519 inválida: se esperan %d bytes en base64", ed25519.PublicKeySize)
+	}
+	return ed25519.PublicKey(data), nil
+}
+
+// ParsePrivateKey lee una clave privada Ed25519 en base64: la semilla de 32 bytes
+// o la clave completa de 64.
+func ParsePrivateKey(encoded string) (ed25519.PrivateKey, error) {
+	data, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encoded))
+	if err != nil {
+		return nil, fmt.Errorf("clave privada Ed25519 inválida: no está en base64")
+	}
+	switch len(data) {
+	case ed25519.SeedSize:
+		return ed25519.NewKeyFromSeed(data), nil
+	case ed25519.PrivateKeySize:
+		return ed25519.PrivateKey(data), nil
+	}
+	return nil, fmt.Errorf("clave privada Ed25519 inválida: se esperan %d o %d bytes", ed25519.SeedSize, ed25519.PrivateKeySize)
+}
+
+// VerifyReport verifica el hash y la firma del reporte y su enlace con el reporte
+// anterior (nil para el primero de la cadena). Retorna también el contenido leído.
+func VerifyReport(report models.RiskReport, previous *models.RiskReport, keys Keyring) (*Payload, []Check) {
+	payload, err := ParsePayload(report.Payload)
+	if err != nil {
+		return nil, []Check{{Name: CheckHash, Detail: err.Error()}}
+	}
+
+	checks := make([]Check, 0, 3)
+
+	hash := Hash([]byte(report.Payload))
+	hashCheck := Check{Name: CheckHash, Passed: hash == report.Hash}
+	if !hashCheck.Passed {
+		hashCheck.Detail = fmt.Sprintf("el hash del contenido es %s y el registrado es %s", hash, report.Hash)
+	}
+	checks = append(checks, hashCheck)
+
+	checks = append(checks, verifySignature(report, keys))
+
+	chainCheck := Check{Name: CheckChain, Passed: true}
+	expectedPrevious, expectedSequence := GenesisHash, uint64(1)
+	if previous != nil {
+		expectedPrevious, expectedSequence = previous.Hash, previous.Sequence+1
+	}
+	switch {
+	case payload.Sequence != report.Sequence || report.Sequence != expectedSequence:
+		chainCheck = Check{Name: CheckChain, Detail: fmt.Sprintf("secuencia %d (registrada %d), se esperaba %d",
+			payload.Sequence, report.Sequence, expectedSequence)}
+	case payload.PreviousHash != report.PreviousHash || payload.PreviousHash != expectedPrevious:
+		chainCheck = Check{Name: CheckChain, Detail: fmt.Sprintf("el hash anterior %s no coincide con el del reporte anterior %s",
+			payload.PreviousHash, expectedPrevious)}
+	}
+	checks = append(checks, chainCheck)
+
+	return &payload, checks
+}
+
+// CompareEvaluation verifica que la evaluación guardada siga coincidiendo con el contenido firmado.
+func CompareEvaluation(payload Payload, evaluation models.RiskEvaluation) Check {
+	inputsHash, err := InputsHash(evaluation.InputSnapshot)
+	if err != nil {
+		return Check{Name: CheckEvaluation, Detail: err.Error()}
+	}
+
+	var differences []string
+	if evaluation.ID != payload.RiskEvaluationID || evaluation.CreditRequestID != payload.CreditRequestID {
+		differences = append(differences, "evaluación o solicitud")
+	}
+	if RoundScore(evaluation.Score) != payload.Score {
+		differences = append(differences, "puntaje")
+	}
+	if evaluation.Category != payload.Category {
+		differences = append(differences, "categoría")
+	}
+	if evaluation.Explanation != payload.Explanation {
+		differences = append(differences, "explicación")
+	}
+	if evaluation.EngineVersion != payload.EngineVersion {
+		differences = append(differences, "versión del motor")
+	}
+	if inputsHash != payload.InputsHash {
+		differences = append(differences, "datos de entrada")
+	}
+
+	return difference(CheckEvaluation, "la evaluación guardada", differences)
+}
+
+// CompareCreditRequest verifica que el riesgo vigente de la solicitud coincida con el contenido firmado.
+func CompareCreditRequest(payload Payload, creditRequest models.CreditRequest) Check {
+	var differences []string
+	if RoundScore(creditRequest.RiskScore) != payload.Score {
+		differences = append(differences, "puntaje")
+	}
+	if creditRequest.RiskCategory != payload.Category {
+		differences = append(differences, "categoría")
+	}
+	if creditRequest.RiskExplanation != payload.Explanation {
+		differences = append(differences, "explicación")
+	}
+
+	return difference(CheckCreditRequest, "la solicitud", differences)
+}
+
+// CompareCoverage verifica que cada evaluación tenga su reporte firmado. Una
+// evaluación sin reporte no aparece en la cadena, así que sólo se detecta así.
+func CompareCoverage(evaluations []models.RiskEvaluation, reports []models.RiskReport) Check {
+	reported := make(map[uint]bool, len(reports))
+	for _, report := range reports {
+		reported[report.RiskEvaluationID] = true
+	}
+
+	var missing []string
+	for _, evaluation := range evaluations {
+		if !reported[evaluation.ID] {
+			missing = append(missing, fmt.Sprint(evaluation.ID))
+		}
+	}
+	if len(missing) == 0 {
+		return Check{Name: CheckCoverage, Passed: true}
+	}
+	return Check{Name: CheckCoverage, Detail: "evaluaciones sin reporte firmado: " + strings.Join(missing, ", ")}
+}
+
+// ChainProblem describe un reporte de la cadena que no pasó alguna verificación.
+type ChainProblem struct {
+	Sequence uint64  `json:"sequence"`
+	ReportID uint    `json:"reportId"`
+	Checks   []Check `json:"checks"`
+}
+
+// VerifyChain verifica una cadena completa, ordenada por secuencia. Retorna los reportes con problemas.
+func VerifyChain(reports []models.RiskReport, keys Keyring) []ChainProblem {
+	var problems []ChainProblem
+	var previous *models.RiskReport
+
+	for i := range reports {
+		_, checks := VerifyReport(reports[i], previous, keys)
+		if failed := Failed(checks); len(failed) > 0 {
+			problems = append(problems, ChainProblem{Sequence: reports[i].Sequence, ReportID: reports[i].ID, Checks: failed})
+		}
+		previous = &reports[i]
+	}
+
+	return problems
+}
+
+// Failed retorna las verificaciones que no pasaron.
+func Failed(checks []Check) []Check {
+	var failed []Check
+	for _, check := range checks {
+		if !check.Passed {
+			failed = append(failed, check)
+		}
+	}
+	return failed
+}
+
+func verifySignature(report models.RiskReport, keys Keyring) Check {
+	key, ok := keys[report.KeyID]
+	if !ok {
+		return Check{Name: CheckSignature, Detail: fmt.Sprintf("la clave %s no es de confianza", report.KeyID)}
+	}
+
+	signature, err := base64.StdEncoding.DecodeString(report.Signature)
+	if err != nil || !ed25519.Verify(key, []byte(report.Payload), signature) {
+		return Check{Name: CheckSignature, Detail: "la firma no corresponde al contenido del reporte"}
+	}
+
+	return Check{Name: CheckSignature, Passed: true}
+}
+
+func difference(name, subject string, differences []string) Check {
+	if len(differences) == 0 {
+		return Check{Name: name, Passed: true}
+	}
+	return Check{Name: name, Detail: fmt.Sprintf("%s difiere del reporte firmado en: %s", subject, strings.Join(differences, ", "))}
+}
+
+func marshal(value interface{}) ([]byte, error) {
+	var buffer bytes.Buffer
+	encoder := json.NewEncoder(&buffer)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(value); err != nil {
+		return nil, err
+	}
+	return bytes.TrimSuffix(buffer.Bytes(), []byte("\n")), nil
+}
@@ -0,0 +1,151 @@
+package attestation
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"encoding/base64"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/JhonCamargo53/prueba-tecnica/internal/domain/models"
+)
+
+var signingKey = ed25519.NewKeyFromSeed(bytes.Repeat([]byte{3}, ed25519.SeedSize))
+
+// chain arma una cadena firmada con una evaluación por puntaje.
+func chain(t *testing.T, scores ...float64) []models.RiskReport {
+	t.Helper()
+
+	var reports []models.RiskReport
+	previousHash := GenesisHash
+	for i, score := range scores {
+		evaluation := models.RiskEvaluation{ID: uint(i + 1), CreditRequestID: 10, Score: score, Category: "LOW",
+			Explanation: "Ingreso <alto> & estable", InputSnapshot: models.JSONB(`{"amount":1000}`)}
+
+		payload, err := NewPayload(evaluation, uint64(i+1), previousHash, time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC))
+		if err != nil {
+			t.Fatalf("no se esperaba error: %v", err)
+		}
+		canonical, _ := Canonical(payload)
+
+		report := models.RiskReport{ID: uint(i + 1), Sequence: payload.Sequence, RiskEvaluationID: evaluation.ID,
+			CreditRequestID: 10, Payload: string(canonical), PreviousHash: previousHash, Hash: Hash(canonical),
+			Signature: Sign(signingKey, canonical), KeyID: KeyID(signingKey.Public().(ed25519.PublicKey))}
+		reports = append(reports, report)
+		previousHash = report.Hash
+	}
+	return reports
+}
+
+func TestCanonical_ClavesOrdenadasSinEscapeHTML(t *testing.T) {
+	reports := chain(t, 80.123456789)
+
+	want := `{"category":"LOW","creditRequestId":10,"engineVersion":"","explanation":"Ingreso <alto> & estable",` +
+		`"inputsHash":"` + Hash([]byte(`{"amount":1000}`)) + `","issuedAt":"2026-01-02T03:04:05Z",` +
+		`"previousHash":"` + GenesisHash + `","riskEvaluationId":1,"score":80.1235,"sequence":1}`
+	if reports[0].Payload != want {
+		t.Errorf("contenido canónico inesperado:\n%s\n%s", reports[0].Payload, want)
+	}
+
+	if _, err := ParsePayload(reports[0].Payload); err != nil {
+		t.Errorf("no se esperaba error al leer el contenido canónico: %v", err)
+	}
+	if _, err := ParsePayload(strings.Replace(reports[0].Payload, `"category":"LOW",`, `"category": "LOW",`, 1)); err == nil {
+		t.Errorf("se esperaba rechazar un contenido que no está en forma canónica")
+	}
+}
+
+func TestInputsHash_IgnoraOrdenYEspacios(t *testing.T) {
+	a, _ := InputsHash([]byte(`{"amount": 1000, "assets": [{"id": 1, "marketValue": 2.5}]}`))
+	b, _ := InputsHash([]byte(`{"assets":[{"marketValue":2.5,"id":1}],"amount":1000}`))
+	if a != b {
+		t.Errorf("se esperaba el mismo hash para el mismo snapshot")
+	}
+
+	c, _ := InputsHash([]byte(`{"amount":1001,"assets":[{"id":1,"marketValue":2.5}]}`))
+	if a == c {
+		t.Errorf("se esperaba un hash distinto para un snapshot distinto")
+	}
+
+	if _, err := InputsHash([]byte(`{"amount":`)); err == nil {
+		t.Errorf("se esperaba error con un snapshot inválido")
+	}
+}
+
+func TestVerifyChain_DetectaAlteraciones(t *testing.T) {
+	keys := NewKeyring(signingKey.Public().(ed25519.PublicKey))
+
+	if problems := VerifyChain(chain(t, 10, 20, 30), keys); len(problems) != 0 {
+		t.Fatalf("no se esperaban problemas: %+v", problems)
+	}
+
+	cases := map[string]struct {
+		alter func([]models.RiskReport) []models.RiskReport
+		want  map[uint64]string
+	}{
+		"puntaje alterado": {func(r []models.RiskReport) []models.RiskReport {
+			r[1].Payload = strings.Replace(r[1].Payload, `"score":20`, `"score":25`, 1)
+			return r
+		}, map[uint64]string{2: "HASH,SIGNATURE"}},
+		"hash recalculado": {func(r []models.RiskReport) []models.RiskReport {
+			r[1].Payload = strings.Replace(r[1].Payload, `"score":20`, `"score":25`, 1)
+			r[1].Hash = Hash([]byte(r[1].Payload))
+			return r
+		}, map[uint64]string{2: "SIGNATURE", 3: "CHAIN"}},
+		"reporte eliminado": {func(r []models.RiskReport) []models.RiskReport {
+			return append(r[:1], r[2:]...)
+		}, map[uint64]string{3: "CHAIN"}},
+		"firma de otra clave": {func(r []models.RiskReport) []models.RiskReport {
+			other := ed25519.NewKeyFromSeed(bytes.Repeat([]byte{4}, ed25519.SeedSize))
+			r[0].Signature = Sign(other, []byte(r[0].Payload))
+			return r
+		}, map[uint64]string{1: "SIGNATURE"}},
+	}
+
+	for name, c := range cases {
+		problems := VerifyChain(c.alter(chain(t, 10, 20, 30)), keys)
+
+		got := map[uint64]string{}
+		for _, problem := range problems {
+			var names []string
+			for _, check := range problem.Checks {
+				names = append(names, check.Name)
+			}
+			got[problem.Sequence] = strings.Join(names, ",")
+		}
+		if len(got) != len(c.want) {
+			t.Errorf("%s: se esperaban problemas %v, obtenidos %v", name, c.want, got)
+			continue
+		}
+		for sequence, want := range c.want {
+			if got[sequence] != want {
+				t.Errorf("%s: se esperaban problemas %v, obtenidos %v", name, c.want, got)
+			}
+		}
+	}
+}
+
+func TestParseKeys(t *testing.T) {
+	seed := bytes.Repeat([]byte{3}, ed25519.SeedSize)
+
+	fromSeed, err := ParsePrivateKey(base64.StdEncoding.EncodeToString(seed))
+	if err != nil || !fromSeed.Equal(signingKey) {
+		t.Errorf("se esperaba la clave de la semilla, obtenido: %v", err)
+	}
+	full, err := ParsePrivateKey(base64.StdEncoding.EncodeToString(signingKey))
+	if err != nil || !full.Equal(signingKey) {
+		t.Errorf("se esperaba la clave completa, obtenido: %v", err)
+	}
+	if _, err := ParsePrivateKey(base64.StdEncoding.EncodeToString(seed[:10])); err == nil {
+		t.Errorf("se esperaba error con una clave de tamaño inválido")
+	}
+
+	public, err := ParsePublicKey(" " + base64.StdEncoding.EncodeToString(signingKey.Public().(ed25519.PublicKey)) + "\n")
+	if err != nil || KeyID(public) != KeyID(signingKey.Public().(ed25519.PublicKey)) {
+		t.Errorf("se esperaba la clave pública, obtenido: %v", err)
+	}
+	if _, err := ParsePublicKey("no-es-base64"); err == nil {
+		t.Errorf("se esperaba error con una clave pública inválida")
+	}
+}
@@ -0,0 +1,222 @@
+package authority
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/JhonCamargo53/prueba-tecnica/internal/domain/models"
+)
+
+/*
+
+Atribuciones de aprobación delegadas según el nivel de acceso del rol
+(Role.Access). Cada nivel tiene un monto máximo y una categoría de riesgo
+máxima que puede aprobar, con límites distintos por producto. Una
+aprobación por encima de la atribución de quien decide se escala al menor
+nivel de acceso que la cubre.
+
+*/
+
+//go:embed default-authority.json
+var defaultAuthorityJSON []byte
+
+// Orden de las categorías de riesgo, de menor a mayor riesgo
+var categoryRank = map[string]int{
+	"LOW":    1,
+	"MEDIUM": 2,
+	"HIGH":   3,
+}
+
+type Rules struct {
+	Version  string         `json:"version"`
+	Default  []Limit        `json:"default"`
+	Products []ProductLimit `json:"products"`
+}
+
+// Limit es la atribución de los roles con nivel de acceso mayor o igual a MinAccess.
+type Limit struct {
+	MinAccess int `json:"minAccess"`
+	// Monto máximo que se puede aprobar (0 = sin límite)
+	MaxAmount   float64 `json:"maxAmount"`
+	MaxCategory string  `json:"maxCategory"`
+}
+
+// ProductLimit aplica a las solicitudes cuyo tipo de producto contiene alguna palabra clave.
+type ProductLimit struct {
+	Code     string   `json:"code"`
+	Keywords []string `json:"keywords"`
+	Limits   []Limit  `json:"limits"`
+}
+
+// DefaultRules retorna las atribuciones embebidas en el binario.
+func DefaultRules() *Rules {
+	rules, err := ParseRules(defaultAuthorityJSON)
+	if err != nil {
+		panic(fmt.Sprintf("atribuciones de aprobación por defecto inválidas: %v", err))
+	}
+	return rules
+}
+
+// LoadRules lee y valida las atribuciones desde un archivo JSON. Con ruta vacía
+// retorna las atribuciones embebidas.
+func LoadRules(path string) (*Rules, error) {
+	if path == "" {
+		return DefaultRules(), nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("no se pudo leer el archivo de atribuciones %s: %w", path, err)
+	}
+
+	rules, err := ParseRules(data)
+	if err != nil {
+		return nil, fmt.Errorf("archivo de atribuciones %s: %w", path, err)
+	}
+
+	return rules, nil
+}
+
+func ParseRules(data []byte) (*Rules, error) {
+	var rules Rules
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+
+	if err := decoder.Decode(&rules); err != nil {
+		return nil, fmt.Errorf("JSON de atribuciones inválido: %w", err)
+	}
+
+	if err := rules.Validate(); err != nil {
+		return nil, err
+	}
+
+	// Los niveles se recorren de menor a mayor acceso
+	sortLimits(rules.Default)
+	for i := range rules.Products {
+		sortLimits(rules.Products[i].Limits)
+	}
+
+	return &rules, nil
+}
+
+func (r *Rules) Validate() error {
+	if strings.TrimSpace(r.Version) == "" {
+		return fmt.Errorf("las atribuciones deben tener una versión")
+	}
+
+	if err := validateLimits("default", r.Default); err != nil {
+		return err
+	}
+
+	for i, p := range r.Products {
+		if strings.TrimSpace(p.Code) == "" {
+			return fmt.Errorf("products[%d]: debe tener código", i)
+		}
+		if len(p.Keywords) == 0 {
+			return fmt.Errorf("products[%d]: debe tener al menos una palabra clave", i)
+		}
+		if err := validateLimits(fmt.Sprintf("products[%d]", i), p.Limits); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func validateLimits(name string, limits []Limit) error {
+	if len(limits) == 0 {
+		return fmt.Errorf("%s: debe tener al menos un nivel de atribución", name)
+	}
+
+	seen := map[int]bool{}
+	for i, l := range limits {
+		if l.MinAccess <= 0 {
+			return fmt.Errorf("%s[%d]: minAccess debe ser mayor que cero", name, i)
+		}
+		if seen[l.MinAccess] {
+			return fmt.Errorf("%s[%d]: el nivel de acceso %d está repetido", name, i, l.MinAccess)
+		}
+		if l.MaxAmount < 0 {
+			return fmt.Errorf("%s[%d]: el monto máximo no puede ser negativo", name, i)
+		}
+		if _, ok := categoryRank[l.MaxCategory]; !ok {
+			return fmt.Errorf("%s[%d]: categoría %q inválida (LOW, MEDIUM o HIGH)", name, i, l.MaxCategory)
+		}
+		seen[l.MinAccess] = true
+	}
+
+	return nil
+}
+
+func sortLimits(limits []Limit) {
+	sort.Slice(limits, func(i, j int) bool { return limits[i].MinAccess < limits[j].MinAccess })
+}
+
+// LimitsFor retorna los niveles del producto del catálogo con el mismo código, del
+// primer producto cuyas palabras clave coincidan con el tipo de producto o los
+// niveles por defecto.
+func (r *Rules) LimitsFor(productCode, productType string) []Limit {
+	for _, p := range r.Products {
+		if productCode != "" && strings.EqualFold(p.Code, productCode) {
+			return p.Limits
+		}
+	}
+
+	upper := strings.ToUpper(productType)
+	for _, p := range r.Products {
+		for _, k := range p.Keywords {
+			if k != "" && strings.Contains(upper, strings.ToUpper(k)) {
+				return p.Limits
+			}
+		}
+	}
+	return r.Default
+}
+
+// LimitFor retorna la atribución del nivel de acceso para el producto (nil = no puede aprobar).
+func (r *Rules) LimitFor(productCode, productType string, access int) *Limit {
+	var res *Limit
+	limits := r.LimitsFor(productCode, productType)
+	for i := range limits {
+		if limits[i].MinAccess <= access {
+			res = &limits[i]
+		}
+	}
+	return res
+}
+
+// Covers indica si la atribución alcanza para aprobar el monto y la categoría.
+// Una solicitud sin categoría se trata como la de mayor riesgo.
+func (l Limit) Covers(amount float64, category string) bool {
+	if l.MaxAmount > 0 && amount > l.MaxAmount {
+		return false
+	}
+	rank, ok := categoryRank[category]
+	if !ok {
+		rank = categoryRank["HIGH"]
+	}
+	return rank <= categoryRank[l.MaxCategory]
+}
+
+// CanApprove indica si un rol con el nivel de acceso dado puede aprobar la solicitud.
+func (r *Rules) CanApprove(access int, creditRequest models.CreditRequest) bool {
+	limit := r.LimitFor(creditRequest.ProductCode(), creditRequest.ProductType, access)
+	return limit != nil && limit.Covers(creditRequest.Amount, creditRequest.RiskCategory)
+}
+
+// RequiredAccess retorna el menor nivel de acceso que puede aprobar la solicitud.
+// Retorna false si ningún nivel la cubre.
+func (r *Rules) RequiredAccess(creditRequest models.CreditRequest) (int, bool) {
+	for _, l := range r.LimitsFor(creditRequest.ProductCode(), creditRequest.ProductType) {
+		if l.Covers(creditRequest.Amount, creditRequest.RiskCategory) {
+			return l.MinAccess, true
+		}
+	}
+	return 0, false
+}
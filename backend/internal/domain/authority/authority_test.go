@@ -0,0 +1,100 @@
+package authority
+
+import (
+	"testing"
+
+	"github.com/JhonCamargo53/prueba-tecnica/internal/domain/models"
+)
+
+func TestCanApprove_SegunNivelDeAcceso(t *testing.T) {
+	rules := DefaultRules()
+
+	cr := models.CreditRequest{Amount: 20_000_000, RiskCategory: "MEDIUM", ProductType: "Préstamo Personal"}
+	if !rules.CanApprove(100, cr) {
+		t.Errorf("EMPLOYEE debería poder aprobar 20.000.000 con riesgo MEDIUM")
+	}
+
+	cr.Amount = 60_000_000
+	if rules.CanApprove(100, cr) {
+		t.Errorf("EMPLOYEE no debería poder aprobar por encima de 50.000.000")
+	}
+	if !rules.CanApprove(1000, cr) {
+		t.Errorf("ADMIN no tiene límite de monto")
+	}
+
+	cr.Amount = 10_000_000
+	cr.RiskCategory = "HIGH"
+	if rules.CanApprove(100, cr) {
+		t.Errorf("EMPLOYEE no debería poder aprobar riesgo HIGH")
+	}
+
+	if rules.CanApprove(50, models.CreditRequest{Amount: 1_000, RiskCategory: "LOW"}) {
+		t.Errorf("un nivel de acceso sin atribución no debería poder aprobar")
+	}
+}
+
+func TestCanApprove_LimitesPorProducto(t *testing.T) {
+	rules := DefaultRules()
+
+	housing := models.CreditRequest{Amount: 120_000_000, RiskCategory: "LOW", ProductType: "Crédito de Vivienda"}
+	if !rules.CanApprove(100, housing) {
+		t.Errorf("EMPLOYEE debería poder aprobar vivienda de 120.000.000 con riesgo LOW")
+	}
+
+	housing.RiskCategory = "MEDIUM"
+	if rules.CanApprove(100, housing) {
+		t.Errorf("en vivienda EMPLOYEE sólo aprueba riesgo LOW")
+	}
+
+	consumer := models.CreditRequest{Amount: 40_000_000, RiskCategory: "LOW", ProductType: "Libre inversión"}
+	if rules.CanApprove(100, consumer) {
+		t.Errorf("en libre inversión EMPLOYEE aprueba hasta 30.000.000")
+	}
+}
+
+func TestCanApprove_ProductoDelCatalogoPorCodigo(t *testing.T) {
+	rules := DefaultRules()
+
+	cr := models.CreditRequest{Amount: 120_000_000, RiskCategory: "LOW", ProductType: "Préstamo",
+		CreditProduct: &models.CreditProduct{Code: "housing"}}
+	if !rules.CanApprove(100, cr) {
+		t.Errorf("se esperaban las atribuciones de vivienda por el código del producto")
+	}
+}
+
+func TestRequiredAccess(t *testing.T) {
+	rules := DefaultRules()
+
+	if access, ok := rules.RequiredAccess(models.CreditRequest{Amount: 10_000_000, RiskCategory: "LOW"}); !ok || access != 100 {
+		t.Errorf("se esperaba nivel 100, obtenido %d", access)
+	}
+	// Sin categoría se trata como riesgo HIGH
+	if access, ok := rules.RequiredAccess(models.CreditRequest{Amount: 10_000_000}); !ok || access != 1000 {
+		t.Errorf("se esperaba nivel 1000, obtenido %d", access)
+	}
+
+	limited, err := ParseRules([]byte(`{"version":"v","default":[{"minAccess":100,"maxAmount":1000,"maxCategory":"LOW"}],"products":[]}`))
+	if err != nil {
+		t.Fatalf("no se esperaba error: %v", err)
+	}
+	if _, ok := limited.RequiredAccess(models.CreditRequest{Amount: 5_000, RiskCategory: "LOW"}); ok {
+		t.Errorf("ningún nivel debería cubrir la solicitud")
+	}
+}
+
+func TestParseRules_Invalidas(t *testing.T) {
+	cases := map[string]string{
+		"sin versión":         `{"default":[{"minAccess":100,"maxAmount":0,"maxCategory":"LOW"}],"products":[]}`,
+		"sin niveles":         `{"version":"v","default":[],"products":[]}`,
+		"categoría inválida":  `{"version":"v","default":[{"minAccess":100,"maxAmount":0,"maxCategory":"ALTO"}],"products":[]}`,
+		"nivel repetido":      `{"version":"v","default":[{"minAccess":100,"maxAmount":0,"maxCategory":"LOW"},{"minAccess":100,"maxAmount":0,"maxCategory":"HIGH"}],"products":[]}`,
+		"producto sin código": `{"version":"v","default":[{"minAccess":100,"maxAmount":0,"maxCategory":"LOW"}],"products":[{"keywords":["X"],"limits":[{"minAccess":100,"maxAmount":0,"maxCategory":"LOW"}]}]}`,
+		"campo desconocido":   `{"version":"v","default":[{"minAccess":100,"maxAmount":0,"maxCategory":"LOW","extra":1}],"products":[]}`,
+	}
+
+	for name, data := range cases {
+		if _, err := ParseRules([]byte(data)); err == nil {
+			t.Errorf("%s: se esperaba error", name)
+		}
+	}
+}
@@ -0,0 +1,259 @@
+package backtesting
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/JhonCamargo53/prueba-tecnica/internal/domain/models"
+)
+
+/*
+
+Métricas de back-testing de un motor de riesgo contra decisiones finales.
+Cada observación es el resultado del motor para una solicitud decidida y si
+esa solicitud terminó aprobada.
+
+*/
+
+const (
+	DefaultBands = 10
+	MaxBands     = 50
+)
+
+type Observation struct {
+	Score          float64
+	Recommendation string
+	Approved       bool
+}
+
+// BuildReport calcula todas las métricas del reporte a partir de las observaciones.
+func BuildReport(engine string, observations []Observation, bands int) *models.BacktestReport {
+	report := &models.BacktestReport{
+		Engine:          engine,
+		EngineVersions:  []string{},
+		GeneratedAt:     time.Now(),
+		Requests:        len(observations),
+		ConfusionMatrix: ConfusionMatrix(observations),
+		ScoreBands:      ScoreBands(observations, bands),
+	}
+
+	for _, o := range observations {
+		if o.Approved {
+			report.Approved++
+		} else {
+			report.Rejected++
+		}
+	}
+
+	report.AUC = AUC(observations)
+	report.Gini = 2*report.AUC - 1
+	report.KS, report.KSScore = KS(observations)
+	report.DecisionAgreement = DecisionAgreement(report.ConfusionMatrix)
+
+	return report
+}
+
+// AUC es la probabilidad de que una solicitud aprobada tenga mayor puntaje que una
+// rechazada (los empates cuentan la mitad). Es 0.5 si falta alguna de las dos clases.
+func AUC(observations []Observation) float64 {
+	sorted := sortByScore(observations)
+
+	var positives, negatives int
+	var positiveRanks float64
+
+	// Rango promedio para los puntajes empatados
+	for i := 0; i < len(sorted); {
+		j := i
+		for j < len(sorted) && sorted[j].Score == sorted[i].Score {
+			j++
+		}
+		rank := float64(i+j+1) / 2
+		for k := i; k < j; k++ {
+			if sorted[k].Approved {
+				positives++
+				positiveRanks += rank
+			} else {
+				negatives++
+			}
+		}
+		i = j
+	}
+
+	if positives == 0 || negatives == 0 {
+		return 0.5
+	}
+
+	p, n := float64(positives), float64(negatives)
+	return (positiveRanks - p*(p+1)/2) / (p * n)
+}
+
+// KS es la máxima distancia entre las distribuciones acumuladas de puntaje de
+// rechazadas y aprobadas, junto con el puntaje en el que se alcanza.
+func KS(observations []Observation) (float64, float64) {
+	sorted := sortByScore(observations)
+
+	var positives, negatives int
+	for _, o := range sorted {
+		if o.Approved {
+			positives++
+		} else {
+			negatives++
+		}
+	}
+	if positives == 0 || negatives == 0 {
+		return 0, 0
+	}
+
+	var ks, ksScore float64
+	var cumPositives, cumNegatives int
+
+	for i := 0; i < len(sorted); {
+		score := sorted[i].Score
+		for ; i < len(sorted) && sorted[i].Score == score; i++ {
+			if sorted[i].Approved {
+				cumPositives++
+			} else {
+				cumNegatives++
+			}
+		}
+
+		distance := math.Abs(float64(cumNegatives)/float64(negatives) - float64(cumPositives)/float64(positives))
+		if distance > ks {
+			ks, ksScore = distance, score
+		}
+	}
+
+	return ks, ksScore
+}
+
+// ConfusionMatrix cruza la recomendación del motor con la decisión final.
+func ConfusionMatrix(observations []Observation) []models.BacktestConfusionRow {
+	rows := []models.BacktestConfusionRow{
+		{Recommendation: models.RiskRecommendationApprove},
+		{Recommendation: models.RiskRecommendationReview},
+		{Recommendation: models.RiskRecommendationReject},
+	}
+
+	for _, o := range observations {
+		for i := range rows {
+			if rows[i].Recommendation != o.Recommendation {
+				continue
+			}
+			if o.Approved {
+				rows[i].Approved++
+			} else {
+				rows[i].Rejected++
+			}
+		}
+	}
+
+	return rows
+}
+
+// DecisionAgreement es la proporción de recomendaciones APPROVE y REJECT que
+// coinciden con la decisión final; las solicitudes en REVIEW no cuentan.
+func DecisionAgreement(matrix []models.BacktestConfusionRow) float64 {
+	var agree, total int
+	for _, row := range matrix {
+		switch row.Recommendation {
+		case models.RiskRecommendationApprove:
+			agree += row.Approved
+		case models.RiskRecommendationReject:
+			agree += row.Rejected
+		default:
+			continue
+		}
+		total += row.Approved + row.Rejected
+	}
+	if total == 0 {
+		return 0
+	}
+	return float64(agree) / float64(total)
+}
+
+// ScoreBands divide las observaciones ordenadas por puntaje en bandas de tamaño
+// similar. Los puntajes empatados quedan siempre en la misma banda.
+func ScoreBands(observations []Observation, bands int) []models.BacktestScoreBand {
+	result := []models.BacktestScoreBand{}
+	if len(observations) == 0 {
+		return result
+	}
+	if bands <= 0 {
+		bands = DefaultBands
+	}
+
+	sorted := sortByScore(observations)
+	size := int(math.Ceil(float64(len(sorted)) / float64(bands)))
+
+	var band *models.BacktestScoreBand
+	for i, o := range sorted {
+		if band == nil || (band.Requests >= size && o.Score != sorted[i-1].Score) {
+			result = append(result, models.BacktestScoreBand{MinScore: o.Score})
+			band = &result[len(result)-1]
+		}
+		band.MaxScore = o.Score
+		band.Requests++
+		if o.Approved {
+			band.Approved++
+		} else {
+			band.Rejected++
+		}
+	}
+
+	for i := range result {
+		result[i].ApprovalRate = float64(result[i].Approved) / float64(result[i].Requests)
+	}
+
+	return result
+}
+
+// WriteCSV exporta el reporte en formato largo: una fila por métrica, celda de
+// la matriz de confusión y banda de puntaje.
+func WriteCSV(w io.Writer, report *models.BacktestReport) error {
+	writer := csv.NewWriter(w)
+
+	rows := [][]string{
+		{"section", "label", "minScore", "maxScore", "requests", "approved", "rejected", "value"},
+		{"summary", "requests", "", "", itoa(report.Requests), itoa(report.Approved), itoa(report.Rejected), ""},
+		{"summary", "errors", "", "", "", "", "", itoa(report.Errors)},
+		{"metric", "auc", "", "", "", "", "", ftoa(report.AUC)},
+		{"metric", "gini", "", "", "", "", "", ftoa(report.Gini)},
+		{"metric", "ks", "", "", "", "", "", ftoa(report.KS)},
+		{"metric", "ksScore", "", "", "", "", "", ftoa(report.KSScore)},
+		{"metric", "decisionAgreement", "", "", "", "", "", ftoa(report.DecisionAgreement)},
+	}
+
+	for _, row := range report.ConfusionMatrix {
+		rows = append(rows, []string{"confusion", row.Recommendation, "", "",
+			itoa(row.Approved + row.Rejected), itoa(row.Approved), itoa(row.Rejected), ""})
+	}
+
+	for i, band := range report.ScoreBands {
+		rows = append(rows, []string{"band", fmt.Sprintf("%d", i+1), ftoa(band.MinScore), ftoa(band.MaxScore),
+			itoa(band.Requests), itoa(band.Approved), itoa(band.Rejected), ftoa(band.ApprovalRate)})
+	}
+
+	if err := writer.WriteAll(rows); err != nil {
+		return err
+	}
+	return writer.Error()
+}
+
+func sortByScore(observations []Observation) []Observation {
+	sorted := append([]Observation(nil), observations...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Score < sorted[j].Score })
+	return sorted
+}
+
+func itoa(v int) string {
+	return strconv.Itoa(v)
+}
+
+func ftoa(v float64) string {
+	return strconv.FormatFloat(v, 'f', 4, 64)
+}
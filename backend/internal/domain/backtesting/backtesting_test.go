@@ -0,0 +1,104 @@
+package backtesting
+
+import (
+	"bytes"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/JhonCamargo53/prueba-tecnica/internal/domain/models"
+)
+
+func obs(score float64, recommendation string, approved bool) Observation {
+	return Observation{Score: score, Recommendation: recommendation, Approved: approved}
+}
+
+func TestAUC_SeparacionPerfectaInversaYEmpates(t *testing.T) {
+	perfect := []Observation{obs(20, "", false), obs(30, "", false), obs(70, "", true), obs(80, "", true)}
+	if auc := AUC(perfect); auc != 1 {
+		t.Errorf("se esperaba AUC 1 con separación perfecta, obtenido %.4f", auc)
+	}
+
+	inverse := []Observation{obs(20, "", true), obs(80, "", false)}
+	if auc := AUC(inverse); auc != 0 {
+		t.Errorf("se esperaba AUC 0 con orden inverso, obtenido %.4f", auc)
+	}
+
+	ties := []Observation{obs(50, "", true), obs(50, "", false), obs(50, "", true)}
+	if auc := AUC(ties); auc != 0.5 {
+		t.Errorf("se esperaba AUC 0.5 con puntajes empatados, obtenido %.4f", auc)
+	}
+
+	// 3 de 4 pares aprobada/rechazada bien ordenados
+	mixed := []Observation{obs(10, "", false), obs(40, "", true), obs(60, "", false), obs(90, "", true)}
+	if auc := AUC(mixed); math.Abs(auc-0.75) > 1e-9 {
+		t.Errorf("se esperaba AUC 0.75, obtenido %.4f", auc)
+	}
+}
+
+func TestKS_MaximaSeparacion(t *testing.T) {
+	observations := []Observation{obs(10, "", false), obs(20, "", false), obs(40, "", true),
+		obs(45, "", true), obs(50, "", false), obs(90, "", true)}
+
+	ks, score := KS(observations)
+	// En 20: 2/3 de rechazadas y 0 aprobadas acumuladas
+	if math.Abs(ks-2.0/3.0) > 1e-9 || score != 20 {
+		t.Errorf("se esperaba KS 0.6667 en 20, obtenido %.4f en %.0f", ks, score)
+	}
+
+	if ks, _ := KS([]Observation{obs(10, "", true)}); ks != 0 {
+		t.Errorf("se esperaba KS 0 con una sola clase")
+	}
+}
+
+func TestScoreBands_NoSeparaEmpates(t *testing.T) {
+	observations := []Observation{obs(10, "", false), obs(20, "", false), obs(20, "", true),
+		obs(20, "", false), obs(60, "", true), obs(90, "", true)}
+
+	bands := ScoreBands(observations, 3)
+	if len(bands) != 2 {
+		t.Fatalf("se esperaban 2 bandas, obtenidas %d: %+v", len(bands), bands)
+	}
+	if bands[0].MinScore != 10 || bands[0].MaxScore != 20 || bands[0].Requests != 4 || bands[0].Approved != 1 {
+		t.Errorf("primera banda inesperada: %+v", bands[0])
+	}
+	if bands[1].ApprovalRate != 1 {
+		t.Errorf("se esperaba tasa de aprobación 1 en la última banda: %+v", bands[1])
+	}
+}
+
+func TestBuildReport_MatrizYExportacionCSV(t *testing.T) {
+	observations := []Observation{
+		obs(90, models.RiskRecommendationApprove, true),
+		obs(85, models.RiskRecommendationApprove, false),
+		obs(60, models.RiskRecommendationReview, true),
+		obs(20, models.RiskRecommendationReject, false),
+	}
+
+	report := BuildReport("mock", observations, DefaultBands)
+
+	if report.Approved != 2 || report.Rejected != 2 {
+		t.Errorf("conteos inesperados: %d aprobadas, %d rechazadas", report.Approved, report.Rejected)
+	}
+	if report.ConfusionMatrix[0].Approved != 1 || report.ConfusionMatrix[0].Rejected != 1 {
+		t.Errorf("fila APPROVE inesperada: %+v", report.ConfusionMatrix[0])
+	}
+	// APPROVE→aprobada y REJECT→rechazada de 3 decisiones no REVIEW
+	if math.Abs(report.DecisionAgreement-2.0/3.0) > 1e-9 {
+		t.Errorf("se esperaba acuerdo 0.6667, obtenido %.4f", report.DecisionAgreement)
+	}
+	if math.Abs(report.Gini-(2*report.AUC-1)) > 1e-9 {
+		t.Errorf("Gini inconsistente con AUC")
+	}
+
+	var buf bytes.Buffer
+	if err := WriteCSV(&buf, report); err != nil {
+		t.Fatalf("no se esperaba error al exportar CSV: %v", err)
+	}
+	csv := buf.String()
+	for _, expected := range []string{"section,label,", "metric,auc,", "confusion,REVIEW,", "band,1,"} {
+		if !strings.Contains(csv, expected) {
+			t.Errorf("el CSV no contiene %q:\n%s", expected, csv)
+		}
+	}
+}
@@ -0,0 +1,177 @@
+package drift
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/JhonCamargo53/prueba-tecnica/internal/domain/models"
+)
+
+/*
+
+Índice de estabilidad poblacional (PSI):
+
+	PSI = Σ (actual% − base%) · ln(actual% / base%)
+
+Las variables numéricas se discretizan con los cuantiles del período base, de
+modo que cada bin tenga aproximadamente la misma proporción de solicitudes
+base. Las categóricas usan un bin por valor. Las proporciones vacías se
+reemplazan por un mínimo para que el logaritmo esté definido.
+
+Referencia usual: < 0.10 estable, 0.10–0.25 cambio moderado, > 0.25 cambio significativo.
+
+*/
+
+const (
+	DefaultBins = 10
+	// Proporción mínima usada en bins vacíos
+	minShare = 0.0001
+)
+
+// NumericPSI calcula el índice con bins por cuantiles del período base.
+func NumericPSI(baseline, current []float64, bins int) (float64, []models.RiskDriftBin) {
+	if len(baseline) == 0 || len(current) == 0 {
+		return 0, []models.RiskDriftBin{}
+	}
+	if bins <= 0 {
+		bins = DefaultBins
+	}
+
+	edges := quantileEdges(baseline, bins)
+
+	baseCounts := countNumeric(baseline, edges)
+	currentCounts := countNumeric(current, edges)
+
+	labels := make([]string, len(edges)+1)
+	for i := range labels {
+		switch {
+		case len(edges) == 0:
+			labels[i] = "todos"
+		case i == 0:
+			labels[i] = fmt.Sprintf("<= %s", formatEdge(edges[0]))
+		case i == len(edges):
+			labels[i] = fmt.Sprintf("> %s", formatEdge(edges[i-1]))
+		default:
+			labels[i] = fmt.Sprintf("%s – %s", formatEdge(edges[i-1]), formatEdge(edges[i]))
+		}
+	}
+
+	return psi(labels, baseCounts, currentCounts, len(baseline), len(current))
+}
+
+// CategoricalPSI calcula el índice con un bin por valor (sin distinguir mayúsculas).
+// Los valores que no aparecen en el período base tienen su propio bin.
+func CategoricalPSI(baseline, current []string) (float64, []models.RiskDriftBin) {
+	if len(baseline) == 0 || len(current) == 0 {
+		return 0, []models.RiskDriftBin{}
+	}
+
+	index := map[string]int{}
+	var labels []string
+	var baseCounts, currentCounts []int
+
+	add := func(value string, base bool) {
+		key := normalizeCategory(value)
+		i, ok := index[key]
+		if !ok {
+			i = len(labels)
+			index[key] = i
+			labels = append(labels, key)
+			baseCounts = append(baseCounts, 0)
+			currentCounts = append(currentCounts, 0)
+		}
+		if base {
+			baseCounts[i]++
+		} else {
+			currentCounts[i]++
+		}
+	}
+
+	for _, v := range baseline {
+		add(v, true)
+	}
+	for _, v := range current {
+		add(v, false)
+	}
+
+	return psi(labels, baseCounts, currentCounts, len(baseline), len(current))
+}
+
+// Status clasifica un índice según los umbrales de advertencia y alerta.
+func Status(value, warning, alert float64) string {
+	switch {
+	case value >= alert:
+		return models.RiskDriftStatusAlert
+	case value >= warning:
+		return models.RiskDriftStatusWarning
+	default:
+		return models.RiskDriftStatusStable
+	}
+}
+
+func psi(labels []string, baseCounts, currentCounts []int, baseTotal, currentTotal int) (float64, []models.RiskDriftBin) {
+	total := 0.0
+	bins := make([]models.RiskDriftBin, len(labels))
+
+	for i, label := range labels {
+		baseShare := float64(baseCounts[i]) / float64(baseTotal)
+		currentShare := float64(currentCounts[i]) / float64(currentTotal)
+
+		b, c := math.Max(baseShare, minShare), math.Max(currentShare, minShare)
+		contribution := (c - b) * math.Log(c/b)
+		total += contribution
+
+		bins[i] = models.RiskDriftBin{
+			Label:         label,
+			BaselineShare: baseShare,
+			CurrentShare:  currentShare,
+			Contribution:  contribution,
+		}
+	}
+
+	return total, bins
+}
+
+// quantileEdges retorna los límites superiores (inclusivos) de los bins, sin
+// repetidos: con muchos valores iguales resultan menos bins.
+func quantileEdges(values []float64, bins int) []float64 {
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+
+	var edges []float64
+	for i := 1; i < bins; i++ {
+		edge := sorted[int(math.Ceil(float64(i*len(sorted))/float64(bins)))-1]
+		if edge >= sorted[len(sorted)-1] {
+			break
+		}
+		if len(edges) == 0 || edge > edges[len(edges)-1] {
+			edges = append(edges, edge)
+		}
+	}
+	return edges
+}
+
+func countNumeric(values, edges []float64) []int {
+	counts := make([]int, len(edges)+1)
+	for _, v := range values {
+		counts[sort.SearchFloat64s(edges, v)]++
+	}
+	return counts
+}
+
+func normalizeCategory(value string) string {
+	value = strings.ToUpper(strings.TrimSpace(value))
+	if value == "" {
+		return "SIN DATO"
+	}
+	return value
+}
+
+func formatEdge(v float64) string {
+	if v == math.Trunc(v) {
+		return fmt.Sprintf("%.0f", v)
+	}
+	return fmt.Sprintf("%.4f", v)
+}
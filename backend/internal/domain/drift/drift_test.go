@@ -0,0 +1,70 @@
+package drift
+
+import (
+	"math"
+	"testing"
+
+	"github.com/JhonCamargo53/prueba-tecnica/internal/domain/models"
+)
+
+func sequence(from, to float64) []float64 {
+	var values []float64
+	for v := from; v <= to; v++ {
+		values = append(values, v)
+	}
+	return values
+}
+
+func TestNumericPSI_MismaPoblacionEsEstable(t *testing.T) {
+	baseline := sequence(1, 100)
+
+	value, bins := NumericPSI(baseline, sequence(1, 100), 10)
+	if value > 1e-9 {
+		t.Errorf("se esperaba PSI 0 con la misma distribución, obtenido %.6f", value)
+	}
+	if len(bins) != 10 {
+		t.Fatalf("se esperaban 10 bins, obtenidos %d", len(bins))
+	}
+	for _, b := range bins {
+		if math.Abs(b.BaselineShare-0.1) > 1e-9 {
+			t.Errorf("se esperaba 10%% de la base en cada bin: %+v", b)
+		}
+	}
+}
+
+func TestNumericPSI_DesplazamientoEsAlerta(t *testing.T) {
+	value, bins := NumericPSI(sequence(1, 100), sequence(61, 160), 10)
+
+	if Status(value, 0.1, 0.25) != models.RiskDriftStatusAlert {
+		t.Errorf("se esperaba alerta con la población desplazada, PSI %.4f", value)
+	}
+	// Los valores por encima del máximo base quedan en el último bin
+	if last := bins[len(bins)-1]; last.CurrentShare < 0.6 {
+		t.Errorf("se esperaba la mayoría de la población actual en el último bin: %+v", last)
+	}
+}
+
+func TestNumericPSI_ValoresRepetidosReducenBins(t *testing.T) {
+	baseline := []float64{12, 12, 12, 12, 24, 24, 24, 36, 36, 60}
+
+	_, bins := NumericPSI(baseline, baseline, 10)
+	if len(bins) != 4 {
+		t.Errorf("se esperaban 4 bins para 4 valores distintos, obtenidos %d: %+v", len(bins), bins)
+	}
+}
+
+func TestCategoricalPSI_CategoriaNueva(t *testing.T) {
+	baseline := []string{"Vivienda", "vivienda ", "Libre inversión", "Libre inversión"}
+	current := []string{"VIVIENDA", "Libre inversión", "Vehículo", "Vehículo"}
+
+	value, bins := CategoricalPSI(baseline, current)
+	if len(bins) != 3 {
+		t.Fatalf("se esperaban 3 categorías, obtenidas %d: %+v", len(bins), bins)
+	}
+	if bins[2].Label != "VEHÍCULO" || bins[2].BaselineShare != 0 || bins[2].CurrentShare != 0.5 {
+		t.Errorf("bin inesperado para la categoría nueva: %+v", bins[2])
+	}
+	if Status(value, 0.1, 0.25) != models.RiskDriftStatusAlert {
+		t.Errorf("se esperaba alerta por la categoría nueva, PSI %.4f", value)
+	}
+}
@@ -0,0 +1,103 @@
+package finance
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// Métodos de amortización soportados para los planes de pago
+const (
+	AmortizationFrench = "FRENCH" // cuota fija
+	AmortizationGerman = "GERMAN" // abono a capital constante
+	AmortizationBullet = "BULLET" // sólo intereses y el capital en la última cuota
+)
+
+func IsValidAmortizationMethod(method string) bool {
+	switch method {
+	case AmortizationFrench, AmortizationGerman, AmortizationBullet:
+		return true
+	}
+	return false
+}
+
+// AmortizationPeriod es una cuota del plan de pagos. Balance es el saldo de capital
+// después de pagarla.
+type AmortizationPeriod struct {
+	Number    int
+	DueDate   time.Time
+	Payment   float64
+	Principal float64
+	Interest  float64
+	Balance   float64
+}
+
+// AmortizationSchedule genera las cuotas mensuales de un crédito desembolsado en start.
+// Los valores se redondean a centavos y la última cuota abona el saldo pendiente, de
+// modo que el capital amortizado suma exactamente el monto prestado.
+func AmortizationSchedule(principal float64, annualRatePct float64, termMonths int, method string, start time.Time) ([]AmortizationPeriod, error) {
+	if !IsValidAmortizationMethod(method) {
+		return nil, fmt.Errorf("método de amortización desconocido: %s", method)
+	}
+
+	installment, err := FrenchInstallment(principal, annualRatePct, termMonths)
+	if err != nil {
+		return nil, err
+	}
+	installment = roundCents(installment)
+
+	rate := MonthlyRateFromAnnual(annualRatePct)
+	constantPrincipal := roundCents(principal / float64(termMonths))
+
+	periods := make([]AmortizationPeriod, 0, termMonths)
+	balance := roundCents(principal)
+
+	for n := 1; n <= termMonths; n++ {
+		interest := roundCents(balance * rate)
+
+		var amortized float64
+		switch method {
+		case AmortizationFrench:
+			amortized = installment - interest
+		case AmortizationGerman:
+			amortized = constantPrincipal
+		case AmortizationBullet:
+			amortized = 0
+		}
+
+		if n == termMonths || amortized > balance {
+			amortized = balance
+		}
+		amortized = roundCents(amortized)
+		balance = roundCents(balance - amortized)
+
+		periods = append(periods, AmortizationPeriod{
+			Number:    n,
+			DueDate:   AddMonths(start, n),
+			Payment:   roundCents(amortized + interest),
+			Principal: amortized,
+			Interest:  interest,
+			Balance:   balance,
+		})
+	}
+
+	return periods, nil
+}
+
+// AddMonths suma meses a una fecha conservando el día; si el mes destino es más corto
+// se usa su último día (31 de enero + 1 mes = 28 o 29 de febrero).
+func AddMonths(date time.Time, months int) time.Time {
+	firstOfMonth := time.Date(date.Year(), date.Month(), 1, 0, 0, 0, 0, date.Location()).AddDate(0, months, 0)
+	lastDay := firstOfMonth.AddDate(0, 1, -1).Day()
+
+	day := date.Day()
+	if day > lastDay {
+		day = lastDay
+	}
+
+	return time.Date(firstOfMonth.Year(), firstOfMonth.Month(), day, 0, 0, 0, 0, date.Location())
+}
+
+func roundCents(value float64) float64 {
+	return math.Round(value*100) / 100
+}
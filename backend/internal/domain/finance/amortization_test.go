@@ -0,0 +1,95 @@
+package finance
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func sumPrincipal(periods []AmortizationPeriod) float64 {
+	total := 0.0
+	for _, period := range periods {
+		total += period.Principal
+	}
+	return math.Round(total*100) / 100
+}
+
+func TestAmortizationSchedule_Frances(t *testing.T) {
+	start := time.Date(2026, 1, 15, 0, 0, 0, 0, time.UTC)
+
+	// 10.000.000 a 12 meses con 1% mensual => cuota de 888.487,89
+	periods, err := AmortizationSchedule(10_000_000, 12.682503, 12, AmortizationFrench, start)
+	if err != nil {
+		t.Fatalf("no se esperaba error: %v", err)
+	}
+
+	if len(periods) != 12 {
+		t.Fatalf("se esperaban 12 cuotas, obtenido: %d", len(periods))
+	}
+	if math.Abs(periods[0].Interest-100_000) > 1 || math.Abs(periods[0].Payment-888_487.89) > 1 {
+		t.Errorf("primera cuota inesperada: %+v", periods[0])
+	}
+	for _, period := range periods {
+		if math.Abs(period.Payment-888_487.89) > 1 {
+			t.Errorf("se esperaba cuota fija, cuota %d: %.2f", period.Number, period.Payment)
+		}
+	}
+	if periods[11].Balance != 0 || sumPrincipal(periods) != 10_000_000 {
+		t.Errorf("el capital amortizado debe sumar el monto prestado: %.2f, saldo final %.2f", sumPrincipal(periods), periods[11].Balance)
+	}
+	if !periods[0].DueDate.Equal(time.Date(2026, 2, 15, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("la primera cuota vence un mes después del desembolso, obtenido: %s", periods[0].DueDate)
+	}
+}
+
+func TestAmortizationSchedule_AbonoConstante(t *testing.T) {
+	periods, err := AmortizationSchedule(12_000_000, 12.682503, 12, AmortizationGerman, time.Now())
+	if err != nil {
+		t.Fatalf("no se esperaba error: %v", err)
+	}
+
+	for _, period := range periods {
+		if period.Principal != 1_000_000 {
+			t.Errorf("se esperaba abono a capital constante, cuota %d: %.2f", period.Number, period.Principal)
+		}
+	}
+	if periods[0].Payment <= periods[11].Payment {
+		t.Errorf("la cuota debe disminuir con el saldo: primera %.2f, última %.2f", periods[0].Payment, periods[11].Payment)
+	}
+	if periods[11].Balance != 0 {
+		t.Errorf("se esperaba saldo final cero, obtenido: %.2f", periods[11].Balance)
+	}
+}
+
+func TestAmortizationSchedule_Bullet(t *testing.T) {
+	periods, err := AmortizationSchedule(10_000_000, 12.682503, 6, AmortizationBullet, time.Now())
+	if err != nil {
+		t.Fatalf("no se esperaba error: %v", err)
+	}
+
+	for _, period := range periods[:5] {
+		if period.Principal != 0 || math.Abs(period.Interest-100_000) > 1 || period.Balance != 10_000_000 {
+			t.Errorf("antes del vencimiento sólo se pagan intereses: %+v", period)
+		}
+	}
+	if last := periods[5]; last.Principal != 10_000_000 || last.Balance != 0 {
+		t.Errorf("la última cuota debe pagar todo el capital: %+v", last)
+	}
+}
+
+func TestAmortizationSchedule_DatosInvalidos(t *testing.T) {
+	if _, err := AmortizationSchedule(10_000_000, 12, 12, "ANTICIPADO", time.Now()); err == nil {
+		t.Errorf("se esperaba error por método desconocido")
+	}
+	if _, err := AmortizationSchedule(10_000_000, 12, 0, AmortizationFrench, time.Now()); err == nil {
+		t.Errorf("se esperaba error por plazo inválido")
+	}
+}
+
+func TestAddMonths_FinDeMes(t *testing.T) {
+	due := AddMonths(time.Date(2026, 1, 31, 0, 0, 0, 0, time.UTC), 1)
+
+	if !due.Equal(time.Date(2026, 2, 28, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("se esperaba el último día de febrero, obtenido: %s", due)
+	}
+}
@@ -0,0 +1,63 @@
+package finance
+
+import "time"
+
+// Políticas de depreciación soportadas para los tipos de activo
+const (
+	DepreciationNone             = "NONE"
+	DepreciationStraightLine     = "STRAIGHT_LINE"
+	DepreciationDecliningBalance = "DECLINING_BALANCE"
+)
+
+func IsValidDepreciationMethod(method string) bool {
+	switch method {
+	case DepreciationNone, DepreciationStraightLine, DepreciationDecliningBalance:
+		return true
+	}
+	return false
+}
+
+// DepreciatedValue ajusta un valor comercial por el tiempo transcurrido desde
+// que fue registrado. annualRate es la fracción anual (0.15 = 15% por año).
+func DepreciatedValue(value float64, method string, annualRate float64, since time.Time, now time.Time) float64 {
+	if value <= 0 || annualRate <= 0 || since.IsZero() || !now.After(since) {
+		return value
+	}
+
+	years := now.Sub(since).Hours() / (24 * 365)
+
+	switch method {
+	case DepreciationStraightLine:
+		remaining := 1 - annualRate*years
+		if remaining < 0 {
+			remaining = 0
+		}
+		return value * remaining
+	case DepreciationDecliningBalance:
+		remaining := 1.0
+		for y := 0.0; y < years; y++ {
+			portion := years - y
+			if portion > 1 {
+				portion = 1
+			}
+			remaining *= 1 - annualRate*portion
+		}
+		if remaining < 0 {
+			remaining = 0
+		}
+		return value * remaining
+	default:
+		return value
+	}
+}
+
+// CollateralValue aplica el descuento (haircut) del tipo de activo al valor depreciado.
+func CollateralValue(value float64, haircut float64) float64 {
+	if haircut <= 0 {
+		return value
+	}
+	if haircut >= 1 {
+		return 0
+	}
+	return value * (1 - haircut)
+}
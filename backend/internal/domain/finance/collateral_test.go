@@ -0,0 +1,49 @@
+package finance
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestDepreciatedValue_LineaRecta(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	since := now.AddDate(-2, 0, 0)
+
+	value := DepreciatedValue(10_000_000, DepreciationStraightLine, 0.20, since, now)
+
+	if math.Abs(value-6_000_000) > 10_000 {
+		t.Errorf("se esperaba aprox. 6.000.000 tras 2 años al 20%%, obtenido: %.0f", value)
+	}
+}
+
+func TestDepreciatedValue_SaldoDecreciente(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	since := now.AddDate(-2, 0, 0)
+
+	value := DepreciatedValue(10_000_000, DepreciationDecliningBalance, 0.15, since, now)
+
+	// 10.000.000 * 0.85 * 0.85
+	if math.Abs(value-7_225_000) > 10_000 {
+		t.Errorf("se esperaba aprox. 7.225.000 tras 2 años al 15%%, obtenido: %.0f", value)
+	}
+}
+
+func TestDepreciatedValue_SinDepreciacion(t *testing.T) {
+	now := time.Now()
+
+	value := DepreciatedValue(10_000_000, DepreciationNone, 0.20, now.AddDate(-5, 0, 0), now)
+
+	if value != 10_000_000 {
+		t.Errorf("no se esperaba depreciación, obtenido: %.0f", value)
+	}
+}
+
+func TestCollateralValue(t *testing.T) {
+	if v := CollateralValue(10_000_000, 0.3); math.Abs(v-7_000_000) > 0.01 {
+		t.Errorf("se esperaba 7.000.000 con haircut del 30%%, obtenido: %.0f", v)
+	}
+	if v := CollateralValue(10_000_000, 1); v != 0 {
+		t.Errorf("con haircut del 100%% el valor de garantía debe ser cero, obtenido: %.0f", v)
+	}
+}
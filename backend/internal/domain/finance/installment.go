@@ -0,0 +1,126 @@
+package finance
+
+import (
+	"fmt"
+	"math"
+)
+
+/*
+
+Cálculos financieros reutilizables. Las tasas se expresan como tasa
+efectiva anual en porcentaje (por ejemplo 24.5 = 24,5% E.A.), que es
+la convención usada en Colombia para créditos de consumo y vivienda.
+
+*/
+
+// MonthlyRateFromAnnual convierte una tasa efectiva anual (%) a tasa efectiva mensual (decimal).
+func MonthlyRateFromAnnual(annualRatePct float64) float64 {
+	if annualRatePct <= 0 {
+		return 0
+	}
+	return math.Pow(1+annualRatePct/100, 1.0/12.0) - 1
+}
+
+// FrenchInstallment calcula la cuota fija mensual de un crédito con amortización francesa.
+// Con tasa cero la cuota es simplemente capital / plazo.
+func FrenchInstallment(principal float64, annualRatePct float64, termMonths int) (float64, error) {
+	if principal <= 0 {
+		return 0, fmt.Errorf("el monto debe ser mayor que cero")
+	}
+	if termMonths <= 0 {
+		return 0, fmt.Errorf("el plazo debe ser mayor que cero")
+	}
+	if annualRatePct < 0 {
+		return 0, fmt.Errorf("la tasa de interés no puede ser negativa")
+	}
+
+	rate := MonthlyRateFromAnnual(annualRatePct)
+	n := float64(termMonths)
+
+	if rate == 0 {
+		return principal / n, nil
+	}
+
+	factor := math.Pow(1+rate, n)
+	return principal * rate * factor / (factor - 1), nil
+}
+
+// MaxPrincipal es el capital máximo que se paga con una cuota fija mensual (valor
+// presente de la anualidad), la operación inversa de FrenchInstallment.
+func MaxPrincipal(installment float64, annualRatePct float64, termMonths int) (float64, error) {
+	if installment <= 0 {
+		return 0, fmt.Errorf("la cuota debe ser mayor que cero")
+	}
+	if termMonths <= 0 {
+		return 0, fmt.Errorf("el plazo debe ser mayor que cero")
+	}
+	if annualRatePct < 0 {
+		return 0, fmt.Errorf("la tasa de interés no puede ser negativa")
+	}
+
+	rate := MonthlyRateFromAnnual(annualRatePct)
+	n := float64(termMonths)
+
+	if rate == 0 {
+		return installment * n, nil
+	}
+
+	return installment * (1 - math.Pow(1+rate, -n)) / rate, nil
+}
+
+// FirstInstallment calcula la primera cuota mensual según el método de amortización:
+// la cuota fija en FRENCH, el abono constante más los intereses del monto completo en
+// GERMAN (la cuota más alta del plan) y sólo los intereses en BULLET, cuyo capital se
+// paga en la última cuota.
+func FirstInstallment(principal float64, annualRatePct float64, termMonths int, method string) (float64, error) {
+	if !IsValidAmortizationMethod(method) {
+		return 0, fmt.Errorf("método de amortización desconocido: %s", method)
+	}
+	if method == AmortizationFrench {
+		return FrenchInstallment(principal, annualRatePct, termMonths)
+	}
+	if principal <= 0 {
+		return 0, fmt.Errorf("el monto debe ser mayor que cero")
+	}
+	if termMonths <= 0 {
+		return 0, fmt.Errorf("el plazo debe ser mayor que cero")
+	}
+	if annualRatePct < 0 {
+		return 0, fmt.Errorf("la tasa de interés no puede ser negativa")
+	}
+
+	interest := principal * MonthlyRateFromAnnual(annualRatePct)
+	if method == AmortizationBullet {
+		return interest, nil
+	}
+	return principal/float64(termMonths) + interest, nil
+}
+
+// MaxPrincipalFor es el capital máximo cuya primera cuota no supera installment, la
+// operación inversa de FirstInstallment.
+func MaxPrincipalFor(installment float64, annualRatePct float64, termMonths int, method string) (float64, error) {
+	if !IsValidAmortizationMethod(method) {
+		return 0, fmt.Errorf("método de amortización desconocido: %s", method)
+	}
+	if method == AmortizationFrench {
+		return MaxPrincipal(installment, annualRatePct, termMonths)
+	}
+	if installment <= 0 {
+		return 0, fmt.Errorf("la cuota debe ser mayor que cero")
+	}
+	if termMonths <= 0 {
+		return 0, fmt.Errorf("el plazo debe ser mayor que cero")
+	}
+	if annualRatePct < 0 {
+		return 0, fmt.Errorf("la tasa de interés no puede ser negativa")
+	}
+
+	rate := MonthlyRateFromAnnual(annualRatePct)
+	if method == AmortizationBullet {
+		if rate == 0 {
+			return 0, fmt.Errorf("sin tasa de interés la cuota de un crédito BULLET no limita el monto")
+		}
+		return installment / rate, nil
+	}
+	return installment / (1/float64(termMonths) + rate), nil
+}
@@ -0,0 +1,87 @@
+package finance
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestMonthlyRateFromAnnual(t *testing.T) {
+	rate := MonthlyRateFromAnnual(12.682503)
+
+	if math.Abs(rate-0.01) > 1e-6 {
+		t.Errorf("se esperaba tasa mensual de 1%%, obtenido: %.6f", rate)
+	}
+}
+
+func TestFrenchInstallment_ConInteres(t *testing.T) {
+	// 10.000.000 a 12 meses con 1% mensual => cuota de 888.487,89
+	installment, err := FrenchInstallment(10_000_000, 12.682503, 12)
+	if err != nil {
+		t.Fatalf("no se esperaba error: %v", err)
+	}
+
+	if math.Abs(installment-888_487.89) > 1 {
+		t.Errorf("cuota inesperada, obtenido: %.2f", installment)
+	}
+}
+
+func TestFrenchInstallment_SinInteres(t *testing.T) {
+	installment, err := FrenchInstallment(12_000_000, 0, 12)
+	if err != nil {
+		t.Fatalf("no se esperaba error: %v", err)
+	}
+
+	if installment != 1_000_000 {
+		t.Errorf("con tasa cero la cuota debe ser capital/plazo, obtenido: %.2f", installment)
+	}
+}
+
+func TestFrenchInstallment_DatosInvalidos(t *testing.T) {
+	if _, err := FrenchInstallment(0, 10, 12); err == nil {
+		t.Errorf("se esperaba error por monto inválido")
+	}
+	if _, err := FrenchInstallment(1_000_000, 10, 0); err == nil {
+		t.Errorf("se esperaba error por plazo inválido")
+	}
+	if _, err := FrenchInstallment(1_000_000, -1, 12); err == nil {
+		t.Errorf("se esperaba error por tasa negativa")
+	}
+}
+
+func TestMaxPrincipal_InversaDeLaCuota(t *testing.T) {
+	principal, err := MaxPrincipal(888_487.89, 12.682503, 12)
+	if err != nil {
+		t.Fatalf("no se esperaba error: %v", err)
+	}
+
+	if math.Abs(principal-10_000_000) > 10 {
+		t.Errorf("capital inesperado, obtenido: %.2f", principal)
+	}
+}
+
+func TestFirstInstallment_SegunElMetodo(t *testing.T) {
+	start := time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC)
+
+	for _, method := range []string{AmortizationFrench, AmortizationGerman, AmortizationBullet} {
+		installment, err := FirstInstallment(10_000_000, 12.682503, 12, method)
+		if err != nil {
+			t.Fatalf("%s: no se esperaba error: %v", method, err)
+		}
+
+		// Coincide con la primera cuota del plan de pagos
+		periods, _ := AmortizationSchedule(10_000_000, 12.682503, 12, method, start)
+		if math.Abs(installment-periods[0].Payment) > 0.01 {
+			t.Errorf("%s: se esperaba la primera cuota del plan (%.2f), obtenido: %.2f", method, periods[0].Payment, installment)
+		}
+
+		principal, err := MaxPrincipalFor(installment, 12.682503, 12, method)
+		if err != nil || math.Abs(principal-10_000_000) > 10 {
+			t.Errorf("%s: se esperaba recuperar el capital, obtenido: %.2f, %v", method, principal, err)
+		}
+	}
+
+	if _, err := FirstInstallment(10_000_000, 12.682503, 12, "LINEAL"); err == nil {
+		t.Errorf("se esperaba error con un método desconocido")
+	}
+}
@@ -0,0 +1,130 @@
+package i18n
+
+import (
+	"bytes"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"text/template"
+
+	"github.com/leekchan/accounting"
+)
+
+/*
+
+Catálogo de mensajes del análisis de riesgo. Cada idioma tiene un archivo
+JSON con las plantillas de razones, mejoras, etiquetas y encabezados. Las
+plantillas usan text/template con los parámetros numéricos del factor.
+
+*/
+
+const (
+	LanguageES = "es"
+	LanguageEN = "en"
+
+	DefaultLanguage = LanguageES
+)
+
+//go:embed messages/*.json
+var messageFiles embed.FS
+
+var currencyFormats = map[string]accounting.Accounting{
+	LanguageES: {Symbol: "$", Precision: 0, Thousand: ".", Decimal: ","},
+	LanguageEN: {Symbol: "COP", Precision: 0, Thousand: ",", Decimal: ".", Format: "%s %v"},
+}
+
+var catalogs = map[string]map[string]*template.Template{}
+
+func init() {
+	for lang := range currencyFormats {
+		catalog, err := loadCatalog(lang)
+		if err != nil {
+			panic(err)
+		}
+		catalogs[lang] = catalog
+	}
+}
+
+func loadCatalog(lang string) (map[string]*template.Template, error) {
+	data, err := messageFiles.ReadFile("messages/" + lang + ".json")
+	if err != nil {
+		return nil, fmt.Errorf("no existe catálogo de mensajes para el idioma %s", lang)
+	}
+
+	var messages map[string]string
+	if err := json.Unmarshal(data, &messages); err != nil {
+		return nil, fmt.Errorf("catálogo de mensajes %s inválido: %w", lang, err)
+	}
+
+	funcs := templateFuncs(lang)
+	catalog := make(map[string]*template.Template, len(messages))
+
+	for key, text := range messages {
+		tmpl, err := template.New(key).Funcs(funcs).Option("missingkey=error").Parse(text)
+		if err != nil {
+			return nil, fmt.Errorf("catálogo de mensajes %s, clave %s: %w", lang, key, err)
+		}
+		catalog[key] = tmpl
+	}
+
+	return catalog, nil
+}
+
+func templateFuncs(lang string) template.FuncMap {
+	return template.FuncMap{
+		"cop":  func(v float64) string { return FormatCOP(lang, v) },
+		"int":  func(v float64) string { return fmt.Sprintf("%d", int(v)) },
+		"rate": func(v float64) string { return fmt.Sprintf("%.2f%%", v) },
+		"pct0": func(v float64) string { return fmt.Sprintf("%.0f%%", v*100) },
+		"pct1": func(v float64) string { return fmt.Sprintf("%.1f%%", v*100) },
+		"dec2": func(v float64) string { return fmt.Sprintf("%.2f", v) },
+	}
+}
+
+// IsSupported indica si existe catálogo para el idioma.
+func IsSupported(lang string) bool {
+	_, ok := catalogs[lang]
+	return ok
+}
+
+// FormatCOP da formato de pesos colombianos según las convenciones del idioma.
+func FormatCOP(lang string, value float64) string {
+	ac, ok := currencyFormats[lang]
+	if !ok {
+		ac = currencyFormats[DefaultLanguage]
+	}
+	return ac.FormatMoney(value)
+}
+
+// Message renderiza la plantilla de la clave con los parámetros dados.
+func Message(lang, key string, params map[string]float64) (string, error) {
+	catalog, ok := catalogs[lang]
+	if !ok {
+		return "", fmt.Errorf("idioma no soportado: %s", lang)
+	}
+
+	tmpl, ok := catalog[key]
+	if !ok {
+		return "", fmt.Errorf("no existe mensaje %s para el idioma %s", key, lang)
+	}
+
+	if params == nil {
+		params = map[string]float64{}
+	}
+
+	var b bytes.Buffer
+	if err := tmpl.Execute(&b, params); err != nil {
+		return "", fmt.Errorf("mensaje %s (%s): %w", key, lang, err)
+	}
+
+	return b.String(), nil
+}
+
+// MustMessage es como Message pero retorna la clave cuando no se puede renderizar.
+func MustMessage(lang, key string, params map[string]float64) string {
+	text, err := Message(lang, key, params)
+	if err != nil {
+		return key
+	}
+	return text
+}
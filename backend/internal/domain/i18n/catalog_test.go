@@ -0,0 +1,99 @@
+package i18n
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/JhonCamargo53/prueba-tecnica/internal/domain/models"
+)
+
+func TestCatalogos_MismasClaves(t *testing.T) {
+	keys := map[string]map[string]string{}
+
+	for _, lang := range []string{LanguageES, LanguageEN} {
+		data, err := messageFiles.ReadFile("messages/" + lang + ".json")
+		if err != nil {
+			t.Fatalf("no se pudo leer el catálogo %s: %v", lang, err)
+		}
+		var messages map[string]string
+		if err := json.Unmarshal(data, &messages); err != nil {
+			t.Fatalf("catálogo %s inválido: %v", lang, err)
+		}
+		keys[lang] = messages
+	}
+
+	for key := range keys[LanguageES] {
+		if _, ok := keys[LanguageEN][key]; !ok {
+			t.Errorf("falta la clave %s en el catálogo en", key)
+		}
+	}
+	for key := range keys[LanguageEN] {
+		if _, ok := keys[LanguageES][key]; !ok {
+			t.Errorf("falta la clave %s en el catálogo es", key)
+		}
+	}
+}
+
+func TestFormatCOP_PorIdioma(t *testing.T) {
+	if got := FormatCOP(LanguageES, 1_066_185); got != "$1.066.185" {
+		t.Errorf("se esperaba $1.066.185, obtenido: %s", got)
+	}
+	if got := FormatCOP(LanguageEN, 1_066_185); got != "COP 1,066,185" {
+		t.Errorf("se esperaba COP 1,066,185, obtenido: %s", got)
+	}
+}
+
+func TestMessage_FaltaParametro(t *testing.T) {
+	if _, err := Message(LanguageEN, "factor.LOAN_TO_VALUE", nil); err == nil {
+		t.Fatalf("se esperaba error porque falta el parámetro ltv")
+	}
+}
+
+func TestResolveLanguage(t *testing.T) {
+	cases := []struct {
+		query, header string
+		want          string
+		ok            bool
+	}{
+		{"", "", LanguageES, true},
+		{"en", "es-CO", LanguageEN, true},
+		{"", "fr-FR, en-US;q=0.8, es;q=0.5", LanguageEN, true},
+		{"", "es;q=0.3, en;q=0.9", LanguageEN, true},
+		{"", "de", LanguageES, true},
+		{"fr", "", "", false},
+	}
+
+	for _, c := range cases {
+		got, ok := ResolveLanguage(c.query, c.header)
+		if got != c.want || ok != c.ok {
+			t.Errorf("ResolveLanguage(%q, %q) = %q, %v; se esperaba %q, %v", c.query, c.header, got, ok, c.want, c.ok)
+		}
+	}
+}
+
+func TestLocalize_Ingles(t *testing.T) {
+	assessment := &models.RiskAssessment{
+		Score:          62,
+		Category:       "MEDIUM",
+		Recommendation: models.RiskRecommendationReview,
+		Factors: []models.RiskFactor{
+			{Code: "LOAN_TO_VALUE", Points: 15, Params: map[string]float64{"ltv": 0.36}, Message: "La relación préstamo/garantía (LTV) es de 36%."},
+			{Code: "LEGACY", Message: "Mensaje sin plantilla"},
+		},
+		Improvements: []models.RiskImprovement{{Code: "ADD_COLLATERAL", Message: "Incluir activos"}},
+	}
+
+	Localize(assessment, LanguageEN)
+
+	if assessment.Factors[0].Message != "The loan-to-value ratio (LTV) is 36%." {
+		t.Errorf("mensaje inesperado: %s", assessment.Factors[0].Message)
+	}
+	if assessment.Factors[1].Message != "Mensaje sin plantilla" {
+		t.Errorf("se esperaba conservar el mensaje sin plantilla, obtenido: %s", assessment.Factors[1].Message)
+	}
+	if !strings.Contains(assessment.Explanation, "{Risk band:} Medium") ||
+		!strings.Contains(assessment.Explanation, "Possible improvements for future analyses:") {
+		t.Errorf("explicación en inglés inesperada: %s", assessment.Explanation)
+	}
+}
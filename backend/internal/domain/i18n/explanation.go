@@ -0,0 +1,74 @@
+package i18n
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/JhonCamargo53/prueba-tecnica/internal/domain/models"
+)
+
+// Localize reescribe los mensajes de factores y mejoras en el idioma pedido.
+// Si un mensaje no tiene plantilla o le faltan parámetros se conserva el original.
+func Localize(assessment *models.RiskAssessment, lang string) {
+	for i, f := range assessment.Factors {
+		if text, err := Message(lang, "factor."+f.Code, f.Params); err == nil {
+			assessment.Factors[i].Message = text
+		}
+	}
+
+	for i, m := range assessment.Improvements {
+		if text, err := Message(lang, "improvement."+m.Code, nil); err == nil {
+			assessment.Improvements[i].Message = text
+		}
+	}
+
+	for i, k := range assessment.KnockOuts {
+		if text, err := Message(lang, "knockout."+k.Code, k.Params); err == nil {
+			assessment.KnockOuts[i].Message = text
+		}
+	}
+
+	assessment.Explanation = RenderExplanation(assessment, lang)
+}
+
+// RenderExplanation construye la vista en texto de una evaluación estructurada.
+func RenderExplanation(assessment *models.RiskAssessment, lang string) string {
+	var b strings.Builder
+
+	// Los motores calibrados no usan la escala 0–100: se muestra el puntaje y la PD
+	if assessment.ProbabilityOfDefault != nil {
+		fmt.Fprintf(&b, "- {%s} %.0f\n", MustMessage(lang, "explanation.score", nil), assessment.Score)
+		fmt.Fprintf(&b, "- {%s} %.2f%%\n", MustMessage(lang, "explanation.probabilityOfDefault", nil), *assessment.ProbabilityOfDefault*100)
+	} else {
+		fmt.Fprintf(&b, "- {%s} %.1f/100\n", MustMessage(lang, "explanation.score", nil), assessment.Score)
+	}
+	fmt.Fprintf(&b, "- {%s} %s\n", MustMessage(lang, "explanation.category", nil), label(lang, "category.", assessment.Category))
+	fmt.Fprintf(&b, "- {%s} %s.\n\n", MustMessage(lang, "explanation.recommendation", nil), label(lang, "recommendation.", assessment.Recommendation))
+
+	if len(assessment.KnockOuts) > 0 {
+		b.WriteString(MustMessage(lang, "explanation.knockOuts", nil) + "\n")
+		for _, k := range assessment.KnockOuts {
+			b.WriteString("- " + k.Message + "\n")
+		}
+	}
+
+	for _, f := range assessment.Factors {
+		b.WriteString("- " + f.Message + "\n")
+	}
+
+	if len(assessment.Improvements) > 0 {
+		b.WriteString("\n" + MustMessage(lang, "explanation.improvements", nil) + "\n")
+		for _, m := range assessment.Improvements {
+			b.WriteString("- " + m.Message + "\n")
+		}
+	}
+
+	return b.String()
+}
+
+func label(lang, prefix, code string) string {
+	if text, err := Message(lang, prefix+code, nil); err == nil {
+		return text
+	}
+	return code
+}
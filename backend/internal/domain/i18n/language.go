@@ -0,0 +1,76 @@
+package i18n
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ResolveLanguage elige el idioma de la respuesta: primero el parámetro explícito
+// y luego el encabezado Accept-Language. Retorna false si el parámetro explícito
+// no es un idioma soportado.
+func ResolveLanguage(queryLang, acceptLanguage string) (string, bool) {
+	if q := normalize(queryLang); q != "" {
+		if !IsSupported(q) {
+			return "", false
+		}
+		return q, true
+	}
+
+	for _, lang := range parseAcceptLanguage(acceptLanguage) {
+		if IsSupported(lang) {
+			return lang, true
+		}
+	}
+
+	return DefaultLanguage, true
+}
+
+// normalize reduce una etiqueta como "en-US" a su idioma principal ("en").
+func normalize(tag string) string {
+	tag = strings.ToLower(strings.TrimSpace(tag))
+	if i := strings.IndexAny(tag, "-_"); i >= 0 {
+		tag = tag[:i]
+	}
+	return tag
+}
+
+// parseAcceptLanguage retorna los idiomas del encabezado ordenados por preferencia (q).
+func parseAcceptLanguage(header string) []string {
+	type weighted struct {
+		lang string
+		q    float64
+	}
+
+	var entries []weighted
+
+	for _, part := range strings.Split(header, ",") {
+		fields := strings.Split(part, ";")
+		lang := normalize(fields[0])
+		if lang == "" || lang == "*" {
+			continue
+		}
+
+		q := 1.0
+		for _, f := range fields[1:] {
+			f = strings.TrimSpace(f)
+			if strings.HasPrefix(f, "q=") {
+				if v, err := strconv.ParseFloat(f[2:], 64); err == nil {
+					q = v
+				}
+			}
+		}
+
+		if q > 0 {
+			entries = append(entries, weighted{lang: lang, q: q})
+		}
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool { return entries[i].q > entries[j].q })
+
+	langs := make([]string, 0, len(entries))
+	for _, e := range entries {
+		langs = append(langs, e.lang)
+	}
+	return langs
+}
@@ -15,4 +15,11 @@ type Asset struct {
 	Description    string          `gorm:"size:255;not null" json:"description"`
 	CustomerAssets []CustomerAsset `gorm:"foreignKey:AssetID" json:"-"`
 	Status         bool            `gorm:"default:true" json:"status"`
+
+	// Política de garantía del tipo de activo
+	CollateralEligible     bool    `gorm:"default:true" json:"collateralEligible"`
+	CollateralHaircut      float64 `gorm:"default:0" json:"collateralHaircut"`
+	DepreciationMethod     string  `gorm:"size:30" json:"depreciationMethod"`
+	AnnualDepreciationRate float64 `gorm:"default:0" json:"annualDepreciationRate"`
+	RealEstate             bool    `gorm:"default:false" json:"realEstate"`
 }
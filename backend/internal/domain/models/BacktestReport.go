@@ -0,0 +1,51 @@
+package models
+
+import "time"
+
+/*
+
+BacktestReport mide qué tan bien el resultado de un motor de riesgo anticipa
+la decisión final (APROBADO o RECHAZADO) de solicitudes ya decididas. El
+puntaje se trata como predictor de aprobación: AUC y KS se calculan con las
+aprobadas como clase positiva y Gini = 2·AUC − 1.
+
+*/
+
+type BacktestReport struct {
+	Engine string `json:"engine"`
+	// Versiones del motor que produjeron los resultados (pueden cambiar por recarga de reglas)
+	EngineVersions []string   `json:"engineVersions"`
+	From           *time.Time `json:"from"`
+	To             *time.Time `json:"to"`
+	GeneratedAt    time.Time  `json:"generatedAt"`
+	Requests       int        `json:"requests"`
+	Approved       int        `json:"approved"`
+	Rejected       int        `json:"rejected"`
+	Errors         int        `json:"errors"`
+	AUC            float64    `json:"auc"`
+	Gini           float64    `json:"gini"`
+	KS             float64    `json:"ks"`
+	// Puntaje donde se alcanza la máxima separación KS
+	KSScore float64 `json:"ksScore"`
+	// Proporción de recomendaciones APPROVE/REJECT que coinciden con la decisión final
+	DecisionAgreement float64                `json:"decisionAgreement"`
+	ConfusionMatrix   []BacktestConfusionRow `json:"confusionMatrix"`
+	ScoreBands        []BacktestScoreBand    `json:"scoreBands"`
+}
+
+// BacktestConfusionRow cuenta, para una recomendación del motor, las decisiones finales.
+type BacktestConfusionRow struct {
+	Recommendation string `json:"recommendation"`
+	Approved       int    `json:"approved"`
+	Rejected       int    `json:"rejected"`
+}
+
+// BacktestScoreBand agrupa solicitudes por rango de puntaje (bandas de igual tamaño).
+type BacktestScoreBand struct {
+	MinScore     float64 `json:"minScore"`
+	MaxScore     float64 `json:"maxScore"`
+	Requests     int     `json:"requests"`
+	Approved     int     `json:"approved"`
+	Rejected     int     `json:"rejected"`
+	ApprovalRate float64 `json:"approvalRate"`
+}
@@ -0,0 +1,60 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Estados de una decisión manual sobre una solicitud de crédito
+const (
+	// Aplicada directamente, sin requerir confirmación
+	CreditDecisionStatusApplied = "APPLIED"
+	// Contradice al motor por encima del monto configurado y espera la confirmación de un segundo usuario
+	CreditDecisionStatusPendingConfirmation = "PENDING_CONFIRMATION"
+	// Aprobación por encima de la atribución de quien decide; espera a un rol con nivel de acceso suficiente
+	CreditDecisionStatusEscalated = "ESCALATED"
+	CreditDecisionStatusConfirmed = "CONFIRMED"
+	CreditDecisionStatusDeclined  = "DECLINED"
+)
+
+/*
+
+CreditDecision registra la aprobación o el rechazo de una solicitud por un
+usuario. Es un override cuando contradice la recomendación del motor
+(aprobar con REJECT o rechazar con APPROVE); en ese caso la justificación es
+obligatoria y, por encima del monto configurado, el estado de la solicitud
+sólo cambia cuando la confirma un usuario con mayor nivel de acceso. Una
+aprobación que supera la atribución del rol de quien decide se escala al
+nivel de acceso requerido (RequiredAccess).
+
+*/
+
+type CreditDecision struct {
+	ID              uint           `gorm:"primaryKey" json:"ID"`
+	CreatedAt       time.Time      `json:"CreatedAt"`
+	UpdatedAt       time.Time      `json:"UpdatedAt"`
+	DeletedAt       gorm.DeletedAt `gorm:"index" json:"-"`
+	CreditRequestID uint           `gorm:"not null;index" json:"creditRequestId"`
+	CreditRequest   CreditRequest  `gorm:"foreignKey:CreditRequestID" json:"-"`
+	// Estado decidido: APROBADO o RECHAZADO
+	CreditStatusID uint   `gorm:"not null" json:"creditStatusId"`
+	Status         string `gorm:"size:30;not null;index" json:"status"`
+	// Resultado del motor al momento de decidir
+	EngineRecommendation string     `json:"engineRecommendation"`
+	EngineScore          float64    `json:"engineScore"`
+	EngineVersion        string     `json:"engineVersion"`
+	Override             bool       `json:"override"`
+	Justification        string     `gorm:"type:TEXT" json:"justification"`
+	Amount               float64    `json:"amount"`
+	RiskCategory         string     `json:"riskCategory"`
+	DecidedByID          uint       `gorm:"not null;index" json:"decidedById"`
+	DecidedBy            User       `gorm:"foreignKey:DecidedByID" json:"-"`
+	DecidedAt            time.Time  `json:"decidedAt"`
+	ReviewedByID         *uint      `gorm:"index" json:"reviewedById"`
+	ReviewedBy           *User      `gorm:"foreignKey:ReviewedByID" json:"-"`
+	ReviewedAt           *time.Time `json:"reviewedAt"`
+	ReviewComment        string     `gorm:"type:TEXT" json:"reviewComment,omitempty"`
+	// Nivel de acceso mínimo para resolver una decisión escalada (0 = no escalada)
+	RequiredAccess int `gorm:"index" json:"requiredAccess"`
+}
@@ -0,0 +1,22 @@
+package models
+
+/*
+
+CreditOffer es la oferta calculada con la política de precios según el
+riesgo: tasa ofrecida, monto máximo aprobable y plazo sugerido. Se guarda
+embebida en la solicitud; CounterOffer indica que el monto o el plazo
+solicitados no se pueden aprobar tal cual. Installment es la primera cuota
+según el método de amortización del producto. PricingVersion vacía significa
+que la solicitud no tiene oferta (por ejemplo, porque se recomienda rechazarla).
+
+*/
+
+type CreditOffer struct {
+	PricingVersion string  `json:"pricingVersion"`
+	AnnualRate     float64 `json:"annualRate"`
+	MaxAmount      float64 `json:"maxAmount"`
+	Amount         float64 `json:"amount"`
+	TermMonths     int     `json:"termMonths"`
+	Installment    float64 `json:"installment"`
+	CounterOffer   bool    `json:"counterOffer"`
+}
@@ -0,0 +1,141 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type CreditProduct struct {
+	ID        uint           `gorm:"primaryKey" json:"ID"`
+	CreatedAt time.Time      `json:"CreatedAt"`
+	UpdatedAt time.Time      `json:"UpdatedAt"`
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
+	// Código estable del producto (HOUSING, CONSUMER, ...), usado por las políticas
+	Code        string  `gorm:"size:30;unique;not null" json:"code"`
+	Name        string  `gorm:"size:100;not null" json:"name"`
+	Description string  `gorm:"size:255" json:"description"`
+	MinAmount   float64 `gorm:"not null" json:"minAmount"`
+	MaxAmount   float64 `gorm:"not null" json:"maxAmount"`
+	// Plazos (meses) que se pueden solicitar
+	AllowedTerms TermList `gorm:"type:jsonb;not null" json:"allowedTerms"`
+	// Tasa base E.A. (%) del producto
+	BaseAnnualRate     float64 `gorm:"not null" json:"baseAnnualRate"`
+	CollateralRequired bool    `gorm:"default:false" json:"collateralRequired"`
+	// Ponderador de riesgo del producto (1 = neutro, menor = menos riesgoso)
+	RiskWeight float64 `gorm:"default:1" json:"riskWeight"`
+	// Método de amortización del plan de pagos: FRENCH, GERMAN o BULLET
+	AmortizationMethod string `gorm:"size:20;not null;default:FRENCH" json:"amortizationMethod"`
+	// Categorías de documento que deben estar adjuntas para aprobar una solicitud
+	RequiredDocuments DocumentCategoryList `gorm:"type:jsonb" json:"requiredDocuments"`
+	Status            bool                 `gorm:"default:true" json:"status"`
+}
+
+// AllowsTerm indica si el plazo está entre los plazos permitidos del producto.
+func (p CreditProduct) AllowsTerm(termMonths int) bool {
+	for _, t := range p.AllowedTerms {
+		if t == termMonths {
+			return true
+		}
+	}
+	return false
+}
+
+// MaxTerm retorna el mayor plazo permitido del producto.
+func (p CreditProduct) MaxTerm() int {
+	max := 0
+	for _, t := range p.AllowedTerms {
+		if t > max {
+			max = t
+		}
+	}
+	return max
+}
+
+// MissingDocuments retorna las categorías exigidas por el producto que no están
+// entre los documentos dados, en el orden en que el producto las exige.
+func (p CreditProduct) MissingDocuments(documents []Document) []string {
+	present := map[string]bool{}
+	for _, document := range documents {
+		present[document.Category] = true
+	}
+
+	missing := []string{}
+	for _, category := range p.RequiredDocuments {
+		if !present[category] {
+			missing = append(missing, category)
+		}
+	}
+	return missing
+}
+
+// TermList almacena una lista de plazos en una columna jsonb de Postgres.
+type TermList []int
+
+func (t TermList) Value() (driver.Value, error) {
+	if t == nil {
+		return "[]", nil
+	}
+	data, err := json.Marshal([]int(t))
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
+
+func (t *TermList) Scan(value interface{}) error {
+	var data []byte
+	switch v := value.(type) {
+	case nil:
+		*t = nil
+		return nil
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	default:
+		return fmt.Errorf("no se puede convertir %T a TermList", value)
+	}
+	return json.Unmarshal(data, (*[]int)(t))
+}
+
+func (TermList) GormDataType() string {
+	return "jsonb"
+}
+
+// DocumentCategoryList almacena una lista de categorías de documento en una columna jsonb de Postgres.
+type DocumentCategoryList []string
+
+func (l DocumentCategoryList) Value() (driver.Value, error) {
+	if l == nil {
+		return "[]", nil
+	}
+	data, err := json.Marshal([]string(l))
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
+
+func (l *DocumentCategoryList) Scan(value interface{}) error {
+	var data []byte
+	switch v := value.(type) {
+	case nil:
+		*l = nil
+		return nil
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	default:
+		return fmt.Errorf("no se puede convertir %T a DocumentCategoryList", value)
+	}
+	return json.Unmarshal(data, (*[]string)(l))
+}
+
+func (DocumentCategoryList) GormDataType() string {
+	return "jsonb"
+}
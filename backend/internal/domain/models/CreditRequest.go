@@ -7,18 +7,39 @@ import (
 )
 
 type CreditRequest struct {
-	ID              uint           `gorm:"primaryKey" json:"ID"`
-	CreatedAt       time.Time      `json:"CreatedAt"`
-	UpdatedAt       time.Time      `json:"UpdatedAt"`
-	DeletedAt       gorm.DeletedAt `gorm:"index" json:"-"`
-	Amount          float64        `json:"amount"`
-	TermMonths      int            `json:"termMonths"`
-	CustomerID      uint           `gorm:"not null" json:"customerId"`
-	Customer        Customer       `json:"-"`
-	ProductType     string         `json:"productType"`
-	CreditStatusID  uint           `gorm:"not null" json:"creditStatusId"`
-	CreditStatus    CreditStatus   `json:"-"`
-	RiskScore       float64        `gorm:"default:0" json:"riskScore"`
-	RiskCategory    string         `json:"riskCategory"`
-	RiskExplanation string         `json:"riskExplanation" gorm:"type:TEXT"`
+	ID                 uint           `gorm:"primaryKey" json:"ID"`
+	CreatedAt          time.Time      `json:"CreatedAt"`
+	UpdatedAt          time.Time      `json:"UpdatedAt"`
+	DeletedAt          gorm.DeletedAt `gorm:"index" json:"-"`
+	Amount             float64        `json:"amount"`
+	TermMonths         int            `json:"termMonths"`
+	InterestRate       float64        `gorm:"default:0" json:"interestRate"`
+	CustomerID         uint           `gorm:"not null" json:"customerId"`
+	Customer           Customer       `json:"-"`
+	CreditProductID    *uint          `gorm:"index" json:"creditProductId"`
+	CreditProduct      *CreditProduct `json:"creditProduct,omitempty"`
+	ProductType        string         `json:"productType"` // nombre del producto; único dato en solicitudes anteriores al catálogo
+	CreditStatusID     uint           `gorm:"not null" json:"creditStatusId"`
+	CreditStatus       CreditStatus   `json:"-"`
+	RiskScore          float64        `gorm:"default:0" json:"riskScore"`
+	RiskCategory       string         `json:"riskCategory"`
+	RiskExplanation    string         `json:"riskExplanation" gorm:"type:TEXT"`
+	RiskRuleSetVersion string         `json:"riskRuleSetVersion"`
+	RiskAssessment     JSONB          `gorm:"type:jsonb" json:"riskAssessment"`
+	Offer              CreditOffer    `gorm:"embedded;embeddedPrefix:offer_" json:"offer"`
+	LoanAccount        *LoanAccount   `gorm:"foreignKey:CreditRequestID" json:"loanAccount,omitempty"` // se carga al listar y al evaluar el riesgo
+	// Fecha del paso a APROBADO según el historial de estados; se carga al evaluar el riesgo
+	ApprovedAt *time.Time `gorm:"-" json:"approvedAt,omitempty"`
+
+	// Titular, codeudores y fiadores; se cargan al evaluar el riesgo
+	Participants []CreditRequestParticipant `gorm:"foreignKey:CreditRequestID" json:"participants,omitempty"`
+}
+
+// ProductCode retorna el código del producto del catálogo, o vacío si la solicitud
+// no tiene producto cargado.
+func (c CreditRequest) ProductCode() string {
+	if c.CreditProduct == nil {
+		return ""
+	}
+	return c.CreditProduct.Code
 }
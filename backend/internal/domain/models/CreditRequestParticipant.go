@@ -0,0 +1,36 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Roles de un cliente en una solicitud de crédito
+const (
+	ParticipantRoleHolder     = "HOLDER"
+	ParticipantRoleCoBorrower = "CO_BORROWER"
+	ParticipantRoleGuarantor  = "GUARANTOR"
+)
+
+/*
+
+CreditRequestParticipant relaciona un cliente con una solicitud de crédito
+según su rol: el titular (HOLDER, el cliente de la solicitud), los
+codeudores (CO_BORROWER), que responden por el crédito junto al titular, y
+los fiadores (GUARANTOR), que respaldan el crédito con sus ingresos y
+bienes. El motor de riesgo suma los ingresos y bienes de los participantes
+con las ponderaciones de sus reglas.
+
+*/
+
+type CreditRequestParticipant struct {
+	ID              uint           `gorm:"primaryKey" json:"ID"`
+	CreatedAt       time.Time      `json:"CreatedAt"`
+	UpdatedAt       time.Time      `json:"UpdatedAt"`
+	DeletedAt       gorm.DeletedAt `gorm:"index" json:"-"`
+	CreditRequestID uint           `gorm:"not null;index" json:"creditRequestId"`
+	CustomerID      uint           `gorm:"not null;index" json:"customerId"`
+	Customer        Customer       `gorm:"foreignKey:CustomerID" json:"customer"`
+	Role            string         `gorm:"size:20;not null" json:"role"`
+}
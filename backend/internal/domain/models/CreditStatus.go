@@ -6,6 +6,14 @@ import (
 	"gorm.io/gorm"
 )
 
+// IDs de los estados sembrados por el seeder de estados de crédito
+const (
+	CreditStatusPendingID  uint = 1
+	CreditStatusApprovedID uint = 2
+	CreditStatusRejectedID uint = 3
+	CreditStatusInStudyID  uint = 4
+)
+
 type CreditStatus struct {
 	ID        uint           `gorm:"primaryKey" json:"ID"`
 	CreatedAt time.Time      `json:"CreatedAt"`
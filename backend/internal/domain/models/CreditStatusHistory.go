@@ -0,0 +1,42 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Origen de un cambio de estado de una solicitud de crédito
+const (
+	CreditStatusSourceCreated   = "CREDIT_REQUEST_CREATED"
+	CreditStatusSourceUpdated   = "CREDIT_REQUEST_UPDATED"
+	CreditStatusSourceDecision  = "CREDIT_DECISION"
+	CreditStatusSourceConfirmed = "CREDIT_DECISION_CONFIRMED"
+	CreditStatusSourceKnockOut  = "RISK_KNOCK_OUT"
+)
+
+/*
+
+CreditStatusHistory registra cada cambio de estado de una solicitud de
+crédito: estado anterior (nil al crearla), estado nuevo, usuario y rol que
+lo hicieron (nil y SYSTEM en los cambios automáticos) y el origen del
+cambio.
+
+*/
+
+type CreditStatusHistory struct {
+	ID              uint           `gorm:"primaryKey" json:"ID"`
+	CreatedAt       time.Time      `json:"CreatedAt"`
+	UpdatedAt       time.Time      `json:"UpdatedAt"`
+	DeletedAt       gorm.DeletedAt `gorm:"index" json:"-"`
+	CreditRequestID uint           `gorm:"not null;index" json:"creditRequestId"`
+	CreditRequest   CreditRequest  `gorm:"foreignKey:CreditRequestID" json:"-"`
+	FromStatusID    *uint          `json:"fromStatusId"`
+	FromStatus      *CreditStatus  `gorm:"foreignKey:FromStatusID" json:"fromStatus,omitempty"`
+	ToStatusID      uint           `gorm:"not null" json:"toStatusId"`
+	ToStatus        *CreditStatus  `gorm:"foreignKey:ToStatusID" json:"toStatus,omitempty"`
+	ChangedByID     *uint          `json:"changedById"`
+	Role            string         `gorm:"size:50;not null" json:"role"`
+	Source          string         `gorm:"size:50;not null" json:"source"`
+	Comment         string         `json:"comment"`
+}
@@ -14,6 +14,7 @@ type CustomerAsset struct {
 	CreditRequestID uint           `gorm:"not null" json:"creditRequestId"`
 	CreditRequest   CreditRequest  `gorm:"foreignKey:CreditRequestID" json:"-"`
 	AssetID         uint           `gorm:"not null" json:"assetId"`
+	Asset           Asset          `gorm:"foreignKey:AssetID" json:"-"`
 	CustomerID      uint           `gorm:"not null" json:"customerId"`
 	MarketValue     float64        `gorm:"not null" json:"marketValue"`
 	Description     string         `gorm:"size:255" json:"description"`
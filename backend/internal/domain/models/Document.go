@@ -0,0 +1,76 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Entidades a las que se puede adjuntar un documento
+const (
+	DocumentOwnerCustomer      = "CUSTOMER"
+	DocumentOwnerCreditRequest = "CREDIT_REQUEST"
+	DocumentOwnerCustomerAsset = "CUSTOMER_ASSET"
+)
+
+// Categorías de documento; los productos exigen algunas para aprobar una solicitud
+const (
+	DocumentCategoryIdentity      = "IDENTITY"
+	DocumentCategoryIncomeProof   = "INCOME_PROOF"
+	DocumentCategoryBankStatement = "BANK_STATEMENT"
+	DocumentCategoryPropertyDeed  = "PROPERTY_DEED"
+	DocumentCategoryVehicleTitle  = "VEHICLE_TITLE"
+	DocumentCategoryOther         = "OTHER"
+)
+
+var documentCategories = []string{
+	DocumentCategoryIdentity,
+	DocumentCategoryIncomeProof,
+	DocumentCategoryBankStatement,
+	DocumentCategoryPropertyDeed,
+	DocumentCategoryVehicleTitle,
+	DocumentCategoryOther,
+}
+
+// DocumentCategories retorna las categorías de documento válidas.
+func DocumentCategories() []string {
+	return append([]string(nil), documentCategories...)
+}
+
+func IsValidDocumentCategory(category string) bool {
+	for _, c := range documentCategories {
+		if c == category {
+			return true
+		}
+	}
+	return false
+}
+
+func IsValidDocumentOwnerType(ownerType string) bool {
+	return ownerType == DocumentOwnerCustomer || ownerType == DocumentOwnerCreditRequest || ownerType == DocumentOwnerCustomerAsset
+}
+
+/*
+
+Document es un archivo adjunto a un cliente, a una solicitud de crédito o a
+un bien del cliente (OwnerType y OwnerID). El contenido se guarda en el
+almacenamiento de documentos bajo StorageKey; aquí quedan sus metadatos y
+la suma SHA-256, que se verifica al descargarlo.
+
+*/
+
+type Document struct {
+	ID           uint           `gorm:"primaryKey" json:"ID"`
+	CreatedAt    time.Time      `json:"CreatedAt"`
+	UpdatedAt    time.Time      `json:"UpdatedAt"`
+	DeletedAt    gorm.DeletedAt `gorm:"index" json:"-"`
+	OwnerType    string         `gorm:"size:20;not null;index:idx_documents_owner" json:"ownerType"`
+	OwnerID      uint           `gorm:"not null;index:idx_documents_owner" json:"ownerId"`
+	Category     string         `gorm:"size:30;not null" json:"category"`
+	FileName     string         `gorm:"size:255;not null" json:"fileName"`
+	ContentType  string         `gorm:"size:100;not null" json:"contentType"`
+	Size         int64          `gorm:"not null" json:"size"`
+	SHA256       string         `gorm:"size:64;not null" json:"sha256"`
+	StorageKey   string         `gorm:"size:255;not null" json:"-"`
+	UploadedByID uint           `gorm:"not null" json:"uploadedById"`
+}
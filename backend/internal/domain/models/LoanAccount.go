@@ -0,0 +1,76 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Estados de la cuenta de un crédito desembolsado
+const (
+	LoanAccountStatusActive  = "ACTIVE"
+	LoanAccountStatusPaidOff = "PAID_OFF"
+)
+
+/*
+
+LoanAccount es la cuenta del crédito de una solicitud aprobada. Lleva los
+saldos a partir de los pagos registrados contra el plan de pagos y la mora
+calculada por el job diario: días de atraso de la cuota vencida más
+antigua (DaysPastDue), su franja (DelinquencyBucket) y la mayor mora que
+ha tenido la cuenta (MaxDaysPastDue), que es la que usa el motor de riesgo.
+
+*/
+
+type LoanAccount struct {
+	ID                   uint            `gorm:"primaryKey" json:"ID"`
+	CreatedAt            time.Time       `json:"CreatedAt"`
+	UpdatedAt            time.Time       `json:"UpdatedAt"`
+	DeletedAt            gorm.DeletedAt  `gorm:"index" json:"-"`
+	CreditRequestID      uint            `gorm:"not null;uniqueIndex" json:"creditRequestId"`
+	PaymentScheduleID    uint            `gorm:"not null" json:"paymentScheduleId"`
+	PaymentSchedule      PaymentSchedule `gorm:"foreignKey:PaymentScheduleID" json:"-"`
+	Principal            float64         `json:"principal"`
+	OutstandingPrincipal float64         `json:"outstandingPrincipal"`
+	PrincipalPaid        float64         `gorm:"default:0" json:"principalPaid"`
+	InterestPaid         float64         `gorm:"default:0" json:"interestPaid"`
+	Status               string          `gorm:"size:20;not null" json:"status"`
+	DaysPastDue          int             `gorm:"default:0" json:"daysPastDue"`
+	DelinquencyBucket    string          `gorm:"size:10;not null" json:"delinquencyBucket"`
+	MaxDaysPastDue       int             `gorm:"default:0" json:"maxDaysPastDue"`
+	LastPaymentAt        *time.Time      `json:"lastPaymentAt"`
+	DelinquencyUpdatedAt *time.Time      `json:"delinquencyUpdatedAt"`
+}
+
+// HasPayments indica si la cuenta ya tiene pagos registrados.
+func (a LoanAccount) HasPayments() bool {
+	return a.LastPaymentAt != nil
+}
+
+// LoanPayment es un pago registrado en la cuenta, con su distribución por cuota.
+type LoanPayment struct {
+	ID              uint                    `gorm:"primaryKey" json:"ID"`
+	CreatedAt       time.Time               `json:"CreatedAt"`
+	UpdatedAt       time.Time               `json:"UpdatedAt"`
+	DeletedAt       gorm.DeletedAt          `gorm:"index" json:"-"`
+	LoanAccountID   uint                    `gorm:"not null;index" json:"loanAccountId"`
+	Amount          float64                 `gorm:"not null" json:"amount"`
+	InterestAmount  float64                 `json:"interestAmount"`
+	PrincipalAmount float64                 `json:"principalAmount"`
+	PaidAt          time.Time               `gorm:"not null" json:"paidAt"`
+	Reference       string                  `gorm:"size:100" json:"reference"`
+	RegisteredByID  uint                    `gorm:"not null" json:"registeredById"`
+	Allocations     []LoanPaymentAllocation `gorm:"foreignKey:LoanPaymentID" json:"allocations"`
+}
+
+// LoanPaymentAllocation es la parte de un pago aplicada a una cuota del plan.
+type LoanPaymentAllocation struct {
+	ID                uint           `gorm:"primaryKey" json:"ID"`
+	CreatedAt         time.Time      `json:"CreatedAt"`
+	UpdatedAt         time.Time      `json:"UpdatedAt"`
+	DeletedAt         gorm.DeletedAt `gorm:"index" json:"-"`
+	LoanPaymentID     uint           `gorm:"not null;index" json:"loanPaymentId"`
+	InstallmentNumber int            `gorm:"not null" json:"installmentNumber"`
+	InterestAmount    float64        `json:"interestAmount"`
+	PrincipalAmount   float64        `json:"principalAmount"`
+}
@@ -0,0 +1,69 @@
+package models
+
+import (
+	"math"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+/*
+
+PaymentSchedule es el plan de pagos de una solicitud aprobada. Se genera al
+aprobarla con el monto y el plazo de la solicitud, la tasa de la oferta (o
+la de la solicitud o la tasa base del producto si no hay oferta) y el
+método de amortización del producto. StartDate es la fecha de aprobación y
+la primera cuota vence un mes después.
+
+*/
+
+type PaymentSchedule struct {
+	ID              uint                         `gorm:"primaryKey" json:"ID"`
+	CreatedAt       time.Time                    `json:"CreatedAt"`
+	UpdatedAt       time.Time                    `json:"UpdatedAt"`
+	DeletedAt       gorm.DeletedAt               `gorm:"index" json:"-"`
+	CreditRequestID uint                         `gorm:"not null;uniqueIndex" json:"creditRequestId"`
+	CreditRequest   CreditRequest                `gorm:"foreignKey:CreditRequestID" json:"-"`
+	Method          string                       `gorm:"size:20;not null" json:"method"`
+	Principal       float64                      `json:"principal"`
+	AnnualRate      float64                      `json:"annualRate"`
+	TermMonths      int                          `json:"termMonths"`
+	StartDate       time.Time                    `json:"startDate"`
+	TotalInterest   float64                      `json:"totalInterest"`
+	TotalPayment    float64                      `json:"totalPayment"`
+	Installments    []PaymentScheduleInstallment `gorm:"foreignKey:PaymentScheduleID" json:"installments"`
+}
+
+// PaymentScheduleInstallment es una cuota del plan; Balance es el saldo de capital
+// después de pagarla. InterestPaid y PrincipalPaid acumulan lo abonado por los pagos
+// y PaidAt es la fecha del pago que la completó.
+type PaymentScheduleInstallment struct {
+	ID                uint           `gorm:"primaryKey" json:"ID"`
+	CreatedAt         time.Time      `json:"CreatedAt"`
+	UpdatedAt         time.Time      `json:"UpdatedAt"`
+	DeletedAt         gorm.DeletedAt `gorm:"index" json:"-"`
+	PaymentScheduleID uint           `gorm:"not null;index" json:"paymentScheduleId"`
+	Number            int            `gorm:"not null" json:"number"`
+	DueDate           time.Time      `json:"dueDate"`
+	Payment           float64        `json:"payment"`
+	Principal         float64        `json:"principal"`
+	Interest          float64        `json:"interest"`
+	Balance           float64        `json:"balance"`
+	InterestPaid      float64        `gorm:"default:0" json:"interestPaid"`
+	PrincipalPaid     float64        `gorm:"default:0" json:"principalPaid"`
+	PaidAt            *time.Time     `json:"paidAt"`
+}
+
+// PendingInterest retorna el interés de la cuota que falta por pagar.
+func (i PaymentScheduleInstallment) PendingInterest() float64 {
+	return math.Max(0, math.Round((i.Interest-i.InterestPaid)*100)/100)
+}
+
+// PendingPrincipal retorna el capital de la cuota que falta por pagar.
+func (i PaymentScheduleInstallment) PendingPrincipal() float64 {
+	return math.Max(0, math.Round((i.Principal-i.PrincipalPaid)*100)/100)
+}
+
+func (i PaymentScheduleInstallment) IsPaid() bool {
+	return i.PendingInterest() == 0 && i.PendingPrincipal() == 0
+}
@@ -0,0 +1,60 @@
+package models
+
+// Recomendaciones del motor de riesgo
+const (
+	RiskRecommendationApprove = "APPROVE"
+	RiskRecommendationReview  = "REVIEW"
+	RiskRecommendationReject  = "REJECT"
+)
+
+/*
+
+RiskAssessment es el resultado estructurado de una evaluación de riesgo.
+El puntaje es BaseScore más la suma de los puntos de cada factor, acotado
+al rango del motor. Los motores calibrados (scorecard) informan además la
+probabilidad de incumplimiento. Explanation es la vista en texto del mismo
+resultado.
+
+*/
+
+type RiskAssessment struct {
+	// Versión de las reglas del motor; vacía si la solicitud se rechazó por políticas sin puntuar
+	EngineVersion string `json:"engineVersion"`
+	// Versión de las políticas que rechazaron la solicitud
+	PolicyVersion string  `json:"policyVersion,omitempty"`
+	BaseScore     float64 `json:"baseScore"`
+	Score         float64 `json:"score"`
+	// Probabilidad de incumplimiento (0–1); nil si el motor no está calibrado
+	ProbabilityOfDefault *float64          `json:"probabilityOfDefault,omitempty"`
+	Category             string            `json:"category"`
+	Recommendation       string            `json:"recommendation"`
+	Factors              []RiskFactor      `json:"factors"`
+	Improvements         []RiskImprovement `json:"improvements"`
+	// Reglas de política incumplidas; si hay alguna la solicitud se rechaza sin puntuar
+	KnockOuts   []RiskKnockOut `json:"knockOuts,omitempty"`
+	Explanation string         `json:"-"`
+}
+
+// RiskFactor es un elemento que aporta (o explica) el puntaje. Los factores
+// informativos tienen Points en cero.
+type RiskFactor struct {
+	Code          string   `json:"code"`
+	Points        float64  `json:"points"`
+	ObservedValue *float64 `json:"observedValue,omitempty"`
+	// Parámetros con los que se renderiza el mensaje en cada idioma
+	Params  map[string]float64 `json:"params,omitempty"`
+	Message string             `json:"message"`
+}
+
+type RiskImprovement struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// RiskKnockOut es una regla de política incumplida que rechaza la solicitud
+// automáticamente, antes de ejecutar el motor de riesgo.
+type RiskKnockOut struct {
+	Code    string             `json:"code"`
+	Params  map[string]float64 `json:"params,omitempty"`
+	Message string             `json:"message"`
+}
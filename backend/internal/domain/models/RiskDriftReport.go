@@ -0,0 +1,83 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Estado de estabilidad de una característica o de un reporte completo
+const (
+	RiskDriftStatusStable           = "STABLE"
+	RiskDriftStatusWarning          = "WARNING"
+	RiskDriftStatusAlert            = "ALERT"
+	RiskDriftStatusInsufficientData = "INSUFFICIENT_DATA"
+)
+
+// Características monitoreadas: las entradas del motor de riesgo y el puntaje resultante
+const (
+	RiskDriftScore         = "SCORE"
+	RiskDriftMonthlyIncome = "MONTHLY_INCOME"
+	RiskDriftAmount        = "AMOUNT"
+	RiskDriftTermMonths    = "TERM_MONTHS"
+	RiskDriftAssetRatio    = "ASSET_RATIO"
+	RiskDriftProductType   = "PRODUCT_TYPE"
+)
+
+/*
+
+RiskDriftReport compara la población de solicitudes de un período con la de
+un período base. Para cada característica se calcula el índice de
+estabilidad (PSI para el puntaje, CSI para las entradas del motor) sobre los
+bins definidos con el período base. El estado del reporte es el peor de sus
+características.
+
+*/
+
+type RiskDriftReport struct {
+	ID              uint           `gorm:"primaryKey" json:"ID"`
+	CreatedAt       time.Time      `gorm:"index" json:"CreatedAt"`
+	UpdatedAt       time.Time      `json:"UpdatedAt"`
+	DeletedAt       gorm.DeletedAt `gorm:"index" json:"-"`
+	BaselineFrom    time.Time      `json:"baselineFrom"`
+	BaselineTo      time.Time      `json:"baselineTo"`
+	CurrentFrom     time.Time      `json:"currentFrom"`
+	CurrentTo       time.Time      `json:"currentTo"`
+	BaselineCount   int            `json:"baselineCount"`
+	CurrentCount    int            `json:"currentCount"`
+	WarningPSI      float64        `json:"warningPsi"`
+	AlertPSI        float64        `json:"alertPsi"`
+	Status          string         `gorm:"size:20;not null;index" json:"status"`
+	MaxPSI          float64        `json:"maxPsi"`
+	Characteristics JSONB          `gorm:"type:jsonb" json:"characteristics"`
+}
+
+// RiskDriftCharacteristic es la estabilidad de una característica; se guarda
+// como JSON dentro del reporte.
+type RiskDriftCharacteristic struct {
+	Code   string         `json:"code"`
+	PSI    float64        `json:"psi"`
+	Status string         `json:"status"`
+	Bins   []RiskDriftBin `json:"bins"`
+}
+
+// RiskDriftBin es la proporción de solicitudes de cada período en un bin y su aporte al índice.
+type RiskDriftBin struct {
+	Label         string  `json:"label"`
+	BaselineShare float64 `json:"baselineShare"`
+	CurrentShare  float64 `json:"currentShare"`
+	Contribution  float64 `json:"contribution"`
+}
+
+// RiskDriftObservation son los datos de una solicitud que se monitorean.
+type RiskDriftObservation struct {
+	CreditRequestID uint
+	MonthlyIncome   float64
+	Amount          float64
+	TermMonths      int
+	AssetValue      float64
+	ProductType     string
+	Score           float64
+	// Vacío si la solicitud no se ha evaluado; su puntaje no se monitorea
+	EngineVersion string
+}
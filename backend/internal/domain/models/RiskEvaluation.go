@@ -0,0 +1,291 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Motivos que disparan una evaluación de riesgo
+const (
+	RiskTriggerCreditRequestCreated = "CREDIT_REQUEST_CREATED"
+	RiskTriggerCreditRequestUpdated = "CREDIT_REQUEST_UPDATED"
+	RiskTriggerCustomerAssetCreated = "CUSTOMER_ASSET_CREATED"
+	RiskTriggerCustomerAssetUpdated = "CUSTOMER_ASSET_UPDATED"
+	RiskTriggerCustomerAssetDeleted = "CUSTOMER_ASSET_DELETED"
+	RiskTriggerParticipantAdded     = "PARTICIPANT_ADDED"
+	RiskTriggerParticipantRemoved   = "PARTICIPANT_REMOVED"
+)
+
+type RiskEvaluation struct {
+	ID              uint           `gorm:"primaryKey" json:"ID"`
+	CreatedAt       time.Time      `json:"CreatedAt"`
+	UpdatedAt       time.Time      `json:"UpdatedAt"`
+	DeletedAt       gorm.DeletedAt `gorm:"index" json:"-"`
+	CreditRequestID uint           `gorm:"not null;index" json:"creditRequestId"`
+	CreditRequest   CreditRequest  `gorm:"foreignKey:CreditRequestID" json:"-"`
+	Trigger         string         `gorm:"size:50;not null" json:"trigger"`
+	EngineVersion   string         `json:"engineVersion"`
+	Score           float64        `json:"score"`
+	Category        string         `json:"category"`
+	Explanation     string         `gorm:"type:TEXT" json:"explanation"`
+	Assessment      JSONB          `gorm:"type:jsonb" json:"assessment"`
+	InputSnapshot   JSONB          `gorm:"type:jsonb" json:"inputSnapshot"`
+}
+
+// RiskInputSnapshot guarda los datos con los que se calculó una evaluación.
+type RiskInputSnapshot struct {
+	CreditRequestID uint    `json:"creditRequestId"`
+	CustomerID      uint    `json:"customerId"`
+	MonthlyIncome   float64 `json:"monthlyIncome"`
+	Amount          float64 `json:"amount"`
+	TermMonths      int     `json:"termMonths"`
+	InterestRate    float64 `json:"interestRate"`
+	ProductType     string  `json:"productType"`
+	// Producto del catálogo; vacío en solicitudes anteriores al catálogo
+	Product      *RiskSnapshotProduct `json:"product,omitempty"`
+	Assets       []RiskSnapshotAsset  `json:"assets"`
+	PriorCredits []RiskSnapshotCredit `json:"priorCredits"`
+	// Codeudores y fiadores; vacío en los snapshots anteriores a los participantes
+	Participants []RiskSnapshotParticipant `json:"participants,omitempty"`
+}
+
+// RiskSnapshotProduct son los datos del producto del catálogo que usan el motor
+// (ponderador de riesgo y tasa base) y las políticas (código).
+type RiskSnapshotProduct struct {
+	ID                 uint    `json:"id"`
+	Code               string  `json:"code"`
+	RiskWeight         float64 `json:"riskWeight"`
+	BaseAnnualRate     float64 `json:"baseAnnualRate"`
+	CollateralRequired bool    `json:"collateralRequired"`
+	AmortizationMethod string  `json:"amortizationMethod,omitempty"`
+}
+
+type RiskSnapshotParticipant struct {
+	CustomerID    uint    `json:"customerId"`
+	Role          string  `json:"role"`
+	MonthlyIncome float64 `json:"monthlyIncome"`
+}
+
+type RiskSnapshotAsset struct {
+	ID                     uint      `json:"id"`
+	AssetID                uint      `json:"assetId"`
+	CustomerID             uint      `json:"customerId"`
+	AssetName              string    `json:"assetName"`
+	MarketValue            float64   `json:"marketValue"`
+	Description            string    `json:"description"`
+	CreatedAt              time.Time `json:"createdAt"`
+	CollateralEligible     bool      `json:"collateralEligible"`
+	CollateralHaircut      float64   `json:"collateralHaircut"`
+	DepreciationMethod     string    `json:"depreciationMethod"`
+	AnnualDepreciationRate float64   `json:"annualDepreciationRate"`
+	RealEstate             bool      `json:"realEstate"`
+}
+
+type RiskSnapshotCredit struct {
+	ID             uint      `json:"id"`
+	Amount         float64   `json:"amount"`
+	TermMonths     int       `json:"termMonths"`
+	InterestRate   float64   `json:"interestRate"`
+	ProductType    string    `json:"productType"`
+	CreditStatusID uint      `json:"creditStatusId"`
+	CreatedAt      time.Time `json:"createdAt"`
+	// Fecha de aprobación según el historial de estados; vacía si no la tiene
+	ApprovedAt *time.Time `json:"approvedAt,omitempty"`
+	// Producto del catálogo y cuenta del crédito desembolsado; vacíos si no tiene
+	Product     *RiskSnapshotProduct `json:"product,omitempty"`
+	LoanAccount *RiskSnapshotLoan    `json:"loanAccount,omitempty"`
+}
+
+// RiskSnapshotLoan es el comportamiento de pago de un crédito con el que el motor
+// califica el historial (pagos realizados y mora).
+type RiskSnapshotLoan struct {
+	Status               string     `json:"status"`
+	OutstandingPrincipal float64    `json:"outstandingPrincipal"`
+	DaysPastDue          int        `json:"daysPastDue"`
+	MaxDaysPastDue       int        `json:"maxDaysPastDue"`
+	LastPaymentAt        *time.Time `json:"lastPaymentAt"`
+}
+
+// NewRiskInputSnapshot copia los datos con los que se evalúa una solicitud.
+func NewRiskInputSnapshot(customer Customer, creditRequest CreditRequest,
+	otherCredits []CreditRequest, assets []CustomerAsset) RiskInputSnapshot {
+
+	snapshot := RiskInputSnapshot{
+		CreditRequestID: creditRequest.ID,
+		CustomerID:      customer.ID,
+		MonthlyIncome:   customer.MonthlyIncome,
+		Amount:          creditRequest.Amount,
+		TermMonths:      creditRequest.TermMonths,
+		InterestRate:    creditRequest.InterestRate,
+		ProductType:     creditRequest.ProductType,
+		Product:         newRiskSnapshotProduct(creditRequest.CreditProduct),
+		Assets:          make([]RiskSnapshotAsset, 0, len(assets)),
+		PriorCredits:    make([]RiskSnapshotCredit, 0, len(otherCredits)),
+	}
+
+	for _, a := range assets {
+		snapshot.Assets = append(snapshot.Assets, RiskSnapshotAsset{
+			ID:                     a.ID,
+			AssetID:                a.AssetID,
+			CustomerID:             a.CustomerID,
+			AssetName:              a.Asset.Name,
+			MarketValue:            a.MarketValue,
+			Description:            a.Description,
+			CreatedAt:              a.CreatedAt,
+			CollateralEligible:     a.Asset.CollateralEligible,
+			CollateralHaircut:      a.Asset.CollateralHaircut,
+			DepreciationMethod:     a.Asset.DepreciationMethod,
+			AnnualDepreciationRate: a.Asset.AnnualDepreciationRate,
+			RealEstate:             a.Asset.RealEstate,
+		})
+	}
+
+	for _, p := range creditRequest.Participants {
+		if p.Role == ParticipantRoleHolder {
+			continue
+		}
+		snapshot.Participants = append(snapshot.Participants, RiskSnapshotParticipant{
+			CustomerID:    p.CustomerID,
+			Role:          p.Role,
+			MonthlyIncome: p.Customer.MonthlyIncome,
+		})
+	}
+
+	for _, c := range otherCredits {
+		credit := RiskSnapshotCredit{
+			ID:             c.ID,
+			Amount:         c.Amount,
+			TermMonths:     c.TermMonths,
+			InterestRate:   c.InterestRate,
+			ProductType:    c.ProductType,
+			CreditStatusID: c.CreditStatusID,
+			CreatedAt:      c.CreatedAt,
+			ApprovedAt:     c.ApprovedAt,
+			Product:        newRiskSnapshotProduct(c.CreditProduct),
+		}
+		if c.LoanAccount != nil {
+			credit.LoanAccount = &RiskSnapshotLoan{
+				Status:               c.LoanAccount.Status,
+				OutstandingPrincipal: c.LoanAccount.OutstandingPrincipal,
+				DaysPastDue:          c.LoanAccount.DaysPastDue,
+				MaxDaysPastDue:       c.LoanAccount.MaxDaysPastDue,
+				LastPaymentAt:        c.LoanAccount.LastPaymentAt,
+			}
+		}
+		snapshot.PriorCredits = append(snapshot.PriorCredits, credit)
+	}
+
+	return snapshot
+}
+
+// Models reconstruye los datos de evaluación a partir del snapshot, por ejemplo
+// en un servicio externo que recibe el snapshot por HTTP.
+func (s RiskInputSnapshot) Models() (Customer, CreditRequest, []CreditRequest, []CustomerAsset) {
+	customer := Customer{ID: s.CustomerID, MonthlyIncome: s.MonthlyIncome}
+
+	creditRequest := CreditRequest{
+		ID:           s.CreditRequestID,
+		Amount:       s.Amount,
+		TermMonths:   s.TermMonths,
+		InterestRate: s.InterestRate,
+		CustomerID:   s.CustomerID,
+		ProductType:  s.ProductType,
+	}
+	creditRequest.CreditProductID, creditRequest.CreditProduct = s.Product.model()
+
+	for _, p := range s.Participants {
+		creditRequest.Participants = append(creditRequest.Participants, CreditRequestParticipant{
+			CreditRequestID: s.CreditRequestID,
+			CustomerID:      p.CustomerID,
+			Customer:        Customer{ID: p.CustomerID, MonthlyIncome: p.MonthlyIncome},
+			Role:            p.Role,
+		})
+	}
+
+	otherCredits := make([]CreditRequest, 0, len(s.PriorCredits))
+	for _, c := range s.PriorCredits {
+		other := CreditRequest{
+			ID:             c.ID,
+			CreatedAt:      c.CreatedAt,
+			Amount:         c.Amount,
+			TermMonths:     c.TermMonths,
+			InterestRate:   c.InterestRate,
+			CustomerID:     s.CustomerID,
+			ProductType:    c.ProductType,
+			CreditStatusID: c.CreditStatusID,
+			ApprovedAt:     c.ApprovedAt,
+		}
+		other.CreditProductID, other.CreditProduct = c.Product.model()
+		if c.LoanAccount != nil {
+			other.LoanAccount = &LoanAccount{
+				CreditRequestID:      c.ID,
+				Principal:            c.Amount,
+				OutstandingPrincipal: c.LoanAccount.OutstandingPrincipal,
+				Status:               c.LoanAccount.Status,
+				DaysPastDue:          c.LoanAccount.DaysPastDue,
+				MaxDaysPastDue:       c.LoanAccount.MaxDaysPastDue,
+				LastPaymentAt:        c.LoanAccount.LastPaymentAt,
+			}
+		}
+		otherCredits = append(otherCredits, other)
+	}
+
+	assets := make([]CustomerAsset, 0, len(s.Assets))
+	for _, a := range s.Assets {
+		customerID := a.CustomerID
+		if customerID == 0 {
+			customerID = s.CustomerID
+		}
+		assets = append(assets, CustomerAsset{
+			ID:          a.ID,
+			CreatedAt:   a.CreatedAt,
+			AssetID:     a.AssetID,
+			CustomerID:  customerID,
+			MarketValue: a.MarketValue,
+			Description: a.Description,
+			Asset: Asset{
+				ID:                     a.AssetID,
+				Name:                   a.AssetName,
+				CollateralEligible:     a.CollateralEligible,
+				CollateralHaircut:      a.CollateralHaircut,
+				DepreciationMethod:     a.DepreciationMethod,
+				AnnualDepreciationRate: a.AnnualDepreciationRate,
+				RealEstate:             a.RealEstate,
+			},
+		})
+	}
+
+	return customer, creditRequest, otherCredits, assets
+}
+
+func newRiskSnapshotProduct(product *CreditProduct) *RiskSnapshotProduct {
+	if product == nil {
+		return nil
+	}
+	return &RiskSnapshotProduct{
+		ID:                 product.ID,
+		Code:               product.Code,
+		RiskWeight:         product.RiskWeight,
+		BaseAnnualRate:     product.BaseAnnualRate,
+		CollateralRequired: product.CollateralRequired,
+		AmortizationMethod: product.AmortizationMethod,
+	}
+}
+
+// model reconstruye el producto del catálogo con los datos del snapshot.
+func (p *RiskSnapshotProduct) model() (*uint, *CreditProduct) {
+	if p == nil {
+		return nil, nil
+	}
+	id := p.ID
+	return &id, &CreditProduct{
+		ID:                 p.ID,
+		Code:               p.Code,
+		RiskWeight:         p.RiskWeight,
+		BaseAnnualRate:     p.BaseAnnualRate,
+		CollateralRequired: p.CollateralRequired,
+		AmortizationMethod: p.AmortizationMethod,
+		Status:             true,
+	}
+}
@@ -0,0 +1,27 @@
+package models
+
+import "time"
+
+/*
+
+RiskReport es el reporte firmado de una evaluación de riesgo. Payload guarda
+los bytes exactos del JSON canónico (TEXT y no jsonb, que reordenaría las
+claves), Hash su SHA-256 y Signature la firma Ed25519 en base64 con la clave
+KeyID. Cada reporte incluye el hash del anterior (PreviousHash), de modo que
+los reportes forman una cadena de sólo inserción: no tiene UpdatedAt ni
+DeletedAt y la base de datos rechaza las actualizaciones y eliminaciones.
+
+*/
+
+type RiskReport struct {
+	ID               uint      `gorm:"primaryKey" json:"ID"`
+	CreatedAt        time.Time `json:"CreatedAt"`
+	Sequence         uint64    `gorm:"not null;uniqueIndex" json:"sequence"`
+	RiskEvaluationID uint      `gorm:"not null;uniqueIndex" json:"riskEvaluationId"`
+	CreditRequestID  uint      `gorm:"not null;index" json:"creditRequestId"`
+	Payload          string    `gorm:"type:TEXT;not null" json:"payload"`
+	PreviousHash     string    `gorm:"size:64;not null;uniqueIndex" json:"previousHash"`
+	Hash             string    `gorm:"size:64;not null;uniqueIndex" json:"hash"`
+	Signature        string    `gorm:"size:88;not null" json:"signature"`
+	KeyID            string    `gorm:"size:16;not null" json:"keyId"`
+}
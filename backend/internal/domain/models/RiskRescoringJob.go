@@ -0,0 +1,71 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Estados de un proceso de re-scoring de la cartera
+const (
+	RiskRescoringStatusRunning     = "RUNNING"
+	RiskRescoringStatusCompleted   = "COMPLETED"
+	RiskRescoringStatusInterrupted = "INTERRUPTED"
+	RiskRescoringStatusFailed      = "FAILED"
+)
+
+// Motivo de las evaluaciones guardadas por un re-scoring
+const RiskTriggerBatchRescoring = "BATCH_RESCORING"
+
+/*
+
+RiskRescoringJob es una ejecución del re-scoring masivo. Las solicitudes se
+recorren por ID ascendente y LastProcessedID marca hasta dónde terminó el
+último lote, de modo que un proceso interrumpido se reanuda desde ahí con
+el mismo filtro. En modo dry-run no se modifica ninguna solicitud: sólo se
+registran los cambios de puntaje y categoría que produciría el motor.
+
+*/
+
+type RiskRescoringJob struct {
+	ID              uint           `gorm:"primaryKey" json:"ID"`
+	CreatedAt       time.Time      `json:"CreatedAt"`
+	UpdatedAt       time.Time      `json:"UpdatedAt"`
+	DeletedAt       gorm.DeletedAt `gorm:"index" json:"-"`
+	Status          string         `gorm:"size:20;not null;index" json:"status"`
+	DryRun          bool           `json:"dryRun"`
+	Workers         int            `json:"workers"`
+	Filter          JSONB          `gorm:"type:jsonb" json:"filter"`
+	LastProcessedID uint           `json:"lastProcessedId"`
+	Processed       int            `json:"processed"`
+	Changed         int            `json:"changed"`
+	Failed          int            `json:"failed"`
+	Error           string         `gorm:"type:TEXT" json:"error,omitempty"`
+	FinishedAt      *time.Time     `json:"finishedAt"`
+}
+
+// RiskRescoringFilter limita las solicitudes a re-evaluar; los campos vacíos no filtran.
+type RiskRescoringFilter struct {
+	CustomerID      *uint      `json:"customerId,omitempty"`
+	CreditStatusIDs []uint     `json:"creditStatusIds,omitempty"`
+	CreatedFrom     *time.Time `json:"createdFrom,omitempty"`
+	CreatedTo       *time.Time `json:"createdTo,omitempty"`
+	// Sólo solicitudes evaluadas con esta versión de motor
+	EngineVersion string `json:"engineVersion,omitempty"`
+}
+
+// RiskRescoringChange registra una solicitud cuyo puntaje o categoría cambió
+// (o que no se pudo re-evaluar) durante un re-scoring.
+type RiskRescoringChange struct {
+	ID               uint      `gorm:"primaryKey" json:"ID"`
+	CreatedAt        time.Time `json:"CreatedAt"`
+	JobID            uint      `gorm:"not null;index" json:"jobId"`
+	CreditRequestID  uint      `gorm:"not null;index" json:"creditRequestId"`
+	PreviousVersion  string    `json:"previousVersion"`
+	PreviousScore    float64   `json:"previousScore"`
+	PreviousCategory string    `json:"previousCategory"`
+	EngineVersion    string    `json:"engineVersion"`
+	Score            float64   `json:"score"`
+	Category         string    `json:"category"`
+	Error            string    `gorm:"type:TEXT" json:"error,omitempty"`
+}
@@ -0,0 +1,46 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ShadowRiskEvaluation guarda el resultado de un motor challenger que se ejecutó
+// en modo sombra junto al champion. El resultado del champion se copia para
+// poder comparar sin recalcular.
+type ShadowRiskEvaluation struct {
+	ID                     uint           `gorm:"primaryKey" json:"ID"`
+	CreatedAt              time.Time      `gorm:"index" json:"CreatedAt"`
+	UpdatedAt              time.Time      `json:"UpdatedAt"`
+	DeletedAt              gorm.DeletedAt `gorm:"index" json:"-"`
+	RiskEvaluationID       uint           `gorm:"not null;index" json:"riskEvaluationId"`
+	RiskEvaluation         RiskEvaluation `gorm:"foreignKey:RiskEvaluationID" json:"-"`
+	CreditRequestID        uint           `gorm:"not null;index" json:"creditRequestId"`
+	Engine                 string         `gorm:"size:50;not null;index" json:"engine"`
+	EngineVersion          string         `json:"engineVersion"`
+	Score                  float64        `json:"score"`
+	Category               string         `json:"category"`
+	Recommendation         string         `json:"recommendation"`
+	Assessment             JSONB          `gorm:"type:jsonb" json:"assessment"`
+	Error                  string         `gorm:"type:TEXT" json:"error,omitempty"`
+	DurationMs             int64          `json:"durationMs"`
+	ChampionEngineVersion  string         `json:"championEngineVersion"`
+	ChampionScore          float64        `json:"championScore"`
+	ChampionCategory       string         `json:"championCategory"`
+	ChampionRecommendation string         `json:"championRecommendation"`
+}
+
+// EngineComparison resume qué tanto coincide un motor challenger con el champion.
+type EngineComparison struct {
+	Engine                      string  `json:"engine"`
+	EngineVersion               string  `json:"engineVersion"`
+	ChampionEngineVersion       string  `json:"championEngineVersion"`
+	Evaluations                 int     `json:"evaluations"`
+	Errors                      int     `json:"errors"`
+	CategoryAgreementRate       float64 `json:"categoryAgreementRate"`
+	RecommendationAgreementRate float64 `json:"recommendationAgreementRate"`
+	MeanScoreDelta              float64 `json:"meanScoreDelta"`
+	MeanAbsoluteScoreDelta      float64 `json:"meanAbsoluteScoreDelta"`
+	MaxAbsoluteScoreDelta       float64 `json:"maxAbsoluteScoreDelta"`
+}
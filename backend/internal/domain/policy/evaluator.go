@@ -0,0 +1,51 @@
+package policy
+
+import (
+	"time"
+
+	"github.com/JhonCamargo53/prueba-tecnica/internal/domain/i18n"
+	"github.com/JhonCamargo53/prueba-tecnica/internal/domain/models"
+	"github.com/JhonCamargo53/prueba-tecnica/internal/domain/ports"
+)
+
+// PolicyRiskEvaluator revisa las políticas antes de delegar en el motor de riesgo.
+// Si hay rechazos automáticos el motor no se ejecuta.
+type PolicyRiskEvaluator struct {
+	rules *Rules
+	next  ports.RiskEvaluator
+}
+
+func NewPolicyRiskEvaluator(rules *Rules, next ports.RiskEvaluator) ports.RiskEvaluator {
+	return &PolicyRiskEvaluator{
+		rules: rules,
+		next:  next,
+	}
+}
+
+func (e *PolicyRiskEvaluator) Evaluate(customer models.Customer, currentCreditRequest models.CreditRequest,
+	otherCredits []models.CreditRequest, assets []models.CustomerAsset) (*models.RiskAssessment, error) {
+
+	knockOuts := Check(e.rules, customer, currentCreditRequest, otherCredits, time.Now())
+	if len(knockOuts) == 0 {
+		return e.next.Evaluate(customer, currentCreditRequest, otherCredits, assets)
+	}
+
+	return KnockOutAssessment(e.rules, knockOuts), nil
+}
+
+// KnockOutAssessment construye el resultado de una solicitud rechazada por políticas.
+// Ningún motor la puntuó, así que no lleva versión de motor sino la de las políticas.
+func KnockOutAssessment(rules *Rules, knockOuts []models.RiskKnockOut) *models.RiskAssessment {
+	assessment := &models.RiskAssessment{
+		PolicyVersion:  rules.Version,
+		Category:       "HIGH",
+		Recommendation: models.RiskRecommendationReject,
+		Factors:        []models.RiskFactor{},
+		Improvements:   []models.RiskImprovement{},
+		KnockOuts:      knockOuts,
+	}
+
+	assessment.Explanation = i18n.RenderExplanation(assessment, i18n.DefaultLanguage)
+
+	return assessment
+}
@@ -0,0 +1,289 @@
+package policy
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/JhonCamargo53/prueba-tecnica/internal/domain/i18n"
+	"github.com/JhonCamargo53/prueba-tecnica/internal/domain/models"
+)
+
+/*
+
+Políticas de crédito de cumplimiento obligatorio (knock-out). Se revisan
+antes del motor de riesgo: si la solicitud incumple alguna, se rechaza de
+forma automática con los códigos de las reglas incumplidas, sin importar
+el puntaje que hubiera obtenido.
+
+*/
+
+//go:embed default-policy.json
+var defaultPolicyJSON []byte
+
+// Códigos de rechazo automático
+const (
+	KnockOutInactiveCustomer = "INACTIVE_CUSTOMER"
+	KnockOutMinIncome        = "MIN_INCOME"
+	KnockOutInvalidTerm      = "INVALID_TERM"
+	KnockOutMaxTerm          = "MAX_TERM"
+	KnockOutInvalidAmount    = "INVALID_AMOUNT"
+	KnockOutMaxAmount        = "MAX_AMOUNT_TO_INCOME"
+	KnockOutRecentRejections = "RECENT_REJECTIONS"
+)
+
+type Rules struct {
+	Version                string                `json:"version"`
+	RejectInactiveCustomer bool                  `json:"rejectInactiveCustomer"`
+	Default                ProductLimits         `json:"default"`
+	Products               []ProductPolicy       `json:"products"`
+	RecentRejections       RecentRejectionsRules `json:"recentRejections"`
+	Participants           ParticipantRules      `json:"participants"`
+}
+
+// ProductLimits son los límites de un producto; un valor en cero desactiva la regla.
+type ProductLimits struct {
+	MinMonthlyIncome float64 `json:"minMonthlyIncome"`
+	MaxTermMonths    int     `json:"maxTermMonths"`
+	// Monto máximo expresado en múltiplos del ingreso mensual
+	MaxAmountToIncome float64 `json:"maxAmountToIncome"`
+}
+
+// ProductPolicy aplica a las solicitudes cuyo tipo de producto contiene alguna palabra clave.
+type ProductPolicy struct {
+	Code     string   `json:"code"`
+	Keywords []string `json:"keywords"`
+	ProductLimits
+}
+
+// RecentRejectionsRules rechaza a clientes con más de MaxCount rechazos en WindowDays días.
+type RecentRejectionsRules struct {
+	WindowDays int `json:"windowDays"`
+	MaxCount   int `json:"maxCount"`
+}
+
+// ParticipantRules pondera el ingreso de codeudores y fiadores que se suma al del
+// titular en las reglas de ingreso mínimo y de monto máximo; en cero no se suma.
+type ParticipantRules struct {
+	CoBorrowerIncomeWeight float64 `json:"coBorrowerIncomeWeight"`
+	GuarantorIncomeWeight  float64 `json:"guarantorIncomeWeight"`
+}
+
+// IncomeWeight retorna la ponderación del ingreso de un participante según su rol.
+func (p ParticipantRules) IncomeWeight(role string) float64 {
+	switch role {
+	case models.ParticipantRoleCoBorrower:
+		return p.CoBorrowerIncomeWeight
+	case models.ParticipantRoleGuarantor:
+		return p.GuarantorIncomeWeight
+	}
+	return 0
+}
+
+// DefaultRules retorna las políticas embebidas en el binario.
+func DefaultRules() *Rules {
+	rules, err := ParseRules(defaultPolicyJSON)
+	if err != nil {
+		panic(fmt.Sprintf("políticas de crédito por defecto inválidas: %v", err))
+	}
+	return rules
+}
+
+// LoadRules lee y valida las políticas desde un archivo JSON. Con ruta vacía
+// retorna las políticas embebidas.
+func LoadRules(path string) (*Rules, error) {
+	if path == "" {
+		return DefaultRules(), nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("no se pudo leer el archivo de políticas %s: %w", path, err)
+	}
+
+	rules, err := ParseRules(data)
+	if err != nil {
+		return nil, fmt.Errorf("archivo de políticas %s: %w", path, err)
+	}
+
+	return rules, nil
+}
+
+func ParseRules(data []byte) (*Rules, error) {
+	var rules Rules
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+
+	if err := decoder.Decode(&rules); err != nil {
+		return nil, fmt.Errorf("JSON de políticas inválido: %w", err)
+	}
+
+	if err := rules.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &rules, nil
+}
+
+func (r *Rules) Validate() error {
+	if strings.TrimSpace(r.Version) == "" {
+		return fmt.Errorf("las políticas deben tener una versión")
+	}
+
+	if err := r.Default.validate("default"); err != nil {
+		return err
+	}
+
+	for i, p := range r.Products {
+		if strings.TrimSpace(p.Code) == "" {
+			return fmt.Errorf("products[%d]: debe tener código", i)
+		}
+		if len(p.Keywords) == 0 {
+			return fmt.Errorf("products[%d]: debe tener al menos una palabra clave", i)
+		}
+		if err := p.validate(fmt.Sprintf("products[%d]", i)); err != nil {
+			return err
+		}
+	}
+
+	if r.RecentRejections.WindowDays < 0 || r.RecentRejections.MaxCount < 0 {
+		return fmt.Errorf("recentRejections: los valores no pueden ser negativos")
+	}
+
+	for name, weight := range map[string]float64{
+		"participants.coBorrowerIncomeWeight": r.Participants.CoBorrowerIncomeWeight,
+		"participants.guarantorIncomeWeight":  r.Participants.GuarantorIncomeWeight,
+	} {
+		if weight < 0 || weight > 1 {
+			return fmt.Errorf("%s debe estar entre 0 y 1", name)
+		}
+	}
+
+	return nil
+}
+
+func (l ProductLimits) validate(name string) error {
+	if l.MinMonthlyIncome < 0 || l.MaxTermMonths < 0 || l.MaxAmountToIncome < 0 {
+		return fmt.Errorf("%s: los límites no pueden ser negativos", name)
+	}
+	return nil
+}
+
+// LimitsFor retorna los límites del producto del catálogo con el mismo código, del
+// primer producto cuyas palabras clave coincidan con el tipo de producto o los
+// límites por defecto.
+func (r *Rules) LimitsFor(productCode, productType string) ProductLimits {
+	for _, p := range r.Products {
+		if productCode != "" && strings.EqualFold(p.Code, productCode) {
+			return p.ProductLimits
+		}
+	}
+
+	upper := strings.ToUpper(productType)
+	for _, p := range r.Products {
+		for _, k := range p.Keywords {
+			if k != "" && strings.Contains(upper, strings.ToUpper(k)) {
+				return p.ProductLimits
+			}
+		}
+	}
+	return r.Default
+}
+
+// Check retorna las reglas de política que incumple la solicitud (vacío = cumple todas).
+// El ingreso mínimo y el monto máximo se revisan con el ingreso del titular más el de
+// los codeudores y fiadores, ponderado según su rol.
+func Check(rules *Rules, customer models.Customer, creditRequest models.CreditRequest,
+	otherCredits []models.CreditRequest, now time.Time) []models.RiskKnockOut {
+
+	var knockOuts []models.RiskKnockOut
+	add := func(code string, params map[string]float64) {
+		knockOuts = append(knockOuts, models.RiskKnockOut{
+			Code:    code,
+			Params:  params,
+			Message: i18n.MustMessage(i18n.DefaultLanguage, "knockout."+code, params),
+		})
+	}
+
+	if rules.RejectInactiveCustomer && !customer.Status {
+		add(KnockOutInactiveCustomer, nil)
+	}
+
+	limits := rules.LimitsFor(creditRequest.ProductCode(), creditRequest.ProductType)
+	income := rules.householdIncome(customer, creditRequest)
+
+	if income <= 0 || (limits.MinMonthlyIncome > 0 && income < limits.MinMonthlyIncome) {
+		add(KnockOutMinIncome, map[string]float64{"income": income, "minIncome": limits.MinMonthlyIncome})
+	}
+
+	switch {
+	case creditRequest.TermMonths <= 0:
+		add(KnockOutInvalidTerm, map[string]float64{"termMonths": float64(creditRequest.TermMonths)})
+	case limits.MaxTermMonths > 0 && creditRequest.TermMonths > limits.MaxTermMonths:
+		add(KnockOutMaxTerm, map[string]float64{
+			"termMonths":    float64(creditRequest.TermMonths),
+			"maxTermMonths": float64(limits.MaxTermMonths),
+		})
+	}
+
+	switch {
+	case creditRequest.Amount <= 0:
+		add(KnockOutInvalidAmount, map[string]float64{"amount": creditRequest.Amount})
+	case limits.MaxAmountToIncome > 0 && income > 0 && creditRequest.Amount > limits.MaxAmountToIncome*income:
+		add(KnockOutMaxAmount, map[string]float64{
+			"amount":    creditRequest.Amount,
+			"multiple":  limits.MaxAmountToIncome,
+			"maxAmount": limits.MaxAmountToIncome * income,
+		})
+	}
+
+	if window := rules.RecentRejections; window.WindowDays > 0 {
+		since := now.AddDate(0, 0, -window.WindowDays)
+		if count := rejectedSince(otherCredits, since); count > window.MaxCount {
+			add(KnockOutRecentRejections, map[string]float64{
+				"count":      float64(count),
+				"maxCount":   float64(window.MaxCount),
+				"windowDays": float64(window.WindowDays),
+			})
+		}
+	}
+
+	return knockOuts
+}
+
+// householdIncome suma al ingreso del titular el de los participantes de la solicitud
+// con la ponderación de su rol.
+func (r *Rules) householdIncome(customer models.Customer, creditRequest models.CreditRequest) float64 {
+	income := customer.MonthlyIncome
+	for _, p := range creditRequest.Participants {
+		if p.CustomerID == customer.ID || p.Role == models.ParticipantRoleHolder || p.Customer.MonthlyIncome <= 0 {
+			continue
+		}
+		income += r.Participants.IncomeWeight(p.Role) * p.Customer.MonthlyIncome
+	}
+	return income
+}
+
+// rejectedSince cuenta las solicitudes rechazadas desde la fecha dada. La fecha
+// de rechazo es la última actualización de la solicitud.
+func rejectedSince(otherCredits []models.CreditRequest, since time.Time) int {
+	count := 0
+	for _, other := range otherCredits {
+		if other.CreditStatusID != models.CreditStatusRejectedID {
+			continue
+		}
+		rejectedAt := other.UpdatedAt
+		if rejectedAt.IsZero() {
+			rejectedAt = other.CreatedAt
+		}
+		if !rejectedAt.Before(since) {
+			count++
+		}
+	}
+	return count
+}
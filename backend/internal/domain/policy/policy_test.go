@@ -0,0 +1,152 @@
+package policy
+
+import (
+	"testing"
+	"time"
+
+	"github.com/JhonCamargo53/prueba-tecnica/internal/domain/models"
+)
+
+func codes(t *testing.T, knockOuts []models.RiskKnockOut) map[string]bool {
+	t.Helper()
+	res := map[string]bool{}
+	for _, k := range knockOuts {
+		res[k.Code] = true
+		if k.Message == "" {
+			t.Errorf("el rechazo %s no tiene mensaje", k.Code)
+		}
+	}
+	return res
+}
+
+func TestCheck_SolicitudQueCumple(t *testing.T) {
+	customer := models.Customer{MonthlyIncome: 4_000_000, Status: true}
+	cr := models.CreditRequest{Amount: 20_000_000, TermMonths: 36, ProductType: "Libre inversión"}
+
+	if knockOuts := Check(DefaultRules(), customer, cr, nil, time.Now()); len(knockOuts) != 0 {
+		t.Fatalf("no se esperaban rechazos, obtenidos: %+v", knockOuts)
+	}
+}
+
+func TestCheck_IngresoCeroYPlazoInvalido(t *testing.T) {
+	customer := models.Customer{MonthlyIncome: 0, Status: true}
+	cr := models.CreditRequest{Amount: 5_000_000, TermMonths: 0}
+
+	got := codes(t, Check(DefaultRules(), customer, cr, nil, time.Now()))
+
+	if !got[KnockOutMinIncome] || !got[KnockOutInvalidTerm] {
+		t.Errorf("se esperaban MIN_INCOME e INVALID_TERM, obtenidos: %v", got)
+	}
+}
+
+func TestCheck_LimitesPorProducto(t *testing.T) {
+	rules := DefaultRules()
+	customer := models.Customer{MonthlyIncome: 3_000_000, Status: true}
+
+	// Vivienda admite plazos largos y montos altos
+	housing := models.CreditRequest{Amount: 200_000_000, TermMonths: 240, ProductType: "Crédito de vivienda"}
+	if knockOuts := Check(rules, customer, housing, nil, time.Now()); len(knockOuts) != 0 {
+		t.Errorf("no se esperaban rechazos para vivienda, obtenidos: %+v", knockOuts)
+	}
+
+	// El mismo monto y plazo en libre inversión supera los límites del producto
+	consumer := models.CreditRequest{Amount: 200_000_000, TermMonths: 240, ProductType: "Libre inversión"}
+	got := codes(t, Check(rules, customer, consumer, nil, time.Now()))
+	if !got[KnockOutMaxTerm] || !got[KnockOutMaxAmount] {
+		t.Errorf("se esperaban MAX_TERM y MAX_AMOUNT_TO_INCOME, obtenidos: %v", got)
+	}
+}
+
+func TestCheck_ProductoDelCatalogoPorCodigo(t *testing.T) {
+	customer := models.Customer{MonthlyIncome: 3_000_000, Status: true}
+
+	// El código del catálogo manda sobre el texto del tipo de producto
+	cr := models.CreditRequest{Amount: 200_000_000, TermMonths: 240, ProductType: "Vivienda para libre inversión",
+		CreditProduct: &models.CreditProduct{Code: "HOUSING"}}
+	if knockOuts := Check(DefaultRules(), customer, cr, nil, time.Now()); len(knockOuts) != 0 {
+		t.Errorf("se esperaban los límites de vivienda, obtenidos: %+v", knockOuts)
+	}
+}
+
+func TestCheck_ClienteInactivoYRechazosRecientes(t *testing.T) {
+	now := time.Now()
+	customer := models.Customer{MonthlyIncome: 4_000_000, Status: false}
+	cr := models.CreditRequest{Amount: 10_000_000, TermMonths: 24}
+
+	others := []models.CreditRequest{
+		{CreditStatusID: models.CreditStatusRejectedID, UpdatedAt: now.AddDate(0, 0, -10)},
+		{CreditStatusID: models.CreditStatusRejectedID, UpdatedAt: now.AddDate(0, 0, -30)},
+		{CreditStatusID: models.CreditStatusRejectedID, UpdatedAt: now.AddDate(0, 0, -60)},
+		// Fuera de la ventana de 90 días
+		{CreditStatusID: models.CreditStatusRejectedID, UpdatedAt: now.AddDate(0, 0, -200)},
+	}
+
+	got := codes(t, Check(DefaultRules(), customer, cr, others, now))
+	if !got[KnockOutInactiveCustomer] || !got[KnockOutRecentRejections] {
+		t.Errorf("se esperaban INACTIVE_CUSTOMER y RECENT_REJECTIONS, obtenidos: %v", got)
+	}
+
+	// Con dos rechazos recientes no se supera el máximo
+	got = codes(t, Check(DefaultRules(), models.Customer{MonthlyIncome: 4_000_000, Status: true}, cr, others[1:], now))
+	if got[KnockOutRecentRejections] {
+		t.Errorf("no se esperaba RECENT_REJECTIONS con 2 rechazos en la ventana")
+	}
+}
+
+func TestCheck_IngresoDeLosParticipantes(t *testing.T) {
+	customer := models.Customer{ID: 1, MonthlyIncome: 800_000, Status: true}
+	cr := models.CreditRequest{Amount: 20_000_000, TermMonths: 36, ProductType: "Libre inversión"}
+
+	// Sólo con el ingreso del titular no alcanza el mínimo ni el monto
+	got := codes(t, Check(DefaultRules(), customer, cr, nil, time.Now()))
+	if !got[KnockOutMinIncome] || !got[KnockOutMaxAmount] {
+		t.Fatalf("se esperaban MIN_INCOME y MAX_AMOUNT_TO_INCOME, obtenidos: %v", got)
+	}
+
+	// El ingreso del fiador no se suma con la ponderación por defecto
+	cr.Participants = []models.CreditRequestParticipant{
+		{CustomerID: 1, Role: models.ParticipantRoleHolder, Customer: customer},
+		{CustomerID: 3, Role: models.ParticipantRoleGuarantor, Customer: models.Customer{ID: 3, MonthlyIncome: 5_000_000}},
+	}
+	if got := codes(t, Check(DefaultRules(), customer, cr, nil, time.Now())); !got[KnockOutMinIncome] {
+		t.Errorf("el fiador no debería sumar ingreso, obtenidos: %v", got)
+	}
+
+	// Con el codeudor el ingreso del hogar cumple ambas reglas
+	cr.Participants = append(cr.Participants, models.CreditRequestParticipant{
+		CustomerID: 2, Role: models.ParticipantRoleCoBorrower, Customer: models.Customer{ID: 2, MonthlyIncome: 1_000_000},
+	})
+	if knockOuts := Check(DefaultRules(), customer, cr, nil, time.Now()); len(knockOuts) != 0 {
+		t.Errorf("no se esperaban rechazos con el ingreso del codeudor, obtenidos: %+v", knockOuts)
+	}
+}
+
+func TestPolicyRiskEvaluator_NoEjecutaElMotorSiHayRechazo(t *testing.T) {
+	next := &stubEvaluator{}
+	evaluator := NewPolicyRiskEvaluator(DefaultRules(), next)
+
+	assessment, err := evaluator.Evaluate(models.Customer{Status: true}, models.CreditRequest{Amount: 1, TermMonths: 12}, nil, nil)
+	if err != nil {
+		t.Fatalf("no se esperaba error: %v", err)
+	}
+	if next.called {
+		t.Errorf("no se esperaba ejecutar el motor de riesgo")
+	}
+	if assessment.Recommendation != models.RiskRecommendationReject || len(assessment.KnockOuts) == 0 {
+		t.Errorf("se esperaba un rechazo con códigos, obtenido: %+v", assessment)
+	}
+	// La versión de las políticas no se confunde con la del motor, que no se ejecutó
+	if assessment.PolicyVersion != DefaultRules().Version || assessment.EngineVersion != "" {
+		t.Errorf("se esperaba sólo la versión de las políticas, obtenido: %q / %q", assessment.PolicyVersion, assessment.EngineVersion)
+	}
+}
+
+type stubEvaluator struct {
+	called bool
+}
+
+func (s *stubEvaluator) Evaluate(customer models.Customer, currentCreditRequest models.CreditRequest,
+	otherCredits []models.CreditRequest, assets []models.CustomerAsset) (*models.RiskAssessment, error) {
+	s.called = true
+	return &models.RiskAssessment{}, nil
+}
@@ -0,0 +1,18 @@
+package ports
+
+import "github.com/JhonCamargo53/prueba-tecnica/internal/domain/models"
+
+type CreditDecisionRepository interface {
+	Create(decision *models.CreditDecision) error
+	Update(decision *models.CreditDecision) error
+	// Apply guarda en una sola transacción la decisión (nueva o resuelta), el nuevo
+	// estado de la solicitud y su cambio en el historial (change nil si el estado no
+	// cambia). Falla sin guardar nada si la solicitud ya no está en el estado de origen
+	Apply(decision *models.CreditDecision, change *models.CreditStatusHistory) error
+	FindByID(id uint) (*models.CreditDecision, error)
+	FindByCreditRequestID(creditRequestID uint) ([]models.CreditDecision, error)
+	// FindPending retorna las decisiones que esperan confirmación, de la más antigua a la más reciente
+	FindPending() ([]models.CreditDecision, error)
+	// FindEscalated retorna las decisiones escaladas que puede resolver un nivel de acceso, de la más antigua a la más reciente
+	FindEscalated(access int) ([]models.CreditDecision, error)
+}
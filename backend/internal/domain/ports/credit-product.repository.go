@@ -0,0 +1,13 @@
+package ports
+
+import "github.com/JhonCamargo53/prueba-tecnica/internal/domain/models"
+
+type CreditProductRepository interface {
+	FindAll() ([]models.CreditProduct, error)
+	FindByID(id uint) (*models.CreditProduct, error)
+	FindByCode(code string) (*models.CreditProduct, error)
+	Create(product *models.CreditProduct) error
+	Save(product *models.CreditProduct) error
+	Delete(id uint) error
+	HasCreditRequests(id uint) (bool, error)
+}
@@ -0,0 +1,10 @@
+package ports
+
+import "github.com/JhonCamargo53/prueba-tecnica/internal/domain/models"
+
+type CreditRequestParticipantRepository interface {
+	FindByCreditRequestID(creditRequestID uint) ([]models.CreditRequestParticipant, error)
+	FindByID(id uint) (*models.CreditRequestParticipant, error)
+	Create(participant *models.CreditRequestParticipant) error
+	Delete(id uint) error
+}
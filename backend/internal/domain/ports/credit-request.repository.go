@@ -9,6 +9,8 @@ type CreditRequestRepository interface {
 	Create(creditRequest *models.CreditRequest) (*models.CreditRequest, error)
 	Update(id uint, creditRequest *models.CreditRequest) (*models.CreditRequest, error)
 	Delete(id uint) error
-	UpdateCreditRiskEvaluation(id uint, score float64, category string, explanation string) (*models.CreditRequest, error)
+	UpdateCreditRiskEvaluation(id uint, assessment *models.RiskAssessment) (*models.CreditRequest, error)
+	UpdateCreditStatus(id uint, creditStatusID uint) error
+	UpdateCreditOffer(id uint, offer *models.CreditOffer) error
 	FindDataToEvaluateRisk(id uint) (models.Customer, *models.CreditRequest, []models.CreditRequest, []models.CustomerAsset, error)
 }
@@ -0,0 +1,9 @@
+package ports
+
+import "github.com/JhonCamargo53/prueba-tecnica/internal/domain/models"
+
+type CreditStatusHistoryRepository interface {
+	Create(history *models.CreditStatusHistory) error
+	// FindByCreditRequestID retorna los cambios de estado del más antiguo al más reciente
+	FindByCreditRequestID(creditRequestID uint) ([]models.CreditStatusHistory, error)
+}
@@ -0,0 +1,10 @@
+package ports
+
+import "io"
+
+// DocumentStorage guarda el contenido de los documentos adjuntos bajo una clave.
+type DocumentStorage interface {
+	Save(key string, content io.Reader) error
+	Open(key string) (io.ReadCloser, error)
+	Delete(key string) error
+}
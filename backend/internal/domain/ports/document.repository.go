@@ -0,0 +1,12 @@
+package ports
+
+import "github.com/JhonCamargo53/prueba-tecnica/internal/domain/models"
+
+type DocumentRepository interface {
+	FindByID(id uint) (*models.Document, error)
+	FindByOwner(ownerType string, ownerID uint) ([]models.Document, error)
+	// FindForCreditRequest retorna los documentos de la solicitud, de su titular y de los bienes asociados a ella
+	FindForCreditRequest(creditRequestID, customerID uint) ([]models.Document, error)
+	Create(document *models.Document) error
+	Delete(id uint) error
+}
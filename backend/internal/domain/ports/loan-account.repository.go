@@ -0,0 +1,21 @@
+package ports
+
+import "github.com/JhonCamargo53/prueba-tecnica/internal/domain/models"
+
+type LoanAccountRepository interface {
+	// FindByCreditRequestID retorna la cuenta con su plan y cuotas, o nil si la solicitud no tiene cuenta
+	FindByCreditRequestID(creditRequestID uint) (*models.LoanAccount, error)
+	// FindActive retorna las cuentas activas con su plan y cuotas
+	FindActive() ([]models.LoanAccount, error)
+	// FindDelinquent retorna las cuentas activas en mora, de la mayor a la menor
+	FindDelinquent() ([]models.LoanAccount, error)
+	Create(account *models.LoanAccount) error
+	// UpdateDelinquency guarda los días de mora, la franja y la mayor mora de la cuenta
+	UpdateDelinquency(account *models.LoanAccount) error
+	// RegisterPayment bloquea la cuenta (SELECT ... FOR UPDATE) y la carga con su plan y
+	// cuotas; con ella build arma el pago y retorna las cuotas que cambiaron. Guarda en
+	// una sola transacción el pago con su distribución, esas cuotas y los saldos de la
+	// cuenta; si build falla no guarda nada
+	RegisterPayment(accountID uint, build func(account *models.LoanAccount) (*models.LoanPayment, []models.PaymentScheduleInstallment, error)) error
+	FindPayments(loanAccountID uint) ([]models.LoanPayment, error)
+}
@@ -0,0 +1,10 @@
+package ports
+
+import "github.com/JhonCamargo53/prueba-tecnica/internal/domain/models"
+
+type PaymentScheduleRepository interface {
+	// FindByCreditRequestID retorna el plan con sus cuotas ordenadas, o nil si la solicitud no tiene plan
+	FindByCreditRequestID(creditRequestID uint) (*models.PaymentSchedule, error)
+	// Create guarda el plan junto con sus cuotas
+	Create(schedule *models.PaymentSchedule) error
+}
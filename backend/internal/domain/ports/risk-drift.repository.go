@@ -0,0 +1,15 @@
+package ports
+
+import (
+	"time"
+
+	"github.com/JhonCamargo53/prueba-tecnica/internal/domain/models"
+)
+
+type RiskDriftRepository interface {
+	// FindObservations retorna los datos monitoreados de las solicitudes creadas en [from, to)
+	FindObservations(from, to time.Time) ([]models.RiskDriftObservation, error)
+	Create(report *models.RiskDriftReport) error
+	FindAll() ([]models.RiskDriftReport, error)
+	FindByID(id uint) (*models.RiskDriftReport, error)
+}
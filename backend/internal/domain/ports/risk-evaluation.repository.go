@@ -0,0 +1,8 @@
+package ports
+
+import "github.com/JhonCamargo53/prueba-tecnica/internal/domain/models"
+
+type RiskEvaluationRepository interface {
+	Create(evaluation *models.RiskEvaluation) error
+	FindByCreditRequestID(creditRequestID uint) ([]models.RiskEvaluation, error)
+}
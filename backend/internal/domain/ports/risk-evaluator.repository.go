@@ -4,5 +4,11 @@ import "github.com/JhonCamargo53/prueba-tecnica/internal/domain/models"
 
 type RiskEvaluator interface {
 	Evaluate(customer models.Customer, currentCreditRequest models.CreditRequest,
-		otherCredits []models.CreditRequest, assets []models.CustomerAsset) (float64, string, string, error)
+		otherCredits []models.CreditRequest, assets []models.CustomerAsset) (*models.RiskAssessment, error)
+}
+
+// NamedRiskEvaluator identifica un motor registrado, por ejemplo un challenger en modo sombra.
+type NamedRiskEvaluator struct {
+	Name      string
+	Evaluator RiskEvaluator
 }
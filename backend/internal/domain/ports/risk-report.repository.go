@@ -0,0 +1,19 @@
+package ports
+
+import "github.com/JhonCamargo53/prueba-tecnica/internal/domain/models"
+
+type RiskReportRepository interface {
+	// Append agrega un reporte al final de la cadena. build recibe el último reporte
+	// (nil si la cadena está vacía) y arma el nuevo; ambos pasos ocurren bajo un
+	// bloqueo, de modo que dos reportes nunca apuntan al mismo anterior
+	Append(build func(last *models.RiskReport) (*models.RiskReport, error)) (*models.RiskReport, error)
+	FindByID(id uint) (*models.RiskReport, error)
+	// FindBySequence retorna el reporte con esa secuencia, o nil si no existe
+	FindBySequence(sequence uint64) (*models.RiskReport, error)
+	FindByCreditRequestID(creditRequestID uint) ([]models.RiskReport, error)
+	// FindAfter retorna hasta limit reportes con secuencia mayor que afterSequence, en orden ascendente
+	FindAfter(afterSequence uint64, limit int) ([]models.RiskReport, error)
+	// FindUnreported retorna hasta limit evaluaciones sin reporte con id mayor que
+	// afterID, en orden ascendente
+	FindUnreported(afterID uint, limit int) ([]models.RiskEvaluation, error)
+}
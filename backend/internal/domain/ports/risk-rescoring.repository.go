@@ -0,0 +1,14 @@
+package ports
+
+import "github.com/JhonCamargo53/prueba-tecnica/internal/domain/models"
+
+type RiskRescoringRepository interface {
+	// FindCreditRequestIDs retorna hasta limit IDs mayores que afterID, en orden ascendente.
+	FindCreditRequestIDs(filter models.RiskRescoringFilter, afterID uint, limit int) ([]uint, error)
+	CreateJob(job *models.RiskRescoringJob) error
+	UpdateJob(job *models.RiskRescoringJob) error
+	FindJobByID(id uint) (*models.RiskRescoringJob, error)
+	FindJobs() ([]models.RiskRescoringJob, error)
+	CreateChanges(changes []models.RiskRescoringChange) error
+	FindChangesByJobID(jobID uint) ([]models.RiskRescoringChange, error)
+}
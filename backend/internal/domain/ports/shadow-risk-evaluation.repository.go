@@ -0,0 +1,13 @@
+package ports
+
+import (
+	"time"
+
+	"github.com/JhonCamargo53/prueba-tecnica/internal/domain/models"
+)
+
+type ShadowRiskEvaluationRepository interface {
+	Create(evaluation *models.ShadowRiskEvaluation) error
+	// FindBetween retorna las evaluaciones sombra en el rango; un límite nil no filtra.
+	FindBetween(from, to *time.Time) ([]models.ShadowRiskEvaluation, error)
+}
@@ -0,0 +1,257 @@
+package pricing
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"strings"
+
+	"github.com/JhonCamargo53/prueba-tecnica/internal/domain/finance"
+	"github.com/JhonCamargo53/prueba-tecnica/internal/domain/models"
+)
+
+/*
+
+Precios según el riesgo. A partir de la categoría de la evaluación y del
+producto se calcula la tasa ofrecida (tasa base del producto + spread de la
+categoría) y la cuota máxima que admite el ingreso del cliente. Con ellas y el
+método de amortización del producto (la primera cuota, la más alta en GERMAN y
+sólo intereses en BULLET) se obtiene el monto máximo aprobable; si el monto pedido lo supera se busca un
+plazo mayor dentro del máximo del producto y, si no alcanza, se ofrece el
+monto máximo como contraoferta.
+
+*/
+
+//go:embed default-pricing.json
+var defaultPricingJSON []byte
+
+// Los montos ofrecidos se redondean hacia abajo a múltiplos de este valor
+const amountRounding = 1000
+
+type Rules struct {
+	Version string `json:"version"`
+	// Tope de la tasa ofrecida (E.A. %), por ejemplo la tasa de usura vigente
+	MaxAnnualRate float64                  `json:"maxAnnualRate"`
+	Default       ProductPricing           `json:"default"`
+	Products      []ProductRule            `json:"products"`
+	Categories    map[string]CategoryPrice `json:"categories"`
+}
+
+type ProductPricing struct {
+	BaseAnnualRate float64 `json:"baseAnnualRate"`
+	MaxTermMonths  int     `json:"maxTermMonths"`
+}
+
+// ProductRule aplica a las solicitudes cuyo tipo de producto contiene alguna palabra clave.
+type ProductRule struct {
+	Code     string   `json:"code"`
+	Keywords []string `json:"keywords"`
+	ProductPricing
+}
+
+// CategoryPrice define el spread (puntos E.A.) y la relación cuota/ingreso máxima de una categoría.
+type CategoryPrice struct {
+	Spread             float64 `json:"spread"`
+	MaxPaymentToIncome float64 `json:"maxPaymentToIncome"`
+}
+
+// DefaultRules retorna la política de precios embebida en el binario.
+func DefaultRules() *Rules {
+	rules, err := ParseRules(defaultPricingJSON)
+	if err != nil {
+		panic(fmt.Sprintf("política de precios por defecto inválida: %v", err))
+	}
+	return rules
+}
+
+// LoadRules lee y valida la política de precios desde un archivo JSON. Con ruta
+// vacía retorna la política embebida.
+func LoadRules(path string) (*Rules, error) {
+	if path == "" {
+		return DefaultRules(), nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("no se pudo leer el archivo de precios %s: %w", path, err)
+	}
+
+	rules, err := ParseRules(data)
+	if err != nil {
+		return nil, fmt.Errorf("archivo de precios %s: %w", path, err)
+	}
+
+	return rules, nil
+}
+
+func ParseRules(data []byte) (*Rules, error) {
+	var rules Rules
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+
+	if err := decoder.Decode(&rules); err != nil {
+		return nil, fmt.Errorf("JSON de precios inválido: %w", err)
+	}
+
+	if err := rules.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &rules, nil
+}
+
+func (r *Rules) Validate() error {
+	if strings.TrimSpace(r.Version) == "" {
+		return fmt.Errorf("la política de precios debe tener una versión")
+	}
+
+	if r.MaxAnnualRate <= 0 {
+		return fmt.Errorf("maxAnnualRate debe ser mayor que cero")
+	}
+
+	if err := r.Default.validate("default"); err != nil {
+		return err
+	}
+
+	for i, p := range r.Products {
+		if len(p.Keywords) == 0 {
+			return fmt.Errorf("products[%d]: debe tener al menos una palabra clave", i)
+		}
+		if err := p.validate(fmt.Sprintf("products[%d]", i)); err != nil {
+			return err
+		}
+	}
+
+	for _, category := range []string{"LOW", "MEDIUM", "HIGH"} {
+		price, ok := r.Categories[category]
+		if !ok {
+			return fmt.Errorf("categories: falta la categoría %s", category)
+		}
+		if price.Spread < 0 {
+			return fmt.Errorf("categories.%s: el spread no puede ser negativo", category)
+		}
+		if price.MaxPaymentToIncome <= 0 || price.MaxPaymentToIncome > 1 {
+			return fmt.Errorf("categories.%s: maxPaymentToIncome debe estar entre 0 y 1", category)
+		}
+	}
+
+	return nil
+}
+
+func (p ProductPricing) validate(name string) error {
+	if p.BaseAnnualRate <= 0 {
+		return fmt.Errorf("%s: baseAnnualRate debe ser mayor que cero", name)
+	}
+	if p.MaxTermMonths <= 0 {
+		return fmt.Errorf("%s: maxTermMonths debe ser mayor que cero", name)
+	}
+	return nil
+}
+
+// PricingFor retorna el precio del producto del catálogo con el mismo código, del
+// primer producto cuyas palabras clave coincidan con el tipo de producto o el
+// precio por defecto.
+func (r *Rules) PricingFor(productCode, productType string) ProductPricing {
+	for _, p := range r.Products {
+		if productCode != "" && strings.EqualFold(p.Code, productCode) {
+			return p.ProductPricing
+		}
+	}
+
+	upper := strings.ToUpper(productType)
+	for _, p := range r.Products {
+		for _, k := range p.Keywords {
+			if k != "" && strings.Contains(upper, strings.ToUpper(k)) {
+				return p.ProductPricing
+			}
+		}
+	}
+	return r.Default
+}
+
+// Quote calcula la oferta para una solicitud evaluada. Retorna nil cuando no hay
+// oferta: la evaluación recomienda rechazar o faltan datos para calcularla.
+func Quote(rules *Rules, assessment *models.RiskAssessment, customer models.Customer,
+	creditRequest models.CreditRequest) *models.CreditOffer {
+
+	if assessment == nil || assessment.Recommendation == models.RiskRecommendationReject || len(assessment.KnockOuts) > 0 {
+		return nil
+	}
+
+	price, ok := rules.Categories[assessment.Category]
+	if !ok || customer.MonthlyIncome <= 0 || creditRequest.Amount <= 0 || creditRequest.TermMonths <= 0 {
+		return nil
+	}
+
+	product := rules.PricingFor(creditRequest.ProductCode(), creditRequest.ProductType)
+
+	// El producto del catálogo define la tasa base y los plazos que se pueden ofrecer
+	catalog := creditRequest.CreditProduct
+	if catalog != nil && (catalog.BaseAnnualRate <= 0 || catalog.MaxTerm() <= 0) {
+		catalog = nil
+	}
+	if catalog != nil {
+		product.BaseAnnualRate = catalog.BaseAnnualRate
+		product.MaxTermMonths = catalog.MaxTerm()
+	}
+
+	// La cuota que se compara con el ingreso depende del método de amortización del producto
+	method := finance.AmortizationFrench
+	if creditRequest.CreditProduct != nil && creditRequest.CreditProduct.AmortizationMethod != "" {
+		method = creditRequest.CreditProduct.AmortizationMethod
+	}
+
+	rate := math.Min(product.BaseAnnualRate+price.Spread, rules.MaxAnnualRate)
+	maxInstallment := price.MaxPaymentToIncome * customer.MonthlyIncome
+
+	term := creditRequest.TermMonths
+	if term > product.MaxTermMonths {
+		term = product.MaxTermMonths
+	}
+
+	maxAmount := maxAmountFor(maxInstallment, rate, method, term)
+
+	// Si el monto no alcanza con el plazo pedido se busca el menor plazo que lo permita
+	if creditRequest.Amount > maxAmount {
+		for t := term + 1; t <= product.MaxTermMonths; t++ {
+			if catalog != nil && !catalog.AllowsTerm(t) {
+				continue
+			}
+			if candidate := maxAmountFor(maxInstallment, rate, method, t); candidate >= creditRequest.Amount {
+				term, maxAmount = t, candidate
+				break
+			}
+		}
+		// En BULLET el plazo no cambia el monto máximo, así que se conserva el pedido
+		if creditRequest.Amount > maxAmount {
+			if candidate := maxAmountFor(maxInstallment, rate, method, product.MaxTermMonths); candidate > maxAmount {
+				term, maxAmount = product.MaxTermMonths, candidate
+			}
+		}
+	}
+
+	amount := math.Min(creditRequest.Amount, maxAmount)
+	installment, _ := finance.FirstInstallment(amount, rate, term, method)
+
+	return &models.CreditOffer{
+		PricingVersion: rules.Version,
+		AnnualRate:     rate,
+		MaxAmount:      maxAmount,
+		Amount:         amount,
+		TermMonths:     term,
+		Installment:    math.Round(installment),
+		CounterOffer:   amount < creditRequest.Amount || term != creditRequest.TermMonths,
+	}
+}
+
+func maxAmountFor(maxInstallment, rate float64, method string, term int) float64 {
+	principal, err := finance.MaxPrincipalFor(maxInstallment, rate, term, method)
+	if err != nil {
+		return 0
+	}
+	return math.Floor(principal/amountRounding) * amountRounding
+}
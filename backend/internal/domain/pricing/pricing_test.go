@@ -0,0 +1,141 @@
+package pricing
+
+import (
+	"math"
+	"testing"
+
+	"github.com/JhonCamargo53/prueba-tecnica/internal/domain/finance"
+	"github.com/JhonCamargo53/prueba-tecnica/internal/domain/models"
+)
+
+func TestQuote_TasaSegunCategoria(t *testing.T) {
+	rules := DefaultRules()
+	customer := models.Customer{MonthlyIncome: 5_000_000}
+	cr := models.CreditRequest{Amount: 10_000_000, TermMonths: 36, ProductType: "Libre inversión"}
+
+	low := Quote(rules, &models.RiskAssessment{Category: "LOW", Recommendation: models.RiskRecommendationApprove}, customer, cr)
+	high := Quote(rules, &models.RiskAssessment{Category: "HIGH", Recommendation: models.RiskRecommendationReview}, customer, cr)
+
+	if low == nil || high == nil {
+		t.Fatalf("se esperaba oferta para ambas categorías")
+	}
+	if low.AnnualRate != 20 || high.AnnualRate != 29 {
+		t.Errorf("tasas inesperadas, LOW=%.2f HIGH=%.2f", low.AnnualRate, high.AnnualRate)
+	}
+	if low.MaxAmount <= high.MaxAmount {
+		t.Errorf("se esperaba mayor monto máximo para riesgo bajo: %.0f vs %.0f", low.MaxAmount, high.MaxAmount)
+	}
+	if low.CounterOffer || low.Amount != cr.Amount || low.TermMonths != cr.TermMonths {
+		t.Errorf("no se esperaba contraoferta: %+v", low)
+	}
+}
+
+func TestQuote_ContraofertaConPlazoMayor(t *testing.T) {
+	rules := DefaultRules()
+	customer := models.Customer{MonthlyIncome: 2_000_000}
+	// La cuota a 12 meses supera el 30% del ingreso, pero a un plazo mayor sí cabe
+	cr := models.CreditRequest{Amount: 10_000_000, TermMonths: 12, ProductType: "Libre inversión"}
+
+	offer := Quote(rules, &models.RiskAssessment{Category: "MEDIUM", Recommendation: models.RiskRecommendationReview}, customer, cr)
+	if offer == nil {
+		t.Fatalf("se esperaba oferta")
+	}
+
+	if !offer.CounterOffer || offer.TermMonths <= 12 || offer.Amount != cr.Amount {
+		t.Fatalf("se esperaba contraoferta por el monto pedido a mayor plazo: %+v", offer)
+	}
+	if offer.Installment > 0.30*customer.MonthlyIncome+1 {
+		t.Errorf("la cuota ofrecida (%.0f) supera el máximo de la categoría", offer.Installment)
+	}
+
+	// El plazo sugerido es el menor que permite el monto
+	previous, _ := finance.MaxPrincipal(0.30*customer.MonthlyIncome, offer.AnnualRate, offer.TermMonths-1)
+	if previous >= cr.Amount {
+		t.Errorf("se esperaba el menor plazo posible, %d meses también alcanza", offer.TermMonths-1)
+	}
+}
+
+func TestQuote_ContraofertaConMontoMaximo(t *testing.T) {
+	rules := DefaultRules()
+	customer := models.Customer{MonthlyIncome: 1_500_000}
+	cr := models.CreditRequest{Amount: 200_000_000, TermMonths: 60, ProductType: "Libre inversión"}
+
+	offer := Quote(rules, &models.RiskAssessment{Category: "LOW", Recommendation: models.RiskRecommendationApprove}, customer, cr)
+	if offer == nil {
+		t.Fatalf("se esperaba oferta")
+	}
+
+	if !offer.CounterOffer || offer.TermMonths != 72 || offer.Amount != offer.MaxAmount || offer.MaxAmount >= cr.Amount {
+		t.Errorf("se esperaba ofrecer el monto máximo al plazo máximo del producto: %+v", offer)
+	}
+}
+
+func TestQuote_UsaTasaYPlazosDelProducto(t *testing.T) {
+	rules := DefaultRules()
+	customer := models.Customer{MonthlyIncome: 2_000_000}
+	cr := models.CreditRequest{Amount: 10_000_000, TermMonths: 12, ProductType: "Préstamo personal",
+		CreditProduct: &models.CreditProduct{Code: "PERSONAL", BaseAnnualRate: 18, AllowedTerms: models.TermList{12, 36, 60}}}
+
+	offer := Quote(rules, &models.RiskAssessment{Category: "MEDIUM", Recommendation: models.RiskRecommendationReview}, customer, cr)
+	if offer == nil {
+		t.Fatalf("se esperaba oferta")
+	}
+
+	if offer.AnnualRate != 22 {
+		t.Errorf("se esperaba la tasa base del producto más el spread (22), obtenido: %.2f", offer.AnnualRate)
+	}
+	// La contraoferta sólo usa plazos permitidos por el producto
+	if !offer.CounterOffer || offer.TermMonths != 36 {
+		t.Errorf("se esperaba contraoferta a 36 meses: %+v", offer)
+	}
+}
+
+func TestQuote_CuotaSegunMetodoDeAmortizacion(t *testing.T) {
+	rules := DefaultRules()
+	customer := models.Customer{MonthlyIncome: 2_000_000}
+	assessment := &models.RiskAssessment{Category: "MEDIUM", Recommendation: models.RiskRecommendationReview}
+
+	offers := map[string]*models.CreditOffer{}
+	for _, method := range []string{finance.AmortizationFrench, finance.AmortizationGerman, finance.AmortizationBullet} {
+		cr := models.CreditRequest{Amount: 10_000_000, TermMonths: 36, ProductType: "Préstamo personal",
+			CreditProduct: &models.CreditProduct{Code: "PERSONAL", BaseAnnualRate: 18, AllowedTerms: models.TermList{12, 36, 60},
+				AmortizationMethod: method}}
+
+		offer := Quote(rules, assessment, customer, cr)
+		if offer == nil {
+			t.Fatalf("%s: se esperaba oferta", method)
+		}
+		want, _ := finance.FirstInstallment(offer.Amount, offer.AnnualRate, offer.TermMonths, method)
+		if offer.Installment != math.Round(want) {
+			t.Errorf("%s: se esperaba la primera cuota del método (%.0f), obtenido: %.0f", method, want, offer.Installment)
+		}
+		offers[method] = offer
+	}
+
+	// La primera cuota de abono constante supera la cuota fija, y la de BULLET sólo paga intereses
+	french, german, bullet := offers[finance.AmortizationFrench], offers[finance.AmortizationGerman], offers[finance.AmortizationBullet]
+	if !(bullet.Installment < french.Installment && french.Installment < german.Installment) {
+		t.Errorf("cuotas inesperadas, FRENCH=%.0f GERMAN=%.0f BULLET=%.0f", french.Installment, german.Installment, bullet.Installment)
+	}
+	if !(german.MaxAmount < french.MaxAmount && french.MaxAmount < bullet.MaxAmount) {
+		t.Errorf("montos máximos inesperados, FRENCH=%.0f GERMAN=%.0f BULLET=%.0f", french.MaxAmount, german.MaxAmount, bullet.MaxAmount)
+	}
+
+	// En BULLET un plazo mayor no aumenta el monto: la contraoferta conserva el plazo pedido
+	cr := models.CreditRequest{Amount: 500_000_000, TermMonths: 36, ProductType: "Préstamo personal",
+		CreditProduct: &models.CreditProduct{Code: "PERSONAL", BaseAnnualRate: 18, AllowedTerms: models.TermList{12, 36, 60},
+			AmortizationMethod: finance.AmortizationBullet}}
+	if offer := Quote(rules, assessment, customer, cr); offer == nil || !offer.CounterOffer || offer.TermMonths != 36 || offer.Amount != offer.MaxAmount {
+		t.Errorf("se esperaba contraoferta por el monto máximo al plazo pedido: %+v", offer)
+	}
+}
+
+func TestQuote_SinOfertaSiSeRecomiendaRechazar(t *testing.T) {
+	rules := DefaultRules()
+	customer := models.Customer{MonthlyIncome: 5_000_000}
+	cr := models.CreditRequest{Amount: 10_000_000, TermMonths: 36}
+
+	if offer := Quote(rules, &models.RiskAssessment{Category: "HIGH", Recommendation: models.RiskRecommendationReject}, customer, cr); offer != nil {
+		t.Errorf("no se esperaba oferta para una recomendación de rechazo: %+v", offer)
+	}
+}
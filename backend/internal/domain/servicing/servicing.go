@@ -0,0 +1,118 @@
+package servicing
+
+import (
+	"math"
+	"time"
+
+	"github.com/JhonCamargo53/prueba-tecnica/internal/domain/models"
+)
+
+/*
+
+Reglas de la cartera de créditos desembolsados: distribución de los pagos
+entre las cuotas del plan y cálculo de la mora. Un pago se aplica a las
+cuotas en orden de vencimiento, primero al interés pendiente de cada cuota
+y luego a su capital; lo que sobra pasa a la cuota siguiente (abono
+anticipado). La mora se mide desde la cuota vencida más antigua sin pagar.
+
+*/
+
+// Franjas de mora según los días de atraso
+const (
+	BucketCurrent = "CURRENT"
+	Bucket1To29   = "1-29"
+	Bucket30To59  = "30-59"
+	Bucket60To89  = "60-89"
+	Bucket90Plus  = "90+"
+)
+
+// BucketFor retorna la franja de mora de los días de atraso.
+func BucketFor(daysPastDue int) string {
+	switch {
+	case daysPastDue <= 0:
+		return BucketCurrent
+	case daysPastDue < 30:
+		return Bucket1To29
+	case daysPastDue < 60:
+		return Bucket30To59
+	case daysPastDue < 90:
+		return Bucket60To89
+	default:
+		return Bucket90Plus
+	}
+}
+
+// Outstanding retorna el capital y el interés pendientes del plan.
+func Outstanding(installments []models.PaymentScheduleInstallment) (principal float64, interest float64) {
+	for _, installment := range installments {
+		principal += installment.PendingPrincipal()
+		interest += installment.PendingInterest()
+	}
+	return roundCents(principal), roundCents(interest)
+}
+
+// Allocate aplica el pago a las cuotas (ordenadas por número) y las actualiza. Retorna
+// la distribución por cuota y el valor que no se pudo aplicar porque el plan quedó pagado.
+func Allocate(installments []models.PaymentScheduleInstallment, amount float64, paidAt time.Time) ([]models.LoanPaymentAllocation, float64) {
+	var allocations []models.LoanPaymentAllocation
+	remaining := roundCents(amount)
+
+	for i := range installments {
+		if remaining <= 0 {
+			break
+		}
+
+		installment := &installments[i]
+		if installment.IsPaid() {
+			continue
+		}
+
+		interest := math.Min(remaining, installment.PendingInterest())
+		remaining = roundCents(remaining - interest)
+
+		principal := math.Min(remaining, installment.PendingPrincipal())
+		remaining = roundCents(remaining - principal)
+
+		installment.InterestPaid = roundCents(installment.InterestPaid + interest)
+		installment.PrincipalPaid = roundCents(installment.PrincipalPaid + principal)
+		if installment.IsPaid() {
+			paid := paidAt
+			installment.PaidAt = &paid
+		}
+
+		allocations = append(allocations, models.LoanPaymentAllocation{
+			InstallmentNumber: installment.Number,
+			InterestAmount:    interest,
+			PrincipalAmount:   principal,
+		})
+	}
+
+	return allocations, remaining
+}
+
+// DaysPastDue retorna los días de atraso de la cuota vencida más antigua sin pagar
+// a la fecha asOf; cero si el crédito está al día.
+func DaysPastDue(installments []models.PaymentScheduleInstallment, asOf time.Time) int {
+	asOfDate := truncateDay(asOf)
+
+	for _, installment := range installments {
+		if installment.IsPaid() {
+			continue
+		}
+		dueDate := truncateDay(installment.DueDate)
+		if !asOfDate.After(dueDate) {
+			return 0
+		}
+		return int(asOfDate.Sub(dueDate).Hours() / 24)
+	}
+
+	return 0
+}
+
+func truncateDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+func roundCents(value float64) float64 {
+	return math.Round(value*100) / 100
+}
@@ -0,0 +1,90 @@
+package servicing
+
+import (
+	"testing"
+	"time"
+
+	"github.com/JhonCamargo53/prueba-tecnica/internal/domain/models"
+)
+
+func schedule(start time.Time) []models.PaymentScheduleInstallment {
+	// Tres cuotas de 110.000: 100.000 de capital y 10.000 de interés
+	installments := make([]models.PaymentScheduleInstallment, 3)
+	for i := range installments {
+		installments[i] = models.PaymentScheduleInstallment{
+			Number:    i + 1,
+			DueDate:   start.AddDate(0, i+1, 0),
+			Payment:   110_000,
+			Principal: 100_000,
+			Interest:  10_000,
+		}
+	}
+	return installments
+}
+
+func TestAllocate_InteresAntesQueCapital(t *testing.T) {
+	installments := schedule(time.Date(2026, 1, 10, 0, 0, 0, 0, time.UTC))
+	paidAt := time.Date(2026, 2, 10, 0, 0, 0, 0, time.UTC)
+
+	allocations, remaining := Allocate(installments, 130_000, paidAt)
+
+	if remaining != 0 || len(allocations) != 2 {
+		t.Fatalf("distribución inesperada: %+v, sobrante %.2f", allocations, remaining)
+	}
+	if allocations[0].InterestAmount != 10_000 || allocations[0].PrincipalAmount != 100_000 {
+		t.Errorf("la primera cuota debe quedar pagada: %+v", allocations[0])
+	}
+	if allocations[1].InterestAmount != 10_000 || allocations[1].PrincipalAmount != 10_000 {
+		t.Errorf("el excedente debe cubrir primero el interés de la siguiente cuota: %+v", allocations[1])
+	}
+	if installments[0].PaidAt == nil || installments[1].PaidAt != nil {
+		t.Errorf("sólo la primera cuota debe quedar pagada")
+	}
+
+	principal, interest := Outstanding(installments)
+	if principal != 190_000 || interest != 10_000 {
+		t.Errorf("saldo pendiente inesperado: capital %.2f, interés %.2f", principal, interest)
+	}
+}
+
+func TestAllocate_SobranteCuandoElPlanQuedaPagado(t *testing.T) {
+	installments := schedule(time.Now())
+
+	_, remaining := Allocate(installments, 400_000, time.Now())
+
+	if remaining != 70_000 {
+		t.Errorf("se esperaba un sobrante de 70.000, obtenido: %.2f", remaining)
+	}
+	for _, installment := range installments {
+		if !installment.IsPaid() {
+			t.Errorf("la cuota %d debe quedar pagada", installment.Number)
+		}
+	}
+}
+
+func TestDaysPastDue(t *testing.T) {
+	start := time.Date(2026, 1, 10, 0, 0, 0, 0, time.UTC)
+	installments := schedule(start)
+
+	if dpd := DaysPastDue(installments, time.Date(2026, 2, 10, 18, 0, 0, 0, time.UTC)); dpd != 0 {
+		t.Errorf("el día del vencimiento no hay mora, obtenido: %d", dpd)
+	}
+	if dpd := DaysPastDue(installments, time.Date(2026, 3, 14, 0, 0, 0, 0, time.UTC)); dpd != 32 {
+		t.Errorf("se esperaban 32 días de mora desde la primera cuota, obtenido: %d", dpd)
+	}
+
+	Allocate(installments, 110_000, time.Now())
+	if dpd := DaysPastDue(installments, time.Date(2026, 3, 14, 0, 0, 0, 0, time.UTC)); dpd != 4 {
+		t.Errorf("con la primera cuota pagada la mora se cuenta desde la segunda, obtenido: %d", dpd)
+	}
+}
+
+func TestBucketFor(t *testing.T) {
+	cases := map[int]string{0: BucketCurrent, 1: Bucket1To29, 29: Bucket1To29, 30: Bucket30To59, 60: Bucket60To89, 89: Bucket60To89, 90: Bucket90Plus, 400: Bucket90Plus}
+
+	for days, want := range cases {
+		if got := BucketFor(days); got != want {
+			t.Errorf("%d días: se esperaba %s, obtenido %s", days, want, got)
+		}
+	}
+}
@@ -0,0 +1,230 @@
+package workflow
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+)
+
+/*
+
+Grafo de estados de las solicitudes de crédito. Define los estados con los
+que se puede crear una solicitud, a qué estado puede pasar cada uno, qué
+roles pueden hacer cada cambio y qué estados son terminales (ninguna
+transición sale de ellos). Los cambios automáticos, como el rechazo por
+políticas, se hacen con el rol SYSTEM.
+
+*/
+
+//go:embed default-workflow.json
+var defaultWorkflowJSON []byte
+
+// Rol con el que se registran los cambios de estado automáticos
+const SystemRole = "SYSTEM"
+
+type Rules struct {
+	Version     string           `json:"version"`
+	Statuses    []StatusRule     `json:"statuses"`
+	Transitions []TransitionRule `json:"transitions"`
+}
+
+// StatusRule asocia el nombre de un estado con su id en la tabla credit_statuses.
+type StatusRule struct {
+	ID   uint   `json:"id"`
+	Name string `json:"name"`
+	// Estado con el que se puede crear una solicitud
+	Initial bool `json:"initial"`
+	// Estado final: no admite transiciones de salida
+	Terminal bool `json:"terminal"`
+}
+
+type TransitionRule struct {
+	From  string   `json:"from"`
+	To    string   `json:"to"`
+	Roles []string `json:"roles"`
+}
+
+// DefaultRules retorna el grafo de estados embebido en el binario.
+func DefaultRules() *Rules {
+	rules, err := ParseRules(defaultWorkflowJSON)
+	if err != nil {
+		panic(fmt.Sprintf("grafo de estados por defecto inválido: %v", err))
+	}
+	return rules
+}
+
+// LoadRules lee y valida el grafo de estados desde un archivo JSON. Con ruta
+// vacía retorna el grafo embebido.
+func LoadRules(path string) (*Rules, error) {
+	if path == "" {
+		return DefaultRules(), nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("no se pudo leer el archivo de estados %s: %w", path, err)
+	}
+
+	rules, err := ParseRules(data)
+	if err != nil {
+		return nil, fmt.Errorf("archivo de estados %s: %w", path, err)
+	}
+
+	return rules, nil
+}
+
+func ParseRules(data []byte) (*Rules, error) {
+	var rules Rules
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+
+	if err := decoder.Decode(&rules); err != nil {
+		return nil, fmt.Errorf("JSON de estados inválido: %w", err)
+	}
+
+	if err := rules.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &rules, nil
+}
+
+func (r *Rules) Validate() error {
+	if strings.TrimSpace(r.Version) == "" {
+		return fmt.Errorf("el grafo de estados debe tener una versión")
+	}
+
+	if len(r.Statuses) == 0 {
+		return fmt.Errorf("el grafo de estados debe tener al menos un estado")
+	}
+
+	ids := map[uint]bool{}
+	names := map[string]bool{}
+	initial := false
+
+	for i, s := range r.Statuses {
+		if s.ID == 0 {
+			return fmt.Errorf("statuses[%d]: debe tener id", i)
+		}
+		if strings.TrimSpace(s.Name) == "" {
+			return fmt.Errorf("statuses[%d]: debe tener nombre", i)
+		}
+		if ids[s.ID] || names[s.Name] {
+			return fmt.Errorf("statuses[%d]: el estado %s (%d) está repetido", i, s.Name, s.ID)
+		}
+		if s.Initial && s.Terminal {
+			return fmt.Errorf("statuses[%d]: el estado %s no puede ser inicial y terminal", i, s.Name)
+		}
+		ids[s.ID] = true
+		names[s.Name] = true
+		initial = initial || s.Initial
+	}
+
+	if !initial {
+		return fmt.Errorf("el grafo de estados debe tener al menos un estado inicial")
+	}
+
+	pairs := map[string]bool{}
+
+	for i, t := range r.Transitions {
+		from := r.statusByName(t.From)
+		if from == nil {
+			return fmt.Errorf("transitions[%d]: no existe el estado %q", i, t.From)
+		}
+		if r.statusByName(t.To) == nil {
+			return fmt.Errorf("transitions[%d]: no existe el estado %q", i, t.To)
+		}
+		if t.From == t.To {
+			return fmt.Errorf("transitions[%d]: el estado de origen y destino son iguales", i)
+		}
+		if from.Terminal {
+			return fmt.Errorf("transitions[%d]: el estado %s es terminal y no admite transiciones", i, t.From)
+		}
+		if len(t.Roles) == 0 {
+			return fmt.Errorf("transitions[%d]: debe tener al menos un rol", i)
+		}
+		key := t.From + "->" + t.To
+		if pairs[key] {
+			return fmt.Errorf("transitions[%d]: la transición %s → %s está repetida", i, t.From, t.To)
+		}
+		pairs[key] = true
+	}
+
+	return nil
+}
+
+func (r *Rules) statusByName(name string) *StatusRule {
+	for i := range r.Statuses {
+		if r.Statuses[i].Name == name {
+			return &r.Statuses[i]
+		}
+	}
+	return nil
+}
+
+func (r *Rules) status(id uint) *StatusRule {
+	for i := range r.Statuses {
+		if r.Statuses[i].ID == id {
+			return &r.Statuses[i]
+		}
+	}
+	return nil
+}
+
+// StatusName retorna el nombre del estado o su id si no está en el grafo.
+func (r *Rules) StatusName(id uint) string {
+	if s := r.status(id); s != nil {
+		return s.Name
+	}
+	return fmt.Sprintf("%d", id)
+}
+
+// IsTerminal indica si el estado es final. Un estado que no está en el grafo no es terminal.
+func (r *Rules) IsTerminal(statusID uint) bool {
+	s := r.status(statusID)
+	return s != nil && s.Terminal
+}
+
+// CheckInitial valida que una solicitud pueda crearse con el estado dado.
+func (r *Rules) CheckInitial(statusID uint) error {
+	s := r.status(statusID)
+	if s == nil {
+		return fmt.Errorf("el estado %d no está definido en el flujo de estados", statusID)
+	}
+	if !s.Initial {
+		return fmt.Errorf("una solicitud no puede crearse en estado %s", s.Name)
+	}
+	return nil
+}
+
+// Check valida que el rol pueda cambiar la solicitud del estado from al estado to.
+func (r *Rules) Check(from, to uint, role string) error {
+	source := r.status(from)
+	if source == nil {
+		return fmt.Errorf("el estado %d no está definido en el flujo de estados", from)
+	}
+	if r.status(to) == nil {
+		return fmt.Errorf("el estado %d no está definido en el flujo de estados", to)
+	}
+	if source.Terminal {
+		return fmt.Errorf("transición no permitida: el estado %s es terminal", source.Name)
+	}
+
+	for _, t := range r.Transitions {
+		if t.From != source.Name || t.To != r.StatusName(to) {
+			continue
+		}
+		for _, allowed := range t.Roles {
+			if strings.EqualFold(allowed, role) {
+				return nil
+			}
+		}
+		return fmt.Errorf("el rol %s no puede cambiar el estado de %s a %s", role, t.From, t.To)
+	}
+
+	return fmt.Errorf("transición no permitida: %s → %s", source.Name, r.StatusName(to))
+}
@@ -0,0 +1,73 @@
+package workflow
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/JhonCamargo53/prueba-tecnica/internal/domain/models"
+)
+
+func TestCheck_GrafoPorDefecto(t *testing.T) {
+	rules := DefaultRules()
+
+	cases := []struct {
+		from, to uint
+		role     string
+		allowed  bool
+	}{
+		{models.CreditStatusPendingID, models.CreditStatusInStudyID, "EMPLOYEE", true},
+		{models.CreditStatusInStudyID, models.CreditStatusApprovedID, "EMPLOYEE", true},
+		{models.CreditStatusPendingID, models.CreditStatusRejectedID, SystemRole, true},
+		{models.CreditStatusPendingID, models.CreditStatusApprovedID, SystemRole, false},
+		{models.CreditStatusApprovedID, models.CreditStatusPendingID, "ADMIN", false},
+		{models.CreditStatusRejectedID, models.CreditStatusInStudyID, "EMPLOYEE", false},
+		{models.CreditStatusRejectedID, models.CreditStatusInStudyID, "ADMIN", true},
+		{models.CreditStatusRejectedID, models.CreditStatusApprovedID, "ADMIN", false},
+	}
+
+	for _, c := range cases {
+		err := rules.Check(c.from, c.to, c.role)
+		if c.allowed && err != nil {
+			t.Errorf("%s → %s con %s: no se esperaba error, obtenido: %v", rules.StatusName(c.from), rules.StatusName(c.to), c.role, err)
+		}
+		if !c.allowed && err == nil {
+			t.Errorf("%s → %s con %s: se esperaba error", rules.StatusName(c.from), rules.StatusName(c.to), c.role)
+		}
+	}
+}
+
+func TestCheck_EstadoTerminal(t *testing.T) {
+	err := DefaultRules().Check(models.CreditStatusApprovedID, models.CreditStatusRejectedID, "ADMIN")
+	if err == nil || !strings.Contains(err.Error(), "terminal") {
+		t.Fatalf("se esperaba error por estado terminal, obtenido: %v", err)
+	}
+}
+
+func TestCheckInitial(t *testing.T) {
+	rules := DefaultRules()
+
+	if err := rules.CheckInitial(models.CreditStatusPendingID); err != nil {
+		t.Errorf("PENDIENTE debería ser estado inicial: %v", err)
+	}
+	if err := rules.CheckInitial(models.CreditStatusApprovedID); err == nil {
+		t.Errorf("APROBADO no debería ser estado inicial")
+	}
+}
+
+func TestParseRules_Invalidas(t *testing.T) {
+	cases := map[string]string{
+		"sin versión":         `{"statuses":[{"id":1,"name":"A","initial":true}],"transitions":[]}`,
+		"sin estado inicial":  `{"version":"v","statuses":[{"id":1,"name":"A"}],"transitions":[]}`,
+		"estado desconocido":  `{"version":"v","statuses":[{"id":1,"name":"A","initial":true}],"transitions":[{"from":"A","to":"B","roles":["ADMIN"]}]}`,
+		"salida de terminal":  `{"version":"v","statuses":[{"id":1,"name":"A","initial":true},{"id":2,"name":"B","terminal":true}],"transitions":[{"from":"B","to":"A","roles":["ADMIN"]}]}`,
+		"sin roles":           `{"version":"v","statuses":[{"id":1,"name":"A","initial":true},{"id":2,"name":"B"}],"transitions":[{"from":"A","to":"B","roles":[]}]}`,
+		"campo desconocido":   `{"version":"v","statuses":[{"id":1,"name":"A","initial":true}],"transitions":[],"extra":1}`,
+		"transición repetida": `{"version":"v","statuses":[{"id":1,"name":"A","initial":true},{"id":2,"name":"B"}],"transitions":[{"from":"A","to":"B","roles":["ADMIN"]},{"from":"A","to":"B","roles":["EMPLOYEE"]}]}`,
+	}
+
+	for name, data := range cases {
+		if _, err := ParseRules([]byte(data)); err == nil {
+			t.Errorf("%s: se esperaba error", name)
+		}
+	}
+}
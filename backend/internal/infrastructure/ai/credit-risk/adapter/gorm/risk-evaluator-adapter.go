@@ -7,26 +7,27 @@ import (
 )
 
 type RiskEvaluatorAdapter struct {
+	rules *engines.RuleSetStore
 }
 
-func NewRiskEvaluatorAdapter() ports.RiskEvaluator {
-	return &RiskEvaluatorAdapter{}
+func NewRiskEvaluatorAdapter(rules *engines.RuleSetStore) ports.RiskEvaluator {
+	return &RiskEvaluatorAdapter{
+		rules: rules,
+	}
 }
 
 func (a *RiskEvaluatorAdapter) Evaluate(customer models.Customer, currentCreditRequest models.CreditRequest,
-	otherCredits []models.CreditRequest, assets []models.CustomerAsset) (float64, string, string, error) {
+	otherCredits []models.CreditRequest, assets []models.CustomerAsset) (*models.RiskAssessment, error) {
+
+	// Tomar una sola versión de reglas para toda la evaluación (puede recargarse en caliente)
+	rules := a.rules.Current()
 
 	// Realizar el analisis de riesgo
-	score, category, explanation, err := engines.EvaluateCreditRisk(
+	return engines.AssessCreditRisk(
+		rules,
 		customer,
 		currentCreditRequest,
 		otherCredits,
 		assets,
 	)
-
-	if err != nil {
-		return 0, "", "", err
-	}
-
-	return score, category, explanation, nil
 }
@@ -0,0 +1,29 @@
+package adapters
+
+import (
+	"github.com/JhonCamargo53/prueba-tecnica/internal/domain/models"
+	"github.com/JhonCamargo53/prueba-tecnica/internal/domain/ports"
+	engines "github.com/JhonCamargo53/prueba-tecnica/internal/infrastructure/ai/credit-risk/engines"
+)
+
+type ScorecardEvaluatorAdapter struct {
+	scorecard *engines.Scorecard
+}
+
+func NewScorecardEvaluatorAdapter(scorecard *engines.Scorecard) ports.RiskEvaluator {
+	return &ScorecardEvaluatorAdapter{
+		scorecard: scorecard,
+	}
+}
+
+func (a *ScorecardEvaluatorAdapter) Evaluate(customer models.Customer, currentCreditRequest models.CreditRequest,
+	otherCredits []models.CreditRequest, assets []models.CustomerAsset) (*models.RiskAssessment, error) {
+
+	return engines.AssessWithScorecard(
+		a.scorecard,
+		customer,
+		currentCreditRequest,
+		otherCredits,
+		assets,
+	)
+}
@@ -0,0 +1,131 @@
+package httpAdapter
+
+import (
+	"fmt"
+
+	"github.com/JhonCamargo53/prueba-tecnica/internal/domain/models"
+)
+
+/*
+
+Contrato JSON del servicio externo de scoring (versión 1).
+
+	POST {RISK_HTTP_URL}
+	Content-Type: application/json
+	Authorization: Bearer {RISK_HTTP_API_KEY}   (opcional)
+
+Cuerpo de la petición (ScoreRequest):
+
+	{
+	  "contractVersion": "1",
+	  "input": {
+	    "creditRequestId": 7,            // 0 en simulaciones
+	    "customerId": 1,
+	    "monthlyIncome": 5000000,
+	    "amount": 20000000,
+	    "termMonths": 36,
+	    "interestRate": 24.5,            // % E.A.; 0 = tasa del producto
+	    "productType": "Vivienda",
+	    "product": { "id": 1, "code": "HOUSING", "riskWeight": 0.8,   // omitido sin producto del catálogo
+	                 "baseAnnualRate": 13.5, "collateralRequired": true },
+	    "assets": [{ "id": 3, "assetId": 1, "customerId": 1, "assetName": "INMUEBLE", "marketValue": 80000000,
+	                 "description": "Apartamento", "createdAt": "2025-01-10T00:00:00Z",
+	                 "collateralEligible": true, "collateralHaircut": 0.3,
+	                 "depreciationMethod": "NONE", "annualDepreciationRate": 0, "realEstate": true }],
+	    "priorCredits": [{ "id": 2, "amount": 5000000, "termMonths": 12, "interestRate": 0,
+	                       "productType": "Libre inversión", "creditStatusId": 2,
+	                       "createdAt": "2024-06-01T00:00:00Z",
+	                       "product": { "id": 2, "code": "CONSUMER", "riskWeight": 1.2,
+	                                    "baseAnnualRate": 24, "collateralRequired": false },
+	                       "loanAccount": { "status": "ACTIVE", "outstandingPrincipal": 2500000,
+	                                        "daysPastDue": 0, "maxDaysPastDue": 12,
+	                                        "lastPaymentAt": "2024-12-01T00:00:00Z" } }],   // loanAccount sólo en créditos desembolsados
+	    "participants": [{ "customerId": 4, "role": "CO_BORROWER", "monthlyIncome": 3000000 }]
+	  }
+	}
+
+Respuesta 200 (ScoreResponse):
+
+	{
+	  "engineVersion": "model-3.1",      // obligatorio
+	  "baseScore": 50,
+	  "score": 72.5,                     // entre 0 y 100
+	  "category": "MEDIUM",              // LOW | MEDIUM | HIGH
+	  "recommendation": "REVIEW",        // APPROVE | REVIEW | REJECT
+	  "factors": [{ "code": "PAYMENT_TO_INCOME", "points": 20, "observedValue": 0.21,
+	                "params": { "ratio": 0.21 }, "message": "..." }],
+	  "improvements": [{ "code": "ADD_COLLATERAL", "message": "..." }]
+	}
+
+Los códigos de factores y mejoras que existen en el catálogo de mensajes se
+renderizan localmente en cada idioma; los demás conservan el mensaje recibido.
+Las respuestas 5xx y 429 se reintentan; las 4xx no.
+
+*/
+
+const ContractVersion = "1"
+
+type ScoreRequest struct {
+	ContractVersion string                   `json:"contractVersion"`
+	Input           models.RiskInputSnapshot `json:"input"`
+}
+
+type ScoreResponse struct {
+	EngineVersion  string                   `json:"engineVersion"`
+	BaseScore      float64                  `json:"baseScore"`
+	Score          float64                  `json:"score"`
+	Category       string                   `json:"category"`
+	Recommendation string                   `json:"recommendation"`
+	Factors        []models.RiskFactor      `json:"factors"`
+	Improvements   []models.RiskImprovement `json:"improvements"`
+}
+
+// Validate revisa que la respuesta cumpla el contrato.
+func (r ScoreResponse) Validate() error {
+	if r.EngineVersion == "" {
+		return fmt.Errorf("la respuesta no tiene engineVersion")
+	}
+
+	if r.Score < 0 || r.Score > 100 {
+		return fmt.Errorf("score fuera de rango: %.2f", r.Score)
+	}
+
+	switch r.Category {
+	case "LOW", "MEDIUM", "HIGH":
+	default:
+		return fmt.Errorf("categoría desconocida: %q", r.Category)
+	}
+
+	switch r.Recommendation {
+	case models.RiskRecommendationApprove, models.RiskRecommendationReview, models.RiskRecommendationReject:
+	default:
+		return fmt.Errorf("recomendación desconocida: %q", r.Recommendation)
+	}
+
+	return nil
+}
+
+func (r ScoreResponse) Assessment() *models.RiskAssessment {
+	return &models.RiskAssessment{
+		EngineVersion:  r.EngineVersion,
+		BaseScore:      r.BaseScore,
+		Score:          r.Score,
+		Category:       r.Category,
+		Recommendation: r.Recommendation,
+		Factors:        r.Factors,
+		Improvements:   r.Improvements,
+	}
+}
+
+// NewScoreResponse construye la respuesta del contrato desde una evaluación local.
+func NewScoreResponse(assessment *models.RiskAssessment) ScoreResponse {
+	return ScoreResponse{
+		EngineVersion:  assessment.EngineVersion,
+		BaseScore:      assessment.BaseScore,
+		Score:          assessment.Score,
+		Category:       assessment.Category,
+		Recommendation: assessment.Recommendation,
+		Factors:        assessment.Factors,
+		Improvements:   assessment.Improvements,
+	}
+}
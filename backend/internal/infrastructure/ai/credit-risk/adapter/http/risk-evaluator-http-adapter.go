@@ -0,0 +1,145 @@
+package httpAdapter
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/JhonCamargo53/prueba-tecnica/internal/domain/i18n"
+	"github.com/JhonCamargo53/prueba-tecnica/internal/domain/models"
+	"github.com/JhonCamargo53/prueba-tecnica/internal/domain/ports"
+	"github.com/JhonCamargo53/prueba-tecnica/internal/infrastructure/logger"
+)
+
+/*
+
+HTTPRiskEvaluatorAdapter evalúa el riesgo llamando a un servicio externo que
+implementa el contrato de contract.go. Cada intento tiene su propio timeout;
+los errores de red, 429 y 5xx se reintentan con espera exponencial. Si todos
+los intentos fallan y hay un motor de respaldo configurado, se usa ese motor.
+
+*/
+
+type Options struct {
+	URL      string
+	APIKey   string
+	Timeout  time.Duration
+	Retries  int
+	Backoff  time.Duration
+	Fallback ports.RiskEvaluator
+}
+
+type HTTPRiskEvaluatorAdapter struct {
+	client  *http.Client
+	options Options
+}
+
+func NewHTTPRiskEvaluatorAdapter(options Options) ports.RiskEvaluator {
+	if options.Timeout <= 0 {
+		options.Timeout = 2 * time.Second
+	}
+	if options.Retries < 0 {
+		options.Retries = 0
+	}
+	if options.Backoff <= 0 {
+		options.Backoff = 100 * time.Millisecond
+	}
+
+	return &HTTPRiskEvaluatorAdapter{
+		client:  &http.Client{},
+		options: options,
+	}
+}
+
+func (a *HTTPRiskEvaluatorAdapter) Evaluate(customer models.Customer, currentCreditRequest models.CreditRequest,
+	otherCredits []models.CreditRequest, assets []models.CustomerAsset) (*models.RiskAssessment, error) {
+
+	body, err := json.Marshal(ScoreRequest{
+		ContractVersion: ContractVersion,
+		Input:           models.NewRiskInputSnapshot(customer, currentCreditRequest, otherCredits, assets),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var lastErr error
+
+	for attempt := 0; attempt <= a.options.Retries; attempt++ {
+		if attempt > 0 {
+			time.Sleep(a.options.Backoff * time.Duration(1<<(attempt-1)))
+		}
+
+		response, retryable, err := a.call(body)
+		if err == nil {
+			assessment := response.Assessment()
+			i18n.Localize(assessment, i18n.DefaultLanguage)
+			return assessment, nil
+		}
+
+		lastErr = err
+		if !retryable {
+			break
+		}
+	}
+
+	if a.options.Fallback == nil {
+		return nil, fmt.Errorf("servicio de riesgo externo no disponible: %w", lastErr)
+	}
+
+	logger.WriteJSON(map[string]interface{}{
+		"timestamp":         time.Now().Format(time.RFC3339),
+		"level":             "warning",
+		"event":             "risk_http_fallback",
+		"url":               a.options.URL,
+		"credit_request_id": currentCreditRequest.ID,
+		"error":             lastErr.Error(),
+	})
+
+	return a.options.Fallback.Evaluate(customer, currentCreditRequest, otherCredits, assets)
+}
+
+// call hace un intento y retorna si el error amerita reintentar.
+func (a *HTTPRiskEvaluatorAdapter) call(body []byte) (*ScoreResponse, bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), a.options.Timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, a.options.URL, bytes.NewReader(body))
+	if err != nil {
+		return nil, false, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	if a.options.APIKey != "" {
+		req.Header.Set("Authorization", "Bearer "+a.options.APIKey)
+	}
+
+	res, err := a.client.Do(req)
+	if err != nil {
+		return nil, true, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == http.StatusTooManyRequests || res.StatusCode >= 500 {
+		return nil, true, fmt.Errorf("el servicio de riesgo respondió %d", res.StatusCode)
+	}
+
+	if res.StatusCode != http.StatusOK {
+		detail, _ := io.ReadAll(io.LimitReader(res.Body, 512))
+		return nil, false, fmt.Errorf("el servicio de riesgo respondió %d: %s", res.StatusCode, bytes.TrimSpace(detail))
+	}
+
+	var response ScoreResponse
+	if err := json.NewDecoder(res.Body).Decode(&response); err != nil {
+		return nil, false, fmt.Errorf("respuesta inválida del servicio de riesgo: %w", err)
+	}
+
+	if err := response.Validate(); err != nil {
+		return nil, false, fmt.Errorf("respuesta inválida del servicio de riesgo: %w", err)
+	}
+
+	return &response, false, nil
+}
@@ -0,0 +1,189 @@
+package httpAdapter
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/JhonCamargo53/prueba-tecnica/internal/domain/models"
+	engines "github.com/JhonCamargo53/prueba-tecnica/internal/infrastructure/ai/credit-risk/engines"
+)
+
+type fallbackEvaluator struct {
+	called bool
+}
+
+func (f *fallbackEvaluator) Evaluate(customer models.Customer, currentCreditRequest models.CreditRequest,
+	otherCredits []models.CreditRequest, assets []models.CustomerAsset) (*models.RiskAssessment, error) {
+	f.called = true
+	return &models.RiskAssessment{EngineVersion: "mock", Score: 50, Category: "MEDIUM"}, nil
+}
+
+func validResponse() ScoreResponse {
+	return ScoreResponse{
+		EngineVersion:  "model-1",
+		BaseScore:      50,
+		Score:          72,
+		Category:       "MEDIUM",
+		Recommendation: models.RiskRecommendationReview,
+		Factors: []models.RiskFactor{
+			{Code: "FIRST_REQUEST", Points: 5},
+			{Code: "MODEL_SIGNAL", Points: 17, Message: "Señal del modelo externo"},
+		},
+	}
+}
+
+func newOptions(url string) Options {
+	return Options{URL: url, Timeout: 200 * time.Millisecond, Retries: 2, Backoff: time.Millisecond}
+}
+
+func TestEvaluate_RespuestaValida(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req ScoreRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.ContractVersion != ContractVersion {
+			http.Error(w, "petición inválida", http.StatusBadRequest)
+			return
+		}
+		if req.Input.Amount != 10_000_000 || req.Input.MonthlyIncome != 4_000_000 {
+			http.Error(w, "datos inesperados", http.StatusBadRequest)
+			return
+		}
+		json.NewEncoder(w).Encode(validResponse())
+	}))
+	defer server.Close()
+
+	adapter := NewHTTPRiskEvaluatorAdapter(newOptions(server.URL))
+
+	assessment, err := adapter.Evaluate(models.Customer{MonthlyIncome: 4_000_000}, models.CreditRequest{Amount: 10_000_000, TermMonths: 12}, nil, nil)
+	if err != nil {
+		t.Fatalf("no se esperaba error: %v", err)
+	}
+
+	if assessment.Score != 72 || assessment.EngineVersion != "model-1" {
+		t.Errorf("evaluación inesperada: %+v", assessment)
+	}
+	if !strings.Contains(assessment.Explanation, "Es la primera solicitud") ||
+		!strings.Contains(assessment.Explanation, "Señal del modelo externo") {
+		t.Errorf("se esperaba la explicación renderizada localmente, obtenido: %s", assessment.Explanation)
+	}
+}
+
+func TestEvaluate_ReintentaErroresDelServidor(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) < 3 {
+			http.Error(w, "no disponible", http.StatusServiceUnavailable)
+			return
+		}
+		json.NewEncoder(w).Encode(validResponse())
+	}))
+	defer server.Close()
+
+	adapter := NewHTTPRiskEvaluatorAdapter(newOptions(server.URL))
+
+	if _, err := adapter.Evaluate(models.Customer{}, models.CreditRequest{}, nil, nil); err != nil {
+		t.Fatalf("se esperaba éxito en el tercer intento: %v", err)
+	}
+	if calls != 3 {
+		t.Errorf("se esperaban 3 intentos, obtenido: %d", calls)
+	}
+}
+
+func TestEvaluate_TimeoutUsaRespaldo(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(100 * time.Millisecond)
+		json.NewEncoder(w).Encode(validResponse())
+	}))
+	defer server.Close()
+
+	fallback := &fallbackEvaluator{}
+	options := newOptions(server.URL)
+	options.Timeout = 10 * time.Millisecond
+	options.Retries = 1
+	options.Fallback = fallback
+
+	assessment, err := NewHTTPRiskEvaluatorAdapter(options).Evaluate(models.Customer{}, models.CreditRequest{}, nil, nil)
+	if err != nil {
+		t.Fatalf("no se esperaba error con motor de respaldo: %v", err)
+	}
+	if !fallback.called || assessment.EngineVersion != "mock" {
+		t.Errorf("se esperaba el resultado del motor de respaldo, obtenido: %+v", assessment)
+	}
+}
+
+func TestEvaluate_ErrorDeClienteNoReintentaNiUsaRespaldoSiNoHay(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		http.Error(w, "contrato no soportado", http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	_, err := NewHTTPRiskEvaluatorAdapter(newOptions(server.URL)).Evaluate(models.Customer{}, models.CreditRequest{}, nil, nil)
+	if err == nil {
+		t.Fatalf("se esperaba error sin motor de respaldo")
+	}
+	if calls != 1 {
+		t.Errorf("no se debería reintentar un 400, intentos: %d", calls)
+	}
+}
+
+func TestScoreResponse_Validate(t *testing.T) {
+	response := validResponse()
+	response.Category = "UNKNOWN"
+
+	if err := response.Validate(); err == nil {
+		t.Fatalf("se esperaba error por categoría desconocida")
+	}
+}
+
+func TestScoreRequest_SnapshotConservaCarteraYProducto(t *testing.T) {
+	paidAt := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	consumer := &models.CreditProduct{ID: 2, Code: "CONSUMER", BaseAnnualRate: 26, RiskWeight: 1.3}
+	customer := models.Customer{ID: 1, MonthlyIncome: 6_000_000}
+	creditRequest := models.CreditRequest{ID: 9, CustomerID: 1, Amount: 8_000_000, TermMonths: 24, ProductType: "Libre inversión",
+		CreditProduct: consumer}
+	otherCredits := []models.CreditRequest{
+		{ID: 2, CustomerID: 1, Amount: 5_000_000, TermMonths: 12, CreditStatusID: models.CreditStatusApprovedID,
+			CreatedAt: time.Now().AddDate(0, -2, 0), CreditProduct: consumer,
+			LoanAccount: &models.LoanAccount{Status: models.LoanAccountStatusActive, OutstandingPrincipal: 2_000_000,
+				MaxDaysPastDue: 5, LastPaymentAt: &paidAt}},
+		{ID: 3, CustomerID: 1, Amount: 3_000_000, TermMonths: 12, CreditStatusID: models.CreditStatusApprovedID,
+			LoanAccount: &models.LoanAccount{Status: models.LoanAccountStatusActive, OutstandingPrincipal: 3_000_000,
+				DaysPastDue: 45, MaxDaysPastDue: 45}},
+	}
+
+	body, err := json.Marshal(ScoreRequest{ContractVersion: ContractVersion,
+		Input: models.NewRiskInputSnapshot(customer, creditRequest, otherCredits, nil)})
+	if err != nil {
+		t.Fatalf("no se esperaba error: %v", err)
+	}
+	var request ScoreRequest
+	if err := json.Unmarshal(body, &request); err != nil {
+		t.Fatalf("no se esperaba error: %v", err)
+	}
+	gotCustomer, gotRequest, gotCredits, gotAssets := request.Input.Models()
+
+	rules := engines.DefaultRuleSet()
+	want, _ := engines.AssessCreditRisk(rules, customer, creditRequest, otherCredits, nil)
+	got, _ := engines.AssessCreditRisk(rules, gotCustomer, gotRequest, gotCredits, gotAssets)
+
+	codes := map[string]bool{}
+	for _, factor := range got.Factors {
+		codes[factor.Code] = true
+	}
+	if !codes[engines.FactorRepaymentPerforming] || !codes[engines.FactorDelinquency] {
+		t.Errorf("se esperaba calificar la cartera recibida por el contrato, factores: %+v", got.Factors)
+	}
+	if !codes[engines.FactorProductRiskWeight] || gotRequest.ProductCode() != "CONSUMER" ||
+		gotCredits[0].CreditProduct == nil || gotCredits[0].CreditProduct.BaseAnnualRate != 26 {
+		t.Errorf("se esperaba el producto del catálogo recibido por el contrato: %+v", gotRequest.CreditProduct)
+	}
+	if got.Score != want.Score || len(got.Factors) != len(want.Factors) {
+		t.Errorf("se esperaba la misma evaluación que con los datos originales: %.2f vs %.2f", got.Score, want.Score)
+	}
+}
@@ -3,9 +3,10 @@ package engines
 import (
 	"fmt"
 	"strings"
+	"time"
 
-	"github.com/JhonCamargo53/prueba-tecnica/internal/infrastructure/helper"
-
+	"github.com/JhonCamargo53/prueba-tecnica/internal/domain/finance"
+	"github.com/JhonCamargo53/prueba-tecnica/internal/domain/i18n"
 	"github.com/JhonCamargo53/prueba-tecnica/internal/domain/models"
 )
 
@@ -14,256 +15,521 @@ import (
 EvaluateCreditRisk recibe un CreditRequest (con sólo el ID llenado),
 carga la info necesaria, calcula el riesgo, actualiza el registro
 y devuelve la explicación en lenguaje natural.
+Los pesos y umbrales provienen del RuleSet recibido.
 
 */
 
-func EvaluateCreditRisk(customer models.Customer, currentCreditRequest models.CreditRequest,
+func EvaluateCreditRisk(rules *RuleSet, customer models.Customer, currentCreditRequest models.CreditRequest,
 	otherCredits []models.CreditRequest, assets []models.CustomerAsset) (float64, string, string, error) {
 
+	assessment, err := AssessCreditRisk(rules, customer, currentCreditRequest, otherCredits, assets)
+	if err != nil {
+		return 0, "", "", err
+	}
+
+	return assessment.Score, assessment.Category, assessment.Explanation, nil
+}
+
+// AssessCreditRisk calcula el resultado estructurado (factores, recomendación y mejoras)
+// y su vista en texto.
+func AssessCreditRisk(rules *RuleSet, customer models.Customer, currentCreditRequest models.CreditRequest,
+	otherCredits []models.CreditRequest, assets []models.CustomerAsset) (*models.RiskAssessment, error) {
+
+	if rules == nil {
+		return nil, fmt.Errorf("no hay un conjunto de reglas de riesgo configurado")
+	}
+
 	//Calcular puntaje
-	score, reasons, improvements := calculateScore(customer, currentCreditRequest, otherCredits, assets)
+	score, factors, improvements := calculateScore(rules, customer, currentCreditRequest, otherCredits, assets)
 
 	//Determinar categoría y recomendación
-	categoryEN, categoryES := riskCategory(score)
-	recommendation := recommendationFromScore(score)
+
+	assessment := &models.RiskAssessment{
+		EngineVersion:  rules.Version,
+		BaseScore:      rules.BaseScore,
+		Score:          score,
+		Category:       riskCategory(rules, score),
+		Recommendation: recommendationFromScore(rules, score),
+		Factors:        factors,
+		Improvements:   improvements,
+	}
 
 	//Construir explicación en lenguaje natural
-	explanation := buildExplanation(score, categoryES, recommendation, reasons, improvements)
+	assessment.Explanation = RenderExplanation(assessment)
 
-	return score, categoryEN, explanation, nil
+	return assessment, nil
 }
 
-func calculateScore(customer models.Customer, currentCreditRequest models.CreditRequest,
-	otherCredits []models.CreditRequest, assets []models.CustomerAsset) (float64, []string, []string) {
+// Códigos de los factores del motor
+const (
+	FactorPaymentToIncomeInvalid = "PAYMENT_TO_INCOME_INVALID"
+	FactorPaymentToIncome        = "PAYMENT_TO_INCOME"
+	FactorParticipantIncome      = "PARTICIPANT_INCOME"
+	FactorDebtService            = "DEBT_SERVICE"
+	FactorDebtServiceHigh        = "DEBT_SERVICE_HIGH"
+	FactorDebtServiceExcessive   = "DEBT_SERVICE_EXCESSIVE"
+	FactorCollateralNone         = "COLLATERAL_NONE"
+	FactorCollateralValue        = "COLLATERAL_VALUE"
+	FactorCollateralIneligible   = "COLLATERAL_INELIGIBLE"
+	FactorLoanToValue            = "LOAN_TO_VALUE"
+	FactorRealEstateCollateral   = "REAL_ESTATE_COLLATERAL"
+	FactorGuarantorCollateral    = "GUARANTOR_COLLATERAL"
+	FactorFirstRequest           = "FIRST_REQUEST"
+	FactorRequestCount           = "REQUEST_COUNT"
+	FactorRequestCountElevated   = "REQUEST_COUNT_ELEVATED"
+	FactorRequestCountExcessive  = "REQUEST_COUNT_EXCESSIVE"
+	FactorApprovedHistory        = "APPROVED_HISTORY"
+	FactorManyApproved           = "MANY_APPROVED"
+	FactorRejectedHistory        = "REJECTED_HISTORY"
+	FactorRepaymentPerforming    = "REPAYMENT_PERFORMING"
+	FactorDelinquency            = "DELINQUENCY"
+	FactorProductHousing         = "PRODUCT_HOUSING"
+	FactorProductConsumer        = "PRODUCT_CONSUMER"
+	FactorProductRiskWeight      = "PRODUCT_RISK_WEIGHT"
+)
 
-	score := 50.0
-	var reasons []string
-	var improvements []string
+// Códigos de las mejoras sugeridas
+const (
+	ImprovementFixIncomeData      = "FIX_INCOME_DATA"
+	ImprovementReduceInstallment  = "REDUCE_INSTALLMENT"
+	ImprovementConsolidateDebt    = "CONSOLIDATE_DEBT"
+	ImprovementAddCollateral      = "ADD_COLLATERAL"
+	ImprovementEligibleCollateral = "ADD_ELIGIBLE_COLLATERAL"
+	ImprovementIncreaseCollateral = "INCREASE_COLLATERAL"
+	ImprovementReduceRequests     = "REDUCE_REQUESTS"
+	ImprovementBuildHistory       = "BUILD_HISTORY"
+	ImprovementReviewRejections   = "REVIEW_REJECTIONS"
+	ImprovementPreferSecured      = "PREFER_SECURED"
+	ImprovementKeepLoansCurrent   = "KEEP_LOANS_CURRENT"
+)
+
+// scoreBuilder acumula el puntaje junto con los factores que lo explican.
+type scoreBuilder struct {
+	score        float64
+	factors      []models.RiskFactor
+	improvements []models.RiskImprovement
+}
+
+// Los mensajes se renderizan en el idioma por defecto; pueden volver a
+// renderizarse en otro idioma a partir del código y los parámetros.
+func (b *scoreBuilder) factor(code string, points float64, observed *float64, params map[string]float64) {
+	b.score += points
+	b.factors = append(b.factors, models.RiskFactor{
+		Code:          code,
+		Points:        points,
+		ObservedValue: observed,
+		Params:        params,
+		Message:       i18n.MustMessage(i18n.DefaultLanguage, "factor."+code, params),
+	})
+}
+
+func (b *scoreBuilder) improve(code string) {
+	b.improvements = append(b.improvements, models.RiskImprovement{
+		Code:    code,
+		Message: i18n.MustMessage(i18n.DefaultLanguage, "improvement."+code, nil),
+	})
+}
+
+func observed(v float64) *float64 {
+	return &v
+}
+
+func calculateScore(rules *RuleSet, customer models.Customer, currentCreditRequest models.CreditRequest,
+	otherCredits []models.CreditRequest, assets []models.CustomerAsset) (float64, []models.RiskFactor, []models.RiskImprovement) {
+
+	b := &scoreBuilder{score: rules.BaseScore}
+	now := time.Now()
 
 	// Relación cuota / ingreso
 	amount := currentCreditRequest.Amount
 	term := float64(currentCreditRequest.TermMonths)
 	income := customer.MonthlyIncome
 
+	// Ingresos de codeudores y fiadores, ponderados según su rol
+	roles := participantRoles(customer, currentCreditRequest)
+	participantIncome, participantCount := 0.0, 0
+	for _, p := range currentCreditRequest.Participants {
+		if _, ok := roles[p.CustomerID]; !ok || p.Customer.MonthlyIncome <= 0 {
+			continue
+		}
+		if weight := rules.Participants.IncomeWeight(p.Role); weight > 0 {
+			participantIncome += weight * p.Customer.MonthlyIncome
+			participantCount++
+		}
+	}
+	if participantIncome > 0 {
+		b.factor(FactorParticipantIncome, 0, observed(participantIncome), map[string]float64{
+			"count":             float64(participantCount),
+			"holderIncome":      income,
+			"participantIncome": participantIncome,
+		})
+		income += participantIncome
+	}
+
 	if amount <= 0 || term <= 0 || income <= 0 {
-		score -= 15
-		reasons = append(reasons,
-			"No fue posible calcular adecuadamente la relación cuota/ingreso (monto, plazo o ingreso inválidos).")
-		improvements = append(improvements,
-			"Registrar un ingreso mensual realista y/o ajustar el monto y el plazo del crédito.")
+		b.factor(FactorPaymentToIncomeInvalid, rules.PaymentToIncome.InvalidDataPoints, nil, nil)
+		b.improve(ImprovementFixIncomeData)
 	} else {
-		quota := amount / term
+		// Primera cuota según el método de amortización del producto, a la tasa de la
+		// solicitud o del producto; es la misma cuota con la que se calcula la oferta
+		annualRate := rules.AnnualInterestRateFor(currentCreditRequest)
+		quota, _ := finance.FirstInstallment(amount, annualRate, currentCreditRequest.TermMonths, amortizationMethodFor(currentCreditRequest))
 		ratio := quota / income // cuota / ingreso
 
-		ratioPct := ratio * 100
-		reasons = append(reasons,
-			fmt.Sprintf(
-				"La cuota mensual estimada es de %s, lo que corresponde al %.1f%% del ingreso mensual del cliente (%s).",
-				helper.FormatCOP(quota),
-				ratioPct,
-				helper.FormatCOP(income),
-			),
-		)
-
-		switch {
-		case ratio <= 0.20:
-			score += 25
-		case ratio <= 0.30:
-			score += 20
-		case ratio <= 0.40:
-			score += 10
-		case ratio <= 0.50:
-		default:
-			score -= 15
-			improvements = append(improvements,
-				"Se recomienda reducir el monto solicitado o ampliar el plazo para que la cuota no supere el 30% del ingreso mensual.")
+		points := rules.PaymentToIncome.AbovePoints
+		band, inBand := matchUpperBand(rules.PaymentToIncome.Bands, ratio)
+		if inBand {
+			points = band.Points
+		}
+
+		b.factor(FactorPaymentToIncome, points, observed(ratio), map[string]float64{
+			"installment": quota,
+			"termMonths":  term,
+			"annualRate":  annualRate,
+			"ratio":       ratio,
+			"income":      income,
+		})
+
+		if !inBand {
+			b.improve(ImprovementReduceInstallment)
+		}
+
+		// Servicio total de la deuda: cuotas de créditos aprobados vigentes + nueva cuota
+		activeCount, activeInstallments := activeApprovedInstallments(rules, otherCredits, now)
+
+		if activeCount > 0 {
+			totalDebtService := activeInstallments + quota
+			debtRatio := totalDebtService / income
+
+			points := rules.DebtService.AbovePoints
+			band, inBand := matchUpperBand(rules.DebtService.Bands, debtRatio)
+			if inBand {
+				points = band.Points
+			}
+
+			b.factor(FactorDebtService, points, observed(debtRatio), map[string]float64{
+				"activeCount":        float64(activeCount),
+				"activeInstallments": activeInstallments,
+				"totalDebtService":   totalDebtService,
+				"ratio":              debtRatio,
+			})
+
+			switch {
+			case inBand && band.Points < 0:
+				b.factor(FactorDebtServiceHigh, 0, observed(debtRatio), nil)
+			case !inBand:
+				b.factor(FactorDebtServiceExcessive, 0, observed(debtRatio), nil)
+				b.improve(ImprovementConsolidateDebt)
+			}
 		}
 	}
 
-	//Activos asociados a ESTE crédito
+	//Activos asociados a ESTE crédito, valorados según la política de su tipo de activo
 	totalAssetsValue := 0.0
-	hasViviendaAsset := false
+	collateralValue := 0.0
+	guarantorCollateralValue := 0.0
+	ineligibleCount := 0
+	hasRealEstateAsset := false
 
 	for _, a := range assets {
 		totalAssetsValue += a.MarketValue
 
-		desc := strings.ToLower(a.Description)
-		if strings.Contains(desc, "vivienda") ||
-			strings.Contains(desc, "casa") ||
-			strings.Contains(desc, "apartamento") {
-			hasViviendaAsset = true
+		value, eligible := assetCollateralValue(rules.Assets.UnknownTypeHaircut, a, now)
+		if !eligible {
+			ineligibleCount++
+			continue
+		}
+
+		// Los bienes de los fiadores respaldan el crédito sólo en parte
+		if role, ok := roles[a.CustomerID]; ok {
+			value *= rules.Participants.AssetWeight(role)
+			if role == models.ParticipantRoleGuarantor {
+				guarantorCollateralValue += value
+			}
+		}
+
+		collateralValue += value
+		if a.Asset.RealEstate && value > 0 {
+			hasRealEstateAsset = true
 		}
 	}
 
 	if totalAssetsValue <= 0 {
-		reasons = append(reasons,
-			"No se registran activos con valor asociado específicamente a esta solicitud de crédito.")
-		improvements = append(improvements,
-			"Incluir activos con valor de mercado (por ejemplo vivienda o vehículo) como respaldo del crédito.")
+		b.factor(FactorCollateralNone, 0, nil, nil)
+		b.improve(ImprovementAddCollateral)
 	} else {
 
-		ratioAssets := totalAssetsValue / amount
-		reasons = append(reasons,
-			fmt.Sprintf(
-				"El valor total de los activos registrados para este crédito es de %s, lo que equivale a %.1f veces el monto solicitado.",
-				helper.FormatCOP(totalAssetsValue),
-				ratioAssets,
-			),
-		)
-
-		switch {
-		case ratioAssets >= 2.0:
-			score += 20
-		case ratioAssets >= 1.0:
-			score += 15
-		case ratioAssets >= 0.5:
-			score += 8
-		default:
-			// pocos activos frente al monto
-			improvements = append(improvements,
-				"El valor de los activos es bajo frente al monto solicitado; se recomienda aumentar garantías.")
+		b.factor(FactorCollateralValue, 0, observed(collateralValue), map[string]float64{
+			"marketValue":     totalAssetsValue,
+			"collateralValue": collateralValue,
+		})
+
+		if guarantorCollateralValue > 0 {
+			b.factor(FactorGuarantorCollateral, 0, observed(guarantorCollateralValue), map[string]float64{
+				"collateralValue": guarantorCollateralValue,
+				"weight":          rules.Participants.GuarantorAssetWeight,
+			})
+		}
+
+		if ineligibleCount > 0 {
+			b.factor(FactorCollateralIneligible, 0, observed(float64(ineligibleCount)),
+				map[string]float64{"count": float64(ineligibleCount)})
+		}
+
+		if collateralValue <= 0 {
+			b.improve(ImprovementEligibleCollateral)
+		} else {
+			loanToValue := amount / collateralValue
+
+			points := 0.0
+			band, inBand := matchUpperBand(rules.Assets.LoanToValueBands, loanToValue)
+			if inBand {
+				points = band.Points
+			}
+
+			b.factor(FactorLoanToValue, points, observed(loanToValue), map[string]float64{"ltv": loanToValue})
+
+			if !inBand {
+				// poca garantía frente al monto
+				b.improve(ImprovementIncreaseCollateral)
+			}
 		}
 
-		if hasViviendaAsset {
-			score += 8
-			reasons = append(reasons,
-				"Se registra al menos un activo tipo vivienda como respaldo, lo cual mejora el perfil de riesgo.")
+		if hasRealEstateAsset {
+			b.factor(FactorRealEstateCollateral, rules.Assets.RealEstatePoints, nil, nil)
 		}
 	}
 
 	totalCredits := len(otherCredits)
 	approvedCount := 0
 	rejectedCount := 0
+	var loans []models.LoanAccount
 
 	for _, other := range otherCredits {
 		switch other.CreditStatusID {
-		case 2: // APROBADO
-			approvedCount++
-		case 3: // RECHAZADO
+		case models.CreditStatusApprovedID:
+			// Los créditos con cuenta se califican por su comportamiento de pago real
+			if other.LoanAccount != nil {
+				loans = append(loans, *other.LoanAccount)
+			} else {
+				approvedCount++
+			}
+		case models.CreditStatusRejectedID:
 			rejectedCount++
 		}
 	}
 
 	// Número total de créditos solicitados
+	countBand, inBand := matchUpperBand(rules.History.RequestCountBands, float64(totalCredits))
+	requestCount := map[string]float64{"count": float64(totalCredits)}
+
 	switch {
-	case totalCredits == 0:
-		score += 5
-		reasons = append(reasons, "Es la primera solicitud de crédito registrada para este cliente.")
-	case totalCredits <= 3:
-		reasons = append(reasons,
-			fmt.Sprintf("El cliente ha realizado %d solicitudes de crédito en el sistema.", totalCredits))
-	case totalCredits <= 5:
-		score -= 5
-		reasons = append(reasons,
-			fmt.Sprintf("El cliente ha realizado %d solicitudes de crédito; esto incrementa ligeramente el riesgo.", totalCredits))
+	case inBand && totalCredits == 0:
+		b.factor(FactorFirstRequest, countBand.Points, observed(0), nil)
+	case inBand && countBand.Points >= 0:
+		b.factor(FactorRequestCount, countBand.Points, observed(float64(totalCredits)), requestCount)
+	case inBand:
+		b.factor(FactorRequestCountElevated, countBand.Points, observed(float64(totalCredits)), requestCount)
 	default:
-		score -= 10
-		reasons = append(reasons,
-			fmt.Sprintf("El cliente ha realizado %d solicitudes de crédito; un número alto de solicitudes eleva el riesgo.", totalCredits))
-		improvements = append(improvements,
-			"Reducir la cantidad de solicitudes de crédito simultáneas o recientes.")
+		b.factor(FactorRequestCountExcessive, rules.History.RequestCountAbovePoints, observed(float64(totalCredits)), requestCount)
+		b.improve(ImprovementReduceRequests)
+	}
+
+	// Historial de pagos: créditos con pagos y sin mora de 30 días o más, y la mayor mora alcanzada
+	performingCount := 0
+	worstDaysPastDue := 0
+	for _, loan := range loans {
+		if loan.HasPayments() && loan.MaxDaysPastDue < 30 {
+			performingCount++
+		}
+		if loan.MaxDaysPastDue > worstDaysPastDue {
+			worstDaysPastDue = loan.MaxDaysPastDue
+		}
 	}
 
-	// Créditos aprobados / culminados
+	if performingCount > 0 {
+		b.factor(FactorRepaymentPerforming, rules.History.PerformingLoanPoints, observed(float64(performingCount)),
+			map[string]float64{"count": float64(performingCount)})
+	}
+	if worstDaysPastDue > 0 {
+		points := rules.History.DelinquencyAbovePoints
+		if band, ok := matchUpperBand(rules.History.DelinquencyBands, float64(worstDaysPastDue)); ok {
+			points = band.Points
+		}
+		b.factor(FactorDelinquency, points, observed(float64(worstDaysPastDue)),
+			map[string]float64{"days": float64(worstDaysPastDue)})
+		b.improve(ImprovementKeepLoansCurrent)
+	}
+
+	// Créditos aprobados sin cuenta (anteriores a la cartera)
 	if approvedCount > 0 {
-		score += 5
-		reasons = append(reasons,
-			fmt.Sprintf("Historial positivo: %d crédito(s) aprobado(s) en el sistema.", approvedCount))
-		if approvedCount >= 3 {
-			score += 5
-			reasons = append(reasons,
-				"El cliente tiene varios créditos aprobados, lo que indica buen comportamiento histórico.")
+		approved := map[string]float64{"count": float64(approvedCount)}
+		b.factor(FactorApprovedHistory, rules.History.ApprovedPoints, observed(float64(approvedCount)), approved)
+		if approvedCount >= rules.History.ManyApprovedThreshold {
+			b.factor(FactorManyApproved, rules.History.ManyApprovedPoints, observed(float64(approvedCount)), approved)
 		}
-	} else {
-		improvements = append(improvements,
-			"No se encuentran créditos aprobados previos; mantener un buen comportamiento en este crédito ayudará al historial.")
+	} else if performingCount == 0 {
+		b.improve(ImprovementBuildHistory)
 	}
 
 	// Créditos rechazados
 	if rejectedCount > 0 {
+		points := rules.History.MultipleRejectedPoints
 		if rejectedCount == 1 {
-			score -= 8
-		} else {
-			score -= 12
+			points = rules.History.SingleRejectedPoints
 		}
-		reasons = append(reasons,
-			fmt.Sprintf("Se encuentran %d crédito(s) rechazado(s) previamente, lo que disminuye el puntaje de riesgo.", rejectedCount))
-		improvements = append(improvements,
-			"Revisar las causas de rechazo de solicitudes anteriores y corregirlas antes de solicitar nuevos créditos.")
+		b.factor(FactorRejectedHistory, points, observed(float64(rejectedCount)),
+			map[string]float64{"count": float64(rejectedCount)})
+		b.improve(ImprovementReviewRejections)
 	}
 
-	//Tipo de producto (vivienda / libre inversión)
-	productType := strings.ToUpper(strings.TrimSpace(currentCreditRequest.ProductType))
-
-	if strings.Contains(productType, "VIVIENDA") || strings.Contains(productType, "HIPOTEC") {
-		score += 10
-		reasons = append(reasons,
-			"El producto corresponde a crédito de vivienda/hipotecario, que suele estar respaldado en activos reales.")
-	}
-
-	if strings.Contains(productType, "LIBRE") || strings.Contains(productType, "CONSUMO") {
-		score -= 10
-		reasons = append(reasons,
-			"El producto es de libre inversión/consumo, usualmente más riesgoso por no estar asociado a un activo específico.")
-		improvements = append(improvements,
-			"Para montos altos se recomienda preferir créditos respaldados en vivienda u otros activos.")
+	// Producto: el ponderador de riesgo del catálogo o, en solicitudes anteriores
+	// al catálogo, las palabras clave del tipo de producto (vivienda / libre inversión)
+	if catalog := currentCreditRequest.CreditProduct; catalog != nil && catalog.RiskWeight > 0 {
+		points := (1 - catalog.RiskWeight) * rules.ProductRiskWeightPoints
+		b.factor(FactorProductRiskWeight, points, observed(catalog.RiskWeight),
+			map[string]float64{"riskWeight": catalog.RiskWeight})
+		if catalog.RiskWeight > 1 && !catalog.CollateralRequired {
+			b.improve(ImprovementPreferSecured)
+		}
+	} else {
+		productType := strings.ToUpper(strings.TrimSpace(currentCreditRequest.ProductType))
+
+		for _, product := range rules.Products {
+			if !containsAnyKeyword(productType, product.Keywords) {
+				continue
+			}
+
+			switch product.Code {
+			case ProductRuleHousing:
+				b.factor(FactorProductHousing, product.Points, nil, nil)
+			case ProductRuleConsumer:
+				b.factor(FactorProductConsumer, product.Points, nil, nil)
+				b.improve(ImprovementPreferSecured)
+			}
+		}
 	}
 
-	if score < 0 {
-		score = 0
+	score := b.score
+	if score < rules.MinScore {
+		score = rules.MinScore
 	}
-	if score > 100 {
-		score = 100
+	if score > rules.MaxScore {
+		score = rules.MaxScore
 	}
 
-	return score, reasons, improvements
+	return score, b.factors, b.improvements
 }
 
-func riskCategory(score float64) (categoryEN, categoryES string) {
+func riskCategory(rules *RuleSet, score float64) string {
 	switch {
-	case score >= 80:
-		return "LOW", "Bajo"
-	case score >= 55:
-		return "MEDIUM", "Medio"
+	case score >= rules.Categories.LowFrom:
+		return "LOW"
+	case score >= rules.Categories.MediumFrom:
+		return "MEDIUM"
 	default:
-		return "HIGH", "Alto"
+		return "HIGH"
 	}
 }
 
-func recommendationFromScore(score float64) string {
+func recommendationFromScore(rules *RuleSet, score float64) string {
 	switch {
-	case score >= 75:
-		return "APROBAR"
-	case score >= 55:
-		return "DEJAR EN ESTUDIO / APROBAR CON CONDICIONES"
+	case score >= rules.Recommendation.ApproveFrom:
+		return models.RiskRecommendationApprove
+	case score >= rules.Recommendation.StudyFrom:
+		return models.RiskRecommendationReview
 	default:
-		return "NO APROBAR"
+		return models.RiskRecommendationReject
+	}
+}
+
+// RenderExplanation construye la vista en texto (idioma por defecto) de una evaluación estructurada.
+func RenderExplanation(assessment *models.RiskAssessment) string {
+	return i18n.RenderExplanation(assessment, i18n.DefaultLanguage)
+}
+
+// containsAnyKeyword indica si el texto contiene alguna de las palabras clave (sin distinguir mayúsculas).
+func containsAnyKeyword(text string, keywords []string) bool {
+	upper := strings.ToUpper(text)
+	for _, k := range keywords {
+		if k != "" && strings.Contains(upper, strings.ToUpper(k)) {
+			return true
+		}
+	}
+	return false
+}
+
+// activeApprovedInstallments suma las cuotas de los créditos aprobados vigentes.
+func activeApprovedInstallments(rules *RuleSet, otherCredits []models.CreditRequest, now time.Time) (int, float64) {
+	count := 0
+	total := 0.0
+
+	for _, other := range otherCredits {
+		if other.CreditStatusID != models.CreditStatusApprovedID || !isActiveCredit(other, now) {
+			continue
+		}
+
+		annualRate := rules.AnnualInterestRateFor(other)
+		installment, err := finance.FirstInstallment(other.Amount, annualRate, other.TermMonths, amortizationMethodFor(other))
+		if err != nil {
+			continue
+		}
+
+		count++
+		total += installment
+	}
+
+	return count, total
+}
+
+// amortizationMethodFor retorna el método de amortización del producto de la
+// solicitud; francés si no tiene producto del catálogo.
+func amortizationMethodFor(creditRequest models.CreditRequest) string {
+	if creditRequest.CreditProduct != nil && creditRequest.CreditProduct.AmortizationMethod != "" {
+		return creditRequest.CreditProduct.AmortizationMethod
 	}
+	return finance.AmortizationFrench
 }
 
-func buildExplanation(
-	score float64,
-	categoryES string,
-	recommendation string,
-	reasons []string,
-	improvements []string,
-) string {
-	var b strings.Builder
-
-	fmt.Fprintf(&b, "- {Puntaje de riesgo:} %.1f/100\n", score)
-	fmt.Fprintf(&b, "- {Rango de riesgo:} %s\n", categoryES)
-	fmt.Fprintf(&b, "- {Recomendación del motor:} %s.\n\n", recommendation)
-
-	for _, r := range reasons {
-		b.WriteString("- " + r + "\n")
+// isActiveCredit indica si un crédito aprobado sigue comprometiendo ingreso. Con cuenta
+// de crédito vale su estado y su saldo; sin ella, que el plazo contado desde la
+// aprobación (o desde la creación, si no hay historial) no haya terminado.
+func isActiveCredit(credit models.CreditRequest, now time.Time) bool {
+	if account := credit.LoanAccount; account != nil {
+		return account.Status == models.LoanAccountStatusActive && account.OutstandingPrincipal > 0
 	}
 
-	if len(improvements) > 0 {
-		b.WriteString("\nPosibles mejoras para futuros análisis:\n")
-		for _, m := range improvements {
-			b.WriteString("- " + m + "\n")
+	start := credit.CreatedAt
+	if credit.ApprovedAt != nil {
+		start = *credit.ApprovedAt
+	}
+	return start.IsZero() || start.AddDate(0, credit.TermMonths, 0).After(now)
+}
+
+// participantRoles retorna el rol de los codeudores y fiadores de la solicitud por
+// cliente; el titular no se incluye porque su ingreso y sus bienes cuentan completos.
+func participantRoles(customer models.Customer, creditRequest models.CreditRequest) map[uint]string {
+	roles := make(map[uint]string, len(creditRequest.Participants))
+	for _, p := range creditRequest.Participants {
+		if p.CustomerID == customer.ID || p.Role == models.ParticipantRoleHolder {
+			continue
 		}
+		roles[p.CustomerID] = p.Role
+	}
+	return roles
+}
+
+// assetCollateralValue valora un activo con la depreciación y el descuento de su tipo.
+// Si el tipo de activo no viene cargado se aplica el descuento genérico recibido.
+func assetCollateralValue(unknownTypeHaircut float64, a models.CustomerAsset, now time.Time) (float64, bool) {
+	if a.Asset.ID == 0 {
+		return finance.CollateralValue(a.MarketValue, unknownTypeHaircut), true
+	}
+
+	if !a.Asset.CollateralEligible {
+		return 0, false
 	}
 
-	return b.String()
+	depreciated := finance.DepreciatedValue(a.MarketValue, a.Asset.DepreciationMethod, a.Asset.AnnualDepreciationRate, a.CreatedAt, now)
+	return finance.CollateralValue(depreciated, a.Asset.CollateralHaircut), true
 }
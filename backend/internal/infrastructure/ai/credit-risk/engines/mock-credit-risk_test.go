@@ -3,6 +3,7 @@ package engines
 import (
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/JhonCamargo53/prueba-tecnica/internal/domain/models"
 )
@@ -24,6 +25,14 @@ func TestEvaluateCreditRisk_LowRiskScenario(t *testing.T) {
 		{
 			MarketValue: 10_000_000,
 			Description: "Vivienda principal",
+			Asset: models.Asset{
+				ID:                 1,
+				Name:               "INMUEBLE",
+				CollateralEligible: true,
+				CollateralHaircut:  0.3,
+				DepreciationMethod: "NONE",
+				RealEstate:         true,
+			},
 		},
 	}
 
@@ -33,6 +42,7 @@ func TestEvaluateCreditRisk_LowRiskScenario(t *testing.T) {
 	}
 
 	score, category, explanation, err := EvaluateCreditRisk(
+		DefaultRuleSet(),
 		customer,
 		current,
 		otherCredits,
@@ -85,6 +95,7 @@ func TestEvaluateCreditRisk_HighRiskScenario(t *testing.T) {
 	}
 
 	score, category, explanation, err := EvaluateCreditRisk(
+		DefaultRuleSet(),
 		customer,
 		current,
 		otherCredits,
@@ -139,6 +150,7 @@ func TestEvaluateCreditRisk_CategoryMatchesScore(t *testing.T) {
 	}
 
 	score, category, explanation, err := EvaluateCreditRisk(
+		DefaultRuleSet(),
 		customer,
 		current,
 		otherCredits,
@@ -166,3 +178,344 @@ func TestEvaluateCreditRisk_CategoryMatchesScore(t *testing.T) {
 		t.Errorf("se esperaba explicación no vacía")
 	}
 }
+
+// Escenario 4: la cuota se calcula con amortización francesa usando la tasa de la solicitud
+func TestEvaluateCreditRisk_CuotaConInteres(t *testing.T) {
+	customer := models.Customer{
+		MonthlyIncome: 5_000_000,
+	}
+
+	current := models.CreditRequest{
+		Amount:       12_000_000,
+		TermMonths:   12,
+		InterestRate: 12.682503, // 1% mensual
+	}
+
+	_, _, explanation, err := EvaluateCreditRisk(DefaultRuleSet(), customer, current, nil, nil)
+
+	if err != nil {
+		t.Fatalf("no se esperaba error, pero se obtuvo: %v", err)
+	}
+
+	// 12.000.000 a 12 meses al 1% mensual => cuota de 1.066.185 (sin interés sería 1.000.000)
+	if !strings.Contains(explanation, "$1.066.185") {
+		t.Errorf("la explicación debería mostrar la cuota con interés, obtenido: %s", explanation)
+	}
+}
+
+func TestEvaluateCreditRisk_CuotaSegunMetodoDeAmortizacion(t *testing.T) {
+	customer := models.Customer{MonthlyIncome: 5_000_000}
+
+	// 12.000.000 a 12 meses al 1% mensual: primera cuota alemana 1.000.000 + 120.000 de
+	// interés; la cuota BULLET sólo paga el interés
+	cases := map[string]string{
+		"GERMAN": "$1.120.000",
+		"BULLET": "$120.000",
+	}
+
+	for method, want := range cases {
+		current := models.CreditRequest{
+			Amount:        12_000_000,
+			TermMonths:    12,
+			InterestRate:  12.682503,
+			CreditProduct: &models.CreditProduct{Code: "TEST", AmortizationMethod: method},
+		}
+
+		_, _, explanation, err := EvaluateCreditRisk(DefaultRuleSet(), customer, current, nil, nil)
+		if err != nil {
+			t.Fatalf("%s: no se esperaba error, pero se obtuvo: %v", method, err)
+		}
+		if !strings.Contains(explanation, want) {
+			t.Errorf("%s: la explicación debería mostrar la cuota %s, obtenido: %s", method, want, explanation)
+		}
+	}
+}
+
+// Escenario 5: créditos aprobados vigentes se suman al servicio total de la deuda
+func TestEvaluateCreditRisk_ServicioTotalDeLaDeuda(t *testing.T) {
+	customer := models.Customer{
+		MonthlyIncome: 5_000_000,
+	}
+
+	current := models.CreditRequest{
+		Amount:     6_000_000,
+		TermMonths: 36,
+	}
+
+	withoutDebt, _, _, err := EvaluateCreditRisk(DefaultRuleSet(), customer, current, nil, nil)
+	if err != nil {
+		t.Fatalf("no se esperaba error, pero se obtuvo: %v", err)
+	}
+
+	// Varios créditos aprobados vigentes que comprometen buena parte del ingreso
+	otherCredits := []models.CreditRequest{
+		{CreditStatusID: 2, Amount: 30_000_000, TermMonths: 24, CreatedAt: time.Now()},
+		{CreditStatusID: 2, Amount: 20_000_000, TermMonths: 24, CreatedAt: time.Now()},
+		// Crédito aprobado cuyo plazo ya terminó: no debe sumar
+		{CreditStatusID: 2, Amount: 90_000_000, TermMonths: 12, CreatedAt: time.Now().AddDate(-2, 0, 0)},
+	}
+
+	withDebt, _, explanation, err := EvaluateCreditRisk(DefaultRuleSet(), customer, current, otherCredits, nil)
+	if err != nil {
+		t.Fatalf("no se esperaba error, pero se obtuvo: %v", err)
+	}
+
+	if !strings.Contains(explanation, "2 crédito(s) aprobado(s) vigente(s)") {
+		t.Errorf("la explicación debería mencionar los créditos vigentes, obtenido: %s", explanation)
+	}
+
+	if !strings.Contains(explanation, "sobreendeudamiento") {
+		t.Errorf("se esperaba alerta de sobreendeudamiento, obtenido: %s", explanation)
+	}
+
+	// El historial positivo suma, pero el sobreendeudamiento debe pesar más
+	if withDebt >= withoutDebt {
+		t.Errorf("el sobreendeudamiento debería reducir el score: sin deuda=%.1f, con deuda=%.1f", withoutDebt, withDebt)
+	}
+}
+
+func TestActiveApprovedInstallments_SegunLaCuentaYLaAprobacion(t *testing.T) {
+	now := time.Now()
+	approvedRecently := now.AddDate(0, -2, 0)
+
+	cases := map[string]struct {
+		credit models.CreditRequest
+		active bool
+	}{
+		"cuenta activa con saldo, aunque el plazo desde la creación terminó": {models.CreditRequest{
+			CreatedAt: now.AddDate(-3, 0, 0), TermMonths: 12,
+			LoanAccount: &models.LoanAccount{Status: models.LoanAccountStatusActive, OutstandingPrincipal: 1_000_000},
+		}, true},
+		"cuenta pagada antes del plazo": {models.CreditRequest{
+			CreatedAt: now, TermMonths: 36,
+			LoanAccount: &models.LoanAccount{Status: models.LoanAccountStatusPaidOff},
+		}, false},
+		"cuenta activa sin saldo": {models.CreditRequest{
+			CreatedAt: now, TermMonths: 36,
+			LoanAccount: &models.LoanAccount{Status: models.LoanAccountStatusActive},
+		}, false},
+		"sin cuenta, aprobada hace poco": {models.CreditRequest{
+			CreatedAt: now.AddDate(-2, 0, 0), TermMonths: 12, ApprovedAt: &approvedRecently,
+		}, true},
+		"sin cuenta ni historial, plazo terminado": {models.CreditRequest{
+			CreatedAt: now.AddDate(-2, 0, 0), TermMonths: 12,
+		}, false},
+	}
+
+	for name, c := range cases {
+		c.credit.CreditStatusID = models.CreditStatusApprovedID
+		c.credit.Amount = 12_000_000
+		count, _ := activeApprovedInstallments(DefaultRuleSet(), []models.CreditRequest{c.credit}, now)
+		if (count == 1) != c.active {
+			t.Errorf("%s: se esperaba vigente=%v, obtenido: %d crédito(s)", name, c.active, count)
+		}
+	}
+}
+
+// Escenario 6: la garantía se valora según el tipo de activo y no por la descripción
+func TestEvaluateCreditRisk_GarantiaSegunTipoDeActivo(t *testing.T) {
+	customer := models.Customer{
+		MonthlyIncome: 6_000_000,
+	}
+
+	current := models.CreditRequest{
+		Amount:     10_000_000,
+		TermMonths: 36,
+	}
+
+	electrodomestico := models.Asset{ID: 3, Name: "ELECTRODOMESTICO", CollateralEligible: false}
+	inmueble := models.Asset{ID: 1, Name: "INMUEBLE", CollateralEligible: true, CollateralHaircut: 0.3, RealEstate: true}
+
+	// La descripción menciona "casa" pero el tipo de activo no es elegible como garantía
+	_, _, explanation, err := EvaluateCreditRisk(DefaultRuleSet(), customer, current, nil, []models.CustomerAsset{
+		{AssetID: 3, Asset: electrodomestico, MarketValue: 40_000_000, Description: "Nevera de la casa"},
+	})
+	if err != nil {
+		t.Fatalf("no se esperaba error, pero se obtuvo: %v", err)
+	}
+
+	if !strings.Contains(explanation, "no son elegibles como garantía") {
+		t.Errorf("se esperaba que el activo no elegible se excluyera, obtenido: %s", explanation)
+	}
+	if strings.Contains(explanation, "inmueble como respaldo") {
+		t.Errorf("no se esperaba bonificación de inmueble por la descripción, obtenido: %s", explanation)
+	}
+
+	// Inmueble de 40.000.000 con 30% de descuento => garantía de 28.000.000 y LTV de 36%
+	_, _, explanation, err = EvaluateCreditRisk(DefaultRuleSet(), customer, current, nil, []models.CustomerAsset{
+		{AssetID: 1, Asset: inmueble, MarketValue: 40_000_000, Description: "Lote"},
+	})
+	if err != nil {
+		t.Fatalf("no se esperaba error, pero se obtuvo: %v", err)
+	}
+
+	if !strings.Contains(explanation, "$28.000.000") || !strings.Contains(explanation, "(LTV) es de 36%") {
+		t.Errorf("se esperaba garantía ajustada de $28.000.000 y LTV de 36%%, obtenido: %s", explanation)
+	}
+	if !strings.Contains(explanation, "inmueble como respaldo") {
+		t.Errorf("se esperaba bonificación por inmueble, obtenido: %s", explanation)
+	}
+}
+
+// Escenario 7: el resultado estructurado explica el puntaje factor por factor
+func TestAssessCreditRisk_FactoresSumanElPuntaje(t *testing.T) {
+	rules := DefaultRuleSet()
+
+	customer := models.Customer{MonthlyIncome: 5_000_000}
+	current := models.CreditRequest{Amount: 8_000_000, TermMonths: 24, ProductType: "Libre inversión"}
+	otherCredits := []models.CreditRequest{{ID: 1, CreditStatusID: 3}}
+
+	assessment, err := AssessCreditRisk(rules, customer, current, otherCredits, nil)
+	if err != nil {
+		t.Fatalf("no se esperaba error, pero se obtuvo: %v", err)
+	}
+
+	total := assessment.BaseScore
+	codes := map[string]bool{}
+	for _, f := range assessment.Factors {
+		total += f.Points
+		codes[f.Code] = true
+		if f.Message == "" {
+			t.Errorf("el factor %s debería tener mensaje", f.Code)
+		}
+	}
+
+	if total != assessment.Score {
+		t.Errorf("se esperaba que los factores sumaran el puntaje %.1f, obtenido: %.1f", assessment.Score, total)
+	}
+
+	for _, code := range []string{FactorPaymentToIncome, FactorCollateralNone, FactorRequestCount, FactorRejectedHistory, FactorProductConsumer} {
+		if !codes[code] {
+			t.Errorf("se esperaba el factor %s, obtenido: %+v", code, assessment.Factors)
+		}
+	}
+
+	if assessment.EngineVersion != rules.Version {
+		t.Errorf("se esperaba versión %s, obtenido: %s", rules.Version, assessment.EngineVersion)
+	}
+	if assessment.Recommendation == "" || len(assessment.Improvements) == 0 {
+		t.Errorf("se esperaba recomendación y mejoras, obtenido: %+v", assessment)
+	}
+
+	if assessment.Explanation != RenderExplanation(assessment) {
+		t.Errorf("la explicación en texto debería ser la vista del resultado estructurado")
+	}
+}
+
+// Escenario: el producto del catálogo reemplaza las palabras clave del tipo de producto
+func TestAssessCreditRisk_PonderadorDelProducto(t *testing.T) {
+	rules := DefaultRuleSet()
+	customer := models.Customer{MonthlyIncome: 5_000_000}
+
+	factorOf := func(cr models.CreditRequest) map[string]models.RiskFactor {
+		assessment, err := AssessCreditRisk(rules, customer, cr, nil, nil)
+		if err != nil {
+			t.Fatalf("no se esperaba error, pero se obtuvo: %v", err)
+		}
+		factors := map[string]models.RiskFactor{}
+		for _, f := range assessment.Factors {
+			factors[f.Code] = f
+		}
+		return factors
+	}
+
+	// El texto dice "vivienda" pero el producto del catálogo es de consumo con ponderador 1.5
+	consumer := models.CreditRequest{Amount: 10_000_000, TermMonths: 24, ProductType: "Vivienda",
+		CreditProduct: &models.CreditProduct{Code: "CONSUMER", BaseAnnualRate: 20, RiskWeight: 1.5}}
+	factors := factorOf(consumer)
+
+	if _, ok := factors[FactorProductHousing]; ok {
+		t.Errorf("con producto del catálogo no se deberían usar las palabras clave")
+	}
+	if f, ok := factors[FactorProductRiskWeight]; !ok || f.Points != -10 {
+		t.Errorf("se esperaban -10 puntos por el ponderador 1.5, obtenido: %+v", f)
+	}
+	if rate := factors[FactorPaymentToIncome].Params["annualRate"]; rate != 20 {
+		t.Errorf("se esperaba la tasa base del producto (20), obtenido: %.2f", rate)
+	}
+
+	housing := consumer
+	housing.CreditProduct = &models.CreditProduct{Code: "HOUSING", BaseAnnualRate: 12, RiskWeight: 0.5, CollateralRequired: true}
+	if f := factorOf(housing)[FactorProductRiskWeight]; f.Points != 10 {
+		t.Errorf("se esperaban 10 puntos por el ponderador 0.5, obtenido: %+v", f)
+	}
+}
+
+// Escenario: los créditos con cuenta se califican por su historial de pagos y no por su estado
+func TestAssessCreditRisk_HistorialDePagos(t *testing.T) {
+	rules := DefaultRuleSet()
+	customer := models.Customer{MonthlyIncome: 5_000_000}
+	current := models.CreditRequest{Amount: 8_000_000, TermMonths: 24, ProductType: "Libre inversión"}
+	paidAt := time.Now()
+
+	factorsOf := func(loan models.LoanAccount) map[string]models.RiskFactor {
+		otherCredits := []models.CreditRequest{{ID: 1, CreditStatusID: 2, LoanAccount: &loan}}
+		assessment, err := AssessCreditRisk(rules, customer, current, otherCredits, nil)
+		if err != nil {
+			t.Fatalf("no se esperaba error, pero se obtuvo: %v", err)
+		}
+		factors := map[string]models.RiskFactor{}
+		for _, f := range assessment.Factors {
+			factors[f.Code] = f
+		}
+		return factors
+	}
+
+	performing := factorsOf(models.LoanAccount{LastPaymentAt: &paidAt, MaxDaysPastDue: 10})
+	if _, ok := performing[FactorApprovedHistory]; ok {
+		t.Errorf("un crédito con cuenta no debería contarse sólo por estar aprobado")
+	}
+	if f, ok := performing[FactorRepaymentPerforming]; !ok || f.Points != rules.History.PerformingLoanPoints {
+		t.Errorf("se esperaba el factor de pagos al día, obtenido: %+v", performing)
+	}
+	if f := performing[FactorDelinquency]; f.Points != -5 {
+		t.Errorf("se esperaban -5 puntos por 10 días de mora, obtenido: %+v", f)
+	}
+
+	delinquent := factorsOf(models.LoanAccount{LastPaymentAt: &paidAt, MaxDaysPastDue: 120})
+	if _, ok := delinquent[FactorRepaymentPerforming]; ok {
+		t.Errorf("un crédito con mora de 90 días o más no está al día")
+	}
+	if f := delinquent[FactorDelinquency]; f.Points != rules.History.DelinquencyAbovePoints || *f.ObservedValue != 120 {
+		t.Errorf("se esperaban %.0f puntos por 120 días de mora, obtenido: %+v", rules.History.DelinquencyAbovePoints, f)
+	}
+}
+
+// Escenario: el codeudor suma su ingreso y los bienes del fiador cuentan con su ponderación
+func TestAssessCreditRisk_ParticipantesSumanIngresosYGarantias(t *testing.T) {
+	rules := DefaultRuleSet()
+	customer := models.Customer{ID: 1, MonthlyIncome: 2_000_000}
+	current := models.CreditRequest{Amount: 60_000_000, TermMonths: 120, ProductType: "Vivienda",
+		Participants: []models.CreditRequestParticipant{
+			{CustomerID: 1, Role: models.ParticipantRoleHolder, Customer: customer},
+			{CustomerID: 2, Role: models.ParticipantRoleCoBorrower, Customer: models.Customer{ID: 2, MonthlyIncome: 3_000_000}},
+			{CustomerID: 3, Role: models.ParticipantRoleGuarantor, Customer: models.Customer{ID: 3, MonthlyIncome: 10_000_000}},
+		}}
+	// Inmueble del fiador valorado sin descuentos por ser de tipo desconocido
+	assets := []models.CustomerAsset{{CustomerID: 3, MarketValue: 100_000_000}}
+
+	assessment, err := AssessCreditRisk(rules, customer, current, nil, assets)
+	if err != nil {
+		t.Fatalf("no se esperaba error, pero se obtuvo: %v", err)
+	}
+	factors := map[string]models.RiskFactor{}
+	for _, f := range assessment.Factors {
+		factors[f.Code] = f
+	}
+
+	// Codeudor al 100%, fiador al 0% en las reglas por defecto
+	if f := factors[FactorParticipantIncome]; f.Params["participantIncome"] != 3_000_000 || f.Params["count"] != 1 {
+		t.Errorf("se esperaba sumar sólo el ingreso del codeudor, obtenido: %+v", f)
+	}
+	if income := factors[FactorPaymentToIncome].Params["income"]; income != 5_000_000 {
+		t.Errorf("se esperaba la relación cuota/ingreso sobre 5.000.000, obtenido: %.0f", income)
+	}
+
+	want := 100_000_000 * (1 - rules.Assets.UnknownTypeHaircut) * rules.Participants.GuarantorAssetWeight
+	if f := factors[FactorGuarantorCollateral]; f.Params["collateralValue"] != want {
+		t.Errorf("se esperaba garantía del fiador de %.0f, obtenido: %+v", want, f)
+	}
+	if f := factors[FactorCollateralValue]; f.Params["collateralValue"] != want {
+		t.Errorf("el valor de garantía debería incluir la ponderación del fiador, obtenido: %+v", f)
+	}
+}
@@ -0,0 +1,141 @@
+package engines
+
+import (
+	"fmt"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/JhonCamargo53/prueba-tecnica/internal/infrastructure/logger"
+)
+
+/*
+
+RuleSetStore mantiene el conjunto de reglas activo. Si se configura una ruta,
+el archivo se revisa periódicamente y se recarga en caliente; una versión
+inválida se descarta y se conserva la última versión válida.
+
+*/
+
+type RuleSetStore struct {
+	path    string
+	current atomic.Pointer[RuleSet]
+
+	mu      sync.Mutex
+	modTime time.Time
+}
+
+// NewRuleSetStore carga y valida las reglas. Con ruta vacía usa las reglas embebidas.
+func NewRuleSetStore(path string) (*RuleSetStore, error) {
+	store := &RuleSetStore{path: path}
+
+	if path == "" {
+		store.current.Store(DefaultRuleSet())
+		return store, nil
+	}
+
+	if err := store.Reload(); err != nil {
+		return nil, err
+	}
+
+	return store, nil
+}
+
+func (s *RuleSetStore) Current() *RuleSet {
+	return s.current.Load()
+}
+
+// Reload vuelve a leer el archivo de reglas y lo activa si es válido.
+func (s *RuleSetStore) Reload() error {
+	if s.path == "" {
+		return fmt.Errorf("no hay archivo de reglas configurado")
+	}
+
+	info, err := os.Stat(s.path)
+	if err != nil {
+		return fmt.Errorf("no se pudo leer el archivo de reglas %s: %w", s.path, err)
+	}
+
+	rules, err := LoadRuleSet(s.path)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	s.modTime = info.ModTime()
+	s.mu.Unlock()
+
+	s.current.Store(rules)
+	return nil
+}
+
+// Watch revisa el archivo cada intervalo y lo recarga cuando cambia.
+// Retorna una función para detener la revisión.
+func (s *RuleSetStore) Watch(interval time.Duration) (stop func()) {
+	done := make(chan struct{})
+
+	if s.path == "" || interval <= 0 {
+		return func() {}
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				s.reloadIfChanged()
+			}
+		}
+	}()
+
+	var once sync.Once
+	return func() { once.Do(func() { close(done) }) }
+}
+
+func (s *RuleSetStore) reloadIfChanged() {
+	info, err := os.Stat(s.path)
+	if err != nil {
+		return
+	}
+
+	s.mu.Lock()
+	changed := info.ModTime().After(s.modTime)
+	s.mu.Unlock()
+
+	if !changed {
+		return
+	}
+
+	previous := s.Current().Version
+
+	if err := s.Reload(); err != nil {
+		// Evitar reintentar el mismo archivo inválido en cada ciclo
+		s.mu.Lock()
+		s.modTime = info.ModTime()
+		s.mu.Unlock()
+
+		logger.WriteJSON(map[string]interface{}{
+			"timestamp": time.Now().Format(time.RFC3339),
+			"level":     "warning",
+			"event":     "risk_rules_reload_failed",
+			"path":      s.path,
+			"version":   previous,
+			"error":     err.Error(),
+		})
+		return
+	}
+
+	logger.WriteJSON(map[string]interface{}{
+		"timestamp":        time.Now().Format(time.RFC3339),
+		"level":            "info",
+		"event":            "risk_rules_reloaded",
+		"path":             s.path,
+		"previous_version": previous,
+		"version":          s.Current().Version,
+	})
+}
@@ -0,0 +1,311 @@
+package engines
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/JhonCamargo53/prueba-tecnica/internal/domain/models"
+)
+
+/*
+
+RuleSet contiene todos los pesos y umbrales que usa el motor mock.
+Se carga desde un archivo JSON versionado para que el equipo de riesgo
+pueda ajustarlo sin recompilar ni redesplegar el backend.
+
+*/
+
+//go:embed rules/default-rule-set.json
+var defaultRuleSetJSON []byte
+
+const (
+	ProductRuleHousing  = "HOUSING"
+	ProductRuleConsumer = "CONSUMER"
+)
+
+type RuleSet struct {
+	Version   string  `json:"version"`
+	BaseScore float64 `json:"baseScore"`
+	MinScore  float64 `json:"minScore"`
+	MaxScore  float64 `json:"maxScore"`
+	// Tasa E.A. (%) usada cuando la solicitud no trae tasa y ningún producto define una
+	DefaultAnnualInterestRate float64              `json:"defaultAnnualInterestRate"`
+	PaymentToIncome           PaymentToIncomeRules `json:"paymentToIncome"`
+	DebtService               DebtServiceRules     `json:"debtService"`
+	Assets                    AssetRules           `json:"assets"`
+	History                   HistoryRules         `json:"history"`
+	Participants              ParticipantRules     `json:"participants"`
+	Products                  []ProductRule        `json:"products"`
+	// Puntos por unidad del ponderador de riesgo del producto del catálogo:
+	// se suman (1 - ponderador) * ProductRiskWeightPoints
+	ProductRiskWeightPoints float64                  `json:"productRiskWeightPoints"`
+	Categories              CategoryThresholds       `json:"categories"`
+	Recommendation          RecommendationThresholds `json:"recommendation"`
+}
+
+// UpperBand aplica cuando el valor observado es menor o igual a UpTo.
+type UpperBand struct {
+	UpTo   float64 `json:"upTo"`
+	Points float64 `json:"points"`
+}
+
+type PaymentToIncomeRules struct {
+	InvalidDataPoints float64     `json:"invalidDataPoints"`
+	Bands             []UpperBand `json:"bands"`
+	AbovePoints       float64     `json:"abovePoints"`
+}
+
+// DebtServiceRules puntúa la relación (cuotas vigentes + nueva cuota) / ingreso.
+type DebtServiceRules struct {
+	Bands       []UpperBand `json:"bands"`
+	AbovePoints float64     `json:"abovePoints"`
+}
+
+// AssetRules puntúa la relación préstamo/garantía (LTV) calculada con el valor
+// de garantía de cada activo según la política de su tipo (descuento y depreciación).
+type AssetRules struct {
+	LoanToValueBands   []UpperBand `json:"loanToValueBands"`
+	UnknownTypeHaircut float64     `json:"unknownTypeHaircut"`
+	RealEstatePoints   float64     `json:"realEstatePoints"`
+}
+
+// ParticipantRules pondera los ingresos y los bienes de los codeudores y fiadores
+// de la solicitud; los del titular cuentan completos.
+type ParticipantRules struct {
+	CoBorrowerIncomeWeight float64 `json:"coBorrowerIncomeWeight"`
+	GuarantorIncomeWeight  float64 `json:"guarantorIncomeWeight"`
+	GuarantorAssetWeight   float64 `json:"guarantorAssetWeight"`
+}
+
+// IncomeWeight retorna la ponderación del ingreso de un participante según su rol.
+func (p ParticipantRules) IncomeWeight(role string) float64 {
+	switch role {
+	case models.ParticipantRoleCoBorrower:
+		return p.CoBorrowerIncomeWeight
+	case models.ParticipantRoleGuarantor:
+		return p.GuarantorIncomeWeight
+	}
+	return 0
+}
+
+// AssetWeight retorna la ponderación del valor de garantía de los bienes de un
+// participante según su rol; los bienes del titular y de los codeudores cuentan completos.
+func (p ParticipantRules) AssetWeight(role string) float64 {
+	if role == models.ParticipantRoleGuarantor {
+		return p.GuarantorAssetWeight
+	}
+	return 1
+}
+
+type HistoryRules struct {
+	RequestCountBands       []UpperBand `json:"requestCountBands"`
+	RequestCountAbovePoints float64     `json:"requestCountAbovePoints"`
+	ApprovedPoints          float64     `json:"approvedPoints"`
+	ManyApprovedThreshold   int         `json:"manyApprovedThreshold"`
+	ManyApprovedPoints      float64     `json:"manyApprovedPoints"`
+	SingleRejectedPoints    float64     `json:"singleRejectedPoints"`
+	MultipleRejectedPoints  float64     `json:"multipleRejectedPoints"`
+	// Puntos por los créditos con pagos y sin mora de 30 días o más
+	PerformingLoanPoints float64 `json:"performingLoanPoints"`
+	// Puntos según la mayor mora (días) alcanzada en los créditos del cliente
+	DelinquencyBands       []UpperBand `json:"delinquencyBands"`
+	DelinquencyAbovePoints float64     `json:"delinquencyAbovePoints"`
+}
+
+type ProductRule struct {
+	Code     string   `json:"code"`
+	Keywords []string `json:"keywords"`
+	Points   float64  `json:"points"`
+	// Tasa E.A. (%) de referencia del producto; 0 = no define tasa
+	AnnualInterestRate float64 `json:"annualInterestRate"`
+}
+
+type CategoryThresholds struct {
+	LowFrom    float64 `json:"lowFrom"`
+	MediumFrom float64 `json:"mediumFrom"`
+}
+
+type RecommendationThresholds struct {
+	ApproveFrom float64 `json:"approveFrom"`
+	StudyFrom   float64 `json:"studyFrom"`
+}
+
+// DefaultRuleSet retorna el conjunto de reglas embebido en el binario.
+func DefaultRuleSet() *RuleSet {
+	rules, err := ParseRuleSet(defaultRuleSetJSON)
+	if err != nil {
+		panic(fmt.Sprintf("conjunto de reglas por defecto inválido: %v", err))
+	}
+	return rules
+}
+
+// LoadRuleSet lee y valida un conjunto de reglas desde un archivo JSON.
+func LoadRuleSet(path string) (*RuleSet, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("no se pudo leer el archivo de reglas %s: %w", path, err)
+	}
+
+	rules, err := ParseRuleSet(data)
+	if err != nil {
+		return nil, fmt.Errorf("archivo de reglas %s: %w", path, err)
+	}
+
+	return rules, nil
+}
+
+func ParseRuleSet(data []byte) (*RuleSet, error) {
+	var rules RuleSet
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+
+	if err := decoder.Decode(&rules); err != nil {
+		return nil, fmt.Errorf("JSON de reglas inválido: %w", err)
+	}
+
+	if err := rules.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &rules, nil
+}
+
+func (r *RuleSet) Validate() error {
+	if strings.TrimSpace(r.Version) == "" {
+		return fmt.Errorf("el conjunto de reglas debe tener una versión")
+	}
+
+	if r.MinScore >= r.MaxScore {
+		return fmt.Errorf("minScore (%.1f) debe ser menor que maxScore (%.1f)", r.MinScore, r.MaxScore)
+	}
+
+	if r.BaseScore < r.MinScore || r.BaseScore > r.MaxScore {
+		return fmt.Errorf("baseScore (%.1f) debe estar entre minScore y maxScore", r.BaseScore)
+	}
+
+	if r.DefaultAnnualInterestRate <= 0 {
+		return fmt.Errorf("defaultAnnualInterestRate debe ser mayor que cero")
+	}
+
+	if err := validateUpperBands("paymentToIncome.bands", r.PaymentToIncome.Bands); err != nil {
+		return err
+	}
+
+	if err := validateUpperBands("debtService.bands", r.DebtService.Bands); err != nil {
+		return err
+	}
+
+	if err := validateUpperBands("assets.loanToValueBands", r.Assets.LoanToValueBands); err != nil {
+		return err
+	}
+
+	if r.Assets.UnknownTypeHaircut < 0 || r.Assets.UnknownTypeHaircut > 1 {
+		return fmt.Errorf("assets.unknownTypeHaircut debe estar entre 0 y 1")
+	}
+
+	for name, weight := range map[string]float64{
+		"participants.coBorrowerIncomeWeight": r.Participants.CoBorrowerIncomeWeight,
+		"participants.guarantorIncomeWeight":  r.Participants.GuarantorIncomeWeight,
+		"participants.guarantorAssetWeight":   r.Participants.GuarantorAssetWeight,
+	} {
+		if weight < 0 || weight > 1 {
+			return fmt.Errorf("%s debe estar entre 0 y 1", name)
+		}
+	}
+
+	if err := validateUpperBands("history.requestCountBands", r.History.RequestCountBands); err != nil {
+		return err
+	}
+
+	if len(r.History.DelinquencyBands) > 0 {
+		if err := validateUpperBands("history.delinquencyBands", r.History.DelinquencyBands); err != nil {
+			return err
+		}
+	}
+
+	if r.History.ManyApprovedThreshold <= 0 {
+		return fmt.Errorf("history.manyApprovedThreshold debe ser mayor que cero")
+	}
+
+	for i, p := range r.Products {
+		if p.Code != ProductRuleHousing && p.Code != ProductRuleConsumer {
+			return fmt.Errorf("products[%d]: código de producto desconocido %q", i, p.Code)
+		}
+		if len(p.Keywords) == 0 {
+			return fmt.Errorf("products[%d]: debe tener al menos una palabra clave", i)
+		}
+		if p.AnnualInterestRate < 0 {
+			return fmt.Errorf("products[%d]: la tasa de interés no puede ser negativa", i)
+		}
+	}
+
+	if r.ProductRiskWeightPoints < 0 {
+		return fmt.Errorf("productRiskWeightPoints no puede ser negativo")
+	}
+
+	if err := validateThresholds(r.MinScore, r.MaxScore, "categories", r.Categories.LowFrom, r.Categories.MediumFrom); err != nil {
+		return err
+	}
+
+	if err := validateThresholds(r.MinScore, r.MaxScore, "recommendation", r.Recommendation.ApproveFrom, r.Recommendation.StudyFrom); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func validateUpperBands(name string, bands []UpperBand) error {
+	if len(bands) == 0 {
+		return fmt.Errorf("%s debe tener al menos una banda", name)
+	}
+	for i := 1; i < len(bands); i++ {
+		if bands[i].UpTo <= bands[i-1].UpTo {
+			return fmt.Errorf("%s debe estar ordenado de forma ascendente por upTo", name)
+		}
+	}
+	return nil
+}
+
+func validateThresholds(min, max float64, name string, high, medium float64) error {
+	if high <= medium {
+		return fmt.Errorf("%s: el umbral superior debe ser mayor que el intermedio", name)
+	}
+	if medium < min || high > max {
+		return fmt.Errorf("%s: los umbrales deben estar entre minScore y maxScore", name)
+	}
+	return nil
+}
+
+// matchUpperBand retorna la primera banda cuyo límite cubre el valor.
+func matchUpperBand(bands []UpperBand, value float64) (UpperBand, bool) {
+	for _, b := range bands {
+		if value <= b.UpTo {
+			return b, true
+		}
+	}
+	return UpperBand{}, false
+}
+
+// AnnualInterestRateFor resuelve la tasa E.A. (%) de una solicitud: la tasa propia,
+// la tasa base del producto del catálogo, la del primer producto de las reglas que
+// coincida y finalmente la tasa por defecto.
+func (r *RuleSet) AnnualInterestRateFor(creditRequest models.CreditRequest) float64 {
+	if creditRequest.InterestRate > 0 {
+		return creditRequest.InterestRate
+	}
+	if creditRequest.CreditProduct != nil && creditRequest.CreditProduct.BaseAnnualRate > 0 {
+		return creditRequest.CreditProduct.BaseAnnualRate
+	}
+	productType := creditRequest.ProductType
+	for _, p := range r.Products {
+		if p.AnnualInterestRate > 0 && containsAnyKeyword(productType, p.Keywords) {
+			return p.AnnualInterestRate
+		}
+	}
+	return r.DefaultAnnualInterestRate
+}
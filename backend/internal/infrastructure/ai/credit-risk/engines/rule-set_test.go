@@ -0,0 +1,95 @@
+package engines
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/JhonCamargo53/prueba-tecnica/internal/domain/models"
+)
+
+func TestDefaultRuleSet_EsValido(t *testing.T) {
+	rules := DefaultRuleSet()
+
+	if rules.Version == "" {
+		t.Fatalf("se esperaba versión en las reglas por defecto")
+	}
+	if rules.BaseScore != 50 {
+		t.Errorf("se esperaba puntaje base 50, obtenido: %.1f", rules.BaseScore)
+	}
+}
+
+func TestParseRuleSet_UmbralesInvalidos(t *testing.T) {
+	rules := DefaultRuleSet()
+	rules.Categories.LowFrom = 40
+	rules.Categories.MediumFrom = 60
+
+	if err := rules.Validate(); err == nil {
+		t.Fatalf("se esperaba error porque el umbral LOW es menor que el MEDIUM")
+	}
+}
+
+func TestParseRuleSet_PonderacionDeParticipantesInvalida(t *testing.T) {
+	rules := DefaultRuleSet()
+	rules.Participants.GuarantorAssetWeight = 1.5
+
+	if err := rules.Validate(); err == nil {
+		t.Fatalf("se esperaba error porque la ponderación de los bienes del fiador es mayor que 1")
+	}
+}
+
+func TestParseRuleSet_SinVersion(t *testing.T) {
+	_, err := ParseRuleSet([]byte(`{"baseScore": 50, "minScore": 0, "maxScore": 100}`))
+	if err == nil {
+		t.Fatalf("se esperaba error porque el conjunto de reglas no tiene versión")
+	}
+}
+
+func TestRuleSetStore_RecargaConservaVersionValida(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rules.json")
+
+	if err := os.WriteFile(path, defaultRuleSetJSON, 0o644); err != nil {
+		t.Fatalf("no se pudo escribir el archivo de reglas: %v", err)
+	}
+
+	store, err := NewRuleSetStore(path)
+	if err != nil {
+		t.Fatalf("no se esperaba error al cargar reglas: %v", err)
+	}
+
+	version := store.Current().Version
+
+	if err := os.WriteFile(path, []byte(`{"version": ""}`), 0o644); err != nil {
+		t.Fatalf("no se pudo sobrescribir el archivo de reglas: %v", err)
+	}
+
+	if err := store.Reload(); err == nil {
+		t.Fatalf("se esperaba error al recargar reglas inválidas")
+	}
+
+	if store.Current().Version != version {
+		t.Errorf("se esperaba conservar la versión %s, obtenido: %s", version, store.Current().Version)
+	}
+}
+
+func TestEvaluateCreditRisk_UsaPesosDelRuleSet(t *testing.T) {
+	customer := models.Customer{MonthlyIncome: 8_000_000}
+	current := models.CreditRequest{Amount: 5_000_000, TermMonths: 24}
+
+	base, _, _, err := EvaluateCreditRisk(DefaultRuleSet(), customer, current, nil, nil)
+	if err != nil {
+		t.Fatalf("no se esperaba error: %v", err)
+	}
+
+	rules := DefaultRuleSet()
+	rules.BaseScore = 40
+
+	adjusted, _, _, err := EvaluateCreditRisk(rules, customer, current, nil, nil)
+	if err != nil {
+		t.Fatalf("no se esperaba error: %v", err)
+	}
+
+	if base-adjusted != 10 {
+		t.Errorf("se esperaba una diferencia de 10 puntos, obtenido: %.1f", base-adjusted)
+	}
+}
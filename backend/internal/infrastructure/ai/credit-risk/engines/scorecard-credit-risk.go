@@ -0,0 +1,170 @@
+package engines
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/JhonCamargo53/prueba-tecnica/internal/domain/finance"
+	"github.com/JhonCamargo53/prueba-tecnica/internal/domain/models"
+)
+
+// Prefijo de los códigos de factor del scorecard: SCORECARD_<característica>[_MISSING]
+const scorecardFactorPrefix = "SCORECARD_"
+
+// Mejora sugerida cuando una característica cae en su peor bin
+var scorecardImprovements = map[string]string{
+	CharacteristicPaymentToIncome: ImprovementReduceInstallment,
+	CharacteristicLoanToValue:     ImprovementIncreaseCollateral,
+	CharacteristicRequestCount:    ImprovementReduceRequests,
+	CharacteristicApprovedCount:   ImprovementBuildHistory,
+	CharacteristicRejectedCount:   ImprovementReviewRejections,
+	CharacteristicProductType:     ImprovementPreferSecured,
+}
+
+// Mejora sugerida cuando la característica no tiene valor
+var scorecardMissingImprovements = map[string]string{
+	CharacteristicPaymentToIncome: ImprovementFixIncomeData,
+	CharacteristicLoanToValue:     ImprovementAddCollateral,
+}
+
+// AssessWithScorecard evalúa la solicitud con un scorecard: suma los puntos del bin
+// de cada característica, calcula la PD y deriva categoría y recomendación de ella.
+func AssessWithScorecard(scorecard *Scorecard, customer models.Customer, currentCreditRequest models.CreditRequest,
+	otherCredits []models.CreditRequest, assets []models.CustomerAsset) (*models.RiskAssessment, error) {
+
+	if scorecard == nil {
+		return nil, fmt.Errorf("no hay un scorecard de riesgo configurado")
+	}
+
+	values := scorecardValues(scorecard, customer, currentCreditRequest, otherCredits, assets, time.Now())
+	b := &scoreBuilder{score: scorecard.BasePoints}
+
+	for _, ch := range scorecard.Characteristics {
+		var bin ScorecardBin
+		var observedValue *float64
+		params := map[string]float64{}
+		code := scorecardFactorPrefix + ch.Code
+
+		value, hasValue := values[ch.Code]
+
+		switch {
+		case ch.Code == CharacteristicProductType:
+			bin = ch.categoricalBin(currentCreditRequest.ProductType)
+		case !hasValue:
+			bin = ch.bin(nil)
+			code += "_MISSING"
+		default:
+			bin = ch.bin(&value)
+			params["value"] = value
+			observedValue = observed(value)
+		}
+
+		params["woe"] = bin.WOE
+		params["points"] = bin.Points
+
+		b.factor(code, bin.Points, observedValue, params)
+
+		if !hasValue && ch.Code != CharacteristicProductType {
+			b.improve(scorecardMissingImprovements[ch.Code])
+		} else if bin.Points <= ch.lowestPoints() {
+			b.improve(scorecardImprovements[ch.Code])
+		}
+	}
+
+	pd := scorecard.ProbabilityOfDefault(b.score)
+
+	assessment := &models.RiskAssessment{
+		EngineVersion:        scorecard.Version,
+		BaseScore:            scorecard.BasePoints,
+		Score:                b.score,
+		ProbabilityOfDefault: &pd,
+		Category:             scorecardCategory(scorecard, pd),
+		Recommendation:       scorecardRecommendation(scorecard, pd),
+		Factors:              b.factors,
+		Improvements:         b.improvements,
+	}
+
+	assessment.Explanation = RenderExplanation(assessment)
+
+	return assessment, nil
+}
+
+// scorecardValues calcula el valor observado de cada característica numérica.
+// Las que no se pueden calcular no aparecen en el mapa.
+func scorecardValues(scorecard *Scorecard, customer models.Customer, currentCreditRequest models.CreditRequest,
+	otherCredits []models.CreditRequest, assets []models.CustomerAsset, now time.Time) map[string]float64 {
+
+	values := map[string]float64{}
+
+	amount := currentCreditRequest.Amount
+	income := customer.MonthlyIncome
+
+	if amount > 0 && currentCreditRequest.TermMonths > 0 && income > 0 {
+		annualRate := currentCreditRequest.InterestRate
+		if annualRate <= 0 {
+			annualRate = scorecard.DefaultAnnualInterestRate
+		}
+		if quota, err := finance.FrenchInstallment(amount, annualRate, currentCreditRequest.TermMonths); err == nil {
+			values[CharacteristicPaymentToIncome] = quota / income
+		}
+	}
+
+	collateralValue := 0.0
+	for _, a := range assets {
+		if value, eligible := assetCollateralValue(scorecard.UnknownAssetHaircut, a, now); eligible {
+			collateralValue += value
+		}
+	}
+	if collateralValue > 0 {
+		values[CharacteristicLoanToValue] = amount / collateralValue
+	}
+
+	approvedCount := 0
+	rejectedCount := 0
+	for _, other := range otherCredits {
+		switch other.CreditStatusID {
+		case models.CreditStatusApprovedID:
+			approvedCount++
+		case models.CreditStatusRejectedID:
+			rejectedCount++
+		}
+	}
+
+	values[CharacteristicRequestCount] = float64(len(otherCredits))
+	values[CharacteristicApprovedCount] = float64(approvedCount)
+	values[CharacteristicRejectedCount] = float64(rejectedCount)
+
+	return values
+}
+
+func (c ScorecardCharacteristic) lowestPoints() float64 {
+	lowest := c.Bins[0].Points
+	for _, b := range c.Bins[1:] {
+		if b.Points < lowest {
+			lowest = b.Points
+		}
+	}
+	return lowest
+}
+
+func scorecardCategory(scorecard *Scorecard, pd float64) string {
+	switch {
+	case pd <= scorecard.Categories.LowMaxPD:
+		return "LOW"
+	case pd <= scorecard.Categories.MediumMaxPD:
+		return "MEDIUM"
+	default:
+		return "HIGH"
+	}
+}
+
+func scorecardRecommendation(scorecard *Scorecard, pd float64) string {
+	switch {
+	case pd <= scorecard.Recommendation.ApproveMaxPD:
+		return models.RiskRecommendationApprove
+	case pd <= scorecard.Recommendation.ReviewMaxPD:
+		return models.RiskRecommendationReview
+	default:
+		return models.RiskRecommendationReject
+	}
+}
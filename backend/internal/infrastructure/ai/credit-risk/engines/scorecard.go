@@ -0,0 +1,269 @@
+package engines
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"strings"
+)
+
+/*
+
+Scorecard es un modelo de regresión logística expresado en puntos. Cada
+característica se discretiza en bins con su WOE y los puntos ya escalados;
+el puntaje es la suma de los puntos y la probabilidad de incumplimiento (PD)
+se obtiene con la escala puntos/odds de la calibración:
+
+	factor = pdo / ln(2)
+	offset = targetScore - factor * ln(targetOdds)
+	odds   = exp((score - offset) / factor)     (buenos : malos)
+	PD     = 1 / (1 + odds)
+
+Las categorías y la recomendación se derivan de umbrales de PD. El archivo
+lo entrega el equipo de analítica con cada nueva versión de coeficientes.
+
+*/
+
+//go:embed rules/default-scorecard.json
+var defaultScorecardJSON []byte
+
+// Características soportadas por el motor scorecard
+const (
+	CharacteristicPaymentToIncome = "PAYMENT_TO_INCOME"
+	CharacteristicLoanToValue     = "LOAN_TO_VALUE"
+	CharacteristicRequestCount    = "REQUEST_COUNT"
+	CharacteristicApprovedCount   = "APPROVED_COUNT"
+	CharacteristicRejectedCount   = "REJECTED_COUNT"
+	CharacteristicProductType     = "PRODUCT_TYPE"
+)
+
+// Características que pueden no tener valor (datos inválidos o sin garantía)
+var characteristicsWithMissing = map[string]bool{
+	CharacteristicPaymentToIncome: true,
+	CharacteristicLoanToValue:     true,
+}
+
+type Scorecard struct {
+	Version     string               `json:"version"`
+	BasePoints  float64              `json:"basePoints"`
+	Calibration ScorecardCalibration `json:"calibration"`
+	// Tasa E.A. (%) usada cuando la solicitud no trae tasa
+	DefaultAnnualInterestRate float64 `json:"defaultAnnualInterestRate"`
+	// Descuento aplicado a activos cuyo tipo no viene cargado
+	UnknownAssetHaircut float64                   `json:"unknownAssetHaircut"`
+	Characteristics     []ScorecardCharacteristic `json:"characteristics"`
+	Categories          PDCategoryThresholds      `json:"categories"`
+	Recommendation      PDRecommendationThreshold `json:"recommendation"`
+}
+
+type ScorecardCalibration struct {
+	TargetScore        float64 `json:"targetScore"`
+	TargetOdds         float64 `json:"targetOdds"`
+	PointsToDoubleOdds float64 `json:"pointsToDoubleOdds"`
+}
+
+type ScorecardCharacteristic struct {
+	Code string `json:"code"`
+	// Bin usado cuando no se puede calcular el valor
+	Missing *ScorecardBin  `json:"missing,omitempty"`
+	Bins    []ScorecardBin `json:"bins"`
+}
+
+// ScorecardBin aplica cuando el valor es menor o igual a UpTo; el último bin
+// numérico no tiene UpTo. En PRODUCT_TYPE los bins se eligen por palabras
+// clave y el último, sin palabras clave, es el bin por defecto.
+type ScorecardBin struct {
+	UpTo     *float64 `json:"upTo,omitempty"`
+	Keywords []string `json:"keywords,omitempty"`
+	WOE      float64  `json:"woe"`
+	Points   float64  `json:"points"`
+}
+
+// PDCategoryThresholds: LOW hasta LowMaxPD, MEDIUM hasta MediumMaxPD, HIGH el resto.
+type PDCategoryThresholds struct {
+	LowMaxPD    float64 `json:"lowMaxPd"`
+	MediumMaxPD float64 `json:"mediumMaxPd"`
+}
+
+// PDRecommendationThreshold: aprobar hasta ApproveMaxPD, estudio hasta ReviewMaxPD.
+type PDRecommendationThreshold struct {
+	ApproveMaxPD float64 `json:"approveMaxPd"`
+	ReviewMaxPD  float64 `json:"reviewMaxPd"`
+}
+
+// DefaultScorecard retorna el scorecard embebido en el binario.
+func DefaultScorecard() *Scorecard {
+	scorecard, err := ParseScorecard(defaultScorecardJSON)
+	if err != nil {
+		panic(fmt.Sprintf("scorecard por defecto inválido: %v", err))
+	}
+	return scorecard
+}
+
+// LoadScorecard lee y valida un scorecard desde un archivo JSON. Con ruta vacía
+// retorna el scorecard embebido.
+func LoadScorecard(path string) (*Scorecard, error) {
+	if path == "" {
+		return DefaultScorecard(), nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("no se pudo leer el archivo de scorecard %s: %w", path, err)
+	}
+
+	scorecard, err := ParseScorecard(data)
+	if err != nil {
+		return nil, fmt.Errorf("archivo de scorecard %s: %w", path, err)
+	}
+
+	return scorecard, nil
+}
+
+func ParseScorecard(data []byte) (*Scorecard, error) {
+	var scorecard Scorecard
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+
+	if err := decoder.Decode(&scorecard); err != nil {
+		return nil, fmt.Errorf("JSON de scorecard inválido: %w", err)
+	}
+
+	if err := scorecard.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &scorecard, nil
+}
+
+func (s *Scorecard) Validate() error {
+	if strings.TrimSpace(s.Version) == "" {
+		return fmt.Errorf("el scorecard debe tener una versión")
+	}
+
+	c := s.Calibration
+	if c.TargetOdds <= 0 || c.PointsToDoubleOdds <= 0 {
+		return fmt.Errorf("calibration: targetOdds y pointsToDoubleOdds deben ser mayores que cero")
+	}
+
+	if s.DefaultAnnualInterestRate <= 0 {
+		return fmt.Errorf("defaultAnnualInterestRate debe ser mayor que cero")
+	}
+
+	if s.UnknownAssetHaircut < 0 || s.UnknownAssetHaircut > 1 {
+		return fmt.Errorf("unknownAssetHaircut debe estar entre 0 y 1")
+	}
+
+	if len(s.Characteristics) == 0 {
+		return fmt.Errorf("el scorecard debe tener al menos una característica")
+	}
+
+	seen := map[string]bool{}
+	for i, ch := range s.Characteristics {
+		if seen[ch.Code] {
+			return fmt.Errorf("characteristics[%d]: característica repetida %q", i, ch.Code)
+		}
+		seen[ch.Code] = true
+
+		if err := ch.validate(); err != nil {
+			return fmt.Errorf("characteristics[%d] (%s): %w", i, ch.Code, err)
+		}
+	}
+
+	if err := validatePDThresholds("categories", s.Categories.LowMaxPD, s.Categories.MediumMaxPD); err != nil {
+		return err
+	}
+
+	if err := validatePDThresholds("recommendation", s.Recommendation.ApproveMaxPD, s.Recommendation.ReviewMaxPD); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (c ScorecardCharacteristic) validate() error {
+	switch c.Code {
+	case CharacteristicPaymentToIncome, CharacteristicLoanToValue, CharacteristicRequestCount,
+		CharacteristicApprovedCount, CharacteristicRejectedCount, CharacteristicProductType:
+	default:
+		return fmt.Errorf("característica desconocida")
+	}
+
+	if len(c.Bins) == 0 {
+		return fmt.Errorf("debe tener al menos un bin")
+	}
+
+	if characteristicsWithMissing[c.Code] && c.Missing == nil {
+		return fmt.Errorf("debe definir el bin missing")
+	}
+
+	last := len(c.Bins) - 1
+
+	if c.Code == CharacteristicProductType {
+		for i, b := range c.Bins {
+			if b.UpTo != nil {
+				return fmt.Errorf("bins[%d]: los bins categóricos no usan upTo", i)
+			}
+			if (i == last) != (len(b.Keywords) == 0) {
+				return fmt.Errorf("sólo el último bin debe ser el bin por defecto sin palabras clave")
+			}
+		}
+		return nil
+	}
+
+	for i, b := range c.Bins {
+		if len(b.Keywords) > 0 {
+			return fmt.Errorf("bins[%d]: los bins numéricos no usan palabras clave", i)
+		}
+		if (i == last) != (b.UpTo == nil) {
+			return fmt.Errorf("sólo el último bin debe quedar abierto (sin upTo)")
+		}
+		if i > 0 && i < last && *b.UpTo <= *c.Bins[i-1].UpTo {
+			return fmt.Errorf("los bins deben estar ordenados de forma ascendente por upTo")
+		}
+	}
+
+	return nil
+}
+
+func validatePDThresholds(name string, low, medium float64) error {
+	if low <= 0 || medium >= 1 || low >= medium {
+		return fmt.Errorf("%s: los umbrales de PD deben cumplir 0 < inferior < superior < 1", name)
+	}
+	return nil
+}
+
+// ProbabilityOfDefault convierte un puntaje en PD con la escala de la calibración.
+func (s *Scorecard) ProbabilityOfDefault(score float64) float64 {
+	factor := s.Calibration.PointsToDoubleOdds / math.Ln2
+	offset := s.Calibration.TargetScore - factor*math.Log(s.Calibration.TargetOdds)
+	odds := math.Exp((score - offset) / factor)
+	return 1 / (1 + odds)
+}
+
+// bin retorna el bin que corresponde al valor numérico (nil = sin valor).
+func (c ScorecardCharacteristic) bin(value *float64) ScorecardBin {
+	if value == nil {
+		return *c.Missing
+	}
+	for _, b := range c.Bins {
+		if b.UpTo == nil || *value <= *b.UpTo {
+			return b
+		}
+	}
+	return c.Bins[len(c.Bins)-1]
+}
+
+// categoricalBin retorna el primer bin cuyas palabras clave coinciden con el texto.
+func (c ScorecardCharacteristic) categoricalBin(text string) ScorecardBin {
+	for _, b := range c.Bins {
+		if len(b.Keywords) == 0 || containsAnyKeyword(text, b.Keywords) {
+			return b
+		}
+	}
+	return c.Bins[len(c.Bins)-1]
+}
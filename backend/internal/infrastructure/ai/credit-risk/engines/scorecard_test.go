@@ -0,0 +1,156 @@
+package engines
+
+import (
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/JhonCamargo53/prueba-tecnica/internal/domain/models"
+)
+
+func TestScorecard_CalibracionEnPuntajeObjetivo(t *testing.T) {
+	scorecard := DefaultScorecard()
+	c := scorecard.Calibration
+
+	// En el puntaje objetivo las odds son las de la calibración
+	want := 1 / (1 + c.TargetOdds)
+	if got := scorecard.ProbabilityOfDefault(c.TargetScore); math.Abs(got-want) > 1e-9 {
+		t.Errorf("se esperaba PD %.6f en el puntaje objetivo, obtenida: %.6f", want, got)
+	}
+
+	// Sumar pointsToDoubleOdds duplica las odds
+	oddsAt := func(score float64) float64 {
+		pd := scorecard.ProbabilityOfDefault(score)
+		return (1 - pd) / pd
+	}
+	ratio := oddsAt(c.TargetScore+c.PointsToDoubleOdds) / oddsAt(c.TargetScore)
+	if math.Abs(ratio-2) > 1e-9 {
+		t.Errorf("se esperaba que las odds se duplicaran, razón obtenida: %.6f", ratio)
+	}
+}
+
+func TestAssessWithScorecard_PerfilBajoYAltoRiesgo(t *testing.T) {
+	scorecard := DefaultScorecard()
+
+	low, err := AssessWithScorecard(scorecard,
+		models.Customer{MonthlyIncome: 8_000_000},
+		models.CreditRequest{Amount: 5_000_000, TermMonths: 24, ProductType: "Crédito de vivienda"},
+		[]models.CreditRequest{{CreditStatusID: 2}},
+		[]models.CustomerAsset{{MarketValue: 20_000_000}},
+	)
+	if err != nil {
+		t.Fatalf("no se esperaba error, pero se obtuvo: %v", err)
+	}
+
+	high, err := AssessWithScorecard(scorecard,
+		models.Customer{MonthlyIncome: 1_000_000},
+		models.CreditRequest{Amount: 30_000_000, TermMonths: 12, ProductType: "Libre inversión"},
+		[]models.CreditRequest{{CreditStatusID: 3}, {CreditStatusID: 3}, {CreditStatusID: 3},
+			{CreditStatusID: 3}, {CreditStatusID: 3}, {CreditStatusID: 3}},
+		nil,
+	)
+	if err != nil {
+		t.Fatalf("no se esperaba error, pero se obtuvo: %v", err)
+	}
+
+	if low.ProbabilityOfDefault == nil || high.ProbabilityOfDefault == nil {
+		t.Fatalf("se esperaba probabilidad de incumplimiento en ambos resultados")
+	}
+	if *low.ProbabilityOfDefault >= *high.ProbabilityOfDefault {
+		t.Errorf("se esperaba menor PD en el perfil de bajo riesgo: %.4f vs %.4f",
+			*low.ProbabilityOfDefault, *high.ProbabilityOfDefault)
+	}
+
+	if low.Category != "LOW" || low.Recommendation != models.RiskRecommendationApprove {
+		t.Errorf("se esperaba LOW/APPROVE, obtenido: %s/%s (PD %.4f)", low.Category, low.Recommendation, *low.ProbabilityOfDefault)
+	}
+	if high.Category != "HIGH" || high.Recommendation != models.RiskRecommendationReject {
+		t.Errorf("se esperaba HIGH/REJECT, obtenido: %s/%s (PD %.4f)", high.Category, high.Recommendation, *high.ProbabilityOfDefault)
+	}
+
+	// Sin garantía el LTV cae en el bin missing y se sugiere agregar garantías
+	hasMissingLTV := false
+	for _, f := range high.Factors {
+		if f.Code == "SCORECARD_LOAN_TO_VALUE_MISSING" {
+			hasMissingLTV = true
+		}
+	}
+	if !hasMissingLTV {
+		t.Errorf("se esperaba el factor SCORECARD_LOAN_TO_VALUE_MISSING")
+	}
+
+	if !strings.Contains(high.Explanation, "Probabilidad de incumplimiento") {
+		t.Errorf("se esperaba la PD en la explicación, obtenida: %s", high.Explanation)
+	}
+}
+
+func TestAssessWithScorecard_FactoresSumanElPuntaje(t *testing.T) {
+	scorecard := DefaultScorecard()
+
+	assessment, err := AssessWithScorecard(scorecard,
+		models.Customer{MonthlyIncome: 3_000_000},
+		models.CreditRequest{Amount: 10_000_000, TermMonths: 36, ProductType: "Vehículo"},
+		[]models.CreditRequest{{CreditStatusID: 2}, {CreditStatusID: 3}},
+		[]models.CustomerAsset{{MarketValue: 8_000_000}},
+	)
+	if err != nil {
+		t.Fatalf("no se esperaba error, pero se obtuvo: %v", err)
+	}
+
+	if len(assessment.Factors) != len(scorecard.Characteristics) {
+		t.Fatalf("se esperaba un factor por característica, obtenidos: %d", len(assessment.Factors))
+	}
+
+	sum := assessment.BaseScore
+	for _, f := range assessment.Factors {
+		sum += f.Points
+		if f.Message == "" {
+			t.Errorf("el factor %s no tiene mensaje", f.Code)
+		}
+	}
+	if math.Abs(sum-assessment.Score) > 1e-9 {
+		t.Errorf("la suma de factores (%.1f) no coincide con el puntaje (%.1f)", sum, assessment.Score)
+	}
+}
+
+func TestLoadScorecard_DesdeArchivo(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "scorecard.json")
+	data := strings.Replace(string(defaultScorecardJSON), `"scorecard-2025.1"`, `"scorecard-test"`, 1)
+
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatalf("no se pudo escribir el archivo de scorecard: %v", err)
+	}
+
+	scorecard, err := LoadScorecard(path)
+	if err != nil {
+		t.Fatalf("no se esperaba error al cargar el scorecard: %v", err)
+	}
+	if scorecard.Version != "scorecard-test" {
+		t.Errorf("se esperaba versión scorecard-test, obtenida: %s", scorecard.Version)
+	}
+}
+
+func TestParseScorecard_BinsInvalidos(t *testing.T) {
+	scorecard := DefaultScorecard()
+
+	// El último bin numérico debe quedar abierto
+	upTo := 10.0
+	ch := &scorecard.Characteristics[2]
+	ch.Bins[len(ch.Bins)-1].UpTo = &upTo
+	if err := scorecard.Validate(); err == nil {
+		t.Fatalf("se esperaba error porque el último bin tiene upTo")
+	}
+
+	scorecard = DefaultScorecard()
+	scorecard.Categories.LowMaxPD = 0.2
+	scorecard.Categories.MediumMaxPD = 0.1
+	if err := scorecard.Validate(); err == nil {
+		t.Fatalf("se esperaba error porque los umbrales de PD no son crecientes")
+	}
+
+	if _, err := ParseScorecard([]byte(`{"version": "x", "characteristics": [{"code": "EDAD", "bins": []}]}`)); err == nil {
+		t.Fatalf("se esperaba error por característica desconocida")
+	}
+}
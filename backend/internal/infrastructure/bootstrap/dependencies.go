@@ -1,24 +1,70 @@
 package bootstrap
 
 import (
+	"log"
+	"time"
+
 	_ "github.com/JhonCamargo53/prueba-tecnica/docs"
 	"github.com/JhonCamargo53/prueba-tecnica/internal/application/services/asset"
 	"github.com/JhonCamargo53/prueba-tecnica/internal/application/services/auth"
+	creditDecision "github.com/JhonCamargo53/prueba-tecnica/internal/application/services/credit-decision"
+	creditProduct "github.com/JhonCamargo53/prueba-tecnica/internal/application/services/credit-product"
 	creditRequest "github.com/JhonCamargo53/prueba-tecnica/internal/application/services/credit-request"
+	creditRequestParticipant "github.com/JhonCamargo53/prueba-tecnica/internal/application/services/credit-request-participant"
 	creditStatus "github.com/JhonCamargo53/prueba-tecnica/internal/application/services/credit-status"
+	creditWorkflow "github.com/JhonCamargo53/prueba-tecnica/internal/application/services/credit-workflow"
 	"github.com/JhonCamargo53/prueba-tecnica/internal/application/services/customer"
 	customerAsset "github.com/JhonCamargo53/prueba-tecnica/internal/application/services/customer-asset"
+	"github.com/JhonCamargo53/prueba-tecnica/internal/application/services/document"
 	documentType "github.com/JhonCamargo53/prueba-tecnica/internal/application/services/document-type"
+	loanAccount "github.com/JhonCamargo53/prueba-tecnica/internal/application/services/loan-account"
+	paymentSchedule "github.com/JhonCamargo53/prueba-tecnica/internal/application/services/payment-schedule"
+	riskBacktesting "github.com/JhonCamargo53/prueba-tecnica/internal/application/services/risk-backtesting"
+	riskDrift "github.com/JhonCamargo53/prueba-tecnica/internal/application/services/risk-drift"
+	riskEvaluation "github.com/JhonCamargo53/prueba-tecnica/internal/application/services/risk-evaluation"
+	riskReport "github.com/JhonCamargo53/prueba-tecnica/internal/application/services/risk-report"
+	riskRescoring "github.com/JhonCamargo53/prueba-tecnica/internal/application/services/risk-rescoring"
+	riskSimulation "github.com/JhonCamargo53/prueba-tecnica/internal/application/services/risk-simulation"
 	"github.com/JhonCamargo53/prueba-tecnica/internal/application/services/role"
 	"github.com/JhonCamargo53/prueba-tecnica/internal/application/services/user"
 	"github.com/JhonCamargo53/prueba-tecnica/internal/config"
-	adapters "github.com/JhonCamargo53/prueba-tecnica/internal/infrastructure/ai/credit-risk/adapter/gorm"
+	"github.com/JhonCamargo53/prueba-tecnica/internal/domain/authority"
+	"github.com/JhonCamargo53/prueba-tecnica/internal/domain/policy"
+	"github.com/JhonCamargo53/prueba-tecnica/internal/domain/ports"
+	"github.com/JhonCamargo53/prueba-tecnica/internal/domain/pricing"
+	"github.com/JhonCamargo53/prueba-tecnica/internal/domain/workflow"
 	repositories "github.com/JhonCamargo53/prueba-tecnica/internal/infrastructure/database/gorm/adapters"
 	"github.com/JhonCamargo53/prueba-tecnica/internal/infrastructure/http/handlers"
+	"github.com/JhonCamargo53/prueba-tecnica/internal/infrastructure/storage"
 	"gorm.io/gorm"
 )
 
-func InitializeDependencies(db *gorm.DB, cfg *config.Config) {
+// Dependencies expone los servicios que se usan fuera de los handlers HTTP (subcomandos).
+type Dependencies struct {
+	RiskRescoring *riskRescoring.RiskRescoringService
+
+	// Procesos en segundo plano, en orden de registro; sólo arrancan en modo servidor
+	jobs []func()
+}
+
+// StartBackgroundJobs emite los reportes de riesgo pendientes y arranca la recarga
+// de reglas y los jobs programados de estabilidad y cartera. Sólo se llama al servir
+// la API: un subcomando como rescore no escribe nada fuera de su tarea ni deja
+// goroutines en ejecución.
+func (d *Dependencies) StartBackgroundJobs() {
+	for _, job := range d.jobs {
+		job()
+	}
+}
+
+func (d *Dependencies) onStart(job func()) {
+	d.jobs = append(d.jobs, job)
+}
+
+// InitializeDependencies crea los repositorios y servicios y registra los handlers,
+// sin escribir en la base de datos ni iniciar procesos en segundo plano.
+func InitializeDependencies(db *gorm.DB, cfg *config.Config) *Dependencies {
+	dependencies := &Dependencies{}
 
 	/* Assets */
 	assetRepo := repositories.NewAssetGormRepository(db)
@@ -52,8 +98,91 @@ func InitializeDependencies(db *gorm.DB, cfg *config.Config) {
 	customerRepo := repositories.NewCustomerGormRepository(db)
 	creditRequestRepo := repositories.NewCreditRequestGormRepository(db)
 
-	/* Risk */
-	riskEvaluator := adapters.NewRiskEvaluatorAdapter()
+	/* Risk: champion y challengers en modo sombra */
+	evaluators := buildRiskEvaluators(cfg, dependencies)
+	riskEvaluator, shadowEvaluators, err := selectRiskEvaluators(evaluators, cfg.RiskChampionEngine, cfg.RiskShadowEngines)
+	if err != nil {
+		log.Fatal("Error configurando motores de riesgo: ", err)
+	}
+	log.Printf("Motor de riesgo champion: %s, challengers: %d", cfg.RiskChampionEngine, len(shadowEvaluators))
+
+	/* Políticas de rechazo automático, revisadas antes del champion */
+	policyRules, err := policy.LoadRules(cfg.RiskPolicyPath)
+	if err != nil {
+		log.Fatal("Error cargando políticas de crédito: ", err)
+	}
+	log.Printf("Políticas de crédito cargadas, versión %s", policyRules.Version)
+	riskEvaluator = policy.NewPolicyRiskEvaluator(policyRules, riskEvaluator)
+
+	/* Precios según el riesgo */
+	pricingRules, err := pricing.LoadRules(cfg.RiskPricingPath)
+	if err != nil {
+		log.Fatal("Error cargando la política de precios: ", err)
+	}
+	log.Printf("Política de precios cargada, versión %s", pricingRules.Version)
+
+	/* Grafo de estados de las solicitudes e historial de cambios */
+	workflowRules, err := workflow.LoadRules(cfg.CreditWorkflowPath)
+	if err != nil {
+		log.Fatal("Error cargando el grafo de estados: ", err)
+	}
+	log.Printf("Grafo de estados cargado, versión %s", workflowRules.Version)
+	creditStatusHistoryRepo := repositories.NewCreditStatusHistoryGormRepository(db)
+	creditWorkflowService := creditWorkflow.NewCreditWorkflowService(workflowRules, creditStatusHistoryRepo,
+		creditRequestRepo, userRepo, roleRepo)
+	handlers.InitCreditWorkflowHandler(creditWorkflowService)
+
+	/* RiskReports: reportes firmados de cada evaluación, encadenados por hash */
+	riskEvaluationRepo := repositories.NewRiskEvaluationGormRepository(db)
+	signingKey, trustedKeys, err := riskReportKeys(cfg)
+	if err != nil {
+		log.Fatal("Error cargando la clave de firma de reportes de riesgo: ", err)
+	}
+	riskReportService := riskReport.NewRiskReportService(repositories.NewRiskReportGormRepository(db), riskEvaluationRepo,
+		creditRequestRepo, signingKey, trustedKeys...)
+	log.Printf("Reportes de riesgo firmados con la clave %s", riskReportService.PublicKeys()[0].KeyID)
+	// Antes de atender solicitudes: emite los reportes que quedaron pendientes
+	dependencies.onStart(func() {
+		if issued, err := riskReportService.IssueMissing(); err != nil {
+			log.Printf("Error emitiendo los reportes de riesgo pendientes: %v", err)
+		} else if issued > 0 {
+			log.Printf("Reportes de riesgo pendientes emitidos: %d", issued)
+		}
+	})
+	handlers.InitRiskReportHandler(riskReportService)
+
+	/* RiskEvaluations */
+	shadowRiskEvaluationRepo := repositories.NewShadowRiskEvaluationGormRepository(db)
+	riskEvaluationService := riskEvaluation.NewRiskEvaluationService(creditRequestRepo, riskEvaluationRepo, riskEvaluator).
+		WithShadowEvaluators(shadowRiskEvaluationRepo, shadowEvaluators...).
+		WithPricing(pricingRules).
+		WithWorkflow(creditWorkflowService).
+		WithReports(riskReportService)
+	handlers.InitRiskEvaluationHandler(riskEvaluationService)
+
+	/* RiskRescoring */
+	riskRescoringRepo := repositories.NewRiskRescoringGormRepository(db)
+	riskRescoringService := riskRescoring.NewRiskRescoringService(riskRescoringRepo, creditRequestRepo, riskEvaluationService)
+	handlers.InitRiskRescoringHandler(riskRescoringService)
+
+	/* RiskBacktesting: cada motor se evalúa con las mismas políticas que el champion */
+	backtestingEvaluators := make(map[string]ports.RiskEvaluator, len(evaluators))
+	for name, evaluator := range evaluators {
+		backtestingEvaluators[name] = policy.NewPolicyRiskEvaluator(policyRules, evaluator)
+	}
+	riskBacktestingService := riskBacktesting.NewRiskBacktestingService(riskRescoringRepo, creditRequestRepo,
+		backtestingEvaluators, cfg.RiskChampionEngine)
+	handlers.InitRiskBacktestingHandler(riskBacktestingService)
+
+	/* RiskDrift: estabilidad de la población frente al período base */
+	driftOptions, err := riskDriftOptions(cfg)
+	if err != nil {
+		log.Fatal("Error configurando el monitoreo de estabilidad: ", err)
+	}
+	riskDriftRepo := repositories.NewRiskDriftGormRepository(db)
+	riskDriftService := riskDrift.NewRiskDriftService(riskDriftRepo, driftOptions)
+	dependencies.onStart(func() { riskDriftService.Schedule(time.Duration(cfg.RiskDriftIntervalHours) * time.Hour) })
+	handlers.InitRiskDriftHandler(riskDriftService)
 
 	/* CustomerAsset */
 	customerAssetRepo := repositories.NewCustomerAssetGormRepository(db)
@@ -62,22 +191,76 @@ func InitializeDependencies(db *gorm.DB, cfg *config.Config) {
 		customerRepo,
 		assetRepo,
 		creditRequestRepo,
-		riskEvaluator,
+		riskEvaluationService,
+		creditWorkflowService,
 	)
 	handlers.InitCustomerAssetHandler(customerAssetService)
 
+	/* CreditProduct: catálogo de productos */
+	creditProductRepo := repositories.NewCreditProductGormRepository(db)
+	creditProductService := creditProduct.NewCreditProductService(creditProductRepo)
+	handlers.InitCreditProductHandler(creditProductService)
+
+	/* RiskSimulation */
+	riskSimulationService := riskSimulation.NewRiskSimulationService(customerRepo, creditRequestRepo, assetRepo,
+		creditProductRepo, riskEvaluator)
+	handlers.InitRiskSimulationHandler(riskSimulationService)
+
 	/* CreditRequest */
 	creditRequestService := creditRequest.NewCreditRequestService(
 		creditRequestRepo,
 		customerRepo,
 		creditStatusRepo,
 		customerAssetRepo,
-		riskEvaluator,
+		creditProductRepo,
+		riskEvaluationService,
+		creditWorkflowService,
 	)
 	handlers.InitCreditRequestHandler(creditRequestService)
 
+	/* CreditRequestParticipant: titular, codeudores y fiadores de las solicitudes */
+	creditRequestParticipantRepo := repositories.NewCreditRequestParticipantGormRepository(db)
+	creditRequestParticipantService := creditRequestParticipant.NewCreditRequestParticipantService(creditRequestParticipantRepo,
+		creditRequestRepo, customerRepo, customerAssetRepo, riskEvaluationService, creditWorkflowService)
+	handlers.InitCreditRequestParticipantHandler(creditRequestParticipantService)
+
+	/* PaymentSchedule: planes de pago de las solicitudes aprobadas */
+	paymentScheduleRepo := repositories.NewPaymentScheduleGormRepository(db)
+	paymentScheduleService := paymentSchedule.NewPaymentScheduleService(paymentScheduleRepo, creditRequestRepo)
+	handlers.InitPaymentScheduleHandler(paymentScheduleService)
+
+	/* LoanAccount: cartera de créditos aprobados, pagos y cálculo diario de mora */
+	loanAccountRepo := repositories.NewLoanAccountGormRepository(db)
+	creditDecisionRepo := repositories.NewCreditDecisionGormRepository(db)
+	loanAccountService := loanAccount.NewLoanAccountService(loanAccountRepo, creditRequestRepo, creditDecisionRepo,
+		creditStatusHistoryRepo, paymentScheduleService)
+	dependencies.onStart(func() { loanAccountService.Schedule(time.Duration(cfg.LoanDelinquencyIntervalHours) * time.Hour) })
+	handlers.InitLoanAccountHandler(loanAccountService)
+
+	/* Document: documentos adjuntos a clientes, solicitudes y bienes */
+	documentStorage, err := storage.NewLocalDocumentStorage(cfg.DocumentStoragePath)
+	if err != nil {
+		log.Fatal("Error preparando el almacenamiento de documentos: ", err)
+	}
+	documentRepo := repositories.NewDocumentGormRepository(db)
+	documentService := document.NewDocumentService(documentRepo, documentStorage, customerRepo, creditRequestRepo, customerAssetRepo,
+		int64(cfg.DocumentMaxSizeMB)<<20)
+	handlers.InitDocumentHandler(documentService)
+
+	/* CreditDecision: decisiones manuales, atribuciones y confirmación de overrides */
+	authorityRules, err := authority.LoadRules(cfg.CreditAuthorityPath)
+	if err != nil {
+		log.Fatal("Error cargando las atribuciones de aprobación: ", err)
+	}
+	log.Printf("Atribuciones de aprobación cargadas, versión %s", authorityRules.Version)
+	creditDecisionService := creditDecision.NewCreditDecisionService(creditDecisionRepo, creditRequestRepo, customerAssetRepo,
+		documentRepo, userRepo, roleRepo, creditWorkflowService, loanAccountService, authorityRules, cfg.CreditOverrideReviewAmount)
+	handlers.InitCreditDecisionHandler(creditDecisionService)
+
 	/* Customers */
 	customerService := customer.NewCustomerService(customerRepo, documentTypeRepo, creditRequestRepo)
 	handlers.InitCustomerHandler(customerService)
 
+	dependencies.RiskRescoring = riskRescoringService
+	return dependencies
 }
@@ -0,0 +1,40 @@
+package bootstrap
+
+import (
+	"fmt"
+	"time"
+
+	riskDrift "github.com/JhonCamargo53/prueba-tecnica/internal/application/services/risk-drift"
+	"github.com/JhonCamargo53/prueba-tecnica/internal/config"
+)
+
+// riskDriftOptions arma las opciones del monitoreo de estabilidad a partir de la configuración.
+func riskDriftOptions(cfg *config.Config) (riskDrift.DriftOptions, error) {
+	options := riskDrift.DriftOptions{
+		WindowDays: cfg.RiskDriftWindowDays,
+		Bins:       cfg.RiskDriftBins,
+		MinSample:  cfg.RiskDriftMinSample,
+		WarningPSI: cfg.RiskDriftWarningPSI,
+		AlertPSI:   cfg.RiskDriftAlertPSI,
+	}
+
+	if (cfg.RiskDriftBaselineFrom == "") != (cfg.RiskDriftBaselineTo == "") {
+		return options, fmt.Errorf("RISK_DRIFT_BASELINE_FROM y RISK_DRIFT_BASELINE_TO deben configurarse juntos")
+	}
+
+	if cfg.RiskDriftBaselineFrom != "" {
+		from, err := time.Parse("2006-01-02", cfg.RiskDriftBaselineFrom)
+		if err != nil {
+			return options, fmt.Errorf("RISK_DRIFT_BASELINE_FROM inválido, formato esperado YYYY-MM-DD")
+		}
+		to, err := time.Parse("2006-01-02", cfg.RiskDriftBaselineTo)
+		if err != nil {
+			return options, fmt.Errorf("RISK_DRIFT_BASELINE_TO inválido, formato esperado YYYY-MM-DD")
+		}
+		// El día final es inclusivo
+		end := to.AddDate(0, 0, 1)
+		options.BaselineFrom, options.BaselineTo = &from, &end
+	}
+
+	return options, options.Validate()
+}
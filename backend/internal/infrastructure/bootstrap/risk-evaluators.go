@@ -0,0 +1,88 @@
+package bootstrap
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/JhonCamargo53/prueba-tecnica/internal/config"
+	"github.com/JhonCamargo53/prueba-tecnica/internal/domain/ports"
+	adapters "github.com/JhonCamargo53/prueba-tecnica/internal/infrastructure/ai/credit-risk/adapter/gorm"
+	httpAdapter "github.com/JhonCamargo53/prueba-tecnica/internal/infrastructure/ai/credit-risk/adapter/http"
+	engines "github.com/JhonCamargo53/prueba-tecnica/internal/infrastructure/ai/credit-risk/engines"
+)
+
+// buildRiskEvaluators registra los motores de riesgo disponibles por nombre. La
+// recarga de las reglas se registra como proceso en segundo plano de dependencies.
+func buildRiskEvaluators(cfg *config.Config, dependencies *Dependencies) map[string]ports.RiskEvaluator {
+	evaluators := map[string]ports.RiskEvaluator{}
+
+	riskRules := loadRuleSetStore("mock", cfg.RiskRulesPath, cfg.RiskRulesReloadSeconds, dependencies)
+	evaluators["mock"] = adapters.NewRiskEvaluatorAdapter(riskRules)
+
+	if cfg.RiskChallengerRulesPath != "" {
+		challengerRules := loadRuleSetStore("mock-challenger", cfg.RiskChallengerRulesPath, cfg.RiskRulesReloadSeconds, dependencies)
+		evaluators["mock-challenger"] = adapters.NewRiskEvaluatorAdapter(challengerRules)
+	}
+
+	scorecard, err := engines.LoadScorecard(cfg.RiskScorecardPath)
+	if err != nil {
+		log.Fatalf("Error cargando el scorecard de riesgo: %v", err)
+	}
+	log.Printf("Scorecard de riesgo cargado, versión %s", scorecard.Version)
+	evaluators["scorecard"] = adapters.NewScorecardEvaluatorAdapter(scorecard)
+
+	if cfg.RiskHTTPURL != "" {
+		var fallback ports.RiskEvaluator
+		if cfg.RiskHTTPFallbackEngine != "" {
+			var ok bool
+			if fallback, ok = evaluators[cfg.RiskHTTPFallbackEngine]; !ok {
+				log.Fatalf("Motor de respaldo desconocido para el servicio de riesgo externo: %s", cfg.RiskHTTPFallbackEngine)
+			}
+		}
+
+		evaluators["http"] = httpAdapter.NewHTTPRiskEvaluatorAdapter(httpAdapter.Options{
+			URL:      cfg.RiskHTTPURL,
+			APIKey:   cfg.RiskHTTPAPIKey,
+			Timeout:  time.Duration(cfg.RiskHTTPTimeoutMs) * time.Millisecond,
+			Retries:  cfg.RiskHTTPRetries,
+			Fallback: fallback,
+		})
+	}
+
+	return evaluators
+}
+
+func loadRuleSetStore(engine, path string, reloadSeconds int, dependencies *Dependencies) *engines.RuleSetStore {
+	rules, err := engines.NewRuleSetStore(path)
+	if err != nil {
+		log.Fatalf("Error cargando reglas del motor de riesgo %s: %v", engine, err)
+	}
+	log.Printf("Reglas del motor de riesgo %s cargadas, versión %s", engine, rules.Current().Version)
+	dependencies.onStart(func() { rules.Watch(time.Duration(reloadSeconds) * time.Second) })
+	return rules
+}
+
+// selectRiskEvaluators separa el champion de los challengers configurados.
+func selectRiskEvaluators(evaluators map[string]ports.RiskEvaluator, champion string,
+	shadows []string) (ports.RiskEvaluator, []ports.NamedRiskEvaluator, error) {
+
+	championEvaluator, ok := evaluators[champion]
+	if !ok {
+		return nil, nil, fmt.Errorf("motor de riesgo champion desconocido: %s", champion)
+	}
+
+	var named []ports.NamedRiskEvaluator
+	for _, name := range shadows {
+		if name == champion {
+			continue
+		}
+		evaluator, ok := evaluators[name]
+		if !ok {
+			return nil, nil, fmt.Errorf("motor de riesgo challenger desconocido: %s", name)
+		}
+		named = append(named, ports.NamedRiskEvaluator{Name: name, Evaluator: evaluator})
+	}
+
+	return championEvaluator, named, nil
+}
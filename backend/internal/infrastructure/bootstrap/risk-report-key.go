@@ -0,0 +1,64 @@
+package bootstrap
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/JhonCamargo53/prueba-tecnica/internal/config"
+	"github.com/JhonCamargo53/prueba-tecnica/internal/domain/attestation"
+)
+
+// riskReportKeys retorna la clave con la que se firman los reportes de riesgo y las
+// claves públicas anteriores de confianza. La clave de RISK_REPORT_SIGNING_KEY tiene
+// prioridad; si no se configura se lee del archivo, y si éste no existe se genera.
+func riskReportKeys(cfg *config.Config) (ed25519.PrivateKey, []ed25519.PublicKey, error) {
+	trusted := make([]ed25519.PublicKey, 0, len(cfg.RiskReportTrustedKeys))
+	for _, encoded := range cfg.RiskReportTrustedKeys {
+		key, err := attestation.ParsePublicKey(encoded)
+		if err != nil {
+			return nil, nil, fmt.Errorf("RISK_REPORT_TRUSTED_KEYS: %w", err)
+		}
+		trusted = append(trusted, key)
+	}
+
+	if cfg.RiskReportSigningKey != "" {
+		key, err := attestation.ParsePrivateKey(cfg.RiskReportSigningKey)
+		if err != nil {
+			return nil, nil, fmt.Errorf("RISK_REPORT_SIGNING_KEY: %w", err)
+		}
+		return key, trusted, nil
+	}
+
+	data, err := os.ReadFile(cfg.RiskReportKeyPath)
+	if err == nil {
+		key, err := attestation.ParsePrivateKey(string(data))
+		if err != nil {
+			return nil, nil, fmt.Errorf("%s: %w", cfg.RiskReportKeyPath, err)
+		}
+		return key, trusted, nil
+	}
+	if !os.IsNotExist(err) {
+		return nil, nil, err
+	}
+
+	// Sólo se guarda la semilla; el archivo queda legible únicamente por el servidor
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := os.MkdirAll(filepath.Dir(cfg.RiskReportKeyPath), 0o700); err != nil {
+		return nil, nil, err
+	}
+	encoded := base64.StdEncoding.EncodeToString(key.Seed()) + "\n"
+	if err := os.WriteFile(cfg.RiskReportKeyPath, []byte(encoded), 0o600); err != nil {
+		return nil, nil, err
+	}
+	log.Printf("Clave de firma de reportes de riesgo generada en %s", cfg.RiskReportKeyPath)
+
+	return key, trusted, nil
+}
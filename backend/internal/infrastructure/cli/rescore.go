@@ -0,0 +1,118 @@
+package cli
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	riskRescoring "github.com/JhonCamargo53/prueba-tecnica/internal/application/services/risk-rescoring"
+	"github.com/JhonCamargo53/prueba-tecnica/internal/domain/models"
+)
+
+/*
+
+Subcomando para re-evaluar solicitudes con el motor de riesgo configurado:
+
+	go run . rescore -dry-run -status 1,4 -from 2025-01-01
+	go run . rescore -resume 12
+
+Con Ctrl+C se termina el lote en curso y el re-scoring queda INTERRUPTED;
+se retoma con -resume y el ID que se imprime al iniciar.
+
+*/
+
+// RunRescore ejecuta el subcomando rescore con los argumentos que siguen al nombre.
+func RunRescore(service *riskRescoring.RiskRescoringService, args []string) error {
+	flags := flag.NewFlagSet("rescore", flag.ContinueOnError)
+	dryRun := flags.Bool("dry-run", false, "sólo reporta los cambios, sin modificar las solicitudes")
+	workers := flags.Int("workers", riskRescoring.DefaultWorkers, "número de evaluaciones en paralelo")
+	customerID := flags.Uint("customer", 0, "ID del cliente (0 = todos)")
+	statuses := flags.String("status", "", "IDs de estado separados por coma")
+	from := flags.String("from", "", "fecha de creación inicial (YYYY-MM-DD)")
+	to := flags.String("to", "", "fecha de creación final inclusiva (YYYY-MM-DD)")
+	engineVersion := flags.String("engine-version", "", "sólo solicitudes evaluadas con esta versión de motor")
+	resume := flags.Uint("resume", 0, "ID del re-scoring a reanudar")
+
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	var job *models.RiskRescoringJob
+	var err error
+
+	if *resume > 0 {
+		job, err = service.Resume(*resume)
+	} else {
+		var filter models.RiskRescoringFilter
+		filter, err = buildFilter(*customerID, *statuses, *from, *to, *engineVersion)
+		if err != nil {
+			return err
+		}
+		job, err = service.Start(riskRescoring.RescoringOptions{Filter: filter, DryRun: *dryRun, Workers: *workers})
+	}
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Re-scoring %d iniciado (dry-run: %t, workers: %d)\n", job.ID, job.DryRun, job.Workers)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	runErr := service.Run(ctx, job)
+
+	fmt.Printf("Re-scoring %d %s: %d procesadas, %d cambios, %d errores\n",
+		job.ID, job.Status, job.Processed, job.Changed, job.Failed)
+
+	if job.Status == models.RiskRescoringStatusInterrupted {
+		fmt.Printf("Para continuar: rescore -resume %d\n", job.ID)
+		return nil
+	}
+
+	return runErr
+}
+
+func buildFilter(customerID uint, statuses, from, to, engineVersion string) (models.RiskRescoringFilter, error) {
+	filter := models.RiskRescoringFilter{EngineVersion: strings.TrimSpace(engineVersion)}
+
+	if customerID > 0 {
+		filter.CustomerID = &customerID
+	}
+
+	for _, value := range strings.Split(statuses, ",") {
+		value = strings.TrimSpace(value)
+		if value == "" {
+			continue
+		}
+		id, err := strconv.ParseUint(value, 10, 64)
+		if err != nil || id == 0 {
+			return filter, fmt.Errorf("estado inválido: %s", value)
+		}
+		filter.CreditStatusIDs = append(filter.CreditStatusIDs, uint(id))
+	}
+
+	if from != "" {
+		parsed, err := time.Parse("2006-01-02", from)
+		if err != nil {
+			return filter, fmt.Errorf("from inválido, formato esperado YYYY-MM-DD")
+		}
+		filter.CreatedFrom = &parsed
+	}
+
+	if to != "" {
+		parsed, err := time.Parse("2006-01-02", to)
+		if err != nil {
+			return filter, fmt.Errorf("to inválido, formato esperado YYYY-MM-DD")
+		}
+		end := parsed.AddDate(0, 0, 1)
+		filter.CreatedTo = &end
+	}
+
+	return filter, nil
+}
@@ -0,0 +1,87 @@
+package adapters
+
+import (
+	"fmt"
+
+	"github.com/JhonCamargo53/prueba-tecnica/internal/domain/models"
+	"github.com/JhonCamargo53/prueba-tecnica/internal/domain/ports"
+	"gorm.io/gorm"
+)
+
+type CreditDecisionGormRepository struct {
+	db *gorm.DB
+}
+
+func NewCreditDecisionGormRepository(db *gorm.DB) ports.CreditDecisionRepository {
+	return &CreditDecisionGormRepository{
+		db: db,
+	}
+}
+
+func (r *CreditDecisionGormRepository) Create(decision *models.CreditDecision) error {
+	return r.db.Create(decision).Error
+}
+
+func (r *CreditDecisionGormRepository) Update(decision *models.CreditDecision) error {
+	return r.db.Save(decision).Error
+}
+
+func (r *CreditDecisionGormRepository) Apply(decision *models.CreditDecision, change *models.CreditStatusHistory) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(decision).Error; err != nil {
+			return err
+		}
+		if change == nil {
+			return nil
+		}
+
+		// El estado de origen evita aplicar dos decisiones concurrentes sobre la misma solicitud
+		result := tx.Model(&models.CreditRequest{}).
+			Where("id = ? AND credit_status_id = ?", change.CreditRequestID, *change.FromStatusID).
+			Update("credit_status_id", change.ToStatusID)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return fmt.Errorf("el estado de la solicitud %d cambió mientras se aplicaba la decisión", change.CreditRequestID)
+		}
+
+		return tx.Create(change).Error
+	})
+}
+
+func (r *CreditDecisionGormRepository) FindByID(id uint) (*models.CreditDecision, error) {
+	var decision models.CreditDecision
+	if err := r.db.First(&decision, id).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &decision, nil
+}
+
+func (r *CreditDecisionGormRepository) FindByCreditRequestID(creditRequestID uint) ([]models.CreditDecision, error) {
+	var decisions []models.CreditDecision
+	if err := r.db.Where("credit_request_id = ?", creditRequestID).Order("created_at desc").Find(&decisions).Error; err != nil {
+		return nil, err
+	}
+	return decisions, nil
+}
+
+func (r *CreditDecisionGormRepository) FindPending() ([]models.CreditDecision, error) {
+	var decisions []models.CreditDecision
+	if err := r.db.Where("status = ?", models.CreditDecisionStatusPendingConfirmation).Order("created_at asc").Find(&decisions).Error; err != nil {
+		return nil, err
+	}
+	return decisions, nil
+}
+
+func (r *CreditDecisionGormRepository) FindEscalated(access int) ([]models.CreditDecision, error) {
+	var decisions []models.CreditDecision
+	if err := r.db.Where("status = ? AND required_access <= ?", models.CreditDecisionStatusEscalated, access).
+		Order("created_at asc").Find(&decisions).Error; err != nil {
+		return nil, err
+	}
+	return decisions, nil
+}
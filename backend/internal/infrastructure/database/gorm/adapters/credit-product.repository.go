@@ -0,0 +1,67 @@
+package adapters
+
+import (
+	"github.com/JhonCamargo53/prueba-tecnica/internal/domain/models"
+	"github.com/JhonCamargo53/prueba-tecnica/internal/domain/ports"
+	"gorm.io/gorm"
+)
+
+type CreditProductGormRepository struct {
+	db *gorm.DB
+}
+
+func NewCreditProductGormRepository(db *gorm.DB) ports.CreditProductRepository {
+	return &CreditProductGormRepository{
+		db: db,
+	}
+}
+
+func (r *CreditProductGormRepository) FindAll() ([]models.CreditProduct, error) {
+	var products []models.CreditProduct
+	if err := r.db.Order("name asc").Find(&products).Error; err != nil {
+		return nil, err
+	}
+	return products, nil
+}
+
+func (r *CreditProductGormRepository) FindByID(id uint) (*models.CreditProduct, error) {
+	var product models.CreditProduct
+	if err := r.db.First(&product, id).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &product, nil
+}
+
+func (r *CreditProductGormRepository) FindByCode(code string) (*models.CreditProduct, error) {
+	var product models.CreditProduct
+	if err := r.db.Where("code = ?", code).First(&product).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &product, nil
+}
+
+func (r *CreditProductGormRepository) Create(product *models.CreditProduct) error {
+	return r.db.Create(product).Error
+}
+
+func (r *CreditProductGormRepository) Save(product *models.CreditProduct) error {
+	return r.db.Save(product).Error
+}
+
+func (r *CreditProductGormRepository) Delete(id uint) error {
+	return r.db.Delete(&models.CreditProduct{}, id).Error
+}
+
+func (r *CreditProductGormRepository) HasCreditRequests(id uint) (bool, error) {
+	var count int64
+	if err := r.db.Model(&models.CreditRequest{}).Where("credit_product_id = ?", id).Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
@@ -0,0 +1,45 @@
+package adapters
+
+import (
+	"github.com/JhonCamargo53/prueba-tecnica/internal/domain/models"
+	"github.com/JhonCamargo53/prueba-tecnica/internal/domain/ports"
+	"gorm.io/gorm"
+)
+
+type CreditRequestParticipantGormRepository struct {
+	db *gorm.DB
+}
+
+func NewCreditRequestParticipantGormRepository(db *gorm.DB) ports.CreditRequestParticipantRepository {
+	return &CreditRequestParticipantGormRepository{
+		db: db,
+	}
+}
+
+func (r *CreditRequestParticipantGormRepository) FindByCreditRequestID(creditRequestID uint) ([]models.CreditRequestParticipant, error) {
+	var participants []models.CreditRequestParticipant
+	if err := r.db.Preload("Customer").Where("credit_request_id = ?", creditRequestID).
+		Order("id asc").Find(&participants).Error; err != nil {
+		return nil, err
+	}
+	return participants, nil
+}
+
+func (r *CreditRequestParticipantGormRepository) FindByID(id uint) (*models.CreditRequestParticipant, error) {
+	var participant models.CreditRequestParticipant
+	if err := r.db.Preload("Customer").First(&participant, id).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &participant, nil
+}
+
+func (r *CreditRequestParticipantGormRepository) Create(participant *models.CreditRequestParticipant) error {
+	return r.db.Omit("Customer").Create(participant).Error
+}
+
+func (r *CreditRequestParticipantGormRepository) Delete(id uint) error {
+	return r.db.Delete(&models.CreditRequestParticipant{}, id).Error
+}
@@ -1,6 +1,9 @@
 package adapters
 
 import (
+	"encoding/json"
+	"time"
+
 	"github.com/JhonCamargo53/prueba-tecnica/internal/domain/models"
 	"github.com/JhonCamargo53/prueba-tecnica/internal/domain/ports"
 	"gorm.io/gorm"
@@ -19,7 +22,7 @@ func NewCreditRequestGormRepository(db *gorm.DB) ports.CreditRequestRepository {
 func (r *CreditRequestGormRepository) FindAll(customerID *uint) ([]models.CreditRequest, error) {
 	var creditRequests []models.CreditRequest
 
-	query := r.db
+	query := r.db.Preload("CreditProduct").Preload("LoanAccount")
 	if customerID != nil {
 		query = query.Where("customer_id = ?", *customerID)
 	}
@@ -33,7 +36,7 @@ func (r *CreditRequestGormRepository) FindAll(customerID *uint) ([]models.Credit
 
 func (r *CreditRequestGormRepository) FindByID(id uint) (*models.CreditRequest, error) {
 	var creditRequest models.CreditRequest
-	if err := r.db.First(&creditRequest, id).Error; err != nil {
+	if err := r.db.Preload("CreditProduct").First(&creditRequest, id).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, nil
 		}
@@ -63,10 +66,24 @@ func (r *CreditRequestGormRepository) Update(id uint, crData *models.CreditReque
 		return nil, err
 	}
 
-	if err := r.db.Model(&cr).Updates(crData).Error; err != nil {
+	// Columnas explícitas: Updates con un struct omite los valores en cero y la tasa
+	// de interés no se podría volver a 0 (la del producto)
+	previousCustomerID := cr.CustomerID
+	if err := r.db.Model(&cr).
+		Select("amount", "term_months", "interest_rate", "customer_id", "credit_product_id", "product_type", "credit_status_id").
+		Updates(crData).Error; err != nil {
 		return nil, err
 	}
 
+	// El titular de la solicitud es su cliente
+	if crData.CustomerID != 0 && crData.CustomerID != previousCustomerID {
+		if err := r.db.Model(&models.CreditRequestParticipant{}).
+			Where("credit_request_id = ? AND role = ?", id, models.ParticipantRoleHolder).
+			Update("customer_id", crData.CustomerID).Error; err != nil {
+			return nil, err
+		}
+	}
+
 	return &cr, nil
 }
 
@@ -74,12 +91,19 @@ func (r *CreditRequestGormRepository) Delete(id uint) error {
 	return r.db.Delete(&models.CreditRequest{}, id).Error
 }
 
-func (r *CreditRequestGormRepository) UpdateCreditRiskEvaluation(id uint, score float64, category string, explanation string) (*models.CreditRequest, error) {
+func (r *CreditRequestGormRepository) UpdateCreditRiskEvaluation(id uint, assessment *models.RiskAssessment) (*models.CreditRequest, error) {
+
+	structured, err := json.Marshal(assessment)
+	if err != nil {
+		return nil, err
+	}
 
-	err := r.db.Model(&models.CreditRequest{}).Where("id = ?", id).Updates(map[string]interface{}{
-		"risk_score":       score,
-		"risk_category":    category,
-		"risk_explanation": explanation,
+	err = r.db.Model(&models.CreditRequest{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"risk_score":            assessment.Score,
+		"risk_category":         assessment.Category,
+		"risk_explanation":      assessment.Explanation,
+		"risk_rule_set_version": assessment.EngineVersion,
+		"risk_assessment":       models.JSONB(structured),
 	}).Error
 
 	if err != nil {
@@ -96,6 +120,65 @@ func (r *CreditRequestGormRepository) UpdateCreditRiskEvaluation(id uint, score
 
 }
 
+func (r *CreditRequestGormRepository) UpdateCreditStatus(id uint, creditStatusID uint) error {
+	return r.db.Model(&models.CreditRequest{}).Where("id = ?", id).Update("credit_status_id", creditStatusID).Error
+}
+
+// UpdateCreditOffer guarda la oferta calculada; con nil borra la oferta anterior.
+func (r *CreditRequestGormRepository) UpdateCreditOffer(id uint, offer *models.CreditOffer) error {
+	if offer == nil {
+		offer = &models.CreditOffer{}
+	}
+
+	return r.db.Model(&models.CreditRequest{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"offer_pricing_version": offer.PricingVersion,
+		"offer_annual_rate":     offer.AnnualRate,
+		"offer_max_amount":      offer.MaxAmount,
+		"offer_amount":          offer.Amount,
+		"offer_term_months":     offer.TermMonths,
+		"offer_installment":     offer.Installment,
+		"offer_counter_offer":   offer.CounterOffer,
+	}).Error
+}
+
+// loadApprovalDates completa ApprovedAt de las solicitudes aprobadas con la fecha de
+// su paso a APROBADO en el historial de estados.
+func (r *CreditRequestGormRepository) loadApprovalDates(creditRequests []models.CreditRequest) error {
+	var ids []uint
+	for _, cr := range creditRequests {
+		if cr.CreditStatusID == models.CreditStatusApprovedID {
+			ids = append(ids, cr.ID)
+		}
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	var rows []struct {
+		CreditRequestID uint
+		ApprovedAt      time.Time
+	}
+	if err := r.db.Model(&models.CreditStatusHistory{}).
+		Select("credit_request_id, MAX(created_at) AS approved_at").
+		Where("credit_request_id IN ? AND to_status_id = ?", ids, models.CreditStatusApprovedID).
+		Group("credit_request_id").
+		Scan(&rows).Error; err != nil {
+		return err
+	}
+
+	approvedAt := make(map[uint]time.Time, len(rows))
+	for _, row := range rows {
+		approvedAt[row.CreditRequestID] = row.ApprovedAt
+	}
+	for i := range creditRequests {
+		if at, ok := approvedAt[creditRequests[i].ID]; ok {
+			creditRequests[i].ApprovedAt = &at
+		}
+	}
+
+	return nil
+}
+
 func (r *CreditRequestGormRepository) FindDataToEvaluateRisk(id uint) (models.Customer, *models.CreditRequest, []models.CreditRequest, []models.CustomerAsset, error) {
 
 	var customer models.Customer
@@ -103,17 +186,21 @@ func (r *CreditRequestGormRepository) FindDataToEvaluateRisk(id uint) (models.Cu
 	var previousRequests []models.CreditRequest
 	var customerAssets []models.CustomerAsset
 
-	if err := r.db.Preload("Customer").First(&creditRequest, id).Error; err != nil {
+	if err := r.db.Preload("Customer").Preload("CreditProduct").Preload("Participants.Customer").First(&creditRequest, id).Error; err != nil {
 		return customer, nil, nil, nil, err
 	}
 
 	customer = creditRequest.Customer
 
-	if err := r.db.Where("customer_id = ? AND id <> ?", customer.ID, creditRequest.ID).Find(&previousRequests).Error; err != nil {
+	if err := r.db.Preload("CreditProduct").Preload("LoanAccount").Where("customer_id = ? AND id <> ?", customer.ID, creditRequest.ID).Find(&previousRequests).Error; err != nil {
+		return customer, nil, nil, nil, err
+	}
+
+	if err := r.loadApprovalDates(previousRequests); err != nil {
 		return customer, nil, nil, nil, err
 	}
 
-	if err := r.db.Where("credit_request_id = ?", creditRequest.ID).Find(&customerAssets).Error; err != nil {
+	if err := r.db.Preload("Asset").Where("credit_request_id = ?", creditRequest.ID).Find(&customerAssets).Error; err != nil {
 		return customer, nil, nil, nil, err
 	}
 
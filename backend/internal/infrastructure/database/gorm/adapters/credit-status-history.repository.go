@@ -0,0 +1,31 @@
+package adapters
+
+import (
+	"github.com/JhonCamargo53/prueba-tecnica/internal/domain/models"
+	"github.com/JhonCamargo53/prueba-tecnica/internal/domain/ports"
+	"gorm.io/gorm"
+)
+
+type CreditStatusHistoryGormRepository struct {
+	db *gorm.DB
+}
+
+func NewCreditStatusHistoryGormRepository(db *gorm.DB) ports.CreditStatusHistoryRepository {
+	return &CreditStatusHistoryGormRepository{
+		db: db,
+	}
+}
+
+func (r *CreditStatusHistoryGormRepository) Create(history *models.CreditStatusHistory) error {
+	return r.db.Create(history).Error
+}
+
+func (r *CreditStatusHistoryGormRepository) FindByCreditRequestID(creditRequestID uint) ([]models.CreditStatusHistory, error) {
+	var history []models.CreditStatusHistory
+	if err := r.db.Preload("FromStatus").Preload("ToStatus").
+		Where("credit_request_id = ?", creditRequestID).
+		Order("created_at asc, id asc").Find(&history).Error; err != nil {
+		return nil, err
+	}
+	return history, nil
+}
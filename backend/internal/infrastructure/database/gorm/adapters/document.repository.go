@@ -0,0 +1,59 @@
+package adapters
+
+import (
+	"github.com/JhonCamargo53/prueba-tecnica/internal/domain/models"
+	"github.com/JhonCamargo53/prueba-tecnica/internal/domain/ports"
+	"gorm.io/gorm"
+)
+
+type DocumentGormRepository struct {
+	db *gorm.DB
+}
+
+func NewDocumentGormRepository(db *gorm.DB) ports.DocumentRepository {
+	return &DocumentGormRepository{
+		db: db,
+	}
+}
+
+func (r *DocumentGormRepository) FindByID(id uint) (*models.Document, error) {
+	var document models.Document
+	if err := r.db.First(&document, id).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &document, nil
+}
+
+func (r *DocumentGormRepository) FindByOwner(ownerType string, ownerID uint) ([]models.Document, error) {
+	var documents []models.Document
+	if err := r.db.Where("owner_type = ? AND owner_id = ?", ownerType, ownerID).
+		Order("id asc").Find(&documents).Error; err != nil {
+		return nil, err
+	}
+	return documents, nil
+}
+
+func (r *DocumentGormRepository) FindForCreditRequest(creditRequestID, customerID uint) ([]models.Document, error) {
+	assetIDs := r.db.Model(&models.CustomerAsset{}).Select("id").Where("credit_request_id = ?", creditRequestID)
+
+	var documents []models.Document
+	if err := r.db.Where("(owner_type = ? AND owner_id = ?) OR (owner_type = ? AND owner_id = ?) OR (owner_type = ? AND owner_id IN (?))",
+		models.DocumentOwnerCreditRequest, creditRequestID,
+		models.DocumentOwnerCustomer, customerID,
+		models.DocumentOwnerCustomerAsset, assetIDs).
+		Order("id asc").Find(&documents).Error; err != nil {
+		return nil, err
+	}
+	return documents, nil
+}
+
+func (r *DocumentGormRepository) Create(document *models.Document) error {
+	return r.db.Create(document).Error
+}
+
+func (r *DocumentGormRepository) Delete(id uint) error {
+	return r.db.Delete(&models.Document{}, id).Error
+}
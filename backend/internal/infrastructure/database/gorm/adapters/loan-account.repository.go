@@ -0,0 +1,119 @@
+package adapters
+
+import (
+	"github.com/JhonCamargo53/prueba-tecnica/internal/domain/models"
+	"github.com/JhonCamargo53/prueba-tecnica/internal/domain/ports"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type LoanAccountGormRepository struct {
+	db *gorm.DB
+}
+
+func NewLoanAccountGormRepository(db *gorm.DB) ports.LoanAccountRepository {
+	return &LoanAccountGormRepository{
+		db: db,
+	}
+}
+
+// withInstallments carga el plan de la cuenta con las cuotas ordenadas por número.
+func withInstallments(db *gorm.DB) *gorm.DB {
+	return db.Preload("PaymentSchedule").Preload("PaymentSchedule.Installments", func(db *gorm.DB) *gorm.DB {
+		return db.Order("number asc")
+	})
+}
+
+func (r *LoanAccountGormRepository) FindByCreditRequestID(creditRequestID uint) (*models.LoanAccount, error) {
+	var account models.LoanAccount
+	if err := withInstallments(r.db).Where("credit_request_id = ?", creditRequestID).First(&account).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &account, nil
+}
+
+func (r *LoanAccountGormRepository) FindActive() ([]models.LoanAccount, error) {
+	var accounts []models.LoanAccount
+	if err := withInstallments(r.db).Where("status = ?", models.LoanAccountStatusActive).
+		Order("id asc").Find(&accounts).Error; err != nil {
+		return nil, err
+	}
+	return accounts, nil
+}
+
+func (r *LoanAccountGormRepository) FindDelinquent() ([]models.LoanAccount, error) {
+	var accounts []models.LoanAccount
+	if err := r.db.Where("status = ? AND days_past_due > 0", models.LoanAccountStatusActive).
+		Order("days_past_due desc, id asc").Find(&accounts).Error; err != nil {
+		return nil, err
+	}
+	return accounts, nil
+}
+
+func (r *LoanAccountGormRepository) Create(account *models.LoanAccount) error {
+	return r.db.Omit("PaymentSchedule").Create(account).Error
+}
+
+func (r *LoanAccountGormRepository) UpdateDelinquency(account *models.LoanAccount) error {
+	return r.db.Model(&models.LoanAccount{}).Where("id = ?", account.ID).Updates(map[string]interface{}{
+		"days_past_due":          account.DaysPastDue,
+		"delinquency_bucket":     account.DelinquencyBucket,
+		"max_days_past_due":      account.MaxDaysPastDue,
+		"delinquency_updated_at": account.DelinquencyUpdatedAt,
+	}).Error
+}
+
+func (r *LoanAccountGormRepository) RegisterPayment(accountID uint,
+	build func(account *models.LoanAccount) (*models.LoanPayment, []models.PaymentScheduleInstallment, error)) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		// El bloqueo serializa los pagos de la cuenta entre instancias del servidor
+		var account models.LoanAccount
+		if err := withInstallments(tx.Clauses(clause.Locking{Strength: "UPDATE"})).First(&account, accountID).Error; err != nil {
+			return err
+		}
+
+		payment, installments, err := build(&account)
+		if err != nil {
+			return err
+		}
+
+		if err := tx.Create(payment).Error; err != nil {
+			return err
+		}
+
+		for _, installment := range installments {
+			if err := tx.Model(&models.PaymentScheduleInstallment{}).Where("id = ?", installment.ID).Updates(map[string]interface{}{
+				"interest_paid":  installment.InterestPaid,
+				"principal_paid": installment.PrincipalPaid,
+				"paid_at":        installment.PaidAt,
+			}).Error; err != nil {
+				return err
+			}
+		}
+
+		return tx.Model(&models.LoanAccount{}).Where("id = ?", account.ID).Updates(map[string]interface{}{
+			"outstanding_principal":  account.OutstandingPrincipal,
+			"principal_paid":         account.PrincipalPaid,
+			"interest_paid":          account.InterestPaid,
+			"status":                 account.Status,
+			"days_past_due":          account.DaysPastDue,
+			"delinquency_bucket":     account.DelinquencyBucket,
+			"max_days_past_due":      account.MaxDaysPastDue,
+			"last_payment_at":        account.LastPaymentAt,
+			"delinquency_updated_at": account.DelinquencyUpdatedAt,
+		}).Error
+	})
+}
+
+func (r *LoanAccountGormRepository) FindPayments(loanAccountID uint) ([]models.LoanPayment, error) {
+	var payments []models.LoanPayment
+	if err := r.db.Preload("Allocations", func(db *gorm.DB) *gorm.DB {
+		return db.Order("installment_number asc")
+	}).Where("loan_account_id = ?", loanAccountID).Order("paid_at asc, id asc").Find(&payments).Error; err != nil {
+		return nil, err
+	}
+	return payments, nil
+}
@@ -0,0 +1,35 @@
+package adapters
+
+import (
+	"github.com/JhonCamargo53/prueba-tecnica/internal/domain/models"
+	"github.com/JhonCamargo53/prueba-tecnica/internal/domain/ports"
+	"gorm.io/gorm"
+)
+
+type PaymentScheduleGormRepository struct {
+	db *gorm.DB
+}
+
+func NewPaymentScheduleGormRepository(db *gorm.DB) ports.PaymentScheduleRepository {
+	return &PaymentScheduleGormRepository{
+		db: db,
+	}
+}
+
+func (r *PaymentScheduleGormRepository) FindByCreditRequestID(creditRequestID uint) (*models.PaymentSchedule, error) {
+	var schedule models.PaymentSchedule
+	err := r.db.Preload("Installments", func(db *gorm.DB) *gorm.DB {
+		return db.Order("number asc")
+	}).Where("credit_request_id = ?", creditRequestID).First(&schedule).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &schedule, nil
+}
+
+func (r *PaymentScheduleGormRepository) Create(schedule *models.PaymentSchedule) error {
+	return r.db.Create(schedule).Error
+}
@@ -0,0 +1,63 @@
+package adapters
+
+import (
+	"time"
+
+	"github.com/JhonCamargo53/prueba-tecnica/internal/domain/models"
+	"github.com/JhonCamargo53/prueba-tecnica/internal/domain/ports"
+	"gorm.io/gorm"
+)
+
+type RiskDriftGormRepository struct {
+	db *gorm.DB
+}
+
+func NewRiskDriftGormRepository(db *gorm.DB) ports.RiskDriftRepository {
+	return &RiskDriftGormRepository{
+		db: db,
+	}
+}
+
+func (r *RiskDriftGormRepository) FindObservations(from, to time.Time) ([]models.RiskDriftObservation, error) {
+	var observations []models.RiskDriftObservation
+
+	err := r.db.Table("credit_requests AS cr").
+		Select(`cr.id AS credit_request_id, c.monthly_income, cr.amount, cr.term_months,
+			COALESCE(SUM(ca.market_value), 0) AS asset_value, cr.product_type,
+			cr.risk_score AS score, cr.risk_rule_set_version AS engine_version`).
+		Joins("JOIN customers c ON c.id = cr.customer_id").
+		Joins("LEFT JOIN customer_assets ca ON ca.credit_request_id = cr.id AND ca.deleted_at IS NULL").
+		Where("cr.deleted_at IS NULL AND cr.created_at >= ? AND cr.created_at < ?", from, to).
+		Group("cr.id, c.monthly_income").
+		Order("cr.id asc").
+		Scan(&observations).Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return observations, nil
+}
+
+func (r *RiskDriftGormRepository) Create(report *models.RiskDriftReport) error {
+	return r.db.Create(report).Error
+}
+
+func (r *RiskDriftGormRepository) FindAll() ([]models.RiskDriftReport, error) {
+	var reports []models.RiskDriftReport
+	if err := r.db.Order("created_at desc").Find(&reports).Error; err != nil {
+		return nil, err
+	}
+	return reports, nil
+}
+
+func (r *RiskDriftGormRepository) FindByID(id uint) (*models.RiskDriftReport, error) {
+	var report models.RiskDriftReport
+	if err := r.db.First(&report, id).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &report, nil
+}
@@ -0,0 +1,33 @@
+package adapters
+
+import (
+	"github.com/JhonCamargo53/prueba-tecnica/internal/domain/models"
+	"github.com/JhonCamargo53/prueba-tecnica/internal/domain/ports"
+	"gorm.io/gorm"
+)
+
+type RiskEvaluationGormRepository struct {
+	db *gorm.DB
+}
+
+func NewRiskEvaluationGormRepository(db *gorm.DB) ports.RiskEvaluationRepository {
+	return &RiskEvaluationGormRepository{
+		db: db,
+	}
+}
+
+func (r *RiskEvaluationGormRepository) Create(evaluation *models.RiskEvaluation) error {
+	return r.db.Create(evaluation).Error
+}
+
+func (r *RiskEvaluationGormRepository) FindByCreditRequestID(creditRequestID uint) ([]models.RiskEvaluation, error) {
+	var evaluations []models.RiskEvaluation
+
+	if err := r.db.Where("credit_request_id = ?", creditRequestID).
+		Order("created_at desc, id desc").
+		Find(&evaluations).Error; err != nil {
+		return nil, err
+	}
+
+	return evaluations, nil
+}
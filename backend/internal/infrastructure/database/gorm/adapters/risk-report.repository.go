@@ -0,0 +1,104 @@
+package adapters
+
+import (
+	"github.com/JhonCamargo53/prueba-tecnica/internal/domain/models"
+	"github.com/JhonCamargo53/prueba-tecnica/internal/domain/ports"
+	"gorm.io/gorm"
+)
+
+// Clave del bloqueo consultivo que serializa la escritura de la cadena de reportes
+const riskReportChainLock = 7_202_500
+
+type RiskReportGormRepository struct {
+	db *gorm.DB
+}
+
+func NewRiskReportGormRepository(db *gorm.DB) ports.RiskReportRepository {
+	return &RiskReportGormRepository{
+		db: db,
+	}
+}
+
+func (r *RiskReportGormRepository) Append(build func(last *models.RiskReport) (*models.RiskReport, error)) (*models.RiskReport, error) {
+	var report *models.RiskReport
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		// El bloqueo se libera al terminar la transacción
+		if err := tx.Exec("SELECT pg_advisory_xact_lock(?)", riskReportChainLock).Error; err != nil {
+			return err
+		}
+
+		var last *models.RiskReport
+		var found models.RiskReport
+		err := tx.Order("sequence desc").First(&found).Error
+		if err == nil {
+			last = &found
+		} else if err != gorm.ErrRecordNotFound {
+			return err
+		}
+
+		built, err := build(last)
+		if err != nil {
+			return err
+		}
+		if err := tx.Create(built).Error; err != nil {
+			return err
+		}
+
+		report = built
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return report, nil
+}
+
+func (r *RiskReportGormRepository) FindByID(id uint) (*models.RiskReport, error) {
+	var report models.RiskReport
+	if err := r.db.First(&report, id).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &report, nil
+}
+
+func (r *RiskReportGormRepository) FindBySequence(sequence uint64) (*models.RiskReport, error) {
+	var report models.RiskReport
+	if err := r.db.Where("sequence = ?", sequence).First(&report).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &report, nil
+}
+
+func (r *RiskReportGormRepository) FindByCreditRequestID(creditRequestID uint) ([]models.RiskReport, error) {
+	var reports []models.RiskReport
+	if err := r.db.Where("credit_request_id = ?", creditRequestID).Order("sequence desc").Find(&reports).Error; err != nil {
+		return nil, err
+	}
+	return reports, nil
+}
+
+func (r *RiskReportGormRepository) FindAfter(afterSequence uint64, limit int) ([]models.RiskReport, error) {
+	var reports []models.RiskReport
+	if err := r.db.Where("sequence > ?", afterSequence).Order("sequence asc").Limit(limit).Find(&reports).Error; err != nil {
+		return nil, err
+	}
+	return reports, nil
+}
+
+func (r *RiskReportGormRepository) FindUnreported(afterID uint, limit int) ([]models.RiskEvaluation, error) {
+	var evaluations []models.RiskEvaluation
+	if err := r.db.Where("id > ?", afterID).
+		Where("NOT EXISTS (SELECT 1 FROM risk_reports WHERE risk_reports.risk_evaluation_id = risk_evaluations.id)").
+		Order("id asc").Limit(limit).Find(&evaluations).Error; err != nil {
+		return nil, err
+	}
+	return evaluations, nil
+}
@@ -0,0 +1,91 @@
+package adapters
+
+import (
+	"github.com/JhonCamargo53/prueba-tecnica/internal/domain/models"
+	"github.com/JhonCamargo53/prueba-tecnica/internal/domain/ports"
+	"gorm.io/gorm"
+)
+
+type RiskRescoringGormRepository struct {
+	db *gorm.DB
+}
+
+func NewRiskRescoringGormRepository(db *gorm.DB) ports.RiskRescoringRepository {
+	return &RiskRescoringGormRepository{
+		db: db,
+	}
+}
+
+func (r *RiskRescoringGormRepository) FindCreditRequestIDs(filter models.RiskRescoringFilter, afterID uint, limit int) ([]uint, error) {
+	var ids []uint
+
+	query := r.db.Model(&models.CreditRequest{}).Where("id > ?", afterID)
+
+	if filter.CustomerID != nil {
+		query = query.Where("customer_id = ?", *filter.CustomerID)
+	}
+
+	if len(filter.CreditStatusIDs) > 0 {
+		query = query.Where("credit_status_id IN ?", filter.CreditStatusIDs)
+	}
+
+	if filter.CreatedFrom != nil {
+		query = query.Where("created_at >= ?", *filter.CreatedFrom)
+	}
+
+	if filter.CreatedTo != nil {
+		query = query.Where("created_at < ?", *filter.CreatedTo)
+	}
+
+	if filter.EngineVersion != "" {
+		query = query.Where("risk_rule_set_version = ?", filter.EngineVersion)
+	}
+
+	if err := query.Order("id asc").Limit(limit).Pluck("id", &ids).Error; err != nil {
+		return nil, err
+	}
+
+	return ids, nil
+}
+
+func (r *RiskRescoringGormRepository) CreateJob(job *models.RiskRescoringJob) error {
+	return r.db.Create(job).Error
+}
+
+func (r *RiskRescoringGormRepository) UpdateJob(job *models.RiskRescoringJob) error {
+	return r.db.Save(job).Error
+}
+
+func (r *RiskRescoringGormRepository) FindJobByID(id uint) (*models.RiskRescoringJob, error) {
+	var job models.RiskRescoringJob
+	if err := r.db.First(&job, id).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &job, nil
+}
+
+func (r *RiskRescoringGormRepository) FindJobs() ([]models.RiskRescoringJob, error) {
+	var jobs []models.RiskRescoringJob
+	if err := r.db.Order("created_at desc").Find(&jobs).Error; err != nil {
+		return nil, err
+	}
+	return jobs, nil
+}
+
+func (r *RiskRescoringGormRepository) CreateChanges(changes []models.RiskRescoringChange) error {
+	if len(changes) == 0 {
+		return nil
+	}
+	return r.db.Create(&changes).Error
+}
+
+func (r *RiskRescoringGormRepository) FindChangesByJobID(jobID uint) ([]models.RiskRescoringChange, error) {
+	var changes []models.RiskRescoringChange
+	if err := r.db.Where("job_id = ?", jobID).Order("credit_request_id asc, id asc").Find(&changes).Error; err != nil {
+		return nil, err
+	}
+	return changes, nil
+}
@@ -0,0 +1,43 @@
+package adapters
+
+import (
+	"time"
+
+	"github.com/JhonCamargo53/prueba-tecnica/internal/domain/models"
+	"github.com/JhonCamargo53/prueba-tecnica/internal/domain/ports"
+	"gorm.io/gorm"
+)
+
+type ShadowRiskEvaluationGormRepository struct {
+	db *gorm.DB
+}
+
+func NewShadowRiskEvaluationGormRepository(db *gorm.DB) ports.ShadowRiskEvaluationRepository {
+	return &ShadowRiskEvaluationGormRepository{
+		db: db,
+	}
+}
+
+func (r *ShadowRiskEvaluationGormRepository) Create(evaluation *models.ShadowRiskEvaluation) error {
+	return r.db.Create(evaluation).Error
+}
+
+func (r *ShadowRiskEvaluationGormRepository) FindBetween(from, to *time.Time) ([]models.ShadowRiskEvaluation, error) {
+	var evaluations []models.ShadowRiskEvaluation
+
+	query := r.db.Model(&models.ShadowRiskEvaluation{})
+
+	if from != nil {
+		query = query.Where("created_at >= ?", *from)
+	}
+
+	if to != nil {
+		query = query.Where("created_at < ?", *to)
+	}
+
+	if err := query.Order("created_at asc, id asc").Find(&evaluations).Error; err != nil {
+		return nil, err
+	}
+
+	return evaluations, nil
+}
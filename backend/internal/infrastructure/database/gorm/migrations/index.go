@@ -6,13 +6,51 @@ import (
 )
 
 func AutoMigrateAll(db *gorm.DB) error {
-	return db.AutoMigrate(
+	err := db.AutoMigrate(
 		&models.User{},
 		&models.DocumentType{},
 		&models.Asset{},
 		&models.Customer{},
+		&models.CreditProduct{},
 		&models.CreditRequest{},
+		&models.CreditRequestParticipant{},
 		&models.CustomerAsset{},
+		&models.Document{},
 		&models.Role{},
+		&models.RiskEvaluation{},
+		&models.ShadowRiskEvaluation{},
+		&models.RiskRescoringJob{},
+		&models.RiskRescoringChange{},
+		&models.RiskDriftReport{},
+		&models.RiskReport{},
+		&models.CreditDecision{},
+		&models.CreditStatusHistory{},
+		&models.PaymentSchedule{},
+		&models.PaymentScheduleInstallment{},
+		&models.LoanAccount{},
+		&models.LoanPayment{},
+		&models.LoanPaymentAllocation{},
 	)
+	if err != nil {
+		return err
+	}
+
+	return protectRiskReports(db)
+}
+
+// protectRiskReports hace que la tabla de reportes de riesgo sea de sólo inserción:
+// un trigger rechaza cualquier UPDATE o DELETE, incluso fuera de la aplicación.
+func protectRiskReports(db *gorm.DB) error {
+	return db.Exec(`
+		CREATE OR REPLACE FUNCTION risk_reports_append_only() RETURNS trigger AS $$
+		BEGIN
+			RAISE EXCEPTION 'risk_reports es de sólo inserción: no se permite %', TG_OP;
+		END;
+		$$ LANGUAGE plpgsql;
+
+		DROP TRIGGER IF EXISTS risk_reports_append_only ON risk_reports;
+		CREATE TRIGGER risk_reports_append_only
+			BEFORE UPDATE OR DELETE ON risk_reports
+			FOR EACH ROW EXECUTE FUNCTION risk_reports_append_only();
+	`).Error
 }
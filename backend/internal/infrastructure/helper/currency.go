@@ -1,14 +1,7 @@
 package helper
 
-import "github.com/leekchan/accounting"
-
-var ac = accounting.Accounting{
-	Symbol:    "$",
-	Precision: 0,
-	Thousand:  ".",
-	Decimal:   ",",
-}
+import "github.com/JhonCamargo53/prueba-tecnica/internal/domain/i18n"
 
 func FormatCOP(value float64) string {
-	return ac.FormatMoney(value)
+	return i18n.FormatCOP(i18n.LanguageES, value)
 }
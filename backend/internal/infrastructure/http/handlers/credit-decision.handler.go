@@ -0,0 +1,215 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	creditDecision "github.com/JhonCamargo53/prueba-tecnica/internal/application/services/credit-decision"
+	"github.com/gorilla/mux"
+)
+
+var creditDecisionService *creditDecision.CreditDecisionService
+
+func InitCreditDecisionHandler(s *creditDecision.CreditDecisionService) {
+	creditDecisionService = s
+}
+
+// CreditDecisionRequest representa la aprobación o el rechazo de una solicitud
+// @Description Decisión sobre una solicitud de crédito
+type CreditDecisionRequest struct {
+	CreditStatusID uint   `json:"creditStatusId" example:"2"`
+	Justification  string `json:"justification" example:"Ingresos adicionales demostrados con extractos bancarios"`
+}
+
+// CreditDecisionReviewRequest representa la confirmación o el descarte de un override
+// @Description Revisión de una decisión pendiente de confirmación
+type CreditDecisionReviewRequest struct {
+	Confirm bool   `json:"confirm" example:"true"`
+	Comment string `json:"comment" example:"Validado con el área de riesgo"`
+}
+
+// PostCreditDecisionHandle godoc
+// @Summary      Registrar la decisión sobre una solicitud de crédito
+// @Description  Aprueba (2) o rechaza (3) la solicitud. Si contradice la recomendación del motor se exige una justificación; si además el monto supera el límite configurado, la decisión queda pendiente hasta que la confirme un usuario con mayor nivel de acceso. Una aprobación por encima de la atribución del rol (monto o categoría de riesgo) queda ESCALATED hasta que la resuelva un rol con atribución suficiente
+// @Tags         Credit Requests
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path int true "ID de la solicitud de crédito"
+// @Param        decision body CreditDecisionRequest true "Decisión"
+// @Success      201 {object} models.CreditDecision "Decisión registrada"
+// @Failure      400 {string} string "Decisión inválida o sin justificación"
+// @Failure      403 {string} string "El rol del usuario no puede hacer el cambio de estado"
+// @Failure      404 {string} string "Solicitud no encontrada"
+// @Failure      409 {string} string "Hay una decisión pendiente, la transición de estado no está permitida, ningún rol tiene atribución para aprobar o falta la garantía o algún documento que exige el producto"
+// @Failure      500 {string} string "Error interno del servidor"
+// @Router       /credit-requests/{id}/decision [post]
+func PostCreditDecisionHandle(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil || id <= 0 {
+		http.Error(w, "ID inválido", http.StatusBadRequest)
+		return
+	}
+
+	var request CreditDecisionRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		http.Error(w, "JSON inválido", http.StatusBadRequest)
+		return
+	}
+
+	requesterId := r.Context().Value("requesterId").(uint)
+
+	decision, err := creditDecisionService.Decide(uint(id), request.CreditStatusID, request.Justification, requesterId)
+	if err != nil {
+		writeCreditDecisionError(w, "Error al registrar la decisión: ", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(decision)
+}
+
+// ReviewCreditDecisionHandle godoc
+// @Summary      Confirmar o descartar una decisión pendiente
+// @Description  Un usuario distinto de quien decidió y con mayor nivel de acceso confirma (aplica el estado) o descarta un override pendiente o una aprobación escalada; en las escaladas debe tener atribución para aprobar la solicitud
+// @Tags         Credit Requests
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path int true "ID de la solicitud de crédito"
+// @Param        decisionId path int true "ID de la decisión"
+// @Param        review body CreditDecisionReviewRequest true "Revisión"
+// @Success      200 {object} models.CreditDecision "Decisión revisada"
+// @Failure      400 {string} string "ID inválido"
+// @Failure      403 {string} string "El usuario no puede confirmar la decisión"
+// @Failure      404 {string} string "Decisión no encontrada"
+// @Failure      409 {string} string "La decisión no está pendiente, la transición de estado no está permitida o falta la garantía o algún documento que exige el producto"
+// @Failure      500 {string} string "Error interno del servidor"
+// @Router       /credit-requests/{id}/decisions/{decisionId}/review [post]
+func ReviewCreditDecisionHandle(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	id, err := strconv.Atoi(params["id"])
+	if err != nil || id <= 0 {
+		http.Error(w, "ID inválido", http.StatusBadRequest)
+		return
+	}
+	decisionID, err := strconv.Atoi(params["decisionId"])
+	if err != nil || decisionID <= 0 {
+		http.Error(w, "ID de decisión inválido", http.StatusBadRequest)
+		return
+	}
+
+	var request CreditDecisionReviewRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		http.Error(w, "JSON inválido", http.StatusBadRequest)
+		return
+	}
+
+	requesterId := r.Context().Value("requesterId").(uint)
+
+	decision, err := creditDecisionService.Review(uint(id), uint(decisionID), requesterId, request.Confirm, request.Comment)
+	if err != nil {
+		writeCreditDecisionError(w, "Error al revisar la decisión: ", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(decision)
+}
+
+// GetCreditDecisionsHandle godoc
+// @Summary      Obtener las decisiones de una solicitud de crédito
+// @Description  Retorna las decisiones registradas, de la más reciente a la más antigua, con su justificación, revisor y fechas
+// @Tags         Credit Requests
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path int true "ID de la solicitud de crédito"
+// @Success      200 {array} models.CreditDecision "Decisiones"
+// @Failure      400 {string} string "ID inválido"
+// @Failure      404 {string} string "Solicitud no encontrada"
+// @Failure      500 {string} string "Error interno del servidor"
+// @Router       /credit-requests/{id}/decisions [get]
+func GetCreditDecisionsHandle(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil || id <= 0 {
+		http.Error(w, "ID inválido", http.StatusBadRequest)
+		return
+	}
+
+	decisions, err := creditDecisionService.GetDecisionsByCreditRequestID(uint(id))
+	if err != nil {
+		writeCreditDecisionError(w, "Error al obtener decisiones: ", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(decisions)
+}
+
+// GetPendingCreditDecisionsHandle godoc
+// @Summary      Obtener las decisiones pendientes de confirmación
+// @Description  Retorna los overrides que esperan la confirmación de un segundo usuario, del más antiguo al más reciente
+// @Tags         Credit Requests
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200 {array} models.CreditDecision "Decisiones pendientes"
+// @Failure      500 {string} string "Error interno del servidor"
+// @Router       /credit-requests/decisions/pending [get]
+func GetPendingCreditDecisionsHandle(w http.ResponseWriter, r *http.Request) {
+	decisions, err := creditDecisionService.GetPendingDecisions()
+	if err != nil {
+		http.Error(w, "Error al obtener decisiones pendientes: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(decisions)
+}
+
+// GetEscalatedCreditDecisionsHandle godoc
+// @Summary      Obtener la cola de aprobaciones escaladas
+// @Description  Retorna las aprobaciones que superaron la atribución de quien decidió y que el usuario autenticado puede resolver según el nivel de acceso de su rol, de la más antigua a la más reciente
+// @Tags         Credit Requests
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200 {array} models.CreditDecision "Decisiones escaladas"
+// @Failure      500 {string} string "Error interno del servidor"
+// @Router       /credit-requests/decisions/escalated [get]
+func GetEscalatedCreditDecisionsHandle(w http.ResponseWriter, r *http.Request) {
+	requesterId := r.Context().Value("requesterId").(uint)
+
+	decisions, err := creditDecisionService.GetEscalatedDecisions(requesterId)
+	if err != nil {
+		writeCreditDecisionError(w, "Error al obtener decisiones escaladas: ", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(decisions)
+}
+
+func writeCreditDecisionError(w http.ResponseWriter, prefix string, err error) {
+	message := err.Error()
+	switch {
+	case strings.Contains(message, "no existe"):
+		http.Error(w, message, http.StatusNotFound)
+	case strings.Contains(message, "pendiente"):
+		http.Error(w, message, http.StatusConflict)
+	case strings.Contains(message, "transición no permitida"), strings.Contains(message, "supera todas las atribuciones"),
+		strings.Contains(message, "exige garantía"), strings.Contains(message, "exige documentos"):
+		http.Error(w, message, http.StatusConflict)
+	case strings.Contains(message, "no puede confirmarla"), strings.Contains(message, "nivel de acceso"),
+		strings.Contains(message, "no puede cambiar el estado"), strings.Contains(message, "no tiene atribución"):
+		http.Error(w, message, http.StatusForbidden)
+	case strings.Contains(message, "justificación"), strings.Contains(message, "la decisión debe ser"):
+		http.Error(w, message, http.StatusBadRequest)
+	default:
+		http.Error(w, prefix+message, http.StatusInternalServerError)
+	}
+}
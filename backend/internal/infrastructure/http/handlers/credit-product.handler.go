@@ -0,0 +1,226 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	creditProduct "github.com/JhonCamargo53/prueba-tecnica/internal/application/services/credit-product"
+	"github.com/JhonCamargo53/prueba-tecnica/internal/domain/models"
+	"github.com/gorilla/mux"
+)
+
+var creditProductService *creditProduct.CreditProductService
+
+func InitCreditProductHandler(s *creditProduct.CreditProductService) {
+	creditProductService = s
+}
+
+// CreditProductRequest representa el cuerpo de la solicitud para crear o actualizar un producto de crédito
+// @Description Configuración de un producto de crédito
+type CreditProductRequest struct {
+	Code               string  `json:"code" example:"VEHICLE"`
+	Name               string  `json:"name" example:"Crédito de vehículo"`
+	Description        string  `json:"description" example:"Compra de vehículo con prenda sobre el bien"`
+	MinAmount          float64 `json:"minAmount" example:"5000000"`
+	MaxAmount          float64 `json:"maxAmount" example:"250000000"`
+	AllowedTerms       []int   `json:"allowedTerms" example:"12,24,36,48,60,72"`
+	BaseAnnualRate     float64 `json:"baseAnnualRate" example:"16.5"`
+	CollateralRequired bool    `json:"collateralRequired" example:"true"`
+	RiskWeight         float64 `json:"riskWeight" example:"0.75"`
+	AmortizationMethod string  `json:"amortizationMethod" example:"FRENCH"`
+	// Categorías de documento exigidas para aprobar: IDENTITY, INCOME_PROOF, BANK_STATEMENT, PROPERTY_DEED, VEHICLE_TITLE u OTHER
+	RequiredDocuments []string `json:"requiredDocuments" example:"IDENTITY,INCOME_PROOF,VEHICLE_TITLE"`
+	Status            *bool    `json:"status" example:"true"`
+}
+
+func (p CreditProductRequest) toModel() models.CreditProduct {
+	// Sin estado explícito el producto queda activo
+	status := true
+	if p.Status != nil {
+		status = *p.Status
+	}
+
+	return models.CreditProduct{
+		Code:               p.Code,
+		Name:               p.Name,
+		Description:        p.Description,
+		MinAmount:          p.MinAmount,
+		MaxAmount:          p.MaxAmount,
+		AllowedTerms:       models.TermList(p.AllowedTerms),
+		BaseAnnualRate:     p.BaseAnnualRate,
+		CollateralRequired: p.CollateralRequired,
+		RiskWeight:         p.RiskWeight,
+		AmortizationMethod: p.AmortizationMethod,
+		RequiredDocuments:  models.DocumentCategoryList(p.RequiredDocuments),
+		Status:             status,
+	}
+}
+
+// GetCreditProductsHandle godoc
+// @Summary      Obtener los productos de crédito
+// @Description  Retorna el catálogo de productos de crédito con sus límites, plazos, tasa base, ponderador de riesgo y método de amortización
+// @Tags         CreditProducts
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200 {array} models.CreditProduct "Lista de productos de crédito"
+// @Failure      500 {string} string "Error interno del servidor"
+// @Router       /credit-products [get]
+func GetCreditProductsHandle(w http.ResponseWriter, r *http.Request) {
+	products, err := creditProductService.GetAllCreditProducts()
+	if err != nil {
+		http.Error(w, "No se pudieron obtener los productos de crédito", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(products)
+}
+
+// GetCreditProductHandle godoc
+// @Summary      Obtener un producto de crédito por ID
+// @Description  Retorna la configuración de un producto de crédito
+// @Tags         CreditProducts
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path int true "ID del producto de crédito"
+// @Success      200 {object} models.CreditProduct "Producto de crédito encontrado"
+// @Failure      400 {string} string "ID inválido"
+// @Failure      404 {string} string "Producto de crédito no encontrado"
+// @Failure      500 {string} string "Error interno del servidor"
+// @Router       /credit-products/{id} [get]
+func GetCreditProductHandle(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil || id <= 0 {
+		http.Error(w, "ID inválido", http.StatusBadRequest)
+		return
+	}
+
+	product, err := creditProductService.GetCreditProductByID(uint(id))
+	if err != nil {
+		writeCreditProductError(w, "Error al obtener producto de crédito: ", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(product)
+}
+
+// PostCreditProductHandle godoc
+// @Summary      Crear un producto de crédito
+// @Description  Crea un producto en el catálogo. Requiere rol ADMIN
+// @Tags         CreditProducts
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request body CreditProductRequest true "Configuración del producto"
+// @Success      201 {object} models.CreditProduct "Producto de crédito creado"
+// @Failure      400 {string} string "Solicitud inválida"
+// @Failure      403 {string} string "Requiere rol ADMIN"
+// @Failure      409 {string} string "El código ya existe"
+// @Failure      500 {string} string "Error interno del servidor"
+// @Router       /credit-products [post]
+func PostCreditProductHandle(w http.ResponseWriter, r *http.Request) {
+	var productData CreditProductRequest
+	if err := json.NewDecoder(r.Body).Decode(&productData); err != nil {
+		http.Error(w, "JSON inválido", http.StatusBadRequest)
+		return
+	}
+
+	product := productData.toModel()
+	created, err := creditProductService.CreateCreditProduct(&product)
+	if err != nil {
+		writeCreditProductError(w, "Error al crear producto de crédito: ", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(created)
+}
+
+// UpdateCreditProductHandle godoc
+// @Summary      Actualizar un producto de crédito
+// @Description  Reemplaza la configuración de un producto. Las solicitudes existentes no se revalidan. Requiere rol ADMIN
+// @Tags         CreditProducts
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path int true "ID del producto de crédito"
+// @Param        request body CreditProductRequest true "Configuración del producto"
+// @Success      200 {object} models.CreditProduct "Producto de crédito actualizado"
+// @Failure      400 {string} string "Solicitud inválida"
+// @Failure      403 {string} string "Requiere rol ADMIN"
+// @Failure      404 {string} string "Producto de crédito no encontrado"
+// @Failure      409 {string} string "El código ya existe"
+// @Failure      500 {string} string "Error interno del servidor"
+// @Router       /credit-products/{id} [put]
+func UpdateCreditProductHandle(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil || id <= 0 {
+		http.Error(w, "ID inválido", http.StatusBadRequest)
+		return
+	}
+
+	var productData CreditProductRequest
+	if err := json.NewDecoder(r.Body).Decode(&productData); err != nil {
+		http.Error(w, "JSON inválido", http.StatusBadRequest)
+		return
+	}
+
+	product := productData.toModel()
+	updated, err := creditProductService.UpdateCreditProduct(uint(id), &product)
+	if err != nil {
+		writeCreditProductError(w, "Error al modificar producto de crédito: ", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(updated)
+}
+
+// DeleteCreditProductHandle godoc
+// @Summary      Eliminar un producto de crédito
+// @Description  Elimina un producto sin solicitudes asociadas. Requiere rol ADMIN
+// @Tags         CreditProducts
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path int true "ID del producto de crédito"
+// @Success      204 "Producto de crédito eliminado"
+// @Failure      400 {string} string "ID inválido"
+// @Failure      403 {string} string "Requiere rol ADMIN"
+// @Failure      404 {string} string "Producto de crédito no encontrado"
+// @Failure      409 {string} string "El producto tiene solicitudes asociadas"
+// @Failure      500 {string} string "Error interno del servidor"
+// @Router       /credit-products/{id} [delete]
+func DeleteCreditProductHandle(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil || id <= 0 {
+		http.Error(w, "ID inválido", http.StatusBadRequest)
+		return
+	}
+
+	if err := creditProductService.DeleteCreditProduct(uint(id)); err != nil {
+		writeCreditProductError(w, "Error al eliminar producto de crédito: ", err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func writeCreditProductError(w http.ResponseWriter, prefix string, err error) {
+	message := err.Error()
+	switch {
+	case strings.Contains(message, "no existe"):
+		http.Error(w, message, http.StatusNotFound)
+	case strings.Contains(message, "ya existe"), strings.Contains(message, "no se puede eliminar"):
+		http.Error(w, message, http.StatusConflict)
+	case strings.Contains(message, "producto"), strings.Contains(message, "plazo"):
+		http.Error(w, message, http.StatusBadRequest)
+	default:
+		http.Error(w, prefix+message, http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,146 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	creditRequestParticipant "github.com/JhonCamargo53/prueba-tecnica/internal/application/services/credit-request-participant"
+	"github.com/gorilla/mux"
+)
+
+var creditRequestParticipantService *creditRequestParticipant.CreditRequestParticipantService
+
+func InitCreditRequestParticipantHandler(s *creditRequestParticipant.CreditRequestParticipantService) {
+	creditRequestParticipantService = s
+}
+
+// CreditRequestParticipantRequest representa un codeudor o fiador de la solicitud
+// @Description Cliente que participa en una solicitud de crédito
+type CreditRequestParticipantRequest struct {
+	CustomerID uint   `json:"customerId" example:"2"`
+	Role       string `json:"role" example:"CO_BORROWER"`
+}
+
+// GetCreditRequestParticipantsHandle godoc
+// @Summary      Participantes de una solicitud de crédito
+// @Description  Lista el titular, los codeudores y los fiadores de la solicitud con los datos de cada cliente
+// @Tags         Credit Requests
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path int true "ID de la solicitud de crédito"
+// @Success      200 {array} models.CreditRequestParticipant
+// @Failure      400 {string} string "ID inválido"
+// @Failure      404 {string} string "Solicitud no encontrada"
+// @Failure      500 {string} string "Error interno del servidor"
+// @Router       /credit-requests/{id}/participants [get]
+func GetCreditRequestParticipantsHandle(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil || id <= 0 {
+		http.Error(w, "ID inválido", http.StatusBadRequest)
+		return
+	}
+
+	participants, err := creditRequestParticipantService.GetParticipants(uint(id))
+	if err != nil {
+		writeCreditRequestParticipantError(w, "Error al obtener los participantes: ", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(participants)
+}
+
+// PostCreditRequestParticipantHandle godoc
+// @Summary      Agregar un codeudor o fiador
+// @Description  Vincula un cliente a la solicitud como codeudor (CO_BORROWER) o fiador (GUARANTOR) y recalcula el riesgo con sus ingresos y bienes ponderados. El titular es el cliente de la solicitud
+// @Tags         Credit Requests
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path int true "ID de la solicitud de crédito"
+// @Param        participant body CreditRequestParticipantRequest true "Participante"
+// @Success      201 {object} models.CreditRequestParticipant "Participante agregado"
+// @Failure      400 {string} string "Rol inválido"
+// @Failure      404 {string} string "Solicitud o cliente no encontrado"
+// @Failure      409 {string} string "El cliente ya participa o la solicitud ya fue decidida"
+// @Failure      500 {string} string "Error interno del servidor"
+// @Router       /credit-requests/{id}/participants [post]
+func PostCreditRequestParticipantHandle(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil || id <= 0 {
+		http.Error(w, "ID inválido", http.StatusBadRequest)
+		return
+	}
+
+	var request CreditRequestParticipantRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		http.Error(w, "JSON inválido", http.StatusBadRequest)
+		return
+	}
+
+	if request.CustomerID == 0 {
+		http.Error(w, "El campo CustomerID es obligatorio", http.StatusBadRequest)
+		return
+	}
+
+	participant, err := creditRequestParticipantService.AddParticipant(uint(id), request.CustomerID, strings.ToUpper(request.Role))
+	if err != nil {
+		writeCreditRequestParticipantError(w, "Error al agregar el participante: ", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(participant)
+}
+
+// DeleteCreditRequestParticipantHandle godoc
+// @Summary      Retirar un codeudor o fiador
+// @Description  Desvincula un codeudor o fiador de la solicitud y recalcula el riesgo. El titular no se puede retirar y los bienes del participante deben retirarse antes
+// @Tags         Credit Requests
+// @Security     BearerAuth
+// @Param        id path int true "ID de la solicitud de crédito"
+// @Param        participantId path int true "ID del participante"
+// @Success      204 "Participante retirado"
+// @Failure      400 {string} string "ID inválido"
+// @Failure      404 {string} string "Solicitud o participante no encontrado"
+// @Failure      409 {string} string "Es el titular, tiene bienes registrados o la solicitud ya fue decidida"
+// @Failure      500 {string} string "Error interno del servidor"
+// @Router       /credit-requests/{id}/participants/{participantId} [delete]
+func DeleteCreditRequestParticipantHandle(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	id, err := strconv.Atoi(params["id"])
+	if err != nil || id <= 0 {
+		http.Error(w, "ID inválido", http.StatusBadRequest)
+		return
+	}
+	participantID, err := strconv.Atoi(params["participantId"])
+	if err != nil || participantID <= 0 {
+		http.Error(w, "ID de participante inválido", http.StatusBadRequest)
+		return
+	}
+
+	if err := creditRequestParticipantService.RemoveParticipant(uint(id), uint(participantID)); err != nil {
+		writeCreditRequestParticipantError(w, "Error al retirar el participante: ", err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func writeCreditRequestParticipantError(w http.ResponseWriter, prefix string, err error) {
+	message := err.Error()
+	switch {
+	case strings.Contains(message, "no existe"):
+		http.Error(w, message, http.StatusNotFound)
+	case strings.Contains(message, "no es válido"):
+		http.Error(w, message, http.StatusBadRequest)
+	case strings.Contains(message, "ya participa"), strings.Contains(message, "ya fue decidida"), strings.Contains(message, "es terminal"),
+		strings.Contains(message, "titular"), strings.Contains(message, "bienes registrados"):
+		http.Error(w, message, http.StatusConflict)
+	default:
+		http.Error(w, prefix+message, http.StatusInternalServerError)
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	creditRequest "github.com/JhonCamargo53/prueba-tecnica/internal/application/services/credit-request"
+	"github.com/JhonCamargo53/prueba-tecnica/internal/domain/i18n"
 	"github.com/JhonCamargo53/prueba-tecnica/internal/domain/models"
 	"github.com/gorilla/mux"
 )
@@ -21,21 +22,23 @@ func InitCreditRequestHandler(s *creditRequest.CreditRequestService) {
 // CreateCreditRequestRequest representa el cuerpo de la solicitud para crear una solicitud de crédito
 // @Description Datos para crear una nueva solicitud de crédito
 type CreateCreditRequestRequest struct {
-	Amount         float64 `json:"amount" example:"10000000"`
-	TermMonths     int     `json:"termMonths" example:"24"`
-	CustomerID     uint    `json:"customerId" example:"1"`
-	ProductType    string  `json:"productType" example:"Préstamo Personal"`
-	CreditStatusID uint    `json:"creditStatusId" example:"1"`
+	Amount          float64 `json:"amount" example:"10000000"`
+	TermMonths      int     `json:"termMonths" example:"24"`
+	InterestRate    float64 `json:"interestRate" example:"24.5"`
+	CustomerID      uint    `json:"customerId" example:"1"`
+	CreditProductID uint    `json:"creditProductId" example:"3"`
+	CreditStatusID  uint    `json:"creditStatusId" example:"1"`
 }
 
 // UpdateCreditRequestRequest representa el cuerpo de la solicitud para actualizar una solicitud de crédito
 // @Description Datos para actualizar una solicitud de crédito existente
 type UpdateCreditRequestRequest struct {
-	Amount         float64 `json:"amount" example:"15000000"`
-	TermMonths     int     `json:"termMonths" example:"36"`
-	CustomerID     uint    `json:"customerId" example:"1"`
-	ProductType    string  `json:"productType" example:"Hipoteca"`
-	CreditStatusID uint    `json:"creditStatusId" example:"2"`
+	Amount          float64 `json:"amount" example:"15000000"`
+	TermMonths      int     `json:"termMonths" example:"36"`
+	InterestRate    float64 `json:"interestRate" example:"13.5"`
+	CustomerID      uint    `json:"customerId" example:"1"`
+	CreditProductID uint    `json:"creditProductId" example:"1"`
+	CreditStatusID  uint    `json:"creditStatusId" example:"2"`
 }
 
 // GetCreditRequestsHandle godoc
@@ -77,14 +80,16 @@ func GetCreditRequestsHandle(w http.ResponseWriter, r *http.Request) {
 
 // GetCreditRequestHandle godoc
 // @Summary      Obtener una solicitud de crédito por ID
-// @Description  Retorna los detalles de una solicitud de crédito específica
+// @Description  Retorna los detalles de una solicitud de crédito específica. La explicación de riesgo se entrega en el idioma pedido (es, en) por parámetro o encabezado Accept-Language
 // @Tags         Credit Requests
 // @Accept       json
 // @Produce      json
 // @Security     BearerAuth
 // @Param        id path int true "ID de la solicitud de crédito"
+// @Param        lang query string false "Idioma de la explicación (es, en)"
+// @Param        Accept-Language header string false "Idioma preferido si no se envía lang"
 // @Success      200 {object} models.CreditRequest "Solicitud de crédito encontrada"
-// @Failure      400 {string} string "ID inválido"
+// @Failure      400 {string} string "ID inválido o idioma no soportado"
 // @Failure      404 {string} string "Solicitud no encontrada"
 // @Failure      500 {string} string "Error interno del servidor"
 // @Router       /credit-requests/{id} [get]
@@ -98,7 +103,13 @@ func GetCreditRequestHandle(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	creditRequest, err := creditRequestService.GetCreditRequestByID(uint(id))
+	lang, ok := i18n.ResolveLanguage(r.URL.Query().Get("lang"), r.Header.Get("Accept-Language"))
+	if !ok {
+		http.Error(w, "Idioma no soportado. Valores permitidos: es, en", http.StatusBadRequest)
+		return
+	}
+
+	creditRequest, err := creditRequestService.GetCreditRequestByIDInLanguage(uint(id), lang)
 	if err != nil {
 		if strings.Contains(err.Error(), "no existe") {
 			http.Error(w, err.Error(), http.StatusNotFound)
@@ -108,6 +119,7 @@ func GetCreditRequestHandle(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	w.Header().Set("Content-Language", lang)
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(creditRequest)
 }
@@ -121,18 +133,19 @@ func GetCreditRequestHandle(w http.ResponseWriter, r *http.Request) {
 // @Security     BearerAuth
 // @Param        request body CreateCreditRequestRequest true "Datos de la solicitud de crédito"
 // @Success      200 {object} models.CreditRequest "Solicitud de crédito creada exitosamente"
-// @Failure      400 {string} string "Solicitud inválida"
-// @Failure      404 {string} string "Cliente o estado de crédito no encontrado"
+// @Failure      400 {string} string "Solicitud inválida, estado inicial no permitido o monto/plazo fuera del producto"
+// @Failure      404 {string} string "Cliente, producto o estado de crédito no encontrado"
 // @Failure      500 {string} string "Error interno del servidor"
 // @Router       /credit-requests [post]
 func PostCreditRequestHandle(w http.ResponseWriter, r *http.Request) {
 
 	var creditRequestData struct {
-		Amount         float64 `json:"amount"`
-		TermMonths     int     ` json:"termMonths"`
-		CustomerID     uint    `json:"customerId"`
-		ProductType    string  `json:"productType"`
-		CreditStatusID uint    `json:"creditStatusId"`
+		Amount          float64 `json:"amount"`
+		TermMonths      int     ` json:"termMonths"`
+		InterestRate    float64 `json:"interestRate"`
+		CustomerID      uint    `json:"customerId"`
+		CreditProductID uint    `json:"creditProductId"`
+		CreditStatusID  uint    `json:"creditStatusId"`
 	}
 
 	err := json.NewDecoder(r.Body).Decode(&creditRequestData)
@@ -152,6 +165,11 @@ func PostCreditRequestHandle(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if creditRequestData.InterestRate < 0 {
+		http.Error(w, "El campo 'Tasa de interés' no puede ser negativo.", http.StatusBadRequest)
+		return
+	}
+
 	if creditRequestData.CustomerID == 0 {
 		http.Error(w, "El campo 'Id del solicitante' es obligatorio.", http.StatusBadRequest)
 		return
@@ -162,19 +180,29 @@ func PostCreditRequestHandle(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if creditRequestData.CreditProductID == 0 {
+		http.Error(w, "El campo 'Producto de crédito' es obligatorio.", http.StatusBadRequest)
+		return
+	}
+
 	creditRequest := models.CreditRequest{
-		Amount:         creditRequestData.Amount,
-		TermMonths:     creditRequestData.TermMonths,
-		CustomerID:     creditRequestData.CustomerID,
-		ProductType:    creditRequestData.ProductType,
-		CreditStatusID: creditRequestData.CreditStatusID,
+		Amount:          creditRequestData.Amount,
+		TermMonths:      creditRequestData.TermMonths,
+		InterestRate:    creditRequestData.InterestRate,
+		CustomerID:      creditRequestData.CustomerID,
+		CreditProductID: &creditRequestData.CreditProductID,
+		CreditStatusID:  creditRequestData.CreditStatusID,
 	}
 
-	createdCreditRequest, err := creditRequestService.CreateCreditRequest(&creditRequest)
+	requesterId := r.Context().Value("requesterId").(uint)
+
+	createdCreditRequest, err := creditRequestService.CreateCreditRequest(&creditRequest, requesterId)
 
 	if err != nil {
 		if strings.Contains(err.Error(), "no existe") {
 			http.Error(w, err.Error(), http.StatusNotFound)
+		} else if strings.Contains(err.Error(), "decisión") || strings.Contains(err.Error(), "estado") || strings.Contains(err.Error(), "producto") {
+			http.Error(w, err.Error(), http.StatusBadRequest)
 		} else {
 			http.Error(w, "Error al crear solicitud de credito: "+err.Error(), http.StatusInternalServerError)
 		}
@@ -196,8 +224,10 @@ func PostCreditRequestHandle(w http.ResponseWriter, r *http.Request) {
 // @Param        id path int true "ID de la solicitud de crédito"
 // @Param        request body UpdateCreditRequestRequest true "Datos actualizados de la solicitud"
 // @Success      200 {object} models.CreditRequest "Solicitud actualizada exitosamente"
-// @Failure      400 {string} string "Solicitud inválida"
+// @Failure      400 {string} string "Solicitud inválida, monto/plazo fuera del producto o cambio a APROBADO/RECHAZADO sin decisión"
+// @Failure      403 {string} string "El rol del usuario no puede hacer el cambio de estado"
 // @Failure      404 {string} string "Solicitud no encontrada"
+// @Failure      409 {string} string "Transición de estado no permitida o solicitud en estado terminal"
 // @Failure      500 {string} string "Error interno del servidor"
 // @Router       /credit-requests/{id} [put]
 func UpdateCreditRequestHandle(w http.ResponseWriter, r *http.Request) {
@@ -210,11 +240,12 @@ func UpdateCreditRequestHandle(w http.ResponseWriter, r *http.Request) {
 	}
 
 	var creditRequestData struct {
-		Amount         float64 `json:"amount"`
-		TermMonths     int     `json:"termMonths"`
-		CustomerID     uint    `json:"customerId"`
-		ProductType    string  `json:"productType"`
-		CreditStatusID uint    `json:"creditStatusId"`
+		Amount          float64 `json:"amount"`
+		TermMonths      int     `json:"termMonths"`
+		InterestRate    float64 `json:"interestRate"`
+		CustomerID      uint    `json:"customerId"`
+		CreditProductID uint    `json:"creditProductId"`
+		CreditStatusID  uint    `json:"creditStatusId"`
 	}
 
 	err = json.NewDecoder(r.Body).Decode(&creditRequestData)
@@ -234,6 +265,11 @@ func UpdateCreditRequestHandle(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if creditRequestData.InterestRate < 0 {
+		http.Error(w, "El campo 'Tasa de interés' no puede ser negativo.", http.StatusBadRequest)
+		return
+	}
+
 	if creditRequestData.CustomerID == 0 {
 		http.Error(w, "El campo 'Id del solicitante' es obligatorio.", http.StatusBadRequest)
 		return
@@ -244,18 +280,32 @@ func UpdateCreditRequestHandle(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if creditRequestData.CreditProductID == 0 {
+		http.Error(w, "El campo 'Producto de crédito' es obligatorio.", http.StatusBadRequest)
+		return
+	}
+
 	creditRequest := models.CreditRequest{
-		Amount:         creditRequestData.Amount,
-		TermMonths:     creditRequestData.TermMonths,
-		CustomerID:     creditRequestData.CustomerID,
-		ProductType:    creditRequestData.ProductType,
-		CreditStatusID: creditRequestData.CreditStatusID,
+		Amount:          creditRequestData.Amount,
+		TermMonths:      creditRequestData.TermMonths,
+		InterestRate:    creditRequestData.InterestRate,
+		CustomerID:      creditRequestData.CustomerID,
+		CreditProductID: &creditRequestData.CreditProductID,
+		CreditStatusID:  creditRequestData.CreditStatusID,
 	}
 
-	updated, err := creditRequestService.UpdateCreditRequest(uint(id), &creditRequest)
+	requesterId := r.Context().Value("requesterId").(uint)
+
+	updated, err := creditRequestService.UpdateCreditRequest(uint(id), &creditRequest, requesterId)
 	if err != nil {
 		if strings.Contains(err.Error(), "no existe") {
 			http.Error(w, err.Error(), http.StatusNotFound)
+		} else if strings.Contains(err.Error(), "transición no permitida") || strings.Contains(err.Error(), "es terminal") {
+			http.Error(w, err.Error(), http.StatusConflict)
+		} else if strings.Contains(err.Error(), "no puede cambiar el estado") {
+			http.Error(w, err.Error(), http.StatusForbidden)
+		} else if strings.Contains(err.Error(), "decisión") || strings.Contains(err.Error(), "flujo de estados") || strings.Contains(err.Error(), "producto") {
+			http.Error(w, err.Error(), http.StatusBadRequest)
 		} else {
 			http.Error(w, "Error al actualizar solicitud de crédito: "+err.Error(), http.StatusInternalServerError)
 		}
@@ -278,6 +328,7 @@ func UpdateCreditRequestHandle(w http.ResponseWriter, r *http.Request) {
 // @Success      204 "Solicitud eliminada exitosamente"
 // @Failure      400 {string} string "ID inválido"
 // @Failure      404 {string} string "Solicitud no encontrada"
+// @Failure      409 {string} string "La solicitud ya fue aprobada o rechazada"
 // @Failure      500 {string} string "Error interno del servidor"
 // @Router       /credit-requests/{id} [delete]
 func DeleteCreditRequestHandle(w http.ResponseWriter, r *http.Request) {
@@ -293,6 +344,8 @@ func DeleteCreditRequestHandle(w http.ResponseWriter, r *http.Request) {
 	if err != nil {
 		if strings.Contains(err.Error(), "no existe") {
 			http.Error(w, err.Error(), http.StatusNotFound)
+		} else if strings.Contains(err.Error(), "ya fue decidida") {
+			http.Error(w, err.Error(), http.StatusConflict)
 		} else {
 			http.Error(w, "Error al eliminar solicitud de credito: "+err.Error(), http.StatusInternalServerError)
 		}
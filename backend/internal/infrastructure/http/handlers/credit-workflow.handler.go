@@ -0,0 +1,50 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	creditWorkflow "github.com/JhonCamargo53/prueba-tecnica/internal/application/services/credit-workflow"
+	"github.com/gorilla/mux"
+)
+
+var creditWorkflowService *creditWorkflow.CreditWorkflowService
+
+func InitCreditWorkflowHandler(s *creditWorkflow.CreditWorkflowService) {
+	creditWorkflowService = s
+}
+
+// GetCreditStatusHistoryHandle godoc
+// @Summary      Historial de estados de una solicitud de crédito
+// @Description  Retorna los cambios de estado de la solicitud, del más antiguo al más reciente, con el usuario, el rol y el origen de cada cambio. Los cambios automáticos se registran con el rol SYSTEM
+// @Tags         Credit Requests
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path int true "ID de la solicitud de crédito"
+// @Success      200 {array} models.CreditStatusHistory
+// @Failure      400 {string} string "ID inválido"
+// @Failure      404 {string} string "Solicitud no encontrada"
+// @Failure      500 {string} string "Error interno del servidor"
+// @Router       /credit-requests/{id}/history [get]
+func GetCreditStatusHistoryHandle(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil || id <= 0 {
+		http.Error(w, "ID inválido", http.StatusBadRequest)
+		return
+	}
+
+	history, err := creditWorkflowService.GetHistory(uint(id))
+	if err != nil {
+		if strings.Contains(err.Error(), "no existe") {
+			http.Error(w, err.Error(), http.StatusNotFound)
+		} else {
+			http.Error(w, "Error al obtener el historial de estados: "+err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(history)
+}
@@ -91,6 +91,7 @@ func GetCustomerAssetsHandle(w http.ResponseWriter, r *http.Request) {
 // @Success      201 {object} models.CustomerAsset "Bien creado exitosamente"
 // @Failure      400 {string} string "Solicitud inválida"
 // @Failure      404 {string} string "Cliente, activo o solicitud no encontrada"
+// @Failure      409 {string} string "La solicitud está en estado terminal"
 // @Failure      500 {string} string "Error interno del servidor"
 // @Router       /customer-assets [post]
 func PostCustomerAssetHandle(w http.ResponseWriter, r *http.Request) {
@@ -149,6 +150,8 @@ func PostCustomerAssetHandle(w http.ResponseWriter, r *http.Request) {
 	if err != nil {
 		if strings.Contains(err.Error(), "no existe") {
 			http.Error(w, err.Error(), http.StatusNotFound)
+		} else if strings.Contains(err.Error(), "es terminal") {
+			http.Error(w, err.Error(), http.StatusConflict)
 		} else {
 			http.Error(w, "Error al crear bien del cliente: "+err.Error(), http.StatusInternalServerError)
 		}
@@ -172,6 +175,7 @@ func PostCustomerAssetHandle(w http.ResponseWriter, r *http.Request) {
 // @Success      201 {object} models.CustomerAsset "Bien actualizado exitosamente"
 // @Failure      400 {string} string "Solicitud inválida"
 // @Failure      404 {string} string "Bien no encontrado"
+// @Failure      409 {string} string "La solicitud está en estado terminal"
 // @Failure      500 {string} string "Error interno del servidor"
 // @Router       /customer-assets/{id} [put]
 func UpdateCustomerAssetHandle(w http.ResponseWriter, r *http.Request) {
@@ -236,6 +240,8 @@ func UpdateCustomerAssetHandle(w http.ResponseWriter, r *http.Request) {
 	if err != nil {
 		if strings.Contains(err.Error(), "no existe") {
 			http.Error(w, err.Error(), http.StatusNotFound)
+		} else if strings.Contains(err.Error(), "es terminal") {
+			http.Error(w, err.Error(), http.StatusConflict)
 		} else {
 			http.Error(w, "Error al actualizar bien del cliente: "+err.Error(), http.StatusInternalServerError)
 		}
@@ -258,6 +264,7 @@ func UpdateCustomerAssetHandle(w http.ResponseWriter, r *http.Request) {
 // @Success      204 "Bien eliminado exitosamente"
 // @Failure      400 {string} string "ID inválido"
 // @Failure      404 {string} string "Bien no encontrado"
+// @Failure      409 {string} string "La solicitud está en estado terminal"
 // @Failure      500 {string} string "Error interno del servidor"
 // @Router       /customer-assets/{id} [delete]
 func DeleteCustomerAssetHandle(w http.ResponseWriter, r *http.Request) {
@@ -273,6 +280,8 @@ func DeleteCustomerAssetHandle(w http.ResponseWriter, r *http.Request) {
 	if err != nil {
 		if strings.Contains(err.Error(), "no existe") {
 			http.Error(w, err.Error(), http.StatusNotFound)
+		} else if strings.Contains(err.Error(), "es terminal") {
+			http.Error(w, err.Error(), http.StatusConflict)
 		} else {
 			http.Error(w, "Error al eliminar usuario: "+err.Error(), http.StatusInternalServerError)
 		}
@@ -0,0 +1,246 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/JhonCamargo53/prueba-tecnica/internal/application/services/document"
+	"github.com/gorilla/mux"
+)
+
+var documentService *document.DocumentService
+
+func InitDocumentHandler(s *document.DocumentService) {
+	documentService = s
+}
+
+// PostDocumentHandle godoc
+// @Summary      Adjuntar un documento
+// @Description  Sube un archivo PDF, JPEG o PNG y lo adjunta a un cliente, una solicitud de crédito o un bien del cliente. El tipo se valida según el contenido del archivo y se guarda su suma SHA-256
+// @Tags         Documents
+// @Accept       multipart/form-data
+// @Produce      json
+// @Security     BearerAuth
+// @Param        ownerType formData string true "Entidad: CUSTOMER, CREDIT_REQUEST o CUSTOMER_ASSET"
+// @Param        ownerId formData int true "ID de la entidad"
+// @Param        category formData string true "Categoría: IDENTITY, INCOME_PROOF, BANK_STATEMENT, PROPERTY_DEED, VEHICLE_TITLE u OTHER"
+// @Param        file formData file true "Archivo"
+// @Success      201 {object} models.Document "Documento adjuntado"
+// @Failure      400 {string} string "Formulario inválido, categoría o entidad inválidas, o archivo vacío"
+// @Failure      404 {string} string "Cliente, solicitud o bien no encontrado"
+// @Failure      413 {string} string "El archivo supera el tamaño máximo"
+// @Failure      415 {string} string "Tipo de archivo no permitido"
+// @Failure      500 {string} string "Error interno del servidor"
+// @Router       /documents [post]
+func PostDocumentHandle(w http.ResponseWriter, r *http.Request) {
+	// Se deja un margen de 1 MB para los demás campos del formulario
+	r.Body = http.MaxBytesReader(w, r.Body, documentService.MaxSize()+1<<20)
+	if err := r.ParseMultipartForm(1 << 20); err != nil {
+		var maxBytesError *http.MaxBytesError
+		if errors.As(err, &maxBytesError) {
+			http.Error(w, "El archivo supera el tamaño máximo permitido", http.StatusRequestEntityTooLarge)
+			return
+		}
+		http.Error(w, "Formulario multipart inválido", http.StatusBadRequest)
+		return
+	}
+	defer r.MultipartForm.RemoveAll()
+
+	ownerID, err := strconv.ParseUint(r.FormValue("ownerId"), 10, 32)
+	if err != nil || ownerID == 0 {
+		http.Error(w, "ownerId inválido", http.StatusBadRequest)
+		return
+	}
+
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		http.Error(w, "El archivo es obligatorio (campo file)", http.StatusBadRequest)
+		return
+	}
+	defer file.Close()
+
+	requesterId := r.Context().Value("requesterId").(uint)
+
+	doc, err := documentService.UploadDocument(r.FormValue("ownerType"), uint(ownerID), r.FormValue("category"),
+		header.Filename, header.Header.Get("Content-Type"), file, requesterId)
+	if err != nil {
+		writeDocumentError(w, "Error al adjuntar el documento: ", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(doc)
+}
+
+// GetDocumentsHandle godoc
+// @Summary      Documentos de una entidad
+// @Description  Lista los documentos adjuntos a un cliente, una solicitud de crédito o un bien del cliente
+// @Tags         Documents
+// @Produce      json
+// @Security     BearerAuth
+// @Param        ownerType query string true "Entidad: CUSTOMER, CREDIT_REQUEST o CUSTOMER_ASSET"
+// @Param        ownerId query int true "ID de la entidad"
+// @Success      200 {array} models.Document
+// @Failure      400 {string} string "Entidad inválida"
+// @Failure      404 {string} string "Cliente, solicitud o bien no encontrado"
+// @Failure      500 {string} string "Error interno del servidor"
+// @Router       /documents [get]
+func GetDocumentsHandle(w http.ResponseWriter, r *http.Request) {
+	ownerID, err := strconv.ParseUint(r.URL.Query().Get("ownerId"), 10, 32)
+	if err != nil || ownerID == 0 {
+		http.Error(w, "ownerId inválido", http.StatusBadRequest)
+		return
+	}
+
+	documents, err := documentService.GetDocuments(r.URL.Query().Get("ownerType"), uint(ownerID))
+	if err != nil {
+		writeDocumentError(w, "Error al obtener los documentos: ", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(documents)
+}
+
+// GetDocumentHandle godoc
+// @Summary      Obtener un documento
+// @Description  Retorna los metadatos de un documento adjunto: entidad, categoría, nombre, tipo, tamaño y suma SHA-256
+// @Tags         Documents
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path int true "ID del documento"
+// @Success      200 {object} models.Document
+// @Failure      400 {string} string "ID inválido"
+// @Failure      404 {string} string "Documento no encontrado"
+// @Failure      500 {string} string "Error interno del servidor"
+// @Router       /documents/{id} [get]
+func GetDocumentHandle(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil || id <= 0 {
+		http.Error(w, "ID inválido", http.StatusBadRequest)
+		return
+	}
+
+	doc, err := documentService.GetDocument(uint(id))
+	if err != nil {
+		writeDocumentError(w, "Error al obtener el documento: ", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(doc)
+}
+
+// DownloadDocumentHandle godoc
+// @Summary      Descargar un documento
+// @Description  Retorna el archivo del documento tras verificar su suma SHA-256, que se incluye en el encabezado X-Checksum-SHA256
+// @Tags         Documents
+// @Produce      application/pdf
+// @Produce      image/jpeg
+// @Produce      image/png
+// @Security     BearerAuth
+// @Param        id path int true "ID del documento"
+// @Success      200 {file} file "Archivo"
+// @Failure      400 {string} string "ID inválido"
+// @Failure      404 {string} string "Documento no encontrado"
+// @Failure      500 {string} string "El archivo no coincide con su suma SHA-256 o error interno"
+// @Router       /documents/{id}/download [get]
+func DownloadDocumentHandle(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil || id <= 0 {
+		http.Error(w, "ID inválido", http.StatusBadRequest)
+		return
+	}
+
+	doc, data, err := documentService.DownloadDocument(uint(id))
+	if err != nil {
+		writeDocumentError(w, "Error al descargar el documento: ", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", doc.ContentType)
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": doc.FileName}))
+	w.Header().Set("Content-Length", strconv.Itoa(len(data)))
+	w.Header().Set("X-Checksum-SHA256", doc.SHA256)
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.Write(data)
+}
+
+// DeleteDocumentHandle godoc
+// @Summary      Eliminar un documento
+// @Description  Elimina el documento y su archivo. Los documentos de una solicitud ya decidida, o de sus bienes, no se pueden eliminar
+// @Tags         Documents
+// @Security     BearerAuth
+// @Param        id path int true "ID del documento"
+// @Success      204 "Documento eliminado"
+// @Failure      400 {string} string "ID inválido"
+// @Failure      404 {string} string "Documento no encontrado"
+// @Failure      409 {string} string "La solicitud ya fue decidida"
+// @Failure      500 {string} string "Error interno del servidor"
+// @Router       /documents/{id} [delete]
+func DeleteDocumentHandle(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil || id <= 0 {
+		http.Error(w, "ID inválido", http.StatusBadRequest)
+		return
+	}
+
+	if err := documentService.DeleteDocument(uint(id)); err != nil {
+		writeDocumentError(w, "Error al eliminar el documento: ", err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetCreditRequestDocumentChecklistHandle godoc
+// @Summary      Documentos exigidos de una solicitud
+// @Description  Compara los documentos que exige el producto de la solicitud con los adjuntos a la solicitud, a su titular y a los bienes asociados. La solicitud no se puede aprobar mientras falte alguno
+// @Tags         Documents
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path int true "ID de la solicitud de crédito"
+// @Success      200 {object} document.DocumentChecklist
+// @Failure      400 {string} string "ID inválido"
+// @Failure      404 {string} string "Solicitud no encontrada"
+// @Failure      500 {string} string "Error interno del servidor"
+// @Router       /credit-requests/{id}/documents/checklist [get]
+func GetCreditRequestDocumentChecklistHandle(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil || id <= 0 {
+		http.Error(w, "ID inválido", http.StatusBadRequest)
+		return
+	}
+
+	checklist, err := documentService.GetChecklist(uint(id))
+	if err != nil {
+		writeDocumentError(w, "Error al obtener los documentos exigidos: ", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(checklist)
+}
+
+func writeDocumentError(w http.ResponseWriter, prefix string, err error) {
+	message := err.Error()
+	switch {
+	case strings.Contains(message, "no existe"):
+		http.Error(w, message, http.StatusNotFound)
+	case strings.Contains(message, "tamaño máximo"):
+		http.Error(w, message, http.StatusRequestEntityTooLarge)
+	case strings.Contains(message, "no está permitido"), strings.Contains(message, "no coincide con el contenido"):
+		http.Error(w, message, http.StatusUnsupportedMediaType)
+	case strings.Contains(message, "ya fue decidida"):
+		http.Error(w, message, http.StatusConflict)
+	case strings.Contains(message, "no es válid"), strings.Contains(message, "está vacío"):
+		http.Error(w, message, http.StatusBadRequest)
+	default:
+		http.Error(w, prefix+message, http.StatusInternalServerError)
+	}
+}
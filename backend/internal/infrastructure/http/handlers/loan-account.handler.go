@@ -0,0 +1,197 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	loanAccount "github.com/JhonCamargo53/prueba-tecnica/internal/application/services/loan-account"
+	"github.com/JhonCamargo53/prueba-tecnica/internal/domain/models"
+	"github.com/gorilla/mux"
+)
+
+var loanAccountService *loanAccount.LoanAccountService
+
+func InitLoanAccountHandler(s *loanAccount.LoanAccountService) {
+	loanAccountService = s
+}
+
+// LoanPaymentRequest representa un pago recibido de un crédito
+// @Description Pago de un crédito desembolsado
+type LoanPaymentRequest struct {
+	Amount float64 `json:"amount" example:"700000"`
+	// Fecha del pago en RFC3339; si se omite se usa la fecha actual
+	PaidAt    *time.Time `json:"paidAt" example:"2026-03-10T15:04:05Z"`
+	Reference string     `json:"reference" example:"Consignación 123"`
+}
+
+// LoanAccountResponse incluye el plan de pagos, que no se serializa con la cuenta
+// @Description Cuenta del crédito con su plan de pagos
+type LoanAccountResponse struct {
+	models.LoanAccount
+	PaymentSchedule models.PaymentSchedule `json:"paymentSchedule"`
+}
+
+// GetLoanAccountHandle godoc
+// @Summary      Cuenta del crédito de una solicitud aprobada
+// @Description  Retorna la cuenta abierta al aprobar la solicitud: saldo de capital, totales pagados, días de mora, franja de mora y el plan de pagos con lo pagado en cada cuota
+// @Tags         Loan Accounts
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path int true "ID de la solicitud de crédito"
+// @Success      200 {object} LoanAccountResponse
+// @Failure      400 {string} string "ID inválido"
+// @Failure      404 {string} string "Solicitud no encontrada"
+// @Failure      409 {string} string "La solicitud no está aprobada o aún no tiene cuenta"
+// @Failure      500 {string} string "Error interno del servidor"
+// @Router       /credit-requests/{id}/loan [get]
+func GetLoanAccountHandle(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil || id <= 0 {
+		http.Error(w, "ID inválido", http.StatusBadRequest)
+		return
+	}
+
+	account, err := loanAccountService.GetLoanAccount(uint(id))
+	if err != nil {
+		writeLoanAccountError(w, "Error al obtener la cuenta del crédito: ", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(LoanAccountResponse{LoanAccount: *account, PaymentSchedule: account.PaymentSchedule})
+}
+
+// GetLoanPaymentsHandle godoc
+// @Summary      Pagos de un crédito
+// @Description  Lista los pagos registrados del crédito de la solicitud, con su distribución entre interés y capital por cuota
+// @Tags         Loan Accounts
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path int true "ID de la solicitud de crédito"
+// @Success      200 {array} models.LoanPayment
+// @Failure      400 {string} string "ID inválido"
+// @Failure      404 {string} string "Solicitud no encontrada"
+// @Failure      409 {string} string "La solicitud no está aprobada"
+// @Failure      500 {string} string "Error interno del servidor"
+// @Router       /credit-requests/{id}/payments [get]
+func GetLoanPaymentsHandle(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil || id <= 0 {
+		http.Error(w, "ID inválido", http.StatusBadRequest)
+		return
+	}
+
+	payments, err := loanAccountService.GetPayments(uint(id))
+	if err != nil {
+		writeLoanAccountError(w, "Error al obtener los pagos: ", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(payments)
+}
+
+// PostLoanPaymentHandle godoc
+// @Summary      Registrar un pago
+// @Description  Aplica el pago a las cuotas en orden de vencimiento, primero al interés y luego al capital; el excedente abona a las cuotas siguientes. Actualiza el saldo y la mora del crédito
+// @Tags         Loan Accounts
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path int true "ID de la solicitud de crédito"
+// @Param        payment body LoanPaymentRequest true "Pago"
+// @Success      201 {object} models.LoanPayment "Pago registrado"
+// @Failure      400 {string} string "Valor o fecha inválidos, o el pago supera el saldo"
+// @Failure      404 {string} string "Solicitud no encontrada"
+// @Failure      409 {string} string "La solicitud no está aprobada o el crédito ya está pagado"
+// @Failure      500 {string} string "Error interno del servidor"
+// @Router       /credit-requests/{id}/payments [post]
+func PostLoanPaymentHandle(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil || id <= 0 {
+		http.Error(w, "ID inválido", http.StatusBadRequest)
+		return
+	}
+
+	var request LoanPaymentRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		http.Error(w, "JSON inválido", http.StatusBadRequest)
+		return
+	}
+
+	var paidAt time.Time
+	if request.PaidAt != nil {
+		paidAt = *request.PaidAt
+	}
+
+	requesterId := r.Context().Value("requesterId").(uint)
+
+	payment, err := loanAccountService.RegisterPayment(uint(id), request.Amount, paidAt, request.Reference, requesterId)
+	if err != nil {
+		writeLoanAccountError(w, "Error al registrar el pago: ", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(payment)
+}
+
+// GetDelinquentLoanAccountsHandle godoc
+// @Summary      Créditos en mora
+// @Description  Lista los créditos activos con días de mora según el último cálculo, de mayor a menor mora
+// @Tags         Loan Accounts
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200 {array} models.LoanAccount
+// @Failure      500 {string} string "Error interno del servidor"
+// @Router       /loan-accounts/delinquent [get]
+func GetDelinquentLoanAccountsHandle(w http.ResponseWriter, r *http.Request) {
+	accounts, err := loanAccountService.GetDelinquentAccounts()
+	if err != nil {
+		http.Error(w, "Error al obtener los créditos en mora: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(accounts)
+}
+
+// ComputeLoanDelinquencyHandle godoc
+// @Summary      Calcular la mora de la cartera
+// @Description  Recalcula los días y la franja de mora (1-29, 30-59, 60-89, 90+) de los créditos activos, sin esperar al cálculo diario
+// @Tags         Loan Accounts
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200 {object} loanAccount.DelinquencySummary "Resumen por franja"
+// @Failure      500 {string} string "Error interno del servidor"
+// @Router       /loan-accounts/delinquency [post]
+func ComputeLoanDelinquencyHandle(w http.ResponseWriter, r *http.Request) {
+	summary, err := loanAccountService.UpdateDelinquency(time.Now())
+	if err != nil {
+		http.Error(w, "Error al calcular la mora: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(summary)
+}
+
+func writeLoanAccountError(w http.ResponseWriter, prefix string, err error) {
+	message := err.Error()
+	switch {
+	case strings.Contains(message, "no existe"):
+		http.Error(w, message, http.StatusNotFound)
+	case strings.Contains(message, "no está aprobada"), strings.Contains(message, "no tiene cuenta"),
+		strings.Contains(message, "ya está pagado"):
+		http.Error(w, message, http.StatusConflict)
+	case strings.Contains(message, "mayor que cero"), strings.Contains(message, "fecha del pago"),
+		strings.Contains(message, "supera el saldo"):
+		http.Error(w, message, http.StatusBadRequest)
+	default:
+		http.Error(w, prefix+message, http.StatusInternalServerError)
+	}
+}
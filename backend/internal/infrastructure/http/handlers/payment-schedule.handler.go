@@ -0,0 +1,100 @@
+package handlers
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	paymentSchedule "github.com/JhonCamargo53/prueba-tecnica/internal/application/services/payment-schedule"
+	"github.com/JhonCamargo53/prueba-tecnica/internal/domain/models"
+	"github.com/gorilla/mux"
+)
+
+var paymentScheduleService *paymentSchedule.PaymentScheduleService
+
+func InitPaymentScheduleHandler(s *paymentSchedule.PaymentScheduleService) {
+	paymentScheduleService = s
+}
+
+// GetPaymentScheduleHandle godoc
+// @Summary      Plan de pagos de una solicitud aprobada
+// @Description  Retorna el plan de amortización generado al aprobar la solicitud: capital, interés, cuota y saldo de cada cuota con su fecha de vencimiento. Con format=csv (o Accept: text/csv) se descarga una fila por cuota
+// @Tags         Credit Requests
+// @Produce      json
+// @Produce      text/csv
+// @Security     BearerAuth
+// @Param        id path int true "ID de la solicitud de crédito"
+// @Param        format query string false "json (por defecto) o csv"
+// @Success      200 {object} models.PaymentSchedule
+// @Failure      400 {string} string "ID o formato inválido"
+// @Failure      404 {string} string "Solicitud no encontrada"
+// @Failure      409 {string} string "La solicitud no está aprobada"
+// @Failure      500 {string} string "Error interno del servidor"
+// @Router       /credit-requests/{id}/schedule [get]
+func GetPaymentScheduleHandle(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil || id <= 0 {
+		http.Error(w, "ID inválido", http.StatusBadRequest)
+		return
+	}
+
+	format := strings.ToLower(r.URL.Query().Get("format"))
+	if format == "" && strings.Contains(r.Header.Get("Accept"), "text/csv") {
+		format = "csv"
+	}
+	if format != "" && format != "json" && format != "csv" {
+		http.Error(w, "format debe ser json o csv", http.StatusBadRequest)
+		return
+	}
+
+	schedule, err := paymentScheduleService.GetSchedule(uint(id))
+	if err != nil {
+		switch {
+		case strings.Contains(err.Error(), "no existe"):
+			http.Error(w, err.Error(), http.StatusNotFound)
+		case strings.Contains(err.Error(), "no está aprobada"):
+			http.Error(w, err.Error(), http.StatusConflict)
+		default:
+			http.Error(w, "Error al obtener el plan de pagos: "+err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+
+	if format == "csv" {
+		w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"plan-de-pagos-%d.csv\"", id))
+		if err := writeScheduleCSV(w, schedule); err != nil {
+			http.Error(w, "Error al exportar el plan de pagos: "+err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(schedule)
+}
+
+// writeScheduleCSV exporta una fila por cuota; las fechas van en formato YYYY-MM-DD.
+func writeScheduleCSV(w io.Writer, schedule *models.PaymentSchedule) error {
+	writer := csv.NewWriter(w)
+
+	rows := [][]string{{"number", "dueDate", "payment", "principal", "interest", "balance"}}
+	for _, installment := range schedule.Installments {
+		rows = append(rows, []string{
+			strconv.Itoa(installment.Number),
+			installment.DueDate.Format("2006-01-02"),
+			strconv.FormatFloat(installment.Payment, 'f', 2, 64),
+			strconv.FormatFloat(installment.Principal, 'f', 2, 64),
+			strconv.FormatFloat(installment.Interest, 'f', 2, 64),
+			strconv.FormatFloat(installment.Balance, 'f', 2, 64),
+		})
+	}
+
+	if err := writer.WriteAll(rows); err != nil {
+		return err
+	}
+	return writer.Error()
+}
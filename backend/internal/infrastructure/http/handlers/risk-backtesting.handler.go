@@ -0,0 +1,79 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	riskBacktesting "github.com/JhonCamargo53/prueba-tecnica/internal/application/services/risk-backtesting"
+	"github.com/JhonCamargo53/prueba-tecnica/internal/domain/backtesting"
+)
+
+var riskBacktestingService *riskBacktesting.RiskBacktestingService
+
+func InitRiskBacktestingHandler(s *riskBacktesting.RiskBacktestingService) {
+	riskBacktestingService = s
+}
+
+// GetRiskBacktestingHandle godoc
+// @Summary      Back-testing de un motor de riesgo
+// @Description  Re-evalúa con el motor indicado las solicitudes APROBADAS y RECHAZADAS del rango de fechas y compara el resultado con la decisión final: matriz de confusión, AUC/Gini, KS y bandas de puntaje
+// @Tags         Risk Engines
+// @Accept       json
+// @Produce      json
+// @Produce      text/csv
+// @Security     BearerAuth
+// @Param        engine query string false "Motor configurado (mock, mock-challenger, scorecard, http); por defecto el champion"
+// @Param        from query string false "Fecha inicial de creación (YYYY-MM-DD)"
+// @Param        to query string false "Fecha final de creación inclusive (YYYY-MM-DD)"
+// @Param        bands query int false "Número de bandas de puntaje (por defecto 10)"
+// @Param        format query string false "json (por defecto) o csv"
+// @Success      200 {object} models.BacktestReport "Reporte de back-testing"
+// @Failure      400 {string} string "Parámetros inválidos"
+// @Failure      500 {string} string "Error interno del servidor"
+// @Router       /risk-engines/backtesting [get]
+func GetRiskBacktestingHandle(w http.ResponseWriter, r *http.Request) {
+	from, to, err := parseDateRange(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	bands := 0
+	if value := r.URL.Query().Get("bands"); value != "" {
+		if bands, err = strconv.Atoi(value); err != nil || bands <= 0 {
+			http.Error(w, "bands debe ser un entero positivo", http.StatusBadRequest)
+			return
+		}
+	}
+
+	format := strings.ToLower(r.URL.Query().Get("format"))
+	if format != "" && format != "json" && format != "csv" {
+		http.Error(w, "format debe ser json o csv", http.StatusBadRequest)
+		return
+	}
+
+	report, err := riskBacktestingService.Backtest(r.URL.Query().Get("engine"), from, to, bands)
+	if err != nil {
+		if strings.Contains(err.Error(), "desconocido") || strings.Contains(err.Error(), "bandas") {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		} else {
+			http.Error(w, "Error al ejecutar el back-testing: "+err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+
+	if format == "csv" {
+		w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"backtesting-%s.csv\"", report.Engine))
+		if err := backtesting.WriteCSV(w, report); err != nil {
+			http.Error(w, "Error al exportar el back-testing: "+err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(report)
+}
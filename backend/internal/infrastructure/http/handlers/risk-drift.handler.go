@@ -0,0 +1,95 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	riskDrift "github.com/JhonCamargo53/prueba-tecnica/internal/application/services/risk-drift"
+	"github.com/gorilla/mux"
+)
+
+var riskDriftService *riskDrift.RiskDriftService
+
+func InitRiskDriftHandler(s *riskDrift.RiskDriftService) {
+	riskDriftService = s
+}
+
+// GetRiskDriftReportsHandle godoc
+// @Summary      Listar reportes de estabilidad poblacional
+// @Description  Retorna los reportes de PSI/CSI calculados, del más reciente al más antiguo
+// @Tags         Risk Engines
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200 {array} models.RiskDriftReport "Reportes de estabilidad"
+// @Failure      500 {string} string "Error interno del servidor"
+// @Router       /risk-engines/drift [get]
+func GetRiskDriftReportsHandle(w http.ResponseWriter, r *http.Request) {
+	reports, err := riskDriftService.GetReports()
+	if err != nil {
+		http.Error(w, "Error al obtener reportes de estabilidad: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(reports)
+}
+
+// GetRiskDriftReportHandle godoc
+// @Summary      Obtener un reporte de estabilidad poblacional
+// @Description  Retorna el PSI del puntaje y el CSI de cada entrada del motor, con sus bins
+// @Tags         Risk Engines
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path int true "ID del reporte"
+// @Success      200 {object} models.RiskDriftReport "Reporte de estabilidad"
+// @Failure      400 {string} string "ID inválido"
+// @Failure      404 {string} string "Reporte no encontrado"
+// @Failure      500 {string} string "Error interno del servidor"
+// @Router       /risk-engines/drift/{id} [get]
+func GetRiskDriftReportHandle(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil || id <= 0 {
+		http.Error(w, "ID inválido", http.StatusBadRequest)
+		return
+	}
+
+	report, err := riskDriftService.GetReportByID(uint(id))
+	if err != nil {
+		if strings.Contains(err.Error(), "no existe") {
+			http.Error(w, err.Error(), http.StatusNotFound)
+		} else {
+			http.Error(w, "Error al obtener el reporte de estabilidad: "+err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(report)
+}
+
+// ComputeRiskDriftHandle godoc
+// @Summary      Calcular la estabilidad poblacional
+// @Description  Calcula y guarda un reporte de PSI/CSI del período actual frente al período base, sin esperar al cálculo programado
+// @Tags         Risk Engines
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Success      201 {object} models.RiskDriftReport "Reporte calculado"
+// @Failure      500 {string} string "Error interno del servidor"
+// @Router       /risk-engines/drift [post]
+func ComputeRiskDriftHandle(w http.ResponseWriter, r *http.Request) {
+	report, err := riskDriftService.Compute(time.Now())
+	if err != nil {
+		http.Error(w, "Error al calcular la estabilidad poblacional: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(report)
+}
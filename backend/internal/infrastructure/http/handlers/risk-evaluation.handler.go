@@ -0,0 +1,114 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	riskEvaluation "github.com/JhonCamargo53/prueba-tecnica/internal/application/services/risk-evaluation"
+	"github.com/gorilla/mux"
+)
+
+var riskEvaluationService *riskEvaluation.RiskEvaluationService
+
+func InitRiskEvaluationHandler(s *riskEvaluation.RiskEvaluationService) {
+	riskEvaluationService = s
+}
+
+// GetCreditRequestEvaluationsHandle godoc
+// @Summary      Obtener el historial de evaluaciones de riesgo
+// @Description  Retorna todas las evaluaciones de riesgo realizadas a una solicitud de crédito, de la más reciente a la más antigua
+// @Tags         Credit Requests
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path int true "ID de la solicitud de crédito"
+// @Success      200 {array} models.RiskEvaluation "Historial de evaluaciones"
+// @Failure      400 {string} string "ID inválido"
+// @Failure      404 {string} string "Solicitud no encontrada"
+// @Failure      500 {string} string "Error interno del servidor"
+// @Router       /credit-requests/{id}/evaluations [get]
+func GetCreditRequestEvaluationsHandle(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	id, err := strconv.Atoi(params["id"])
+
+	if err != nil || id <= 0 {
+		http.Error(w, "ID inválido", http.StatusBadRequest)
+		return
+	}
+
+	evaluations, err := riskEvaluationService.GetEvaluationsByCreditRequestID(uint(id))
+	if err != nil {
+		if strings.Contains(err.Error(), "no existe") {
+			http.Error(w, err.Error(), http.StatusNotFound)
+		} else {
+			http.Error(w, "Error al obtener historial de evaluaciones: "+err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(evaluations)
+}
+
+// GetRiskEngineComparisonHandle godoc
+// @Summary      Comparar motores champion y challenger
+// @Description  Retorna, por motor challenger y versión, la tasa de acuerdo de categoría y recomendación con el champion y las diferencias de puntaje
+// @Tags         Risk Engines
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        from query string false "Fecha inicial (YYYY-MM-DD)"
+// @Param        to query string false "Fecha final inclusive (YYYY-MM-DD)"
+// @Success      200 {array} models.EngineComparison "Comparación de motores"
+// @Failure      400 {string} string "Rango de fechas inválido"
+// @Failure      500 {string} string "Error interno del servidor"
+// @Router       /risk-engines/comparison [get]
+func GetRiskEngineComparisonHandle(w http.ResponseWriter, r *http.Request) {
+	from, to, err := parseDateRange(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	comparisons, err := riskEvaluationService.CompareEngines(from, to)
+	if err != nil {
+		http.Error(w, "Error al comparar motores de riesgo: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(comparisons)
+}
+
+// parseDateRange lee los parámetros from y to (YYYY-MM-DD). El día final es inclusivo,
+// por eso se retorna el inicio del día siguiente como límite exclusivo.
+func parseDateRange(r *http.Request) (*time.Time, *time.Time, error) {
+	var from, to *time.Time
+
+	if value := r.URL.Query().Get("from"); value != "" {
+		parsed, err := time.Parse("2006-01-02", value)
+		if err != nil {
+			return nil, nil, fmt.Errorf("from inválido, formato esperado YYYY-MM-DD")
+		}
+		from = &parsed
+	}
+
+	if value := r.URL.Query().Get("to"); value != "" {
+		parsed, err := time.Parse("2006-01-02", value)
+		if err != nil {
+			return nil, nil, fmt.Errorf("to inválido, formato esperado YYYY-MM-DD")
+		}
+		end := parsed.AddDate(0, 0, 1)
+		to = &end
+	}
+
+	if from != nil && to != nil && !from.Before(*to) {
+		return nil, nil, fmt.Errorf("from debe ser anterior o igual a to")
+	}
+
+	return from, to, nil
+}
@@ -0,0 +1,177 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	riskReport "github.com/JhonCamargo53/prueba-tecnica/internal/application/services/risk-report"
+	"github.com/JhonCamargo53/prueba-tecnica/internal/infrastructure/logger"
+	"github.com/gorilla/mux"
+)
+
+var riskReportService *riskReport.RiskReportService
+
+func InitRiskReportHandler(s *riskReport.RiskReportService) {
+	riskReportService = s
+}
+
+// GetRiskReportHandle godoc
+// @Summary      Obtener un reporte de riesgo firmado
+// @Description  Retorna el reporte firmado de una evaluación: el contenido en JSON canónico (puntaje, categoría, explicación y hash de los datos de entrada), su hash SHA-256, la firma Ed25519 y el hash del reporte anterior en la cadena
+// @Tags         Risk Reports
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path int true "ID del reporte"
+// @Success      200 {object} models.RiskReport
+// @Failure      400 {string} string "ID inválido"
+// @Failure      404 {string} string "Reporte no encontrado"
+// @Failure      500 {string} string "Error interno del servidor"
+// @Router       /risk-reports/{id} [get]
+func GetRiskReportHandle(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil || id <= 0 {
+		http.Error(w, "ID inválido", http.StatusBadRequest)
+		return
+	}
+
+	report, err := riskReportService.GetReport(uint(id))
+	if err != nil {
+		writeRiskReportError(w, "Error al obtener el reporte de riesgo: ", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(report)
+}
+
+// VerifyRiskReportHandle godoc
+// @Summary      Verificar un reporte de riesgo
+// @Description  Verifica el hash y la firma del reporte, su enlace con los reportes anterior y siguiente, y que la evaluación guardada y, si es la más reciente, el puntaje, la categoría y la explicación de la solicitud sigan coincidiendo con lo firmado. valid es false si alguna verificación falla
+// @Tags         Risk Reports
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path int true "ID del reporte"
+// @Success      200 {object} riskReport.RiskReportVerification
+// @Failure      400 {string} string "ID inválido"
+// @Failure      404 {string} string "Reporte no encontrado"
+// @Failure      500 {string} string "Error interno del servidor"
+// @Router       /risk-reports/{id}/verify [get]
+func VerifyRiskReportHandle(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil || id <= 0 {
+		http.Error(w, "ID inválido", http.StatusBadRequest)
+		return
+	}
+
+	verification, err := riskReportService.Verify(uint(id))
+	if err != nil {
+		writeRiskReportError(w, "Error al verificar el reporte de riesgo: ", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(verification)
+}
+
+// GetRiskReportKeysHandle godoc
+// @Summary      Claves públicas de los reportes de riesgo
+// @Description  Retorna las claves públicas Ed25519 (base64) con las que se verifican los reportes: la activa y las anteriores a una rotación
+// @Tags         Risk Reports
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200 {array} riskReport.RiskReportKey
+// @Router       /risk-reports/keys [get]
+func GetRiskReportKeysHandle(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(riskReportService.PublicKeys())
+}
+
+// ExportRiskReportsHandle godoc
+// @Summary      Exportar la cadena de reportes de riesgo
+// @Description  Descarga todos los reportes en orden de secuencia, un JSON por línea (JSON Lines), para verificar la cadena fuera del servidor con el comando risk-report-verify
+// @Tags         Risk Reports
+// @Produce      application/x-ndjson
+// @Security     BearerAuth
+// @Success      200 {file} file "Cadena de reportes"
+// @Failure      403 {string} string "Requiere rol administrador"
+// @Router       /risk-reports/export [get]
+func ExportRiskReportsHandle(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"risk-reports-%s.jsonl\"", time.Now().Format("20060102")))
+
+	// Las líneas se escriben a medida que se leen: un error a mitad de la descarga sólo
+	// se registra, y la cadena incompleta no pasa la verificación fuera del servidor
+	count, err := riskReportService.Export(w)
+	if err != nil {
+		logger.WriteJSON(map[string]interface{}{
+			"timestamp": time.Now().Format(time.RFC3339),
+			"level":     "error",
+			"event":     "risk_report_export_failed",
+			"exported":  count,
+			"error":     err.Error(),
+		})
+	}
+}
+
+// GetUnreportedRiskEvaluationsHandle godoc
+// @Summary      Evaluaciones sin reporte firmado
+// @Description  Retorna las evaluaciones de riesgo que no tienen reporte en la cadena, por ejemplo porque falló su emisión. La exportación no las incluye; el servidor emite sus reportes al iniciar
+// @Tags         Risk Reports
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200 {array} models.RiskEvaluation
+// @Failure      403 {string} string "Requiere rol administrador"
+// @Failure      500 {string} string "Error interno del servidor"
+// @Router       /risk-reports/unreported [get]
+func GetUnreportedRiskEvaluationsHandle(w http.ResponseWriter, r *http.Request) {
+	evaluations, err := riskReportService.FindUnreported()
+	if err != nil {
+		writeRiskReportError(w, "Error al obtener las evaluaciones sin reporte: ", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(evaluations)
+}
+
+// GetCreditRequestRiskReportsHandle godoc
+// @Summary      Reportes de riesgo de una solicitud
+// @Description  Retorna los reportes firmados de las evaluaciones de una solicitud de crédito, del más reciente al más antiguo
+// @Tags         Risk Reports
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path int true "ID de la solicitud de crédito"
+// @Success      200 {array} models.RiskReport
+// @Failure      400 {string} string "ID inválido"
+// @Failure      404 {string} string "Solicitud no encontrada"
+// @Failure      500 {string} string "Error interno del servidor"
+// @Router       /credit-requests/{id}/risk-reports [get]
+func GetCreditRequestRiskReportsHandle(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil || id <= 0 {
+		http.Error(w, "ID inválido", http.StatusBadRequest)
+		return
+	}
+
+	reports, err := riskReportService.GetReportsByCreditRequestID(uint(id))
+	if err != nil {
+		writeRiskReportError(w, "Error al obtener los reportes de riesgo: ", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(reports)
+}
+
+func writeRiskReportError(w http.ResponseWriter, prefix string, err error) {
+	message := err.Error()
+	if strings.Contains(message, "no existe") {
+		http.Error(w, message, http.StatusNotFound)
+		return
+	}
+	http.Error(w, prefix+message, http.StatusInternalServerError)
+}
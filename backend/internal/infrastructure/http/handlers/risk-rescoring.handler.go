@@ -0,0 +1,235 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	riskRescoring "github.com/JhonCamargo53/prueba-tecnica/internal/application/services/risk-rescoring"
+	"github.com/JhonCamargo53/prueba-tecnica/internal/domain/models"
+	"github.com/gorilla/mux"
+)
+
+var riskRescoringService *riskRescoring.RiskRescoringService
+
+func InitRiskRescoringHandler(s *riskRescoring.RiskRescoringService) {
+	riskRescoringService = s
+}
+
+// RiskRescoringRequest son los parámetros para iniciar un re-scoring desde la API.
+type RiskRescoringRequest struct {
+	DryRun          bool   `json:"dryRun"`
+	Workers         int    `json:"workers"`
+	CustomerID      *uint  `json:"customerId"`
+	CreditStatusIDs []uint `json:"creditStatusIds"`
+	From            string `json:"from"`
+	To              string `json:"to"`
+	EngineVersion   string `json:"engineVersion"`
+}
+
+// StartRiskRescoringHandle godoc
+// @Summary      Iniciar un re-scoring de solicitudes
+// @Description  Re-evalúa con el motor configurado todas las solicitudes que cumplan el filtro. Se ejecuta en segundo plano; en modo dry-run sólo reporta los cambios sin modificar las solicitudes
+// @Tags         Risk Engines
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request body RiskRescoringRequest true "Filtro y opciones del re-scoring"
+// @Success      202 {object} models.RiskRescoringJob "Re-scoring iniciado"
+// @Failure      400 {string} string "Datos inválidos"
+// @Failure      409 {string} string "Ya hay un re-scoring en ejecución"
+// @Failure      500 {string} string "Error interno del servidor"
+// @Router       /risk-engines/rescoring [post]
+func StartRiskRescoringHandle(w http.ResponseWriter, r *http.Request) {
+	var request RiskRescoringRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		http.Error(w, "JSON inválido", http.StatusBadRequest)
+		return
+	}
+
+	filter, err := request.filter()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	job, err := riskRescoringService.Start(riskRescoring.RescoringOptions{
+		Filter:  filter,
+		DryRun:  request.DryRun,
+		Workers: request.Workers,
+	})
+	if err != nil {
+		writeRescoringError(w, "Error al iniciar el re-scoring: ", err)
+		return
+	}
+
+	go riskRescoringService.Run(context.Background(), job)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusAccepted)
+	json.NewEncoder(w).Encode(job)
+}
+
+// ResumeRiskRescoringHandle godoc
+// @Summary      Reanudar un re-scoring
+// @Description  Retoma un re-scoring interrumpido o fallido desde el último lote completado
+// @Tags         Risk Engines
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path int true "ID del re-scoring"
+// @Success      202 {object} models.RiskRescoringJob "Re-scoring reanudado"
+// @Failure      400 {string} string "ID inválido o re-scoring terminado"
+// @Failure      404 {string} string "Re-scoring no encontrado"
+// @Failure      409 {string} string "Ya hay un re-scoring en ejecución"
+// @Failure      500 {string} string "Error interno del servidor"
+// @Router       /risk-engines/rescoring/{id}/resume [post]
+func ResumeRiskRescoringHandle(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil || id <= 0 {
+		http.Error(w, "ID inválido", http.StatusBadRequest)
+		return
+	}
+
+	job, err := riskRescoringService.Resume(uint(id))
+	if err != nil {
+		writeRescoringError(w, "Error al reanudar el re-scoring: ", err)
+		return
+	}
+
+	go riskRescoringService.Run(context.Background(), job)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusAccepted)
+	json.NewEncoder(w).Encode(job)
+}
+
+// GetRiskRescoringJobsHandle godoc
+// @Summary      Listar re-scorings
+// @Description  Retorna los re-scorings ejecutados, del más reciente al más antiguo
+// @Tags         Risk Engines
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200 {array} models.RiskRescoringJob "Lista de re-scorings"
+// @Failure      500 {string} string "Error interno del servidor"
+// @Router       /risk-engines/rescoring [get]
+func GetRiskRescoringJobsHandle(w http.ResponseWriter, r *http.Request) {
+	jobs, err := riskRescoringService.GetJobs()
+	if err != nil {
+		http.Error(w, "Error al obtener re-scorings: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(jobs)
+}
+
+// GetRiskRescoringJobHandle godoc
+// @Summary      Obtener un re-scoring
+// @Description  Retorna el estado y el avance de un re-scoring
+// @Tags         Risk Engines
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path int true "ID del re-scoring"
+// @Success      200 {object} models.RiskRescoringJob "Re-scoring"
+// @Failure      400 {string} string "ID inválido"
+// @Failure      404 {string} string "Re-scoring no encontrado"
+// @Failure      500 {string} string "Error interno del servidor"
+// @Router       /risk-engines/rescoring/{id} [get]
+func GetRiskRescoringJobHandle(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil || id <= 0 {
+		http.Error(w, "ID inválido", http.StatusBadRequest)
+		return
+	}
+
+	job, err := riskRescoringService.GetJobByID(uint(id))
+	if err != nil {
+		writeRescoringError(w, "Error al obtener el re-scoring: ", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(job)
+}
+
+// GetRiskRescoringChangesHandle godoc
+// @Summary      Obtener los cambios de un re-scoring
+// @Description  Retorna las solicitudes cuyo puntaje o categoría cambió, o que no se pudieron re-evaluar
+// @Tags         Risk Engines
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path int true "ID del re-scoring"
+// @Success      200 {array} models.RiskRescoringChange "Cambios del re-scoring"
+// @Failure      400 {string} string "ID inválido"
+// @Failure      404 {string} string "Re-scoring no encontrado"
+// @Failure      500 {string} string "Error interno del servidor"
+// @Router       /risk-engines/rescoring/{id}/changes [get]
+func GetRiskRescoringChangesHandle(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil || id <= 0 {
+		http.Error(w, "ID inválido", http.StatusBadRequest)
+		return
+	}
+
+	changes, err := riskRescoringService.GetJobChanges(uint(id))
+	if err != nil {
+		writeRescoringError(w, "Error al obtener los cambios del re-scoring: ", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(changes)
+}
+
+// filter convierte las fechas (YYYY-MM-DD, día final inclusivo) al filtro del re-scoring.
+func (request RiskRescoringRequest) filter() (models.RiskRescoringFilter, error) {
+	filter := models.RiskRescoringFilter{
+		CustomerID:      request.CustomerID,
+		CreditStatusIDs: request.CreditStatusIDs,
+		EngineVersion:   strings.TrimSpace(request.EngineVersion),
+	}
+
+	if request.From != "" {
+		from, err := time.Parse("2006-01-02", request.From)
+		if err != nil {
+			return filter, fmt.Errorf("from inválido, formato esperado YYYY-MM-DD")
+		}
+		filter.CreatedFrom = &from
+	}
+
+	if request.To != "" {
+		to, err := time.Parse("2006-01-02", request.To)
+		if err != nil {
+			return filter, fmt.Errorf("to inválido, formato esperado YYYY-MM-DD")
+		}
+		end := to.AddDate(0, 0, 1)
+		filter.CreatedTo = &end
+	}
+
+	if filter.CreatedFrom != nil && filter.CreatedTo != nil && !filter.CreatedFrom.Before(*filter.CreatedTo) {
+		return filter, fmt.Errorf("from debe ser anterior o igual a to")
+	}
+
+	return filter, nil
+}
+
+func writeRescoringError(w http.ResponseWriter, prefix string, err error) {
+	switch {
+	case strings.Contains(err.Error(), "no existe"):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case strings.Contains(err.Error(), "en ejecución"):
+		http.Error(w, err.Error(), http.StatusConflict)
+	case strings.Contains(err.Error(), "workers"), strings.Contains(err.Error(), "ya terminó"):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	default:
+		http.Error(w, prefix+err.Error(), http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,160 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	riskSimulation "github.com/JhonCamargo53/prueba-tecnica/internal/application/services/risk-simulation"
+	"github.com/JhonCamargo53/prueba-tecnica/internal/domain/i18n"
+	"github.com/JhonCamargo53/prueba-tecnica/internal/domain/models"
+)
+
+var riskSimulationService *riskSimulation.RiskSimulationService
+
+func InitRiskSimulationHandler(s *riskSimulation.RiskSimulationService) {
+	riskSimulationService = s
+}
+
+// SimulateCreditRequestRequest representa una solicitud hipotética para simular el riesgo
+// @Description Datos de la simulación. Se envía customerId o los datos del cliente en customer, y creditProductId para simular con un producto del catálogo
+type SimulateCreditRequestRequest struct {
+	CustomerID      uint                          `json:"customerId" example:"1"`
+	Customer        *SimulationCustomerRequest    `json:"customer,omitempty"`
+	Amount          float64                       `json:"amount" example:"10000000"`
+	TermMonths      int                           `json:"termMonths" example:"24"`
+	InterestRate    float64                       `json:"interestRate" example:"24.5"`
+	ProductType     string                        `json:"productType" example:"Vivienda"`
+	CreditProductID uint                          `json:"creditProductId" example:"1"`
+	Assets          []SimulationCustomerAssetItem `json:"assets"`
+}
+
+// SimulationCustomerRequest son los datos mínimos de un cliente no registrado
+type SimulationCustomerRequest struct {
+	Name          string  `json:"name" example:"Ana Gómez"`
+	MonthlyIncome float64 `json:"monthlyIncome" example:"5000000"`
+}
+
+// SimulationCustomerAssetItem es un activo hipotético que respaldaría el crédito
+type SimulationCustomerAssetItem struct {
+	AssetID     uint    `json:"assetId" example:"1"`
+	MarketValue float64 `json:"marketValue" example:"80000000"`
+	Description string  `json:"description" example:"Apartamento"`
+}
+
+// SimulateCreditRequestResponse es el resultado de la simulación
+type SimulateCreditRequestResponse struct {
+	Score         float64                `json:"score"`
+	Category      string                 `json:"category"`
+	EngineVersion string                 `json:"engineVersion"`
+	Explanation   string                 `json:"explanation"`
+	Assessment    *models.RiskAssessment `json:"assessment"`
+}
+
+// SimulateCreditRequestHandle godoc
+// @Summary      Simular el riesgo de una solicitud de crédito
+// @Description  Calcula puntaje, categoría y explicación de una solicitud hipotética con el motor configurado, sin guardar nada
+// @Tags         Credit Requests
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        lang query string false "Idioma de la explicación (es, en)"
+// @Param        request body SimulateCreditRequestRequest true "Datos de la simulación"
+// @Success      200 {object} SimulateCreditRequestResponse "Resultado de la simulación"
+// @Failure      400 {string} string "Solicitud inválida o fuera de las condiciones del producto"
+// @Failure      404 {string} string "Cliente, bien o producto no encontrado"
+// @Failure      500 {string} string "Error interno del servidor"
+// @Router       /credit-requests/simulate [post]
+func SimulateCreditRequestHandle(w http.ResponseWriter, r *http.Request) {
+
+	var simulationData SimulateCreditRequestRequest
+
+	if err := json.NewDecoder(r.Body).Decode(&simulationData); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if simulationData.Amount <= 0 {
+		http.Error(w, "El campo 'Cantidad' es obligatorio.", http.StatusBadRequest)
+		return
+	}
+
+	if simulationData.TermMonths <= 0 {
+		http.Error(w, "El campo 'Número de meses' es obligatorio.", http.StatusBadRequest)
+		return
+	}
+
+	if simulationData.InterestRate < 0 {
+		http.Error(w, "El campo 'Tasa de interés' no puede ser negativo.", http.StatusBadRequest)
+		return
+	}
+
+	if simulationData.CustomerID == 0 && simulationData.Customer == nil {
+		http.Error(w, "Se debe enviar el campo 'Id del solicitante' o los datos del cliente.", http.StatusBadRequest)
+		return
+	}
+
+	if simulationData.Customer != nil && simulationData.Customer.MonthlyIncome < 0 {
+		http.Error(w, "El campo 'Ingreso mensual' no puede ser negativo.", http.StatusBadRequest)
+		return
+	}
+
+	lang, ok := i18n.ResolveLanguage(r.URL.Query().Get("lang"), r.Header.Get("Accept-Language"))
+	if !ok {
+		http.Error(w, "Idioma no soportado. Valores permitidos: es, en", http.StatusBadRequest)
+		return
+	}
+
+	input := riskSimulation.SimulationInput{
+		CustomerID:      simulationData.CustomerID,
+		Amount:          simulationData.Amount,
+		TermMonths:      simulationData.TermMonths,
+		InterestRate:    simulationData.InterestRate,
+		ProductType:     simulationData.ProductType,
+		CreditProductID: simulationData.CreditProductID,
+	}
+
+	if simulationData.Customer != nil {
+		input.Customer = &models.Customer{
+			Name:          simulationData.Customer.Name,
+			MonthlyIncome: simulationData.Customer.MonthlyIncome,
+		}
+	}
+
+	for _, a := range simulationData.Assets {
+		if a.AssetID == 0 || a.MarketValue < 0 {
+			http.Error(w, "Cada activo debe tener 'Id del bien' y un 'Valor comercial' no negativo.", http.StatusBadRequest)
+			return
+		}
+		input.Assets = append(input.Assets, riskSimulation.SimulationAsset{
+			AssetID:     a.AssetID,
+			MarketValue: a.MarketValue,
+			Description: a.Description,
+		})
+	}
+
+	assessment, err := riskSimulationService.Simulate(input)
+
+	if err != nil {
+		if strings.Contains(err.Error(), "no existe") {
+			http.Error(w, err.Error(), http.StatusNotFound)
+		} else if strings.Contains(err.Error(), "producto") {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		} else {
+			http.Error(w, "Error al simular la solicitud de crédito: "+err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+
+	i18n.Localize(assessment, lang)
+
+	w.Header().Set("Content-Language", lang)
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(SimulateCreditRequestResponse{
+		Score:         assessment.Score,
+		Category:      assessment.Category,
+		EngineVersion: assessment.EngineVersion,
+		Explanation:   assessment.Explanation,
+		Assessment:    assessment,
+	})
+}
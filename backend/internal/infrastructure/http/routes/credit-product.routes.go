@@ -0,0 +1,21 @@
+package routes
+
+import (
+	"github.com/JhonCamargo53/prueba-tecnica/internal/infrastructure/http/handlers"
+	"github.com/JhonCamargo53/prueba-tecnica/internal/infrastructure/http/middlewares"
+	"github.com/gorilla/mux"
+)
+
+func RegisterCreditProductRoutes(router *mux.Router) {
+	creditProductRouter := router.PathPrefix("/credit-products").Subrouter()
+	creditProductRouter.Use(middlewares.AuthMiddleware)
+	creditProductRouter.HandleFunc("", handlers.GetCreditProductsHandle).Methods("GET")
+	creditProductRouter.HandleFunc("/{id}", handlers.GetCreditProductHandle).Methods("GET")
+
+	// La administración del catálogo requiere rol ADMIN
+	adminRouter := creditProductRouter.NewRoute().Subrouter()
+	adminRouter.Use(middlewares.RequireAdminRole)
+	adminRouter.HandleFunc("", handlers.PostCreditProductHandle).Methods("POST")
+	adminRouter.HandleFunc("/{id}", handlers.UpdateCreditProductHandle).Methods("PUT")
+	adminRouter.HandleFunc("/{id}", handlers.DeleteCreditProductHandle).Methods("DELETE")
+}
@@ -10,7 +10,24 @@ func RegisterCreditRequestRoutes(router *mux.Router) {
 	creditRequestRouter := router.PathPrefix("/credit-requests").Subrouter()
 	creditRequestRouter.Use(middlewares.AuthMiddleware)
 	creditRequestRouter.HandleFunc("", handlers.GetCreditRequestsHandle).Methods("GET")
+	creditRequestRouter.HandleFunc("/simulate", handlers.SimulateCreditRequestHandle).Methods("POST")
+	creditRequestRouter.HandleFunc("/decisions/pending", handlers.GetPendingCreditDecisionsHandle).Methods("GET")
+	creditRequestRouter.HandleFunc("/decisions/escalated", handlers.GetEscalatedCreditDecisionsHandle).Methods("GET")
 	creditRequestRouter.HandleFunc("/{id}", handlers.GetCreditRequestHandle).Methods("GET")
+	creditRequestRouter.HandleFunc("/{id}/evaluations", handlers.GetCreditRequestEvaluationsHandle).Methods("GET")
+	creditRequestRouter.HandleFunc("/{id}/risk-reports", handlers.GetCreditRequestRiskReportsHandle).Methods("GET")
+	creditRequestRouter.HandleFunc("/{id}/history", handlers.GetCreditStatusHistoryHandle).Methods("GET")
+	creditRequestRouter.HandleFunc("/{id}/participants", handlers.GetCreditRequestParticipantsHandle).Methods("GET")
+	creditRequestRouter.HandleFunc("/{id}/participants", handlers.PostCreditRequestParticipantHandle).Methods("POST")
+	creditRequestRouter.HandleFunc("/{id}/participants/{participantId}", handlers.DeleteCreditRequestParticipantHandle).Methods("DELETE")
+	creditRequestRouter.HandleFunc("/{id}/documents/checklist", handlers.GetCreditRequestDocumentChecklistHandle).Methods("GET")
+	creditRequestRouter.HandleFunc("/{id}/schedule", handlers.GetPaymentScheduleHandle).Methods("GET")
+	creditRequestRouter.HandleFunc("/{id}/loan", handlers.GetLoanAccountHandle).Methods("GET")
+	creditRequestRouter.HandleFunc("/{id}/payments", handlers.GetLoanPaymentsHandle).Methods("GET")
+	creditRequestRouter.HandleFunc("/{id}/payments", handlers.PostLoanPaymentHandle).Methods("POST")
+	creditRequestRouter.HandleFunc("/{id}/decision", handlers.PostCreditDecisionHandle).Methods("POST")
+	creditRequestRouter.HandleFunc("/{id}/decisions", handlers.GetCreditDecisionsHandle).Methods("GET")
+	creditRequestRouter.HandleFunc("/{id}/decisions/{decisionId}/review", handlers.ReviewCreditDecisionHandle).Methods("POST")
 	creditRequestRouter.HandleFunc("", handlers.PostCreditRequestHandle).Methods("POST")
 	creditRequestRouter.HandleFunc("/{id}", handlers.UpdateCreditRequestHandle).Methods("PUT")
 	creditRequestRouter.HandleFunc("/{id}", handlers.DeleteCreditRequestHandle).Methods("DELETE")
@@ -0,0 +1,17 @@
+package routes
+
+import (
+	"github.com/JhonCamargo53/prueba-tecnica/internal/infrastructure/http/handlers"
+	"github.com/JhonCamargo53/prueba-tecnica/internal/infrastructure/http/middlewares"
+	"github.com/gorilla/mux"
+)
+
+func RegisterDocumentRoutes(router *mux.Router) {
+	documentRouter := router.PathPrefix("/documents").Subrouter()
+	documentRouter.Use(middlewares.AuthMiddleware)
+	documentRouter.HandleFunc("", handlers.GetDocumentsHandle).Methods("GET")
+	documentRouter.HandleFunc("", handlers.PostDocumentHandle).Methods("POST")
+	documentRouter.HandleFunc("/{id}", handlers.GetDocumentHandle).Methods("GET")
+	documentRouter.HandleFunc("/{id}/download", handlers.DownloadDocumentHandle).Methods("GET")
+	documentRouter.HandleFunc("/{id}", handlers.DeleteDocumentHandle).Methods("DELETE")
+}
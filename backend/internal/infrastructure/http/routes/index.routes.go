@@ -8,9 +8,14 @@ func RegisterAllRoutes(router *mux.Router) {
 	RegisterAssetRoutes(router)
 	RegisterAuthRoutes(router)
 	RegisterCreditRequestRoutes(router)
+	RegisterCreditProductRoutes(router)
+	RegisterLoanAccountRoutes(router)
+	RegisterRiskEngineRoutes(router)
+	RegisterRiskReportRoutes(router)
 	RegisterCreditStatusRoutes(router)
 	RegisterCustomerRoutes(router)
 	RegisterDocumentTypeRoutes(router)
+	RegisterDocumentRoutes(router)
 	RegisterUserRoutes(router)
 	RegisterHealthRoutes(router)
 	RegisterCustomerAssetRoutes(router)
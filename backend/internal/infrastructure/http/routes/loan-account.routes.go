@@ -0,0 +1,18 @@
+package routes
+
+import (
+	"github.com/JhonCamargo53/prueba-tecnica/internal/infrastructure/http/handlers"
+	"github.com/JhonCamargo53/prueba-tecnica/internal/infrastructure/http/middlewares"
+	"github.com/gorilla/mux"
+)
+
+func RegisterLoanAccountRoutes(router *mux.Router) {
+	loanAccountRouter := router.PathPrefix("/loan-accounts").Subrouter()
+	loanAccountRouter.Use(middlewares.AuthMiddleware)
+	loanAccountRouter.HandleFunc("/delinquent", handlers.GetDelinquentLoanAccountsHandle).Methods("GET")
+
+	// Ejecutar el cálculo de mora fuera del horario programado requiere rol ADMIN
+	adminRouter := loanAccountRouter.NewRoute().Subrouter()
+	adminRouter.Use(middlewares.RequireAdminRole)
+	adminRouter.HandleFunc("/delinquency", handlers.ComputeLoanDelinquencyHandle).Methods("POST")
+}
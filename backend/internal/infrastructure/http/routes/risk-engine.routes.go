@@ -0,0 +1,23 @@
+package routes
+
+import (
+	"github.com/JhonCamargo53/prueba-tecnica/internal/infrastructure/http/handlers"
+	"github.com/JhonCamargo53/prueba-tecnica/internal/infrastructure/http/middlewares"
+	"github.com/gorilla/mux"
+)
+
+func RegisterRiskEngineRoutes(router *mux.Router) {
+	riskEngineRouter := router.PathPrefix("/risk-engines").Subrouter()
+	riskEngineRouter.Use(middlewares.AuthMiddleware)
+	riskEngineRouter.Use(middlewares.RequireAdminRole)
+	riskEngineRouter.HandleFunc("/comparison", handlers.GetRiskEngineComparisonHandle).Methods("GET")
+	riskEngineRouter.HandleFunc("/backtesting", handlers.GetRiskBacktestingHandle).Methods("GET")
+	riskEngineRouter.HandleFunc("/drift", handlers.GetRiskDriftReportsHandle).Methods("GET")
+	riskEngineRouter.HandleFunc("/drift", handlers.ComputeRiskDriftHandle).Methods("POST")
+	riskEngineRouter.HandleFunc("/drift/{id}", handlers.GetRiskDriftReportHandle).Methods("GET")
+	riskEngineRouter.HandleFunc("/rescoring", handlers.GetRiskRescoringJobsHandle).Methods("GET")
+	riskEngineRouter.HandleFunc("/rescoring", handlers.StartRiskRescoringHandle).Methods("POST")
+	riskEngineRouter.HandleFunc("/rescoring/{id}", handlers.GetRiskRescoringJobHandle).Methods("GET")
+	riskEngineRouter.HandleFunc("/rescoring/{id}/changes", handlers.GetRiskRescoringChangesHandle).Methods("GET")
+	riskEngineRouter.HandleFunc("/rescoring/{id}/resume", handlers.ResumeRiskRescoringHandle).Methods("POST")
+}
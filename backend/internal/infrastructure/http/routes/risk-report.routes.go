@@ -0,0 +1,21 @@
+package routes
+
+import (
+	"github.com/JhonCamargo53/prueba-tecnica/internal/infrastructure/http/handlers"
+	"github.com/JhonCamargo53/prueba-tecnica/internal/infrastructure/http/middlewares"
+	"github.com/gorilla/mux"
+)
+
+func RegisterRiskReportRoutes(router *mux.Router) {
+	riskReportRouter := router.PathPrefix("/risk-reports").Subrouter()
+	riskReportRouter.Use(middlewares.AuthMiddleware)
+	riskReportRouter.HandleFunc("/keys", handlers.GetRiskReportKeysHandle).Methods("GET")
+
+	adminRouter := riskReportRouter.NewRoute().Subrouter()
+	adminRouter.Use(middlewares.RequireAdminRole)
+	adminRouter.HandleFunc("/export", handlers.ExportRiskReportsHandle).Methods("GET")
+	adminRouter.HandleFunc("/unreported", handlers.GetUnreportedRiskEvaluationsHandle).Methods("GET")
+
+	riskReportRouter.HandleFunc("/{id}", handlers.GetRiskReportHandle).Methods("GET")
+	riskReportRouter.HandleFunc("/{id}/verify", handlers.VerifyRiskReportHandle).Methods("GET")
+}
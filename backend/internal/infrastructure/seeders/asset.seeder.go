@@ -3,14 +3,21 @@ package seed
 import "gorm.io/gorm"
 
 func SeedAssets(db *gorm.DB) error {
+	// La política de garantía solo se completa en tipos que aún no la tienen configurada
 	query := `
-    INSERT INTO assets (name, description, created_at, updated_at)
+    INSERT INTO assets (name, description, collateral_eligible, collateral_haircut, depreciation_method, annual_depreciation_rate, real_estate, created_at, updated_at)
     VALUES
-        ('INMUEBLE', 'Bien raíz como casas y apartamentos', NOW(), NOW()),
-        ('VEHICULO', 'Carros, motos y otros medios de transporte', NOW(), NOW()),
-        ('ELECTRODOMESTICO', 'Equipos como neveras, televisores y similares', NOW(), NOW()),
-        ('OTRO', 'Cualquier otro activo', NOW(), NOW())
-    ON CONFLICT (name) DO NOTHING;
+        ('INMUEBLE', 'Bien raíz como casas y apartamentos', true, 0.30, 'NONE', 0, true, NOW(), NOW()),
+        ('VEHICULO', 'Carros, motos y otros medios de transporte', true, 0.40, 'DECLINING_BALANCE', 0.15, false, NOW(), NOW()),
+        ('ELECTRODOMESTICO', 'Equipos como neveras, televisores y similares', false, 1, 'STRAIGHT_LINE', 0.20, false, NOW(), NOW()),
+        ('OTRO', 'Cualquier otro activo', true, 0.60, 'STRAIGHT_LINE', 0.10, false, NOW(), NOW())
+    ON CONFLICT (name) DO UPDATE SET
+        collateral_eligible = EXCLUDED.collateral_eligible,
+        collateral_haircut = EXCLUDED.collateral_haircut,
+        depreciation_method = EXCLUDED.depreciation_method,
+        annual_depreciation_rate = EXCLUDED.annual_depreciation_rate,
+        real_estate = EXCLUDED.real_estate
+    WHERE assets.depreciation_method IS NULL OR assets.depreciation_method = '';
     `
 	return db.Exec(query).Error
 }
@@ -0,0 +1,17 @@
+package seed
+
+import "gorm.io/gorm"
+
+func SeedCreditProducts(db *gorm.DB) error {
+	// Los productos existentes no se modifican: se administran desde /credit-products
+	query := `
+    INSERT INTO credit_products (code, name, description, min_amount, max_amount, allowed_terms, base_annual_rate, collateral_required, risk_weight, required_documents, status, created_at, updated_at)
+    VALUES
+        ('HOUSING', 'Crédito de vivienda', 'Compra de vivienda nueva o usada con hipoteca', 20000000, 1500000000, '[60,120,180,240,300,360]', 12.0, true, 0.5, '["IDENTITY","INCOME_PROOF","PROPERTY_DEED"]', true, NOW(), NOW()),
+        ('VEHICLE', 'Crédito de vehículo', 'Compra de vehículo con prenda sobre el bien', 5000000, 250000000, '[12,24,36,48,60,72]', 16.5, true, 0.75, '["IDENTITY","INCOME_PROOF","VEHICLE_TITLE"]', true, NOW(), NOW()),
+        ('PERSONAL', 'Préstamo personal', 'Crédito de consumo con destino específico', 1000000, 80000000, '[6,12,18,24,36,48,60]', 22.0, false, 1, '["IDENTITY","INCOME_PROOF"]', true, NOW(), NOW()),
+        ('CONSUMER', 'Libre inversión', 'Crédito de consumo sin destino específico', 1000000, 100000000, '[12,24,36,48,60,72]', 20.0, false, 1.5, '["IDENTITY"]', true, NOW(), NOW())
+    ON CONFLICT (code) DO NOTHING;
+    `
+	return db.Exec(query).Error
+}
@@ -0,0 +1,18 @@
+package seed
+
+import "gorm.io/gorm"
+
+func SeedCreditRequestHolders(db *gorm.DB) error {
+	// Las solicitudes anteriores a los participantes registran a su cliente como titular
+	query := `
+    INSERT INTO credit_request_participants (credit_request_id, customer_id, role, created_at, updated_at)
+    SELECT cr.id, cr.customer_id, 'HOLDER', NOW(), NOW()
+    FROM credit_requests cr
+    WHERE cr.deleted_at IS NULL
+      AND NOT EXISTS (
+        SELECT 1 FROM credit_request_participants p
+        WHERE p.credit_request_id = cr.id AND p.role = 'HOLDER' AND p.deleted_at IS NULL
+      );
+    `
+	return db.Exec(query).Error
+}
@@ -22,9 +22,17 @@ func SeedAll(db *gorm.DB) error {
 		return err
 	}
 
+	if err := SeedCreditProducts(db); err != nil {
+		return err
+	}
+
 	if err := SeedUsers(db); err != nil {
 		return err
 	}
 
+	if err := SeedCreditRequestHolders(db); err != nil {
+		return err
+	}
+
 	return nil
 }
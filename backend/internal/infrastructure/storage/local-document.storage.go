@@ -0,0 +1,90 @@
+package storage
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/JhonCamargo53/prueba-tecnica/internal/domain/ports"
+)
+
+// LocalDocumentStorage guarda los documentos como archivos bajo un directorio base.
+type LocalDocumentStorage struct {
+	basePath string
+}
+
+func NewLocalDocumentStorage(basePath string) (ports.DocumentStorage, error) {
+	absolute, err := filepath.Abs(basePath)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(absolute, 0o750); err != nil {
+		return nil, fmt.Errorf("no se pudo crear el directorio de documentos %s: %w", absolute, err)
+	}
+	return &LocalDocumentStorage{basePath: absolute}, nil
+}
+
+// Save escribe primero un archivo temporal y lo renombra, para que un archivo
+// incompleto nunca quede bajo la clave.
+func (s *LocalDocumentStorage) Save(key string, content io.Reader) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".upload-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := io.Copy(tmp, content); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
+
+func (s *LocalDocumentStorage) Open(key string) (io.ReadCloser, error) {
+	path, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, fmt.Errorf("no existe el archivo %s en el almacenamiento de documentos", key)
+	}
+	return file, err
+}
+
+func (s *LocalDocumentStorage) Delete(key string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// path resuelve la clave dentro del directorio base; rechaza claves que salgan de él.
+func (s *LocalDocumentStorage) path(key string) (string, error) {
+	path := filepath.Join(s.basePath, filepath.FromSlash(key))
+	relative, err := filepath.Rel(s.basePath, path)
+	if err != nil || relative == "." || relative == ".." || strings.HasPrefix(relative, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("clave de documento inválida: %s", key)
+	}
+	return path, nil
+}
@@ -4,10 +4,12 @@ import (
 	"fmt"
 	"log"
 	"net/http"
+	"os"
 
 	_ "github.com/JhonCamargo53/prueba-tecnica/docs"
 	"github.com/JhonCamargo53/prueba-tecnica/internal/config"
 	"github.com/JhonCamargo53/prueba-tecnica/internal/infrastructure/bootstrap"
+	"github.com/JhonCamargo53/prueba-tecnica/internal/infrastructure/cli"
 	databaseGorm "github.com/JhonCamargo53/prueba-tecnica/internal/infrastructure/database/gorm"
 	"github.com/JhonCamargo53/prueba-tecnica/internal/infrastructure/database/gorm/migrations"
 	"github.com/JhonCamargo53/prueba-tecnica/internal/infrastructure/http/middlewares"
@@ -53,7 +55,17 @@ func main() {
 		log.Fatal("Error insertando datos iniciales: ", err)
 	}
 
-	bootstrap.InitializeDependencies(databaseGorm.DB, config)
+	dependencies := bootstrap.InitializeDependencies(databaseGorm.DB, config)
+
+	// Subcomando: go run . rescore [flags]
+	if len(os.Args) > 1 && os.Args[1] == "rescore" {
+		if err := cli.RunRescore(dependencies.RiskRescoring, os.Args[2:]); err != nil {
+			log.Fatal("Error en re-scoring: ", err)
+		}
+		return
+	}
+
+	dependencies.StartBackgroundJobs()
 
 	router := mux.NewRouter()
 
@@ -1,11 +1,13 @@
 import Button from '@/components/common/buttons/Button';
 import GenericInput from '@/components/common/inputs/GenericInput';
 import { generateAxiosErrorToast, showSuccessToast } from '@/utils/toastUtils';
-import React, { useState } from 'react';
+import React, { useEffect, useState } from 'react';
 import { SubmitHandler, useForm } from 'react-hook-form';
 import { useModal } from '@/hooks/useModal';
 import { useCreditRequest } from '@/hooks/useCreditRequest';
 import { useCreditStatusStore } from '@/store/useCreditStatusStore';
+import { fetchCreditProducts } from '@/services/creditProductService';
+import { CreditProduct } from '@/types/creditProduct';
 
 interface CreateUpdateCreditRequestProps {
   creditRequest?: CreditRequest;
@@ -18,12 +20,19 @@ const CreateUpdateCreditRequestForm: React.FC<CreateUpdateCreditRequestProps> =
   const { createCreditRequest, updateCreditRequest } = useCreditRequest();
   const { removeLastOpenModal } = useModal();
   const [loading, setLoading] = useState(false);
+  const [creditProducts, setCreditProducts] = useState<CreditProduct[]>([]);
+
+  useEffect(() => {
+    fetchCreditProducts()
+      .then(products => setCreditProducts(products.filter(product => product.status || product.ID === creditRequest?.creditProductId)))
+      .catch(error => generateAxiosErrorToast(error, 'Error al obtener productos de crédito', 'Inténtalo nuevamente'));
+  }, [creditRequest?.creditProductId]);
 
   const { register, handleSubmit, formState: { errors } } = useForm<CreditRequestForm>({
     defaultValues: {
       amount: creditRequest?.amount,
       termMonths: creditRequest?.termMonths,
-      productType: creditRequest?.productType,
+      creditProductId: creditRequest?.creditProductId ?? undefined,
       creditStatusId: creditRequest?.creditStatusId,
     }
   });
@@ -36,6 +45,7 @@ const CreateUpdateCreditRequestForm: React.FC<CreateUpdateCreditRequestProps> =
         ...formData,
         amount: Number(formData.amount),
         termMonths: Number(formData.termMonths),
+        creditProductId: Number(formData.creditProductId),
         creditStatusId: Number(formData.creditStatusId),
         customerId: customerId ? customerId : (creditRequest ? creditRequest.ID : 0),
       }
@@ -86,17 +96,24 @@ const CreateUpdateCreditRequestForm: React.FC<CreateUpdateCreditRequestProps> =
             />
           </div>
 
-          <div className="grid grid-cols-1 gap-4">
-            <GenericInput
-              label="Tipo de producto"
-              placeholder="Ejemplo: Crédito personal"
-              type="text"
-              error={errors.productType}
-              register={register('productType', {
-                required: 'El tipo de producto es obligatorio',
-                maxLength: { value: 50, message: 'No puede tener más de 50 caracteres' }
+          <div>
+            <label className="text-neutral-dark font-bold mb-1 block">Producto de crédito</label>
+            <select
+              className="input-primary w-full h-10"
+              {...register('creditProductId', {
+                required: 'El producto es obligatorio'
               })}
-            />
+            >
+              <option value="">Selecciona un producto</option>
+              {creditProducts.map(product => (
+                <option key={product.ID} value={product.ID}>
+                  {product.name}
+                </option>
+              ))}
+            </select>
+            {errors.creditProductId && (
+              <p className="text-sm text-error font-bold">{errors.creditProductId.message}</p>
+            )}
           </div>
 
           <div>
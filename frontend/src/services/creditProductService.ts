@@ -0,0 +1,9 @@
+import { axiosInstance, BASE_URL } from "@/instances/axiosIntance";
+import { CreditProduct } from "@/types/creditProduct";
+
+const creditProductUrl = BASE_URL + "credit-products";
+
+export const fetchCreditProducts = async (): Promise<CreditProduct[]> => {
+    const response = await axiosInstance.get<CreditProduct[]>(creditProductUrl);
+    return response.data;
+};
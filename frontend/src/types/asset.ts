@@ -3,6 +3,11 @@ export interface Asset{
     name: string;
     description: string;
     status:boolean
+    collateralEligible: boolean;
+    collateralHaircut: number;
+    depreciationMethod: string;
+    annualDepreciationRate: number;
+    realEstate: boolean;
 }
 
 export interface AssetForm{
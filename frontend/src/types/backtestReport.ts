@@ -0,0 +1,33 @@
+interface BacktestConfusionRow {
+    recommendation: 'APPROVE' | 'REVIEW' | 'REJECT'
+    approved: number
+    rejected: number
+}
+
+interface BacktestScoreBand {
+    minScore: number
+    maxScore: number
+    requests: number
+    approved: number
+    rejected: number
+    approvalRate: number
+}
+
+interface BacktestReport {
+    engine: string
+    engineVersions: string[]
+    from: string | null
+    to: string | null
+    generatedAt: string
+    requests: number
+    approved: number
+    rejected: number
+    errors: number
+    auc: number
+    gini: number
+    ks: number
+    ksScore: number
+    decisionAgreement: number
+    confusionMatrix: BacktestConfusionRow[]
+    scoreBands: BacktestScoreBand[]
+}
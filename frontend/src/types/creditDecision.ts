@@ -0,0 +1,21 @@
+interface CreditDecision {
+    ID: number
+    creditRequestId: number
+    creditStatusId: number
+    status: 'APPLIED' | 'PENDING_CONFIRMATION' | 'ESCALATED' | 'CONFIRMED' | 'DECLINED'
+    engineRecommendation: string
+    engineScore: number
+    engineVersion: string
+    override: boolean
+    justification: string
+    amount: number
+    riskCategory: string
+    decidedById: number
+    decidedAt: string
+    reviewedById: number | null
+    reviewedAt: string | null
+    reviewComment?: string
+    requiredAccess: number
+    CreatedAt: string
+    UpdatedAt: string
+}
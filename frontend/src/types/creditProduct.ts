@@ -0,0 +1,15 @@
+export interface CreditProduct {
+    ID: number;
+    code: string;
+    name: string;
+    description: string;
+    minAmount: number;
+    maxAmount: number;
+    allowedTerms: number[];
+    baseAnnualRate: number;
+    collateralRequired: boolean;
+    riskWeight: number;
+    amortizationMethod: 'FRENCH' | 'GERMAN' | 'BULLET';
+    requiredDocuments: import("./document").DocumentCategory[];
+    status: boolean;
+}
@@ -2,20 +2,38 @@ interface CreditRequest {
     ID: number
     amount: number;
     termMonths: number;
+    interestRate: number;
     productType: string
+    creditProductId: number | null
+    creditProduct?: import("./creditProduct").CreditProduct
     creditStatusId: number
     riskScore: number
     riskCategory: string
     riskExplanation: string
+    riskRuleSetVersion: string
+    riskAssessment: RiskAssessment | null
+    offer: CreditOffer
+    participants?: CreditRequestParticipant[]
     customerId: number;
     UpdatedAt: string;
     CreatedAt: string;
 }
 
+interface CreditOffer {
+    pricingVersion: string
+    annualRate: number
+    maxAmount: number
+    amount: number
+    termMonths: number
+    installment: number
+    counterOffer: boolean
+}
+
 interface CreditRequestForm {
     amount: number;
     termMonths: number;
-    productType: string;
+    interestRate?: number;
+    creditProductId: number;
     creditStatusId: number;
     customerId:number;
 }
\ No newline at end of file
@@ -0,0 +1,14 @@
+interface CreditRequestParticipant {
+    ID: number
+    creditRequestId: number
+    customerId: number
+    customer: import("./customer").Customer
+    role: 'HOLDER' | 'CO_BORROWER' | 'GUARANTOR'
+    CreatedAt: string
+    UpdatedAt: string
+}
+
+interface CreditRequestParticipantForm {
+    customerId: number
+    role: 'CO_BORROWER' | 'GUARANTOR'
+}
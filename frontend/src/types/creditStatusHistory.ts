@@ -0,0 +1,14 @@
+interface CreditStatusHistory {
+    ID: number
+    creditRequestId: number
+    fromStatusId: number | null
+    fromStatus?: CreditStatus
+    toStatusId: number
+    toStatus?: CreditStatus
+    changedById: number | null
+    role: string
+    source: 'CREDIT_REQUEST_CREATED' | 'CREDIT_REQUEST_UPDATED' | 'CREDIT_DECISION' | 'CREDIT_DECISION_CONFIRMED' | 'RISK_KNOCK_OUT'
+    comment: string
+    CreatedAt: string
+    UpdatedAt: string
+}
@@ -0,0 +1,32 @@
+export type DocumentOwnerType = 'CUSTOMER' | 'CREDIT_REQUEST' | 'CUSTOMER_ASSET';
+
+export type DocumentCategory =
+    | 'IDENTITY'
+    | 'INCOME_PROOF'
+    | 'BANK_STATEMENT'
+    | 'PROPERTY_DEED'
+    | 'VEHICLE_TITLE'
+    | 'OTHER';
+
+export interface AttachedDocument {
+    ID: number;
+    ownerType: DocumentOwnerType;
+    ownerId: number;
+    category: DocumentCategory;
+    fileName: string;
+    contentType: 'application/pdf' | 'image/jpeg' | 'image/png';
+    size: number;
+    sha256: string;
+    uploadedById: number;
+    CreatedAt: string;
+    UpdatedAt: string;
+}
+
+export interface DocumentChecklist {
+    creditRequestId: number;
+    productCode: string;
+    required: DocumentCategory[];
+    missing: DocumentCategory[];
+    complete: boolean;
+    documents: AttachedDocument[];
+}
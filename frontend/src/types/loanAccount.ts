@@ -0,0 +1,39 @@
+interface LoanAccount {
+    ID: number
+    creditRequestId: number
+    paymentScheduleId: number
+    principal: number
+    outstandingPrincipal: number
+    principalPaid: number
+    interestPaid: number
+    status: 'ACTIVE' | 'PAID_OFF'
+    daysPastDue: number
+    delinquencyBucket: 'CURRENT' | '1-29' | '30-59' | '60-89' | '90+'
+    maxDaysPastDue: number
+    lastPaymentAt: string | null
+    delinquencyUpdatedAt: string | null
+    paymentSchedule?: PaymentSchedule
+    CreatedAt: string
+    UpdatedAt: string
+}
+
+interface LoanPayment {
+    ID: number
+    loanAccountId: number
+    amount: number
+    interestAmount: number
+    principalAmount: number
+    paidAt: string
+    reference: string
+    registeredById: number
+    allocations: LoanPaymentAllocation[]
+    CreatedAt: string
+}
+
+interface LoanPaymentAllocation {
+    ID: number
+    loanPaymentId: number
+    installmentNumber: number
+    interestAmount: number
+    principalAmount: number
+}
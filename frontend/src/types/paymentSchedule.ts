@@ -0,0 +1,28 @@
+interface PaymentSchedule {
+    ID: number
+    creditRequestId: number
+    method: 'FRENCH' | 'GERMAN' | 'BULLET'
+    principal: number
+    annualRate: number
+    termMonths: number
+    startDate: string
+    totalInterest: number
+    totalPayment: number
+    installments: PaymentScheduleInstallment[]
+    CreatedAt: string
+    UpdatedAt: string
+}
+
+interface PaymentScheduleInstallment {
+    ID: number
+    paymentScheduleId: number
+    number: number
+    dueDate: string
+    payment: number
+    principal: number
+    interest: number
+    balance: number
+    interestPaid: number
+    principalPaid: number
+    paidAt: string | null
+}
@@ -0,0 +1,30 @@
+interface RiskAssessment {
+    engineVersion: string
+    baseScore: number
+    score: number
+    probabilityOfDefault?: number
+    category: string
+    recommendation: 'APPROVE' | 'REVIEW' | 'REJECT'
+    factors: RiskFactor[]
+    improvements: RiskImprovement[]
+    knockOuts?: RiskKnockOut[]
+}
+
+interface RiskFactor {
+    code: string
+    points: number
+    observedValue?: number
+    params?: Record<string, number>
+    message: string
+}
+
+interface RiskImprovement {
+    code: string
+    message: string
+}
+
+interface RiskKnockOut {
+    code: string
+    params?: Record<string, number>
+    message: string
+}
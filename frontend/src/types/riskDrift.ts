@@ -0,0 +1,32 @@
+type RiskDriftStatus = 'STABLE' | 'WARNING' | 'ALERT' | 'INSUFFICIENT_DATA'
+
+interface RiskDriftBin {
+    label: string
+    baselineShare: number
+    currentShare: number
+    contribution: number
+}
+
+interface RiskDriftCharacteristic {
+    code: 'SCORE' | 'MONTHLY_INCOME' | 'AMOUNT' | 'TERM_MONTHS' | 'ASSET_RATIO' | 'PRODUCT_TYPE'
+    psi: number
+    status: RiskDriftStatus
+    bins: RiskDriftBin[]
+}
+
+interface RiskDriftReport {
+    ID: number
+    baselineFrom: string
+    baselineTo: string
+    currentFrom: string
+    currentTo: string
+    baselineCount: number
+    currentCount: number
+    warningPsi: number
+    alertPsi: number
+    status: RiskDriftStatus
+    maxPsi: number
+    characteristics: RiskDriftCharacteristic[]
+    CreatedAt: string
+    UpdatedAt: string
+}
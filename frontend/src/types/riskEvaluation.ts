@@ -0,0 +1,13 @@
+interface RiskEvaluation {
+    ID: number
+    creditRequestId: number
+    trigger: string
+    engineVersion: string
+    score: number
+    category: string
+    explanation: string
+    assessment: RiskAssessment | null
+    inputSnapshot: Record<string, unknown>
+    CreatedAt: string
+    UpdatedAt: string
+}
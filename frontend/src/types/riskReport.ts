@@ -0,0 +1,49 @@
+export type RiskReportCheckName = 'HASH' | 'SIGNATURE' | 'CHAIN' | 'EVALUATION' | 'CREDIT_REQUEST';
+
+export interface RiskReport {
+    ID: number;
+    sequence: number;
+    riskEvaluationId: number;
+    creditRequestId: number;
+    payload: string;
+    previousHash: string;
+    hash: string;
+    signature: string;
+    keyId: string;
+    CreatedAt: string;
+}
+
+export interface RiskReportPayload {
+    category: string;
+    creditRequestId: number;
+    engineVersion: string;
+    explanation: string;
+    inputsHash: string;
+    issuedAt: string;
+    previousHash: string;
+    riskEvaluationId: number;
+    score: number;
+    sequence: number;
+}
+
+export interface RiskReportCheck {
+    name: RiskReportCheckName;
+    passed: boolean;
+    detail?: string;
+}
+
+export interface RiskReportVerification {
+    reportId: number;
+    sequence: number;
+    creditRequestId: number;
+    riskEvaluationId: number;
+    valid: boolean;
+    checks: RiskReportCheck[];
+    payload?: RiskReportPayload;
+}
+
+export interface RiskReportKey {
+    keyId: string;
+    publicKey: string;
+    active: boolean;
+}
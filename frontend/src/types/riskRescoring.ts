@@ -0,0 +1,37 @@
+interface RiskRescoringFilter {
+    customerId?: number
+    creditStatusIds?: number[]
+    createdFrom?: string
+    createdTo?: string
+    engineVersion?: string
+}
+
+interface RiskRescoringJob {
+    ID: number
+    status: 'RUNNING' | 'COMPLETED' | 'INTERRUPTED' | 'FAILED'
+    dryRun: boolean
+    workers: number
+    filter: RiskRescoringFilter
+    lastProcessedId: number
+    processed: number
+    changed: number
+    failed: number
+    error?: string
+    finishedAt: string | null
+    CreatedAt: string
+    UpdatedAt: string
+}
+
+interface RiskRescoringChange {
+    ID: number
+    jobId: number
+    creditRequestId: number
+    previousVersion: string
+    previousScore: number
+    previousCategory: string
+    engineVersion: string
+    score: number
+    category: string
+    error?: string
+    CreatedAt: string
+}